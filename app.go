@@ -64,6 +64,22 @@ func (app *App) PostFunc(path string, h http.HandlerFunc) {
 	app.router.HandleFunc(path, h).Methods(http.MethodPost)
 }
 
+func (app *App) Put(path string, h http.Handler) {
+	app.router.Handle(path, h).Methods(http.MethodPut)
+}
+
+func (app *App) PutFunc(path string, h http.HandlerFunc) {
+	app.router.HandleFunc(path, h).Methods(http.MethodPut)
+}
+
+func (app *App) Delete(path string, h http.Handler) {
+	app.router.Handle(path, h).Methods(http.MethodDelete)
+}
+
+func (app *App) DeleteFunc(path string, h http.HandlerFunc) {
+	app.router.HandleFunc(path, h).Methods(http.MethodDelete)
+}
+
 func (app *App) Serve() {
 	app.router.Handle(app.HealthPath, HealthCheckHandler(app.HealthChecks))
 
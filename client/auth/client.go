@@ -1,12 +1,19 @@
 package auth
 
 import (
+	"fmt"
+
 	"github.com/reecerussell/open-social/client"
 )
 
 // Client is a interface to the auth API.
 type Client interface {
 	GenerateToken(in *GenerateTokenRequest) (*GenerateTokenResponse, error)
+	VerifySecondFactor(in *VerifySecondFactorRequest) (*GenerateTokenResponse, error)
+	RefreshToken(in *RefreshTokenRequest) (*GenerateTokenResponse, error)
+	RevokeToken(in *RefreshTokenRequest) error
+	RevokeUserTokens(userReferenceID string) error
+	ReissueToken(userReferenceID string) (*GenerateTokenResponse, error)
 }
 
 type authClient struct {
@@ -29,3 +36,53 @@ func (c *authClient) GenerateToken(in *GenerateTokenRequest) (*GenerateTokenResp
 
 	return &resp, nil
 }
+
+func (c *authClient) VerifySecondFactor(in *VerifySecondFactorRequest) (*GenerateTokenResponse, error) {
+	var resp GenerateTokenResponse
+	err := c.base.Post("/token/2fa", in, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *authClient) RefreshToken(in *RefreshTokenRequest) (*GenerateTokenResponse, error) {
+	var resp GenerateTokenResponse
+	err := c.base.Post("/token/refresh", in, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *authClient) RevokeToken(in *RefreshTokenRequest) error {
+	err := c.base.Post("/token/revoke", in, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *authClient) RevokeUserTokens(userReferenceID string) error {
+	url := fmt.Sprintf("/token/revoke/%s", userReferenceID)
+	err := c.base.Post(url, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *authClient) ReissueToken(userReferenceID string) (*GenerateTokenResponse, error) {
+	var resp GenerateTokenResponse
+	url := fmt.Sprintf("/token/reissue/%s", userReferenceID)
+	err := c.base.Post(url, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
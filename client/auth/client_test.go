@@ -62,3 +62,150 @@ func TestGenerateToken_RequestFails_ReturnsError(t *testing.T) {
 	assert.Nil(t, resp)
 	assert.Equal(t, testError, err)
 }
+
+func TestVerifySecondFactor_GivenValidCode_ReturnsSuccessfulResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testInput := &VerifySecondFactorRequest{
+		ChallengeToken: "<challenge token>",
+		Code:           "123456",
+	}
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/token/2fa", testInput, gomock.Any()).
+		DoAndReturn(func(url string, body, respDest interface{}) error {
+			resp := respDest.(*GenerateTokenResponse)
+			resp.Token = "<access token>"
+
+			return nil
+		})
+
+	c := &authClient{base: mockHTTP}
+
+	resp, err := c.VerifySecondFactor(testInput)
+	assert.NoError(t, err)
+	assert.Equal(t, "<access token>", resp.Token)
+}
+
+func TestVerifySecondFactor_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/token/2fa", gomock.Any(), gomock.Any()).Return(testError)
+
+	c := &authClient{base: mockHTTP}
+
+	resp, err := c.VerifySecondFactor(&VerifySecondFactorRequest{})
+	assert.Nil(t, resp)
+	assert.Equal(t, testError, err)
+}
+
+func TestRefreshToken_GivenValidToken_ReturnsSuccessfulResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testInput := &RefreshTokenRequest{RefreshToken: "<refresh token>"}
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/token/refresh", testInput, gomock.Any()).
+		DoAndReturn(func(url string, body, respDest interface{}) error {
+			resp := respDest.(*GenerateTokenResponse)
+			resp.Token = "<access token>"
+			resp.RefreshToken = "<new refresh token>"
+
+			return nil
+		})
+
+	c := &authClient{base: mockHTTP}
+
+	resp, err := c.RefreshToken(testInput)
+	assert.NoError(t, err)
+	assert.Equal(t, "<access token>", resp.Token)
+	assert.Equal(t, "<new refresh token>", resp.RefreshToken)
+}
+
+func TestRefreshToken_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testInput := &RefreshTokenRequest{RefreshToken: "<refresh token>"}
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/token/refresh", testInput, gomock.Any()).Return(testError)
+
+	c := &authClient{base: mockHTTP}
+
+	resp, err := c.RefreshToken(testInput)
+	assert.Nil(t, resp)
+	assert.Equal(t, testError, err)
+}
+
+func TestRevokeToken_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testInput := &RefreshTokenRequest{RefreshToken: "<refresh token>"}
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/token/revoke", testInput, nil).Return(testError)
+
+	c := &authClient{base: mockHTTP}
+
+	err := c.RevokeToken(testInput)
+	assert.Equal(t, testError, err)
+}
+
+func TestRevokeUserTokens_GivenUser_SendsRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/token/revoke/12345", nil, nil).Return(nil)
+
+	c := &authClient{base: mockHTTP}
+
+	err := c.RevokeUserTokens("12345")
+	assert.NoError(t, err)
+}
+
+func TestReissueToken_GivenValidUser_ReturnsAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/token/reissue/2394", nil, gomock.Any()).
+		DoAndReturn(func(url string, body, respDest interface{}) error {
+			resp := respDest.(*GenerateTokenResponse)
+			resp.Token = "<access token>"
+
+			return nil
+		})
+
+	c := &authClient{base: mockHTTP}
+
+	resp, err := c.ReissueToken("2394")
+	assert.NoError(t, err)
+	assert.Equal(t, "<access token>", resp.Token)
+}
+
+func TestReissueToken_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/token/reissue/2394", nil, gomock.Any()).Return(testError)
+
+	c := &authClient{base: mockHTTP}
+
+	resp, err := c.ReissueToken("2394")
+	assert.Nil(t, resp)
+	assert.Equal(t, testError, err)
+}
@@ -4,4 +4,8 @@ package auth
 type GenerateTokenRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+
+	// IPAddress is the address of the client logging in, used to
+	// lock out clients making too many failed attempts.
+	IPAddress string `json:"ipAddress,omitempty"`
 }
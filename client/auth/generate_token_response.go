@@ -1,7 +1,15 @@
 package auth
 
-// GenerateTokenResponse is the response body of the request.
+// GenerateTokenResponse is the response body of the request. If the user has two-factor
+// authentication enabled, no tokens are issued. Instead, SecondFactorRequired is set, along
+// with a challenge token, which is passed to VerifySecondFactor with a code.
 type GenerateTokenResponse struct {
-	Token   string `json:"token"`
-	Expires int64  `json:"expires"`
+	Token          string `json:"token,omitempty"`
+	Expires        int64  `json:"expires,omitempty"`
+	RefreshToken   string `json:"refreshToken,omitempty"`
+	RefreshExpires int64  `json:"refreshExpires,omitempty"`
+
+	SecondFactorRequired bool   `json:"secondFactorRequired,omitempty"`
+	ChallengeToken       string `json:"challengeToken,omitempty"`
+	ChallengeExpires     int64  `json:"challengeExpires,omitempty"`
 }
@@ -0,0 +1,6 @@
+package auth
+
+// RefreshTokenRequest is the request body used to refresh or revoke a refresh token.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
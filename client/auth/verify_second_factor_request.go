@@ -0,0 +1,11 @@
+package auth
+
+// VerifySecondFactorRequest is the request body used to complete a login challenge.
+type VerifySecondFactorRequest struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"`
+
+	// IPAddress is the address of the client logging in, used to
+	// lock out clients making too many failed attempts.
+	IPAddress string `json:"ipAddress,omitempty"`
+}
@@ -23,20 +23,42 @@ type HTTP interface {
 	// which will be in JSON format. If dest is not nil, the response
 	// body will be JSON decoded to the given destination.
 	Post(url string, body, dest interface{}) error
+
+	// Delete makes a DELETE request to the given url. If dest is not nil,
+	// the response body will be JSON decoded to the given destination.
+	Delete(url string, dest interface{}) error
+
+	// PostContent makes a POST request to the given url, streaming content
+	// as the body, with the given content type. If dest is not nil, the
+	// response body will be JSON decoded to the given destination.
+	PostContent(url, contentType string, content io.Reader, dest interface{}) error
+
+	// GetContent makes a GET request to the given url, returning the content
+	// type and body of the response, rather than decoding it. The body must be closed.
+	GetContent(url string) (string, io.ReadCloser, error)
 }
 
 // NewHTTP returns a new instance of HTTP with a base url.
 func NewHTTP(baseURL string) HTTP {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = time.Second * 10
+
 	return &httpClient{
 		base: &http.Client{
 			Timeout: time.Second * 10,
 		},
+		stream: &http.Client{
+			Transport: transport,
+		},
 		baseURL: baseURL,
 	}
 }
 
+// httpClient is the implementation of HTTP. Content is streamed with stream, which
+// only times out waiting for a response, as a body can take any time to transfer.
 type httpClient struct {
 	base    *http.Client
+	stream  *http.Client
 	baseURL string
 }
 
@@ -52,6 +74,40 @@ func (hc *httpClient) Post(url string, body, dest interface{}) error {
 	return hc.makeRequest(http.MethodPost, url, body, dest)
 }
 
+func (hc *httpClient) Delete(url string, dest interface{}) error {
+	return hc.makeRequest(http.MethodDelete, url, nil, dest)
+}
+
+func (hc *httpClient) PostContent(url, contentType string, content io.Reader, dest interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, getRequestURL(hc.baseURL, url), content)
+	if err != nil {
+		return fmt.Errorf("http: %v", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	return hc.do(hc.stream, req, dest)
+}
+
+func (hc *httpClient) GetContent(url string) (string, io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, getRequestURL(hc.baseURL, url), nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("http: %v", err)
+	}
+
+	resp, err := hc.stream.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("http: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return "", nil, readError(resp)
+	}
+
+	return resp.Header.Get("Content-Type"), resp.Body, nil
+}
+
 func (hc *httpClient) makeRequest(method, url string, body, respDest interface{}) error {
 	reqBody := getRequestBody(method, body)
 	req, _ := http.NewRequest(method, getRequestURL(hc.baseURL, url), reqBody)
@@ -60,24 +116,20 @@ func (hc *httpClient) makeRequest(method, url string, body, respDest interface{}
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := hc.base.Do(req)
+	return hc.do(hc.base, req, respDest)
+}
+
+// do sends the request using the given client, then decodes a successful
+// response to respDest, if it's not nil.
+func (hc *httpClient) do(c *http.Client, req *http.Request, respDest interface{}) error {
+	resp, err := c.Do(req)
 	if err != nil {
 		return fmt.Errorf("http: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		if resp.Header.Get("Content-Type") != "application/json" {
-			return fmt.Errorf("http: server returned a %d status code", resp.StatusCode)
-		}
-
-		var data ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&data)
-		if err != nil {
-			return fmt.Errorf("http: failed to read json response, status code: %d", resp.StatusCode)
-		}
-
-		return NewError(resp.StatusCode, data.Message)
+		return readError(resp)
 	}
 
 	if respDest != nil {
@@ -90,10 +142,25 @@ func (hc *httpClient) makeRequest(method, url string, body, respDest interface{}
 	return nil
 }
 
+// readError returns the error from an unsuccessful response.
+func readError(resp *http.Response) error {
+	if resp.Header.Get("Content-Type") != "application/json" {
+		return fmt.Errorf("http: server returned a %d status code", resp.StatusCode)
+	}
+
+	var data ErrorResponse
+	err := json.NewDecoder(resp.Body).Decode(&data)
+	if err != nil {
+		return fmt.Errorf("http: failed to read json response, status code: %d", resp.StatusCode)
+	}
+
+	return NewError(resp.StatusCode, data.Message)
+}
+
 // getRequestBody returns an io.Reader containing the JSON encoded value of body.
-// If method is "GET" or if the body is nil, a nil-value will be returned.
+// If method is "GET" or "DELETE", or if the body is nil, a nil-value will be returned.
 func getRequestBody(method string, body interface{}) io.Reader {
-	if method == http.MethodGet || body == nil {
+	if method == http.MethodGet || method == http.MethodDelete || body == nil {
 		return nil
 	}
 
@@ -2,8 +2,10 @@ package client
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -194,3 +196,110 @@ func TestHTTPPost_ReturnsNonJSONError_ReturnsError(t *testing.T) {
 	err := hc.Post("/test", data, nil)
 	assert.Equal(t, "http: server returned a 500 status code", err.Error())
 }
+
+func TestHTTPDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/test", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	hc := NewHTTP(server.URL)
+
+	err := hc.Delete("/test", nil)
+	assert.NoError(t, err)
+}
+
+func TestHTTPDelete_ReturnsErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/test", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"an error occured"}`))
+	}))
+	defer server.Close()
+
+	hc := NewHTTP(server.URL)
+
+	err := hc.Delete("/test", nil)
+	assert.Equal(t, "an error occured", err.Error())
+}
+
+func TestHTTPPostContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/test", r.URL.Path)
+		assert.Equal(t, "image/png", r.Header.Get("Content-Type"))
+
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, "Hello World", string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message":"Created"}`))
+	}))
+	defer server.Close()
+
+	hc := NewHTTP(server.URL)
+
+	var data map[string]string
+	err := hc.PostContent("/test", "image/png", strings.NewReader("Hello World"), &data)
+	assert.NoError(t, err)
+	assert.Equal(t, "Created", data["message"])
+}
+
+func TestHTTPPostContent_ReturnsErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"an error occured"}`))
+	}))
+	defer server.Close()
+
+	hc := NewHTTP(server.URL)
+
+	err := hc.PostContent("/test", "image/png", strings.NewReader("Hello World"), nil)
+	assert.Equal(t, "an error occured", err.Error())
+	assert.Equal(t, http.StatusBadRequest, err.(*Error).StatusCode)
+}
+
+func TestHTTPGetContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/test", r.URL.Path)
+
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("Hello World"))
+	}))
+	defer server.Close()
+
+	hc := NewHTTP(server.URL)
+
+	contentType, body, err := hc.GetContent("/test")
+	assert.NoError(t, err)
+	defer body.Close()
+
+	content, _ := ioutil.ReadAll(body)
+	assert.Equal(t, "image/png", contentType)
+	assert.Equal(t, "Hello World", string(content))
+}
+
+func TestHTTPGetContent_ReturnsErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"an error occured"}`))
+	}))
+	defer server.Close()
+
+	hc := NewHTTP(server.URL)
+
+	contentType, body, err := hc.GetContent("/test")
+	assert.Empty(t, contentType)
+	assert.Nil(t, body)
+	assert.Equal(t, "an error occured", err.Error())
+	assert.Equal(t, http.StatusNotFound, err.(*Error).StatusCode)
+}
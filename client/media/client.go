@@ -1,16 +1,28 @@
 package media
 
 import (
-	"encoding/base64"
-	"errors"
+	"io"
+	neturl "net/url"
+	"strconv"
 
 	"github.com/reecerussell/open-social/client"
 )
 
 // Client is an interface used to interact with the media API.
 type Client interface {
-	Create(in *CreateRequest) (*CreateResponse, error)
-	GetContent(referenceID string) (string, []byte, error)
+	// Create streams content, of the given content type, to the media service.
+	Create(contentType string, content io.Reader) (*CreateResponse, error)
+
+	// GetInfo returns the metadata of a media's content, at the named size,
+	// or the default size if it's empty.
+	GetInfo(referenceID, size string) (*Info, error)
+
+	// GetContent returns the content type and a stream of a media's content, at
+	// the named size, or the default size if it's empty, starting offset bytes
+	// into the content. The stream must be closed.
+	GetContent(referenceID, size string, offset int64) (string, io.ReadCloser, error)
+
+	Delete(referenceIDs []string) error
 }
 
 type mediaClient struct {
@@ -24,9 +36,9 @@ func New(url string) Client {
 	}
 }
 
-func (c *mediaClient) Create(in *CreateRequest) (*CreateResponse, error) {
+func (c *mediaClient) Create(contentType string, content io.Reader) (*CreateResponse, error) {
 	var resp CreateResponse
-	err := c.base.Post("/media", in, &resp)
+	err := c.base.PostContent("/media", contentType, content, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -34,17 +46,48 @@ func (c *mediaClient) Create(in *CreateRequest) (*CreateResponse, error) {
 	return &resp, nil
 }
 
-func (c *mediaClient) GetContent(referenceID string) (string, []byte, error) {
-	var data map[string]interface{}
-	err := c.base.Get("/media/content/"+referenceID, &data)
+func (c *mediaClient) GetInfo(referenceID, size string) (*Info, error) {
+	url := "/media/info/" + referenceID
+	if size != "" {
+		url += "?size=" + neturl.QueryEscape(size)
+	}
+
+	var info Info
+	err := c.base.Get(url, &info)
 	if err != nil {
-		return "", nil, err
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+func (c *mediaClient) GetContent(referenceID, size string, offset int64) (string, io.ReadCloser, error) {
+	query := neturl.Values{}
+	if size != "" {
+		query.Set("size", size)
+	}
+
+	if offset > 0 {
+		query.Set("offset", strconv.FormatInt(offset, 10))
+	}
+
+	url := "/media/content/" + referenceID
+	if len(query) > 0 {
+		url += "?" + query.Encode()
+	}
+
+	return c.base.GetContent(url)
+}
+
+func (c *mediaClient) Delete(referenceIDs []string) error {
+	payload := map[string][]string{
+		"referenceIds": referenceIDs,
 	}
 
-	content, err := base64.StdEncoding.DecodeString(data["content"].(string))
+	err := c.base.Post("/media/delete", payload, nil)
 	if err != nil {
-		return "", nil, errors.New("media: server responed with invalid content")
+		return err
 	}
 
-	return data["contentType"].(string), content, nil
+	return nil
 }
@@ -2,7 +2,11 @@ package media
 
 import (
 	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -20,24 +24,19 @@ func TestCreate_GivenValidData_ReturnsSuccessfulResponse(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	testInput := &CreateRequest{
-		ContentType: "text/plain",
-		Content:     "Hello World",
-	}
+	testContent := strings.NewReader("Hello World")
 
 	mockHTTP := mock.NewMockHTTP(ctrl)
-	mockHTTP.EXPECT().Post("/media", testInput, gomock.Any()).
-		DoAndReturn(func(url string, body, respDest interface{}) error {
+	mockHTTP.EXPECT().PostContent("/media", "text/plain", testContent, gomock.Any()).
+		DoAndReturn(func(url, contentType string, content io.Reader, respDest interface{}) error {
 			resp := respDest.(*CreateResponse)
 			resp.ID = 123
 			resp.ReferenceID = "923640234"
-
 			return nil
 		})
 
 	c := &mediaClient{base: mockHTTP}
-
-	resp, err := c.Create(testInput)
+	resp, err := c.Create("text/plain", testContent)
 	assert.NoError(t, err)
 	assert.Equal(t, 123, resp.ID)
 	assert.Equal(t, "923640234", resp.ReferenceID)
@@ -47,18 +46,14 @@ func TestCreate_RequestFails_ReturnsError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	testInput := &CreateRequest{
-		ContentType: "text/plain",
-		Content:     "Hello World",
-	}
+	testContent := strings.NewReader("Hello World")
 	testError := errors.New("an error occured")
 
 	mockHTTP := mock.NewMockHTTP(ctrl)
-	mockHTTP.EXPECT().Post("/media", testInput, gomock.Any()).Return(testError)
+	mockHTTP.EXPECT().PostContent("/media", "text/plain", testContent, gomock.Any()).Return(testError)
 
 	c := &mediaClient{base: mockHTTP}
-
-	resp, err := c.Create(testInput)
+	resp, err := c.Create("text/plain", testContent)
 	assert.Nil(t, resp)
 	assert.Equal(t, testError, err)
 }
@@ -68,25 +63,50 @@ func TestGetContent_GivenValidReference_ReturnsContent(t *testing.T) {
 	defer ctrl.Finish()
 
 	testReferenceID := "19263"
+	testBody := ioutil.NopCloser(strings.NewReader("Hello World"))
 
 	mockHTTP := mock.NewMockHTTP(ctrl)
-	mockHTTP.EXPECT().Get("/media/content/"+testReferenceID, gomock.Any()).
-		DoAndReturn(func(url string, respDest interface{}) error {
-			resp := map[string]interface{}{
-				"contentType": "text/plain",
-				"content":     "SGVsbG8gV29ybGQ=",
-			}
-			*(respDest.(*map[string]interface{})) = resp
+	mockHTTP.EXPECT().GetContent("/media/content/"+testReferenceID).Return("text/plain", testBody, nil)
 
-			return nil
-		})
+	c := &mediaClient{base: mockHTTP}
+	contentType, body, err := c.GetContent(testReferenceID, "", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain", contentType)
+	assert.Equal(t, testBody, body)
+}
+
+func TestGetContent_GivenSize_RequestsSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testReferenceID := "19263"
+	testBody := ioutil.NopCloser(strings.NewReader("Hello World"))
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().GetContent("/media/content/"+testReferenceID+"?size=thumbnail").Return("image/jpeg", testBody, nil)
 
 	c := &mediaClient{base: mockHTTP}
+	contentType, body, err := c.GetContent(testReferenceID, "thumbnail", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "image/jpeg", contentType)
+	assert.Equal(t, testBody, body)
+}
+
+func TestGetContent_GivenOffset_RequestsOffset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testReferenceID := "19263"
+	testBody := ioutil.NopCloser(strings.NewReader("World"))
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().GetContent("/media/content/"+testReferenceID+"?offset=6&size=thumbnail").Return("image/jpeg", testBody, nil)
 
-	contentType, content, err := c.GetContent(testReferenceID)
+	c := &mediaClient{base: mockHTTP}
+	contentType, body, err := c.GetContent(testReferenceID, "thumbnail", 6)
 	assert.NoError(t, err)
-	assert.Equal(t, "text/plain", contentType)
-	assert.Equal(t, "Hello World", string(content))
+	assert.Equal(t, "image/jpeg", contentType)
+	assert.Equal(t, testBody, body)
 }
 
 func TestGetContent_RequestFails_ReturnsError(t *testing.T) {
@@ -97,38 +117,84 @@ func TestGetContent_RequestFails_ReturnsError(t *testing.T) {
 	testError := errors.New("an error occured")
 
 	mockHTTP := mock.NewMockHTTP(ctrl)
-	mockHTTP.EXPECT().Get("/media/content/"+testReferenceID, gomock.Any()).Return(testError)
+	mockHTTP.EXPECT().GetContent("/media/content/"+testReferenceID).Return("", nil, testError)
 
 	c := &mediaClient{base: mockHTTP}
-
-	contentType, content, err := c.GetContent(testReferenceID)
+	contentType, body, err := c.GetContent(testReferenceID, "", 0)
 	assert.Empty(t, contentType)
-	assert.Nil(t, content)
+	assert.Nil(t, body)
 	assert.Equal(t, testError, err)
 }
 
-func TestGetContent_ReturnsInvalidBase64_ReturnsError(t *testing.T) {
+func TestGetInfo_GivenValidReference_ReturnsInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	testReferenceID := "19263"
+	testLastModified := time.Date(2020, 11, 2, 15, 4, 5, 0, time.UTC)
 
 	mockHTTP := mock.NewMockHTTP(ctrl)
-	mockHTTP.EXPECT().Get("/media/content/"+testReferenceID, gomock.Any()).
-		DoAndReturn(func(url string, respDest interface{}) error {
-			resp := map[string]interface{}{
-				"contentType": "text/plain",
-				"content":     "Hello World",
-			}
-			*(respDest.(*map[string]interface{})) = resp
-
+	mockHTTP.EXPECT().Get("/media/info/"+testReferenceID+"?size=thumbnail", gomock.Any()).
+		DoAndReturn(func(url string, dest interface{}) error {
+			info := dest.(*Info)
+			info.ContentType = "image/jpeg"
+			info.Size = 120
+			info.ETag = "abc"
+			info.LastModified = testLastModified
 			return nil
 		})
 
 	c := &mediaClient{base: mockHTTP}
+	info, err := c.GetInfo(testReferenceID, "thumbnail")
+	assert.NoError(t, err)
+	assert.Equal(t, "image/jpeg", info.ContentType)
+	assert.Equal(t, int64(120), info.Size)
+	assert.Equal(t, "abc", info.ETag)
+	assert.Equal(t, testLastModified, info.LastModified)
+}
 
-	contentType, content, err := c.GetContent(testReferenceID)
-	assert.Empty(t, contentType)
-	assert.Nil(t, content)
-	assert.Equal(t, "media: server responed with invalid content", err.Error())
+func TestGetInfo_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/media/info/19263", gomock.Any()).Return(testError)
+
+	c := &mediaClient{base: mockHTTP}
+	info, err := c.GetInfo("19263", "")
+	assert.Nil(t, info)
+	assert.Equal(t, testError, err)
+}
+
+func TestDelete_GivenReferenceIDs_SendsRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testReferenceIDs := []string{"19263", "36291"}
+	testPayload := map[string][]string{"referenceIds": testReferenceIDs}
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/media/delete", testPayload, nil).Return(nil)
+
+	c := &mediaClient{base: mockHTTP}
+
+	err := c.Delete(testReferenceIDs)
+	assert.NoError(t, err)
+}
+
+func TestDelete_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/media/delete", gomock.Any(), nil).Return(testError)
+
+	c := &mediaClient{base: mockHTTP}
+
+	err := c.Delete([]string{"19263"})
+	assert.Equal(t, testError, err)
 }
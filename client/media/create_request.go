@@ -1,7 +0,0 @@
-package media
-
-// CreateRequest is the body of the request.
-type CreateRequest struct {
-	ContentType string `json:"contentType"`
-	Content     string `json:"content"`
-}
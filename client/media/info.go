@@ -0,0 +1,12 @@
+package media
+
+import "time"
+
+// Info is the metadata of a media's content, at a given size. Size and
+// ETag are empty for media uploaded before they were recorded.
+type Info struct {
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"lastModified"`
+}
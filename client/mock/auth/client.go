@@ -47,3 +47,76 @@ func (mr *MockClientMockRecorder) GenerateToken(in interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockClient)(nil).GenerateToken), in)
 }
+
+// VerifySecondFactor mocks base method.
+func (m *MockClient) VerifySecondFactor(in *auth.VerifySecondFactorRequest) (*auth.GenerateTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifySecondFactor", in)
+	ret0, _ := ret[0].(*auth.GenerateTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifySecondFactor indicates an expected call of VerifySecondFactor.
+func (mr *MockClientMockRecorder) VerifySecondFactor(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySecondFactor", reflect.TypeOf((*MockClient)(nil).VerifySecondFactor), in)
+}
+
+// RefreshToken mocks base method.
+func (m *MockClient) RefreshToken(in *auth.RefreshTokenRequest) (*auth.GenerateTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", in)
+	ret0, _ := ret[0].(*auth.GenerateTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockClientMockRecorder) RefreshToken(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockClient)(nil).RefreshToken), in)
+}
+
+// RevokeToken mocks base method.
+func (m *MockClient) RevokeToken(in *auth.RefreshTokenRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockClientMockRecorder) RevokeToken(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockClient)(nil).RevokeToken), in)
+}
+
+// RevokeUserTokens mocks base method.
+func (m *MockClient) RevokeUserTokens(userReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserTokens", userReferenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserTokens indicates an expected call of RevokeUserTokens.
+func (mr *MockClientMockRecorder) RevokeUserTokens(userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserTokens", reflect.TypeOf((*MockClient)(nil).RevokeUserTokens), userReferenceID)
+}
+
+// ReissueToken mocks base method.
+func (m *MockClient) ReissueToken(userReferenceID string) (*auth.GenerateTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReissueToken", userReferenceID)
+	ret0, _ := ret[0].(*auth.GenerateTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReissueToken indicates an expected call of ReissueToken.
+func (mr *MockClientMockRecorder) ReissueToken(userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReissueToken", reflect.TypeOf((*MockClient)(nil).ReissueToken), userReferenceID)
+}
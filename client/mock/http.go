@@ -6,6 +6,7 @@ package mock
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	io "io"
 	reflect "reflect"
 )
 
@@ -59,3 +60,47 @@ func (mr *MockHTTPMockRecorder) Post(url, body, dest interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockHTTP)(nil).Post), url, body, dest)
 }
+
+// Delete mocks base method.
+func (m *MockHTTP) Delete(url string, dest interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", url, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHTTPMockRecorder) Delete(url, dest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHTTP)(nil).Delete), url, dest)
+}
+
+// PostContent mocks base method.
+func (m *MockHTTP) PostContent(url, contentType string, content io.Reader, dest interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostContent", url, contentType, content, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostContent indicates an expected call of PostContent.
+func (mr *MockHTTPMockRecorder) PostContent(url, contentType, content, dest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostContent", reflect.TypeOf((*MockHTTP)(nil).PostContent), url, contentType, content, dest)
+}
+
+// GetContent mocks base method.
+func (m *MockHTTP) GetContent(url string) (string, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContent", url)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetContent indicates an expected call of GetContent.
+func (mr *MockHTTPMockRecorder) GetContent(url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContent", reflect.TypeOf((*MockHTTP)(nil).GetContent), url)
+}
@@ -7,6 +7,7 @@ package mock
 import (
 	gomock "github.com/golang/mock/gomock"
 	media "github.com/reecerussell/open-social/client/media"
+	io "io"
 	reflect "reflect"
 )
 
@@ -34,32 +35,61 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockClient) Create(in *media.CreateRequest) (*media.CreateResponse, error) {
+func (m *MockClient) Create(contentType string, content io.Reader) (*media.CreateResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", in)
+	ret := m.ctrl.Call(m, "Create", contentType, content)
 	ret0, _ := ret[0].(*media.CreateResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockClientMockRecorder) Create(in interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) Create(contentType, content interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockClient)(nil).Create), in)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockClient)(nil).Create), contentType, content)
+}
+
+// GetInfo mocks base method.
+func (m *MockClient) GetInfo(referenceID, size string) (*media.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInfo", referenceID, size)
+	ret0, _ := ret[0].(*media.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInfo indicates an expected call of GetInfo.
+func (mr *MockClientMockRecorder) GetInfo(referenceID, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInfo", reflect.TypeOf((*MockClient)(nil).GetInfo), referenceID, size)
 }
 
 // GetContent mocks base method.
-func (m *MockClient) GetContent(referenceID string) (string, []byte, error) {
+func (m *MockClient) GetContent(referenceID, size string, offset int64) (string, io.ReadCloser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContent", referenceID)
+	ret := m.ctrl.Call(m, "GetContent", referenceID, size, offset)
 	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].([]byte)
+	ret1, _ := ret[1].(io.ReadCloser)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetContent indicates an expected call of GetContent.
-func (mr *MockClientMockRecorder) GetContent(referenceID interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetContent(referenceID, size, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContent", reflect.TypeOf((*MockClient)(nil).GetContent), referenceID, size, offset)
+}
+
+// Delete mocks base method.
+func (m *MockClient) Delete(referenceIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", referenceIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockClientMockRecorder) Delete(referenceIDs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContent", reflect.TypeOf((*MockClient)(nil).GetContent), referenceID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockClient)(nil).Delete), referenceIDs)
 }
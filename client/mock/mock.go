@@ -1,6 +1,8 @@
 //go:generate mockgen -package=mock -source=../users/client.go -destination=users/client.go
 //go:generate mockgen -package=mock -source=../auth/client.go -destination=auth/client.go
 //go:generate mockgen -package=mock -source=../media/client.go -destination=media/client.go
+//go:generate mockgen -package=mock -source=../posts/client.go -destination=posts/client.go
+//go:generate mockgen -package=mock -source=../notifications/client.go -destination=notifications/client.go
 //go:generate mockgen -package=mock -source=../http.go -destination=http.go
 
 package mock
@@ -0,0 +1,196 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../posts/client.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	posts "github.com/reecerussell/open-social/client/posts"
+	reflect "reflect"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockClient) Create(in *posts.CreateRequest) (*posts.CreateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", in)
+	ret0, _ := ret[0].(*posts.CreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockClientMockRecorder) Create(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockClient)(nil).Create), in)
+}
+
+// GetFeed mocks base method.
+func (m *MockClient) GetFeed(userReferenceID, cursor string, limit int) (*posts.Feed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", userReferenceID, cursor, limit)
+	ret0, _ := ret[0].(*posts.Feed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockClientMockRecorder) GetFeed(userReferenceID, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockClient)(nil).GetFeed), userReferenceID, cursor, limit)
+}
+
+// GetProfileFeed mocks base method.
+func (m *MockClient) GetProfileFeed(username, userReferenceID, cursor string, limit int) (*posts.Feed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileFeed", username, userReferenceID, cursor, limit)
+	ret0, _ := ret[0].(*posts.Feed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileFeed indicates an expected call of GetProfileFeed.
+func (mr *MockClientMockRecorder) GetProfileFeed(username, userReferenceID, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileFeed", reflect.TypeOf((*MockClient)(nil).GetProfileFeed), username, userReferenceID, cursor, limit)
+}
+
+// LikePost mocks base method.
+func (m *MockClient) LikePost(postReferenceID, userReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LikePost", postReferenceID, userReferenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LikePost indicates an expected call of LikePost.
+func (mr *MockClientMockRecorder) LikePost(postReferenceID, userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LikePost", reflect.TypeOf((*MockClient)(nil).LikePost), postReferenceID, userReferenceID)
+}
+
+// UnlikePost mocks base method.
+func (m *MockClient) UnlikePost(postReferenceID, userReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlikePost", postReferenceID, userReferenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlikePost indicates an expected call of UnlikePost.
+func (mr *MockClientMockRecorder) UnlikePost(postReferenceID, userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlikePost", reflect.TypeOf((*MockClient)(nil).UnlikePost), postReferenceID, userReferenceID)
+}
+
+// Get mocks base method.
+func (m *MockClient) Get(postReferenceID, userReferenceID string) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", postReferenceID, userReferenceID)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockClientMockRecorder) Get(postReferenceID, userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockClient)(nil).Get), postReferenceID, userReferenceID)
+}
+
+// CreateComment mocks base method.
+func (m *MockClient) CreateComment(postReferenceID string, in *posts.CreateCommentRequest) (*posts.CreateCommentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", postReferenceID, in)
+	ret0, _ := ret[0].(*posts.CreateCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockClientMockRecorder) CreateComment(postReferenceID, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockClient)(nil).CreateComment), postReferenceID, in)
+}
+
+// GetComments mocks base method.
+func (m *MockClient) GetComments(postReferenceID, userReferenceID string) ([]*posts.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", postReferenceID, userReferenceID)
+	ret0, _ := ret[0].([]*posts.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockClientMockRecorder) GetComments(postReferenceID, userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockClient)(nil).GetComments), postReferenceID, userReferenceID)
+}
+
+// DeleteComment mocks base method.
+func (m *MockClient) DeleteComment(postReferenceID, commentReferenceID, userReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", postReferenceID, commentReferenceID, userReferenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockClientMockRecorder) DeleteComment(postReferenceID, commentReferenceID, userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockClient)(nil).DeleteComment), postReferenceID, commentReferenceID, userReferenceID)
+}
+
+// DeleteUser mocks base method.
+func (m *MockClient) DeleteUser(userReferenceID string) (*posts.DeleteUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", userReferenceID)
+	ret0, _ := ret[0].(*posts.DeleteUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockClientMockRecorder) DeleteUser(userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockClient)(nil).DeleteUser), userReferenceID)
+}
+
+// GetExport mocks base method.
+func (m *MockClient) GetExport(userReferenceID string) (*posts.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", userReferenceID)
+	ret0, _ := ret[0].(*posts.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockClientMockRecorder) GetExport(userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockClient)(nil).GetExport), userReferenceID)
+}
@@ -63,6 +63,21 @@ func (mr *MockClientMockRecorder) GetClaims(in interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaims", reflect.TypeOf((*MockClient)(nil).GetClaims), in)
 }
 
+// GetClaimsByReference mocks base method.
+func (m *MockClient) GetClaimsByReference(userReferenceID string) (*users.GetClaimsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClaimsByReference", userReferenceID)
+	ret0, _ := ret[0].(*users.GetClaimsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClaimsByReference indicates an expected call of GetClaimsByReference.
+func (mr *MockClientMockRecorder) GetClaimsByReference(userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaimsByReference", reflect.TypeOf((*MockClient)(nil).GetClaimsByReference), userReferenceID)
+}
+
 // GetIDByReference mocks base method.
 func (m *MockClient) GetIDByReference(referenceID string) (*int, error) {
 	m.ctrl.T.Helper()
@@ -108,14 +123,103 @@ func (mr *MockClientMockRecorder) GetInfo(userReferenceID interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInfo", reflect.TypeOf((*MockClient)(nil).GetInfo), userReferenceID)
 }
 
-// Follow mocks base method.
-func (m *MockClient) Follow(userReferenceID, followerReferenceID string) error {
+// GetFollowers mocks base method.
+func (m *MockClient) GetFollowers(username, userReferenceID, cursor string, limit int) (*users.FollowList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Follow", userReferenceID, followerReferenceID)
+	ret := m.ctrl.Call(m, "GetFollowers", username, userReferenceID, cursor, limit)
+	ret0, _ := ret[0].(*users.FollowList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowers indicates an expected call of GetFollowers.
+func (mr *MockClientMockRecorder) GetFollowers(username, userReferenceID, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowers", reflect.TypeOf((*MockClient)(nil).GetFollowers), username, userReferenceID, cursor, limit)
+}
+
+// GetFollowing mocks base method.
+func (m *MockClient) GetFollowing(username, userReferenceID, cursor string, limit int) (*users.FollowList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowing", username, userReferenceID, cursor, limit)
+	ret0, _ := ret[0].(*users.FollowList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowing indicates an expected call of GetFollowing.
+func (mr *MockClientMockRecorder) GetFollowing(username, userReferenceID, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowing", reflect.TypeOf((*MockClient)(nil).GetFollowing), username, userReferenceID, cursor, limit)
+}
+
+// GetFollowRequests mocks base method.
+func (m *MockClient) GetFollowRequests(userReferenceID, cursor string, limit int) (*users.FollowList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowRequests", userReferenceID, cursor, limit)
+	ret0, _ := ret[0].(*users.FollowList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowRequests indicates an expected call of GetFollowRequests.
+func (mr *MockClientMockRecorder) GetFollowRequests(userReferenceID, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowRequests", reflect.TypeOf((*MockClient)(nil).GetFollowRequests), userReferenceID, cursor, limit)
+}
+
+// ApproveFollowRequest mocks base method.
+func (m *MockClient) ApproveFollowRequest(userReferenceID, followerReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveFollowRequest", userReferenceID, followerReferenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveFollowRequest indicates an expected call of ApproveFollowRequest.
+func (mr *MockClientMockRecorder) ApproveFollowRequest(userReferenceID, followerReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveFollowRequest", reflect.TypeOf((*MockClient)(nil).ApproveFollowRequest), userReferenceID, followerReferenceID)
+}
+
+// RejectFollowRequest mocks base method.
+func (m *MockClient) RejectFollowRequest(userReferenceID, followerReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectFollowRequest", userReferenceID, followerReferenceID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// RejectFollowRequest indicates an expected call of RejectFollowRequest.
+func (mr *MockClientMockRecorder) RejectFollowRequest(userReferenceID, followerReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectFollowRequest", reflect.TypeOf((*MockClient)(nil).RejectFollowRequest), userReferenceID, followerReferenceID)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockClient) UpdateProfile(userReferenceID string, in *users.UpdateProfileRequest) (*users.UpdateProfileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", userReferenceID, in)
+	ret0, _ := ret[0].(*users.UpdateProfileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockClientMockRecorder) UpdateProfile(userReferenceID, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockClient)(nil).UpdateProfile), userReferenceID, in)
+}
+
+// Follow mocks base method.
+func (m *MockClient) Follow(userReferenceID, followerReferenceID string) (*users.FollowResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follow", userReferenceID, followerReferenceID)
+	ret0, _ := ret[0].(*users.FollowResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Follow indicates an expected call of Follow.
 func (mr *MockClientMockRecorder) Follow(userReferenceID, followerReferenceID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -135,3 +239,236 @@ func (mr *MockClientMockRecorder) Unfollow(userReferenceID, followerReferenceID
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfollow", reflect.TypeOf((*MockClient)(nil).Unfollow), userReferenceID, followerReferenceID)
 }
+
+// Block mocks base method.
+func (m *MockClient) Block(userReferenceID, blockerReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", userReferenceID, blockerReferenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockClientMockRecorder) Block(userReferenceID, blockerReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockClient)(nil).Block), userReferenceID, blockerReferenceID)
+}
+
+// Unblock mocks base method.
+func (m *MockClient) Unblock(userReferenceID, blockerReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unblock", userReferenceID, blockerReferenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unblock indicates an expected call of Unblock.
+func (mr *MockClientMockRecorder) Unblock(userReferenceID, blockerReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unblock", reflect.TypeOf((*MockClient)(nil).Unblock), userReferenceID, blockerReferenceID)
+}
+
+// GetBlockedUsers mocks base method.
+func (m *MockClient) GetBlockedUsers(userReferenceID, cursor string, limit int) (*users.FollowList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockedUsers", userReferenceID, cursor, limit)
+	ret0, _ := ret[0].(*users.FollowList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockedUsers indicates an expected call of GetBlockedUsers.
+func (mr *MockClientMockRecorder) GetBlockedUsers(userReferenceID, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockedUsers", reflect.TypeOf((*MockClient)(nil).GetBlockedUsers), userReferenceID, cursor, limit)
+}
+
+// Mute mocks base method.
+func (m *MockClient) Mute(userReferenceID, muterReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Mute", userReferenceID, muterReferenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Mute indicates an expected call of Mute.
+func (mr *MockClientMockRecorder) Mute(userReferenceID, muterReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mute", reflect.TypeOf((*MockClient)(nil).Mute), userReferenceID, muterReferenceID)
+}
+
+// Unmute mocks base method.
+func (m *MockClient) Unmute(userReferenceID, muterReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unmute", userReferenceID, muterReferenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unmute indicates an expected call of Unmute.
+func (mr *MockClientMockRecorder) Unmute(userReferenceID, muterReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unmute", reflect.TypeOf((*MockClient)(nil).Unmute), userReferenceID, muterReferenceID)
+}
+
+// ChangePassword mocks base method.
+func (m *MockClient) ChangePassword(userReferenceID string, in *users.ChangePasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", userReferenceID, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockClientMockRecorder) ChangePassword(userReferenceID, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockClient)(nil).ChangePassword), userReferenceID, in)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockClient) RequestPasswordReset(in *users.RequestPasswordResetRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockClientMockRecorder) RequestPasswordReset(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockClient)(nil).RequestPasswordReset), in)
+}
+
+// ResetPassword mocks base method.
+func (m *MockClient) ResetPassword(in *users.ResetPasswordRequest) (*users.ResetPasswordResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", in)
+	ret0, _ := ret[0].(*users.ResetPasswordResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockClientMockRecorder) ResetPassword(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockClient)(nil).ResetPassword), in)
+}
+
+// VerifySecondFactor mocks base method.
+func (m *MockClient) VerifySecondFactor(in *users.VerifySecondFactorRequest) (*users.GetClaimsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifySecondFactor", in)
+	ret0, _ := ret[0].(*users.GetClaimsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifySecondFactor indicates an expected call of VerifySecondFactor.
+func (mr *MockClientMockRecorder) VerifySecondFactor(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySecondFactor", reflect.TypeOf((*MockClient)(nil).VerifySecondFactor), in)
+}
+
+// EnrolTwoFactor mocks base method.
+func (m *MockClient) EnrolTwoFactor(userReferenceID string) (*users.EnrolTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrolTwoFactor", userReferenceID)
+	ret0, _ := ret[0].(*users.EnrolTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrolTwoFactor indicates an expected call of EnrolTwoFactor.
+func (mr *MockClientMockRecorder) EnrolTwoFactor(userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrolTwoFactor", reflect.TypeOf((*MockClient)(nil).EnrolTwoFactor), userReferenceID)
+}
+
+// ConfirmTwoFactor mocks base method.
+func (m *MockClient) ConfirmTwoFactor(userReferenceID string, in *users.ConfirmTwoFactorRequest) (*users.ConfirmTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTwoFactor", userReferenceID, in)
+	ret0, _ := ret[0].(*users.ConfirmTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTwoFactor indicates an expected call of ConfirmTwoFactor.
+func (mr *MockClientMockRecorder) ConfirmTwoFactor(userReferenceID, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTwoFactor", reflect.TypeOf((*MockClient)(nil).ConfirmTwoFactor), userReferenceID, in)
+}
+
+// DisableTwoFactor mocks base method.
+func (m *MockClient) DisableTwoFactor(userReferenceID string, in *users.DisableTwoFactorRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", userReferenceID, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockClientMockRecorder) DisableTwoFactor(userReferenceID, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockClient)(nil).DisableTwoFactor), userReferenceID, in)
+}
+
+// GetExport mocks base method.
+func (m *MockClient) GetExport(userReferenceID string) (*users.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", userReferenceID)
+	ret0, _ := ret[0].(*users.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockClientMockRecorder) GetExport(userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockClient)(nil).GetExport), userReferenceID)
+}
+
+// RequestDeletion mocks base method.
+func (m *MockClient) RequestDeletion(userReferenceID string, in *users.RequestDeletionRequest) (*users.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestDeletion", userReferenceID, in)
+	ret0, _ := ret[0].(*users.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestDeletion indicates an expected call of RequestDeletion.
+func (mr *MockClientMockRecorder) RequestDeletion(userReferenceID, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDeletion", reflect.TypeOf((*MockClient)(nil).RequestDeletion), userReferenceID, in)
+}
+
+// CancelDeletion mocks base method.
+func (m *MockClient) CancelDeletion(userReferenceID string) (*users.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelDeletion", userReferenceID)
+	ret0, _ := ret[0].(*users.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelDeletion indicates an expected call of CancelDeletion.
+func (mr *MockClientMockRecorder) CancelDeletion(userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelDeletion", reflect.TypeOf((*MockClient)(nil).CancelDeletion), userReferenceID)
+}
+
+// GetDeletion mocks base method.
+func (m *MockClient) GetDeletion(userReferenceID string) (*users.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletion", userReferenceID)
+	ret0, _ := ret[0].(*users.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletion indicates an expected call of GetDeletion.
+func (mr *MockClientMockRecorder) GetDeletion(userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletion", reflect.TypeOf((*MockClient)(nil).GetDeletion), userReferenceID)
+}
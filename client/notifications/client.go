@@ -0,0 +1,79 @@
+package notifications
+
+import (
+	"fmt"
+
+	"github.com/reecerussell/open-social/client"
+)
+
+// Client is an interface used to interact with the notifications API.
+type Client interface {
+	List(userReferenceID string) ([]*Notification, error)
+	GetUnreadCount(userReferenceID string) (int, error)
+	MarkRead(userReferenceID string, ids []string) error
+	DeleteUser(userReferenceID string) error
+}
+
+type notificationsClient struct {
+	base client.HTTP
+}
+
+// New returns a new instance of the notifications client.
+func New(url string) Client {
+	return &notificationsClient{
+		base: client.NewHTTP(url),
+	}
+}
+
+func (c *notificationsClient) List(userReferenceID string) ([]*Notification, error) {
+	var notifications []*Notification
+	err := c.base.Get("/notifications/"+userReferenceID, &notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (c *notificationsClient) GetUnreadCount(userReferenceID string) (int, error) {
+	var resp struct {
+		Count int `json:"count"`
+	}
+	url := fmt.Sprintf("/notifications/%s/unread", userReferenceID)
+	err := c.base.Get(url, &resp)
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Count, nil
+}
+
+// MarkRead marks the user's notifications with the given ids as read. If
+// ids is empty, all of the user's notifications are marked as read.
+func (c *notificationsClient) MarkRead(userReferenceID string, ids []string) error {
+	if ids == nil {
+		ids = []string{}
+	}
+
+	payload := map[string][]string{
+		"ids": ids,
+	}
+
+	url := fmt.Sprintf("/notifications/%s/read", userReferenceID)
+	err := c.base.Post(url, payload, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteUser deletes all of the notifications relating to the user, when their account is deleted.
+func (c *notificationsClient) DeleteUser(userReferenceID string) error {
+	err := c.base.Delete("/notifications/"+userReferenceID, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package notifications
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/client/mock"
+)
+
+func TestNew(t *testing.T) {
+	c := New("http://test.io")
+	assert.NotNil(t, c)
+	assert.NotNil(t, c.(*notificationsClient).base)
+}
+
+func TestList_GivenValidUserReference_ReturnsNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2340703470324"
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/notifications/"+testUserReferenceID, gomock.Any()).
+		DoAndReturn(func(url string, respDest interface{}) error {
+			resp := respDest.(*[]*Notification)
+			*resp = append(*resp, &Notification{
+				ID:   "123",
+				Type: "follow",
+			})
+
+			return nil
+		})
+
+	c := &notificationsClient{base: mockHTTP}
+
+	notifications, err := c.List(testUserReferenceID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(notifications))
+	assert.Equal(t, "123", notifications[0].ID)
+	assert.Equal(t, "follow", notifications[0].Type)
+}
+
+func TestList_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2340703470324"
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/notifications/"+testUserReferenceID, gomock.Any()).Return(testError)
+
+	c := &notificationsClient{base: mockHTTP}
+
+	notifications, err := c.List(testUserReferenceID)
+	assert.Nil(t, notifications)
+	assert.Equal(t, testError, err)
+}
+
+func TestGetUnreadCount_GivenValidUserReference_ReturnsCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2340703470324"
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/notifications/"+testUserReferenceID+"/unread", gomock.Any()).
+		DoAndReturn(func(url string, respDest interface{}) error {
+			resp := respDest.(*struct {
+				Count int `json:"count"`
+			})
+			resp.Count = 5
+
+			return nil
+		})
+
+	c := &notificationsClient{base: mockHTTP}
+
+	count, err := c.GetUnreadCount(testUserReferenceID)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, count)
+}
+
+func TestGetUnreadCount_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2340703470324"
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/notifications/"+testUserReferenceID+"/unread", gomock.Any()).Return(testError)
+
+	c := &notificationsClient{base: mockHTTP}
+
+	count, err := c.GetUnreadCount(testUserReferenceID)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, testError, err)
+}
+
+func TestMarkRead_GivenNoIDs_SendsEmptyList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2340703470324"
+	testPayload := map[string][]string{"ids": {}}
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/notifications/"+testUserReferenceID+"/read", testPayload, nil).Return(nil)
+
+	c := &notificationsClient{base: mockHTTP}
+
+	err := c.MarkRead(testUserReferenceID, nil)
+	assert.NoError(t, err)
+}
+
+func TestMarkRead_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2340703470324"
+	testPayload := map[string][]string{"ids": {"1"}}
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/notifications/"+testUserReferenceID+"/read", testPayload, nil).Return(testError)
+
+	c := &notificationsClient{base: mockHTTP}
+
+	err := c.MarkRead(testUserReferenceID, []string{"1"})
+	assert.Equal(t, testError, err)
+}
+
+func TestDeleteUser_GivenValidUser_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2340703470324"
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Delete("/notifications/"+testUserReferenceID, nil).Return(nil)
+
+	c := &notificationsClient{base: mockHTTP}
+
+	err := c.DeleteUser(testUserReferenceID)
+	assert.NoError(t, err)
+}
+
+func TestDeleteUser_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2340703470324"
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Delete("/notifications/"+testUserReferenceID, nil).Return(testError)
+
+	c := &notificationsClient{base: mockHTTP}
+
+	err := c.DeleteUser(testUserReferenceID)
+	assert.Equal(t, testError, err)
+}
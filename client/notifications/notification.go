@@ -0,0 +1,13 @@
+package notifications
+
+import "time"
+
+// Notification represents a notification sent to a user.
+type Notification struct {
+	ID       string    `json:"id"`
+	Type     string    `json:"type"`
+	Username string    `json:"username"`
+	PostID   *string   `json:"postId"`
+	Created  time.Time `json:"created"`
+	IsRead   bool      `json:"isRead"`
+}
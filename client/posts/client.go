@@ -2,6 +2,8 @@ package posts
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 
 	"github.com/reecerussell/open-social/client"
 )
@@ -9,11 +11,16 @@ import (
 // Client is an interface used to interact with the posts API.
 type Client interface {
 	Create(in *CreateRequest) (*CreateResponse, error)
-	GetFeed(userReferenceID string) ([]*FeedItem, error)
-	GetProfileFeed(username, userReferenceID string) ([]*FeedItem, error)
+	GetFeed(userReferenceID, cursor string, limit int) (*Feed, error)
+	GetProfileFeed(username, userReferenceID, cursor string, limit int) (*Feed, error)
 	LikePost(postReferenceID, userReferenceID string) error
 	UnlikePost(postReferenceID, userReferenceID string) error
 	Get(postReferenceID, userReferenceID string) (*Post, error)
+	CreateComment(postReferenceID string, in *CreateCommentRequest) (*CreateCommentResponse, error)
+	GetComments(postReferenceID, userReferenceID string) ([]*Comment, error)
+	DeleteComment(postReferenceID, commentReferenceID, userReferenceID string) error
+	DeleteUser(userReferenceID string) (*DeleteUserResponse, error)
+	GetExport(userReferenceID string) (*Export, error)
 }
 
 type postsClient struct {
@@ -37,25 +44,25 @@ func (c *postsClient) Create(in *CreateRequest) (*CreateResponse, error) {
 	return &resp, nil
 }
 
-func (c *postsClient) GetFeed(userReferenceID string) ([]*FeedItem, error) {
-	var items []*FeedItem
-	err := c.base.Get("/feed/"+userReferenceID, &items)
+func (c *postsClient) GetFeed(userReferenceID, cursor string, limit int) (*Feed, error) {
+	var feed Feed
+	err := c.base.Get("/feed/"+userReferenceID+pageQuery(cursor, limit), &feed)
 	if err != nil {
 		return nil, err
 	}
 
-	return items, nil
+	return &feed, nil
 }
 
-func (c *postsClient) GetProfileFeed(username, userReferenceID string) ([]*FeedItem, error) {
-	var items []*FeedItem
-	url := fmt.Sprintf("/profile/feed/%s/%s", username, userReferenceID)
-	err := c.base.Get(url, &items)
+func (c *postsClient) GetProfileFeed(username, userReferenceID, cursor string, limit int) (*Feed, error) {
+	var feed Feed
+	url := fmt.Sprintf("/profile/feed/%s/%s", username, userReferenceID) + pageQuery(cursor, limit)
+	err := c.base.Get(url, &feed)
 	if err != nil {
 		return nil, err
 	}
 
-	return items, nil
+	return &feed, nil
 }
 
 func (c *postsClient) LikePost(postReferenceID, userReferenceID string) error {
@@ -96,3 +103,74 @@ func (c *postsClient) Get(postReferenceID, userReferenceID string) (*Post, error
 
 	return &post, nil
 }
+
+func (c *postsClient) CreateComment(postReferenceID string, in *CreateCommentRequest) (*CreateCommentResponse, error) {
+	var resp CreateCommentResponse
+	url := fmt.Sprintf("/posts/%s/comments", postReferenceID)
+	err := c.base.Post(url, in, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *postsClient) GetComments(postReferenceID, userReferenceID string) ([]*Comment, error) {
+	var comments []*Comment
+	url := fmt.Sprintf("/posts/%s/comments/%s", postReferenceID, userReferenceID)
+	err := c.base.Get(url, &comments)
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (c *postsClient) DeleteComment(postReferenceID, commentReferenceID, userReferenceID string) error {
+	url := fmt.Sprintf("/posts/%s/comments/%s/%s", postReferenceID, commentReferenceID, userReferenceID)
+	err := c.base.Delete(url, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *postsClient) DeleteUser(userReferenceID string) (*DeleteUserResponse, error) {
+	var resp DeleteUserResponse
+	err := c.base.Post("/users/delete/"+userReferenceID, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *postsClient) GetExport(userReferenceID string) (*Export, error) {
+	var export Export
+	err := c.base.Get("/users/export/"+userReferenceID, &export)
+	if err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+// pageQuery builds the query string used to request a page of a feed,
+// omitting any values which have not been set.
+func pageQuery(cursor string, limit int) string {
+	q := url.Values{}
+	if cursor != "" {
+		q.Set("cursor", cursor)
+	}
+
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	if len(q) == 0 {
+		return ""
+	}
+
+	return "?" + q.Encode()
+}
@@ -71,8 +71,8 @@ func TestGetFeed_GivenValidUserReference_ReturnsFeed(t *testing.T) {
 	mockHTTP := mock.NewMockHTTP(ctrl)
 	mockHTTP.EXPECT().Get("/feed/"+testUserReferenceID, gomock.Any()).
 		DoAndReturn(func(url string, respDest interface{}) error {
-			resp := (respDest.(*[]*FeedItem))
-			*resp = append(*resp, &FeedItem{
+			resp := (respDest.(*Feed))
+			resp.Items = append(resp.Items, &FeedItem{
 				Caption: "Hello World",
 			})
 
@@ -81,10 +81,10 @@ func TestGetFeed_GivenValidUserReference_ReturnsFeed(t *testing.T) {
 
 	c := &postsClient{base: mockHTTP}
 
-	feedItems, err := c.GetFeed(testUserReferenceID)
+	feed, err := c.GetFeed(testUserReferenceID, "", 0)
 	assert.NoError(t, err)
-	assert.Equal(t, 1, len(feedItems))
-	assert.Equal(t, "Hello World", feedItems[0].Caption)
+	assert.Equal(t, 1, len(feed.Items))
+	assert.Equal(t, "Hello World", feed.Items[0].Caption)
 }
 
 func TestGetFeed_RequestFails_ReturnsError(t *testing.T) {
@@ -99,11 +99,36 @@ func TestGetFeed_RequestFails_ReturnsError(t *testing.T) {
 
 	c := &postsClient{base: mockHTTP}
 
-	feedItems, err := c.GetFeed(testUserReferenceID)
-	assert.Nil(t, feedItems)
+	feed, err := c.GetFeed(testUserReferenceID, "", 0)
+	assert.Nil(t, feed)
 	assert.Equal(t, testError, err)
 }
 
+func TestGetFeed_GivenCursorAndLimit_RequestsPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2340703470324"
+	testCursor := "MjAyMS0wMy0wNFQxMjozMDoxNVp8YWJj"
+	testNextCursor := "bmV4dA"
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	expectedURL := fmt.Sprintf("/feed/%s?cursor=%s&limit=10", testUserReferenceID, testCursor)
+	mockHTTP.EXPECT().Get(expectedURL, gomock.Any()).
+		DoAndReturn(func(url string, respDest interface{}) error {
+			resp := (respDest.(*Feed))
+			resp.NextCursor = &testNextCursor
+
+			return nil
+		})
+
+	c := &postsClient{base: mockHTTP}
+
+	feed, err := c.GetFeed(testUserReferenceID, testCursor, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, testNextCursor, *feed.NextCursor)
+}
+
 func TestGetProfileFeed_GivenValidUserReference_ReturnsFeed(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -115,8 +140,8 @@ func TestGetProfileFeed_GivenValidUserReference_ReturnsFeed(t *testing.T) {
 	expectedURL := fmt.Sprintf("/profile/feed/%s/%s", testUsername, testUserReferenceID)
 	mockHTTP.EXPECT().Get(expectedURL, gomock.Any()).
 		DoAndReturn(func(url string, respDest interface{}) error {
-			resp := (respDest.(*[]*FeedItem))
-			*resp = append(*resp, &FeedItem{
+			resp := (respDest.(*Feed))
+			resp.Items = append(resp.Items, &FeedItem{
 				Caption: "Hello World",
 			})
 
@@ -125,10 +150,10 @@ func TestGetProfileFeed_GivenValidUserReference_ReturnsFeed(t *testing.T) {
 
 	c := &postsClient{base: mockHTTP}
 
-	feedItems, err := c.GetProfileFeed(testUsername, testUserReferenceID)
+	feed, err := c.GetProfileFeed(testUsername, testUserReferenceID, "", 0)
 	assert.NoError(t, err)
-	assert.Equal(t, 1, len(feedItems))
-	assert.Equal(t, "Hello World", feedItems[0].Caption)
+	assert.Equal(t, 1, len(feed.Items))
+	assert.Equal(t, "Hello World", feed.Items[0].Caption)
 }
 
 func TestGetProfileFeed_RequestFails_ReturnsError(t *testing.T) {
@@ -145,8 +170,8 @@ func TestGetProfileFeed_RequestFails_ReturnsError(t *testing.T) {
 
 	c := &postsClient{base: mockHTTP}
 
-	feedItems, err := c.GetProfileFeed(testUsername, testUserReferenceID)
-	assert.Nil(t, feedItems)
+	feed, err := c.GetProfileFeed(testUsername, testUserReferenceID, "", 0)
+	assert.Nil(t, feed)
 	assert.Equal(t, testError, err)
 }
 
@@ -266,3 +291,201 @@ func TestGet_RequestFails_ReturnsError(t *testing.T) {
 	assert.Nil(t, post)
 	assert.Equal(t, testError, err)
 }
+
+func TestCreateComment_GivenValidData_ReturnsSuccessfulResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testPostReferenceID := "3294849323233"
+	testInput := &CreateCommentRequest{
+		UserReferenceID: "12343543",
+		Text:            "Hello World",
+	}
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/posts/"+testPostReferenceID+"/comments", testInput, gomock.Any()).
+		DoAndReturn(func(url string, body, respDest interface{}) error {
+			resp := respDest.(*CreateCommentResponse)
+			resp.ReferenceID = "2304734"
+
+			return nil
+		})
+
+	c := &postsClient{base: mockHTTP}
+
+	resp, err := c.CreateComment(testPostReferenceID, testInput)
+	assert.NoError(t, err)
+	assert.Equal(t, "2304734", resp.ReferenceID)
+}
+
+func TestCreateComment_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testPostReferenceID := "3294849323233"
+	testInput := &CreateCommentRequest{
+		UserReferenceID: "12343543",
+		Text:            "Hello World",
+	}
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/posts/"+testPostReferenceID+"/comments", testInput, gomock.Any()).Return(testError)
+
+	c := &postsClient{base: mockHTTP}
+
+	resp, err := c.CreateComment(testPostReferenceID, testInput)
+	assert.Nil(t, resp)
+	assert.Equal(t, testError, err)
+}
+
+func TestGetComments_GivenValidReferences_ReturnsComments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2340703470324"
+	testPostReferenceID := "3294849323233"
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	expectedURL := fmt.Sprintf("/posts/%s/comments/%s", testPostReferenceID, testUserReferenceID)
+	mockHTTP.EXPECT().Get(expectedURL, gomock.Any()).
+		DoAndReturn(func(url string, respDest interface{}) error {
+			resp := respDest.(*[]*Comment)
+			*resp = append(*resp, &Comment{
+				Text: "Hello World",
+			})
+
+			return nil
+		})
+
+	c := &postsClient{base: mockHTTP}
+	comments, err := c.GetComments(testPostReferenceID, testUserReferenceID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(comments))
+	assert.Equal(t, "Hello World", comments[0].Text)
+}
+
+func TestGetComments_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2340703470324"
+	testPostReferenceID := "3294849323233"
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	expectedURL := fmt.Sprintf("/posts/%s/comments/%s", testPostReferenceID, testUserReferenceID)
+	mockHTTP.EXPECT().Get(expectedURL, gomock.Any()).Return(testError)
+
+	c := &postsClient{base: mockHTTP}
+	comments, err := c.GetComments(testPostReferenceID, testUserReferenceID)
+	assert.Nil(t, comments)
+	assert.Equal(t, testError, err)
+}
+
+func TestDeleteComment_GivenValidReferences_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2340703470324"
+	testPostReferenceID := "3294849323233"
+	testCommentReferenceID := "2389"
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	expectedURL := fmt.Sprintf("/posts/%s/comments/%s/%s", testPostReferenceID, testCommentReferenceID, testUserReferenceID)
+	mockHTTP.EXPECT().Delete(expectedURL, nil).Return(nil)
+
+	c := &postsClient{base: mockHTTP}
+	err := c.DeleteComment(testPostReferenceID, testCommentReferenceID, testUserReferenceID)
+	assert.NoError(t, err)
+}
+
+func TestDeleteComment_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2340703470324"
+	testPostReferenceID := "3294849323233"
+	testCommentReferenceID := "2389"
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	expectedURL := fmt.Sprintf("/posts/%s/comments/%s/%s", testPostReferenceID, testCommentReferenceID, testUserReferenceID)
+	mockHTTP.EXPECT().Delete(expectedURL, nil).Return(testError)
+
+	c := &postsClient{base: mockHTTP}
+	err := c.DeleteComment(testPostReferenceID, testCommentReferenceID, testUserReferenceID)
+	assert.Equal(t, testError, err)
+}
+
+func TestDeleteUser_GivenUserReferenceID_ReturnsMediaIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/users/delete/2389", nil, gomock.Any()).
+		DoAndReturn(func(url string, body, respDest interface{}) error {
+			resp := respDest.(*DeleteUserResponse)
+			resp.MediaIDs = []string{"3289"}
+
+			return nil
+		})
+
+	c := &postsClient{base: mockHTTP}
+
+	resp, err := c.DeleteUser("2389")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3289"}, resp.MediaIDs)
+}
+
+func TestDeleteUser_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/users/delete/2389", nil, gomock.Any()).Return(testError)
+
+	c := &postsClient{base: mockHTTP}
+
+	resp, err := c.DeleteUser("2389")
+	assert.Nil(t, resp)
+	assert.Equal(t, testError, err)
+}
+
+func TestGetExport_GivenUserReferenceID_ReturnsExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/users/export/2389", gomock.Any()).
+		DoAndReturn(func(url string, respDest interface{}) error {
+			export := respDest.(*Export)
+			export.Likes = []*ExportLike{{PostID: "3289"}}
+
+			return nil
+		})
+
+	c := &postsClient{base: mockHTTP}
+
+	export, err := c.GetExport("2389")
+	assert.NoError(t, err)
+	assert.Equal(t, "3289", export.Likes[0].PostID)
+}
+
+func TestGetExport_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/users/export/2389", gomock.Any()).Return(testError)
+
+	c := &postsClient{base: mockHTTP}
+
+	export, err := c.GetExport("2389")
+	assert.Nil(t, export)
+	assert.Equal(t, testError, err)
+}
@@ -0,0 +1,7 @@
+package posts
+
+// CreateCommentRequest is the body of the request.
+type CreateCommentRequest struct {
+	UserReferenceID string `json:"userReferenceId"`
+	Text            string `json:"text"`
+}
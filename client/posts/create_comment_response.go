@@ -0,0 +1,6 @@
+package posts
+
+// CreateCommentResponse is the response of the request.
+type CreateCommentResponse struct {
+	ReferenceID string `json:"referenceId"`
+}
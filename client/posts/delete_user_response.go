@@ -0,0 +1,7 @@
+package posts
+
+// DeleteUserResponse contains the reference ids of the media which was
+// attached to a deleted user's posts.
+type DeleteUserResponse struct {
+	MediaIDs []string `json:"mediaIds"`
+}
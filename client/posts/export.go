@@ -0,0 +1,31 @@
+package posts
+
+import "time"
+
+// Export contains all of a user's post data, for their personal data export.
+type Export struct {
+	Posts    []*ExportPost    `json:"posts"`
+	Likes    []*ExportLike    `json:"likes"`
+	Comments []*ExportComment `json:"comments"`
+}
+
+// ExportPost is a post made by the user.
+type ExportPost struct {
+	ID      string    `json:"id"`
+	MediaID *string   `json:"mediaId"`
+	Posted  time.Time `json:"posted"`
+	Caption string    `json:"caption"`
+}
+
+// ExportLike is a post the user has liked.
+type ExportLike struct {
+	PostID string `json:"postId"`
+}
+
+// ExportComment is a comment the user has made on a post.
+type ExportComment struct {
+	ID     string    `json:"id"`
+	PostID string    `json:"postId"`
+	Posted time.Time `json:"posted"`
+	Text   string    `json:"text"`
+}
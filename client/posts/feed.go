@@ -0,0 +1,7 @@
+package posts
+
+// Feed is a page of feed items, returned from the posts API.
+type Feed struct {
+	Items      []*FeedItem `json:"items"`
+	NextCursor *string     `json:"nextCursor"`
+}
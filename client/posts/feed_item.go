@@ -10,6 +10,7 @@ type FeedItem struct {
 	Posted   time.Time `json:"posted"`
 	Username string    `json:"username"`
 	Likes    int       `json:"likes"`
+	Comments int       `json:"comments"`
 	HasLiked bool      `json:"hasLiked"`
 	IsAuthor bool      `json:"isAuthor"`
 }
@@ -2,6 +2,8 @@ package users
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 
 	"github.com/reecerussell/open-social/client"
 )
@@ -10,11 +12,34 @@ import (
 type Client interface {
 	Create(in *CreateUserRequest) (*CreateUserResponse, error)
 	GetClaims(in *GetClaimsRequest) (*GetClaimsResponse, error)
+	GetClaimsByReference(userReferenceID string) (*GetClaimsResponse, error)
 	GetIDByReference(referenceID string) (*int, error)
 	GetProfile(username, userReferenceID string) (*Profile, error)
 	GetInfo(userReferenceID string) (*Info, error)
-	Follow(userReferenceID, followerReferenceID string) error
+	GetFollowers(username, userReferenceID, cursor string, limit int) (*FollowList, error)
+	GetFollowing(username, userReferenceID, cursor string, limit int) (*FollowList, error)
+	GetFollowRequests(userReferenceID, cursor string, limit int) (*FollowList, error)
+	ApproveFollowRequest(userReferenceID, followerReferenceID string) error
+	RejectFollowRequest(userReferenceID, followerReferenceID string) error
+	UpdateProfile(userReferenceID string, in *UpdateProfileRequest) (*UpdateProfileResponse, error)
+	Follow(userReferenceID, followerReferenceID string) (*FollowResponse, error)
 	Unfollow(userReferenceID, followerReferenceID string) error
+	Block(userReferenceID, blockerReferenceID string) error
+	Unblock(userReferenceID, blockerReferenceID string) error
+	GetBlockedUsers(userReferenceID, cursor string, limit int) (*FollowList, error)
+	Mute(userReferenceID, muterReferenceID string) error
+	Unmute(userReferenceID, muterReferenceID string) error
+	ChangePassword(userReferenceID string, in *ChangePasswordRequest) error
+	RequestPasswordReset(in *RequestPasswordResetRequest) error
+	ResetPassword(in *ResetPasswordRequest) (*ResetPasswordResponse, error)
+	VerifySecondFactor(in *VerifySecondFactorRequest) (*GetClaimsResponse, error)
+	EnrolTwoFactor(userReferenceID string) (*EnrolTwoFactorResponse, error)
+	ConfirmTwoFactor(userReferenceID string, in *ConfirmTwoFactorRequest) (*ConfirmTwoFactorResponse, error)
+	DisableTwoFactor(userReferenceID string, in *DisableTwoFactorRequest) error
+	GetExport(userReferenceID string) (*Export, error)
+	RequestDeletion(userReferenceID string, in *RequestDeletionRequest) (*AccountDeletion, error)
+	CancelDeletion(userReferenceID string) (*AccountDeletion, error)
+	GetDeletion(userReferenceID string) (*AccountDeletion, error)
 }
 
 // New returns a new instance of Client.
@@ -48,6 +73,16 @@ func (c *usersClient) GetClaims(in *GetClaimsRequest) (*GetClaimsResponse, error
 	return &resp, nil
 }
 
+func (c *usersClient) GetClaimsByReference(userReferenceID string) (*GetClaimsResponse, error) {
+	var resp GetClaimsResponse
+	err := c.base.Get("/claims/"+userReferenceID, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
 func (c *usersClient) GetIDByReference(referenceID string) (*int, error) {
 	var resp GetIDByReferenceResponse
 	err := c.base.Get("/users/id/"+referenceID, &resp)
@@ -80,8 +115,72 @@ func (c *usersClient) GetInfo(userReferenceID string) (*Info, error) {
 	return &info, nil
 }
 
-func (c *usersClient) Follow(userReferenceID, followerReferenceID string) error {
+func (c *usersClient) GetFollowers(username, userReferenceID, cursor string, limit int) (*FollowList, error) {
+	var list FollowList
+	url := fmt.Sprintf("/followers/%s/%s", username, userReferenceID) + pageQuery(cursor, limit)
+	err := c.base.Get(url, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+func (c *usersClient) GetFollowing(username, userReferenceID, cursor string, limit int) (*FollowList, error) {
+	var list FollowList
+	url := fmt.Sprintf("/following/%s/%s", username, userReferenceID) + pageQuery(cursor, limit)
+	err := c.base.Get(url, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+func (c *usersClient) UpdateProfile(userReferenceID string, in *UpdateProfileRequest) (*UpdateProfileResponse, error) {
+	var resp UpdateProfileResponse
+	url := fmt.Sprintf("/profile/update/%s", userReferenceID)
+	err := c.base.Post(url, in, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *usersClient) Follow(userReferenceID, followerReferenceID string) (*FollowResponse, error) {
+	var resp FollowResponse
 	url := fmt.Sprintf("/follow/%s/%s", userReferenceID, followerReferenceID)
+	err := c.base.Post(url, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *usersClient) GetFollowRequests(userReferenceID, cursor string, limit int) (*FollowList, error) {
+	var list FollowList
+	err := c.base.Get("/follow-requests/"+userReferenceID+pageQuery(cursor, limit), &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+func (c *usersClient) ApproveFollowRequest(userReferenceID, followerReferenceID string) error {
+	url := fmt.Sprintf("/follow-requests/approve/%s/%s", userReferenceID, followerReferenceID)
+	err := c.base.Post(url, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *usersClient) RejectFollowRequest(userReferenceID, followerReferenceID string) error {
+	url := fmt.Sprintf("/follow-requests/reject/%s/%s", userReferenceID, followerReferenceID)
 	err := c.base.Post(url, nil, nil)
 	if err != nil {
 		return err
@@ -99,3 +198,183 @@ func (c *usersClient) Unfollow(userReferenceID, followerReferenceID string) erro
 
 	return nil
 }
+
+func (c *usersClient) Block(userReferenceID, blockerReferenceID string) error {
+	url := fmt.Sprintf("/block/%s/%s", userReferenceID, blockerReferenceID)
+	err := c.base.Post(url, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *usersClient) Unblock(userReferenceID, blockerReferenceID string) error {
+	url := fmt.Sprintf("/unblock/%s/%s", userReferenceID, blockerReferenceID)
+	err := c.base.Post(url, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *usersClient) GetBlockedUsers(userReferenceID, cursor string, limit int) (*FollowList, error) {
+	var list FollowList
+	err := c.base.Get("/blocks/"+userReferenceID+pageQuery(cursor, limit), &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+func (c *usersClient) Mute(userReferenceID, muterReferenceID string) error {
+	url := fmt.Sprintf("/mute/%s/%s", userReferenceID, muterReferenceID)
+	err := c.base.Post(url, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *usersClient) Unmute(userReferenceID, muterReferenceID string) error {
+	url := fmt.Sprintf("/unmute/%s/%s", userReferenceID, muterReferenceID)
+	err := c.base.Post(url, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *usersClient) ChangePassword(userReferenceID string, in *ChangePasswordRequest) error {
+	url := fmt.Sprintf("/password/change/%s", userReferenceID)
+	err := c.base.Post(url, in, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *usersClient) RequestPasswordReset(in *RequestPasswordResetRequest) error {
+	err := c.base.Post("/password/reset", in, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *usersClient) ResetPassword(in *ResetPasswordRequest) (*ResetPasswordResponse, error) {
+	var resp ResetPasswordResponse
+	err := c.base.Post("/password/reset/confirm", in, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *usersClient) VerifySecondFactor(in *VerifySecondFactorRequest) (*GetClaimsResponse, error) {
+	var resp GetClaimsResponse
+	err := c.base.Post("/claims/2fa", in, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *usersClient) EnrolTwoFactor(userReferenceID string) (*EnrolTwoFactorResponse, error) {
+	var resp EnrolTwoFactorResponse
+	url := fmt.Sprintf("/2fa/enrol/%s", userReferenceID)
+	err := c.base.Post(url, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *usersClient) ConfirmTwoFactor(userReferenceID string, in *ConfirmTwoFactorRequest) (*ConfirmTwoFactorResponse, error) {
+	var resp ConfirmTwoFactorResponse
+	url := fmt.Sprintf("/2fa/confirm/%s", userReferenceID)
+	err := c.base.Post(url, in, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *usersClient) DisableTwoFactor(userReferenceID string, in *DisableTwoFactorRequest) error {
+	url := fmt.Sprintf("/2fa/disable/%s", userReferenceID)
+	err := c.base.Post(url, in, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *usersClient) GetExport(userReferenceID string) (*Export, error) {
+	var export Export
+	err := c.base.Get("/export/"+userReferenceID, &export)
+	if err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+func (c *usersClient) RequestDeletion(userReferenceID string, in *RequestDeletionRequest) (*AccountDeletion, error) {
+	var deletion AccountDeletion
+	err := c.base.Post("/deletion/request/"+userReferenceID, in, &deletion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deletion, nil
+}
+
+func (c *usersClient) CancelDeletion(userReferenceID string) (*AccountDeletion, error) {
+	var deletion AccountDeletion
+	err := c.base.Post("/deletion/cancel/"+userReferenceID, nil, &deletion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deletion, nil
+}
+
+func (c *usersClient) GetDeletion(userReferenceID string) (*AccountDeletion, error) {
+	var deletion AccountDeletion
+	err := c.base.Get("/deletion/"+userReferenceID, &deletion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deletion, nil
+}
+
+// pageQuery builds the query string used to request a page of a list,
+// omitting any values which have not been set.
+func pageQuery(cursor string, limit int) string {
+	q := url.Values{}
+	if cursor != "" {
+		q.Set("cursor", cursor)
+	}
+
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	if len(q) == 0 {
+		return ""
+	}
+
+	return "?" + q.Encode()
+}
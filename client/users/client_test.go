@@ -111,6 +111,47 @@ func TestGetClaims_RequestFails_ReturnsError(t *testing.T) {
 	assert.Equal(t, testError, err)
 }
 
+func TestGetClaimsByReference_GivenValidReferenceID_ReturnsClaims(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testReferenceID := "304324"
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/claims/"+testReferenceID, gomock.Any()).
+		DoAndReturn(func(url string, respDest interface{}) error {
+			resp := respDest.(*GetClaimsResponse)
+			resp.Claims = map[string]interface{}{
+				"foo": "bar",
+			}
+
+			return nil
+		})
+
+	c := &usersClient{base: mockHTTP}
+
+	resp, err := c.GetClaimsByReference(testReferenceID)
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", resp.Claims["foo"])
+}
+
+func TestGetClaimsByReference_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testReferenceID := "304324"
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/claims/"+testReferenceID, gomock.Any()).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	resp, err := c.GetClaimsByReference(testReferenceID)
+	assert.Nil(t, resp)
+	assert.Equal(t, testError, err)
+}
+
 func TestGetIDByReference_GivenValidReferenceID_ReturnsUserID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -234,6 +275,129 @@ func TestGetInfo_RequestFails_ReturnsError(t *testing.T) {
 	assert.Equal(t, testError, err)
 }
 
+func TestGetFollowers_GivenPage_ReturnsList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUsername := "test"
+	testReferenceID := "304324"
+
+	expectedURL := fmt.Sprintf("/followers/%s/%s?cursor=abc&limit=10", testUsername, testReferenceID)
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get(expectedURL, gomock.Any()).
+		DoAndReturn(func(url string, respDest interface{}) error {
+			resp := respDest.(*FollowList)
+			resp.Items = append(resp.Items, &Follow{Username: "alice"})
+
+			return nil
+		})
+
+	c := &usersClient{base: mockHTTP}
+
+	list, err := c.GetFollowers(testUsername, testReferenceID, "abc", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(list.Items))
+	assert.Equal(t, "alice", list.Items[0].Username)
+}
+
+func TestGetFollowers_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/followers/test/304324", gomock.Any()).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	list, err := c.GetFollowers("test", "304324", "", 0)
+	assert.Nil(t, list)
+	assert.Equal(t, testError, err)
+}
+
+func TestGetFollowing_GivenPage_ReturnsList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUsername := "test"
+	testReferenceID := "304324"
+
+	expectedURL := fmt.Sprintf("/following/%s/%s", testUsername, testReferenceID)
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get(expectedURL, gomock.Any()).
+		DoAndReturn(func(url string, respDest interface{}) error {
+			resp := respDest.(*FollowList)
+			resp.Items = append(resp.Items, &Follow{Username: "bob"})
+
+			return nil
+		})
+
+	c := &usersClient{base: mockHTTP}
+
+	list, err := c.GetFollowing(testUsername, testReferenceID, "", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(list.Items))
+	assert.Equal(t, "bob", list.Items[0].Username)
+}
+
+func TestGetFollowing_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/following/test/304324", gomock.Any()).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	list, err := c.GetFollowing("test", "304324", "", 0)
+	assert.Nil(t, list)
+	assert.Equal(t, testError, err)
+}
+
+func TestUpdateProfile_GivenValidData_ReturnsProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testBio := "Hello"
+	testInput := &UpdateProfileRequest{Bio: &testBio}
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/profile/update/3242", testInput, gomock.Any()).
+		DoAndReturn(func(url string, body, respDest interface{}) error {
+			resp := respDest.(*UpdateProfileResponse)
+			resp.Username = "test"
+			resp.Bio = &testBio
+
+			return nil
+		})
+
+	c := &usersClient{base: mockHTTP}
+
+	resp, err := c.UpdateProfile("3242", testInput)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", resp.Username)
+	assert.Equal(t, testBio, *resp.Bio)
+}
+
+func TestUpdateProfile_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/profile/update/3242", gomock.Any(), gomock.Any()).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	resp, err := c.UpdateProfile("3242", &UpdateProfileRequest{})
+	assert.Nil(t, resp)
+	assert.Equal(t, testError, err)
+}
+
 func TestFollow_GivenValidData_ReturnsInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -243,12 +407,19 @@ func TestFollow_GivenValidData_ReturnsInfo(t *testing.T) {
 
 	expectedURL := fmt.Sprintf("/follow/%s/%s", testUserReferenceID, testFollowerReferenceID)
 	mockHTTP := mock.NewMockHTTP(ctrl)
-	mockHTTP.EXPECT().Post(expectedURL, nil, nil).Return(nil)
+	mockHTTP.EXPECT().Post(expectedURL, nil, gomock.Any()).
+		DoAndReturn(func(url string, body, respDest interface{}) error {
+			resp := respDest.(*FollowResponse)
+			resp.IsRequested = true
+
+			return nil
+		})
 
 	c := &usersClient{base: mockHTTP}
 
-	err := c.Follow(testUserReferenceID, testFollowerReferenceID)
+	resp, err := c.Follow(testUserReferenceID, testFollowerReferenceID)
 	assert.NoError(t, err)
+	assert.True(t, resp.IsRequested)
 }
 
 func TestFollow_RequestFails_ReturnsError(t *testing.T) {
@@ -261,45 +432,631 @@ func TestFollow_RequestFails_ReturnsError(t *testing.T) {
 
 	expectedURL := fmt.Sprintf("/follow/%s/%s", testUserReferenceID, testFollowerReferenceID)
 	mockHTTP := mock.NewMockHTTP(ctrl)
-	mockHTTP.EXPECT().Post(expectedURL, nil, nil).Return(testError)
+	mockHTTP.EXPECT().Post(expectedURL, nil, gomock.Any()).Return(testError)
 
 	c := &usersClient{base: mockHTTP}
 
-	err := c.Follow(testUserReferenceID, testFollowerReferenceID)
+	resp, err := c.Follow(testUserReferenceID, testFollowerReferenceID)
+	assert.Nil(t, resp)
 	assert.Equal(t, testError, err)
 }
 
-func TestUnfollow_GivenValidData_ReturnsInfo(t *testing.T) {
+func TestGetFollowRequests_GivenPage_ReturnsList(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	testUserReferenceID := "304324"
-	testFollowerReferenceID := "302470"
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/follow-requests/304324?limit=5", gomock.Any()).
+		DoAndReturn(func(url string, respDest interface{}) error {
+			resp := respDest.(*FollowList)
+			resp.Items = append(resp.Items, &Follow{Username: "alice"})
+
+			return nil
+		})
+
+	c := &usersClient{base: mockHTTP}
+
+	list, err := c.GetFollowRequests("304324", "", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(list.Items))
+}
+
+func TestGetFollowRequests_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
 
-	expectedURL := fmt.Sprintf("/unfollow/%s/%s", testUserReferenceID, testFollowerReferenceID)
 	mockHTTP := mock.NewMockHTTP(ctrl)
-	mockHTTP.EXPECT().Post(expectedURL, nil, nil).Return(nil)
+	mockHTTP.EXPECT().Get("/follow-requests/304324", gomock.Any()).Return(testError)
 
 	c := &usersClient{base: mockHTTP}
 
-	err := c.Unfollow(testUserReferenceID, testFollowerReferenceID)
+	list, err := c.GetFollowRequests("304324", "", 0)
+	assert.Nil(t, list)
+	assert.Equal(t, testError, err)
+}
+
+func TestApproveFollowRequest_GivenValidData_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/follow-requests/approve/304324/302470", nil, nil).Return(nil)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.ApproveFollowRequest("304324", "302470")
 	assert.NoError(t, err)
 }
 
-func TestUnfollow_RequestFails_ReturnsError(t *testing.T) {
+func TestApproveFollowRequest_RequestFails_ReturnsError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	testUserReferenceID := "304324"
-	testFollowerReferenceID := "302470"
 	testError := errors.New("an error occured")
 
-	expectedURL := fmt.Sprintf("/unfollow/%s/%s", testUserReferenceID, testFollowerReferenceID)
 	mockHTTP := mock.NewMockHTTP(ctrl)
-	mockHTTP.EXPECT().Post(expectedURL, nil, nil).Return(testError)
+	mockHTTP.EXPECT().Post("/follow-requests/approve/304324/302470", nil, nil).Return(testError)
 
 	c := &usersClient{base: mockHTTP}
 
-	err := c.Unfollow(testUserReferenceID, testFollowerReferenceID)
+	err := c.ApproveFollowRequest("304324", "302470")
+	assert.Equal(t, testError, err)
+}
+
+func TestRejectFollowRequest_GivenValidData_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/follow-requests/reject/304324/302470", nil, nil).Return(nil)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.RejectFollowRequest("304324", "302470")
+	assert.NoError(t, err)
+}
+
+func TestRejectFollowRequest_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/follow-requests/reject/304324/302470", nil, nil).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.RejectFollowRequest("304324", "302470")
+	assert.Equal(t, testError, err)
+}
+
+func TestBlock_GivenValidData_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/block/304324/302470", nil, nil).Return(nil)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.Block("304324", "302470")
+	assert.NoError(t, err)
+}
+
+func TestBlock_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/block/304324/302470", nil, nil).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.Block("304324", "302470")
+	assert.Equal(t, testError, err)
+}
+
+func TestUnblock_GivenValidData_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/unblock/304324/302470", nil, nil).Return(nil)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.Unblock("304324", "302470")
+	assert.NoError(t, err)
+}
+
+func TestUnblock_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/unblock/304324/302470", nil, nil).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.Unblock("304324", "302470")
+	assert.Equal(t, testError, err)
+}
+
+func TestGetBlockedUsers_GivenPage_ReturnsList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/blocks/304324?limit=5", gomock.Any()).
+		DoAndReturn(func(url string, respDest interface{}) error {
+			resp := respDest.(*FollowList)
+			resp.Items = append(resp.Items, &Follow{Username: "alice"})
+
+			return nil
+		})
+
+	c := &usersClient{base: mockHTTP}
+
+	list, err := c.GetBlockedUsers("304324", "", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(list.Items))
+}
+
+func TestGetBlockedUsers_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/blocks/304324", gomock.Any()).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	list, err := c.GetBlockedUsers("304324", "", 0)
+	assert.Nil(t, list)
+	assert.Equal(t, testError, err)
+}
+
+func TestMute_GivenValidData_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/mute/304324/302470", nil, nil).Return(nil)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.Mute("304324", "302470")
+	assert.NoError(t, err)
+}
+
+func TestMute_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/mute/304324/302470", nil, nil).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.Mute("304324", "302470")
+	assert.Equal(t, testError, err)
+}
+
+func TestUnmute_GivenValidData_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/unmute/304324/302470", nil, nil).Return(nil)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.Unmute("304324", "302470")
+	assert.NoError(t, err)
+}
+
+func TestUnmute_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/unmute/304324/302470", nil, nil).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.Unmute("304324", "302470")
+	assert.Equal(t, testError, err)
+}
+
+func TestUnfollow_GivenValidData_ReturnsInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "304324"
+	testFollowerReferenceID := "302470"
+
+	expectedURL := fmt.Sprintf("/unfollow/%s/%s", testUserReferenceID, testFollowerReferenceID)
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post(expectedURL, nil, nil).Return(nil)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.Unfollow(testUserReferenceID, testFollowerReferenceID)
+	assert.NoError(t, err)
+}
+
+func TestUnfollow_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "304324"
+	testFollowerReferenceID := "302470"
+	testError := errors.New("an error occured")
+
+	expectedURL := fmt.Sprintf("/unfollow/%s/%s", testUserReferenceID, testFollowerReferenceID)
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post(expectedURL, nil, nil).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.Unfollow(testUserReferenceID, testFollowerReferenceID)
+	assert.Equal(t, testError, err)
+}
+
+func TestChangePassword_GivenValidData_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testInput := &ChangePasswordRequest{
+		CurrentPassword: "password123",
+		NewPassword:     "password456",
+	}
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/password/change/2394", testInput, nil).Return(nil)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.ChangePassword("2394", testInput)
+	assert.NoError(t, err)
+}
+
+func TestChangePassword_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/password/change/2394", gomock.Any(), nil).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.ChangePassword("2394", &ChangePasswordRequest{})
+	assert.Equal(t, testError, err)
+}
+
+func TestRequestPasswordReset_GivenValidData_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testInput := &RequestPasswordResetRequest{Username: "test"}
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/password/reset", testInput, nil).Return(nil)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.RequestPasswordReset(testInput)
+	assert.NoError(t, err)
+}
+
+func TestResetPassword_GivenValidData_ReturnsUserReferenceID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testInput := &ResetPasswordRequest{
+		Token:    "92834hf",
+		Password: "password123",
+	}
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/password/reset/confirm", testInput, gomock.Any()).
+		DoAndReturn(func(url string, body, respDest interface{}) error {
+			resp := respDest.(*ResetPasswordResponse)
+			resp.UserReferenceID = "2394"
+
+			return nil
+		})
+
+	c := &usersClient{base: mockHTTP}
+
+	resp, err := c.ResetPassword(testInput)
+	assert.NoError(t, err)
+	assert.Equal(t, "2394", resp.UserReferenceID)
+}
+
+func TestResetPassword_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/password/reset/confirm", gomock.Any(), gomock.Any()).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	resp, err := c.ResetPassword(&ResetPasswordRequest{})
+	assert.Nil(t, resp)
+	assert.Equal(t, testError, err)
+}
+
+func TestVerifySecondFactor_GivenValidData_ReturnsClaims(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testInput := &VerifySecondFactorRequest{
+		ChallengeToken: "293nf",
+		Code:           "123456",
+	}
+	testClaims := map[string]interface{}{"uid": "3242"}
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/claims/2fa", testInput, gomock.Any()).
+		DoAndReturn(func(url string, body, respDest interface{}) error {
+			resp := respDest.(*GetClaimsResponse)
+			resp.Claims = testClaims
+
+			return nil
+		})
+
+	c := &usersClient{base: mockHTTP}
+
+	resp, err := c.VerifySecondFactor(testInput)
+	assert.NoError(t, err)
+	assert.Equal(t, testClaims, resp.Claims)
+}
+
+func TestVerifySecondFactor_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/claims/2fa", gomock.Any(), gomock.Any()).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	resp, err := c.VerifySecondFactor(&VerifySecondFactorRequest{})
+	assert.Nil(t, resp)
+	assert.Equal(t, testError, err)
+}
+
+func TestEnrolTwoFactor_GivenValidData_ReturnsSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/2fa/enrol/3242", nil, gomock.Any()).
+		DoAndReturn(func(url string, body, respDest interface{}) error {
+			resp := respDest.(*EnrolTwoFactorResponse)
+			resp.Secret = "JBSWY3DPEHPK3PXP"
+
+			return nil
+		})
+
+	c := &usersClient{base: mockHTTP}
+
+	resp, err := c.EnrolTwoFactor("3242")
+	assert.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", resp.Secret)
+}
+
+func TestEnrolTwoFactor_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/2fa/enrol/3242", nil, gomock.Any()).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	resp, err := c.EnrolTwoFactor("3242")
+	assert.Nil(t, resp)
+	assert.Equal(t, testError, err)
+}
+
+func TestConfirmTwoFactor_GivenValidData_ReturnsRecoveryCodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testInput := &ConfirmTwoFactorRequest{Code: "123456"}
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/2fa/confirm/3242", testInput, gomock.Any()).
+		DoAndReturn(func(url string, body, respDest interface{}) error {
+			resp := respDest.(*ConfirmTwoFactorResponse)
+			resp.RecoveryCodes = []string{"abcd2345-efgh6723"}
+
+			return nil
+		})
+
+	c := &usersClient{base: mockHTTP}
+
+	resp, err := c.ConfirmTwoFactor("3242", testInput)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"abcd2345-efgh6723"}, resp.RecoveryCodes)
+}
+
+func TestConfirmTwoFactor_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/2fa/confirm/3242", gomock.Any(), gomock.Any()).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	resp, err := c.ConfirmTwoFactor("3242", &ConfirmTwoFactorRequest{})
+	assert.Nil(t, resp)
+	assert.Equal(t, testError, err)
+}
+
+func TestDisableTwoFactor_GivenValidData_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testInput := &DisableTwoFactorRequest{Password: "password123", Code: "123456"}
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/2fa/disable/3242", testInput, nil).Return(nil)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.DisableTwoFactor("3242", testInput)
+	assert.NoError(t, err)
+}
+
+func TestDisableTwoFactor_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/2fa/disable/3242", gomock.Any(), nil).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	err := c.DisableTwoFactor("3242", &DisableTwoFactorRequest{})
+	assert.Equal(t, testError, err)
+}
+
+func TestGetExport_GivenUserReferenceID_ReturnsExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/export/3242", gomock.Any()).
+		DoAndReturn(func(url string, respDest interface{}) error {
+			export := respDest.(*Export)
+			export.Username = "test"
+			export.Followers = []string{"alice"}
+
+			return nil
+		})
+
+	c := &usersClient{base: mockHTTP}
+
+	export, err := c.GetExport("3242")
+	assert.NoError(t, err)
+	assert.Equal(t, "test", export.Username)
+	assert.Equal(t, []string{"alice"}, export.Followers)
+}
+
+func TestGetExport_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/export/3242", gomock.Any()).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	export, err := c.GetExport("3242")
+	assert.Nil(t, export)
+	assert.Equal(t, testError, err)
+}
+
+func TestRequestDeletion_GivenValidData_ReturnsDeletion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testInput := &RequestDeletionRequest{Password: "password123"}
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/deletion/request/3242", testInput, gomock.Any()).
+		DoAndReturn(func(url string, body, respDest interface{}) error {
+			respDest.(*AccountDeletion).Status = DeletionScheduled
+
+			return nil
+		})
+
+	c := &usersClient{base: mockHTTP}
+
+	deletion, err := c.RequestDeletion("3242", testInput)
+	assert.NoError(t, err)
+	assert.Equal(t, DeletionScheduled, deletion.Status)
+}
+
+func TestRequestDeletion_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/deletion/request/3242", gomock.Any(), gomock.Any()).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	deletion, err := c.RequestDeletion("3242", &RequestDeletionRequest{})
+	assert.Nil(t, deletion)
+	assert.Equal(t, testError, err)
+}
+
+func TestCancelDeletion_GivenUserReferenceID_ReturnsDeletion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Post("/deletion/cancel/3242", nil, gomock.Any()).
+		DoAndReturn(func(url string, body, respDest interface{}) error {
+			respDest.(*AccountDeletion).Status = DeletionCancelled
+
+			return nil
+		})
+
+	c := &usersClient{base: mockHTTP}
+
+	deletion, err := c.CancelDeletion("3242")
+	assert.NoError(t, err)
+	assert.Equal(t, DeletionCancelled, deletion.Status)
+}
+
+func TestGetDeletion_RequestFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockHTTP := mock.NewMockHTTP(ctrl)
+	mockHTTP.EXPECT().Get("/deletion/3242", gomock.Any()).Return(testError)
+
+	c := &usersClient{base: mockHTTP}
+
+	deletion, err := c.GetDeletion("3242")
+	assert.Nil(t, deletion)
 	assert.Equal(t, testError, err)
 }
@@ -0,0 +1,29 @@
+package users
+
+import "time"
+
+// Account deletion statuses.
+const (
+	DeletionScheduled  = "scheduled"
+	DeletionInProgress = "inProgress"
+	DeletionCancelled  = "cancelled"
+	DeletionCompleted  = "completed"
+)
+
+// RequestDeletionRequest represents the request body of an account deletion request.
+type RequestDeletionRequest struct {
+	Password string `json:"password"`
+
+	// IPAddress is the address of the client, used to lock out
+	// clients making too many failed attempts.
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// AccountDeletion represents the status of a user's account deletion.
+type AccountDeletion struct {
+	Status    string    `json:"status"`
+	Requested time.Time `json:"requested"`
+	Scheduled time.Time `json:"scheduled"`
+	Attempts  int       `json:"attempts"`
+	LastError *string   `json:"lastError"`
+}
@@ -0,0 +1,22 @@
+package users
+
+// Follow is an entry in a list of a user's followers, or the users they follow.
+type Follow struct {
+	ID          string  `json:"id"`
+	Username    string  `json:"username"`
+	MediaID     *string `json:"mediaId"`
+	IsFollowing bool    `json:"isFollowing"`
+}
+
+// FollowResponse represents the response body of a follow request. If the user is
+// private, IsRequested is true until they approve the follow.
+type FollowResponse struct {
+	IsFollowing bool `json:"isFollowing"`
+	IsRequested bool `json:"isRequested"`
+}
+
+// FollowList is a page of follows, returned from the users API.
+type FollowList struct {
+	Items      []*Follow `json:"items"`
+	NextCursor *string   `json:"nextCursor"`
+}
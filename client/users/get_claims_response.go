@@ -1,6 +1,15 @@
 package users
 
-// GetClaimsResponse represents the response body of a get claims request.
+// GetClaimsResponse represents the response body of a get claims request. If the user has
+// two-factor authentication enabled, a challenge is returned in place of their claims.
 type GetClaimsResponse struct {
-	Claims map[string]interface{} `json:"claims"`
+	Claims    map[string]interface{} `json:"claims,omitempty"`
+	Challenge *LoginChallenge        `json:"challenge,omitempty"`
+}
+
+// LoginChallenge represents a challenge to be completed with a second factor, before
+// the user's claims are returned. Expires is a unix timestamp.
+type LoginChallenge struct {
+	Token   string `json:"token"`
+	Expires int64  `json:"expires"`
 }
@@ -2,8 +2,10 @@ package users
 
 // Info is a data transfer object used to provide basic information on a user.
 type Info struct {
-	ID            string  `json:"id"`
-	Username      string  `json:"username"`
-	MediaID       *string `json:"mediaId"`
-	FollowerCount int     `json:"followerCount"`
+	ID             string  `json:"id"`
+	Username       string  `json:"username"`
+	MediaID        *string `json:"mediaId"`
+	FollowerCount  int     `json:"followerCount"`
+	FollowingCount int     `json:"followingCount"`
+	IsPrivate      bool    `json:"isPrivate"`
 }
@@ -0,0 +1,27 @@
+package users
+
+// ChangePasswordRequest represents the request body of a change password request.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+
+	// IPAddress is the address of the client, used to lock out
+	// clients making too many failed attempts.
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// RequestPasswordResetRequest represents the request body of a request for a password reset.
+type RequestPasswordResetRequest struct {
+	Username string `json:"username"`
+}
+
+// ResetPasswordRequest represents the request body of a reset password request.
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// ResetPasswordResponse represents the response body of a reset password request.
+type ResetPasswordResponse struct {
+	UserReferenceID string `json:"userReferenceId"`
+}
@@ -2,12 +2,33 @@ package users
 
 // Profile contains the data returns from getting a user's profile.
 type Profile struct {
-	UserID        string  `json:"userId"`
-	Username      string  `json:"username"`
-	MediaID       *string `json:"mediaId"`
-	Bio           *string `json:"bio"`
-	FollowerCount int     `json:"followerCount"`
-	IsFollowing   bool    `json:"isFollowing"`
-	IsOwner       bool    `json:"isOwner"`
-	PostCount     int     `json:"postCount"`
+	UserID         string  `json:"userId"`
+	Username       string  `json:"username"`
+	MediaID        *string `json:"mediaId"`
+	Bio            *string `json:"bio"`
+	FollowerCount  int     `json:"followerCount"`
+	IsFollowing    bool    `json:"isFollowing"`
+	IsOwner        bool    `json:"isOwner"`
+	PostCount      int     `json:"postCount"`
+	FollowingCount int     `json:"followingCount"`
+	IsPrivate      bool    `json:"isPrivate"`
+	IsRequested    bool    `json:"isRequested"`
+	IsMuted        bool    `json:"isMuted"`
+}
+
+// UpdateProfileRequest represents the request body of an update profile request.
+// Fields which are nil are left unchanged, and an empty bio clears it.
+type UpdateProfileRequest struct {
+	Username  *string `json:"username,omitempty"`
+	Bio       *string `json:"bio,omitempty"`
+	MediaID   *int    `json:"mediaId,omitempty"`
+	IsPrivate *bool   `json:"isPrivate,omitempty"`
+}
+
+// UpdateProfileResponse represents the response body of an update profile request.
+type UpdateProfileResponse struct {
+	Username        string  `json:"username"`
+	Bio             *string `json:"bio"`
+	UsernameChanged bool    `json:"usernameChanged"`
+	IsPrivate       bool    `json:"isPrivate"`
 }
@@ -0,0 +1,34 @@
+package users
+
+// VerifySecondFactorRequest represents the request body of a request to complete a login challenge.
+type VerifySecondFactorRequest struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"`
+	IPAddress      string `json:"ipAddress"`
+}
+
+// EnrolTwoFactorResponse represents the response body of a request to enrol in two-factor authentication.
+type EnrolTwoFactorResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// ConfirmTwoFactorRequest represents the request body of a request to confirm two-factor authentication.
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code"`
+}
+
+// ConfirmTwoFactorResponse represents the response body of a request to confirm two-factor authentication.
+type ConfirmTwoFactorResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// DisableTwoFactorRequest represents the request body of a request to disable two-factor authentication.
+type DisableTwoFactorRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+
+	// IPAddress is the address of the client, used to lock out
+	// clients making too many failed attempts.
+	IPAddress string `json:"ipAddress,omitempty"`
+}
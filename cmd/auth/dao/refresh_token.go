@@ -0,0 +1,15 @@
+package dao
+
+import "time"
+
+// RefreshToken is a data access object for the refresh token domain.
+type RefreshToken struct {
+	ID              int64
+	TokenHash       string
+	FamilyID        string
+	UserReferenceID string
+	Created         time.Time
+	Expires         time.Time
+	Used            *time.Time
+	Revoked         *time.Time
+}
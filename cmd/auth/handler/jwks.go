@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/jwks"
+)
+
+// JWKSHandler handles HTTP GET requests for the key set used to verify access tokens.
+type JWKSHandler struct {
+	core.Handler
+	set *jwks.Set
+}
+
+// NewJWKSHandler returns a new instance of JWKSHandler, serving the given key set.
+func NewJWKSHandler(set *jwks.Set) *JWKSHandler {
+	return &JWKSHandler{
+		set: set,
+	}
+}
+
+// ServeHTTP handles requests for the key set.
+func (h *JWKSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	h.Respond(w, h.set)
+}
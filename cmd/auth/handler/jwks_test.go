@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/jwks"
+)
+
+func TestJWKSHandler(t *testing.T) {
+	set := &jwks.Set{
+		Keys: []*jwks.Key{
+			{Type: "RSA", Use: "sig", ID: "key", Algorithm: "RS256", Modulus: "AQAB", Exponent: "AQAB"},
+		},
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, jwks.Path, nil)
+	rr := httptest.NewRecorder()
+	NewJWKSHandler(set).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "public, max-age=300", rr.Header().Get("Cache-Control"))
+
+	var data jwks.Set
+	json.NewDecoder(rr.Body).Decode(&data)
+	assert.Equal(t, set, &data)
+}
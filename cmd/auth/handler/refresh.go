@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/client"
+	"github.com/reecerussell/open-social/client/users"
+	"github.com/reecerussell/open-social/cmd/auth/keys"
+	"github.com/reecerussell/open-social/cmd/auth/model"
+	"github.com/reecerussell/open-social/cmd/auth/repository"
+	"github.com/reecerussell/open-social/database"
+)
+
+// RefreshHandler handles HTTP POST requests to exchange a refresh token for
+// a new access token. The refresh token is rotated, so a new refresh token is
+// also issued, and the one given can't be used again.
+type RefreshHandler struct {
+	core.Handler
+	client users.Client
+	signer keys.Signer
+	repo   repository.RefreshTokenRepository
+	uow    database.UnitOfWork
+	opts   TokenOptions
+}
+
+// RefreshRequest is the request body of a refresh request.
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// NewRefreshHandler returns a new instance of RefreshHandler.
+func NewRefreshHandler(client users.Client, signer keys.Signer, repo repository.RefreshTokenRepository, uow database.UnitOfWork, opts TokenOptions) *RefreshHandler {
+	return &RefreshHandler{
+		client: client,
+		signer: signer,
+		repo:   repo,
+		uow:    uow,
+		opts:   opts,
+	}
+}
+
+// ServeHTTP handles requests to refresh an access token.
+func (h *RefreshHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var data RefreshRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	var current *model.RefreshToken
+	var response TokenResponse
+
+	ctx := r.Context()
+	err := h.uow.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		current, err = h.repo.Get(ctx, data.RefreshToken)
+		if err != nil {
+			return err
+		}
+
+		next, err := current.Rotate(refreshLifetime(h.opts.RefreshExpiryDays))
+		if err != nil {
+			return err
+		}
+
+		claims, err := h.client.GetClaimsByReference(current.UserReferenceID())
+		if err != nil {
+			return err
+		}
+
+		token, exp, err := buildAccessToken(h.signer, claims.Claims, h.opts)
+		if err != nil {
+			return err
+		}
+
+		err = h.repo.Update(ctx, current)
+		if err != nil {
+			return err
+		}
+
+		err = h.repo.Create(ctx, next)
+		if err != nil {
+			return err
+		}
+
+		response = TokenResponse{
+			Token:          token,
+			Expires:        exp.Unix(),
+			RefreshToken:   next.Token(),
+			RefreshExpires: next.Expires().Unix(),
+		}
+
+		return nil
+	})
+	if err != nil {
+		h.handleError(ctx, w, current, err)
+		return
+	}
+
+	h.Respond(w, response)
+}
+
+func (h *RefreshHandler) handleError(ctx context.Context, w http.ResponseWriter, current *model.RefreshToken, err error) {
+	switch err {
+	case model.ErrRefreshTokenReused:
+		// The token has been used before, so either the user or an attacker
+		// holds a stolen token. Revoke the whole family, to log both out.
+		log.Printf("WARN: refresh token reused, revoking family %s\n", current.FamilyID())
+
+		revokeErr := h.repo.RevokeFamily(ctx, current.FamilyID())
+		if revokeErr != nil {
+			h.RespondError(w, revokeErr, http.StatusInternalServerError)
+			return
+		}
+
+		h.RespondError(w, err, http.StatusUnauthorized)
+	case repository.ErrRefreshTokenNotFound, model.ErrRefreshTokenExpired, model.ErrRefreshTokenRevoked:
+		h.RespondError(w, err, http.StatusUnauthorized)
+	default:
+		if e, ok := err.(*client.Error); ok {
+			status := e.StatusCode
+			if status == http.StatusNotFound {
+				status = http.StatusUnauthorized
+			}
+
+			h.RespondError(w, e, status)
+			return
+		}
+
+		h.RespondError(w, err, http.StatusInternalServerError)
+	}
+}
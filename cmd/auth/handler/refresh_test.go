@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/client"
+	usersmock "github.com/reecerussell/open-social/client/mock/users"
+	"github.com/reecerussell/open-social/client/users"
+	"github.com/reecerussell/open-social/cmd/auth/dao"
+	keysmock "github.com/reecerussell/open-social/cmd/auth/mock/keys"
+	mock "github.com/reecerussell/open-social/cmd/auth/mock/repository"
+	"github.com/reecerussell/open-social/cmd/auth/model"
+	"github.com/reecerussell/open-social/cmd/auth/repository"
+	dbMock "github.com/reecerussell/open-social/mock/database"
+)
+
+const testRefreshToken = "Hb2ZWq7xA9m"
+
+var testTokenOptions = TokenOptions{
+	Issuer:            "open-social-auth",
+	Audience:          "open-social",
+	ExpiryMinutes:     15,
+	RefreshExpiryDays: 30,
+}
+
+func newMockUow(ctrl *gomock.Controller) *dbMock.MockUnitOfWork {
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	return mockUow
+}
+
+func newMockSigner(ctrl *gomock.Controller) *keysmock.MockSigner {
+	mockSigner := keysmock.NewMockSigner(ctrl)
+	mockSigner.EXPECT().Sign(gomock.Any()).Return("my-token", nil).AnyTimes()
+
+	return mockSigner
+}
+
+func refreshRequest() *http.Request {
+	body := `{"refreshToken":"` + testRefreshToken + `"}`
+	req, _ := http.NewRequest(http.MethodPost, "/token/refresh", strings.NewReader(body))
+
+	return req
+}
+
+func TestRefreshHandler_GivenValidToken_RotatesToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	current := model.RefreshTokenFromDao(&dao.RefreshToken{
+		ID:              1,
+		FamilyID:        "family",
+		UserReferenceID: "user",
+		Expires:         time.Now().UTC().Add(time.Hour),
+	})
+
+	mockRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testRefreshToken).Return(current, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), current).DoAndReturn(func(ctx context.Context, rt *model.RefreshToken) error {
+		assert.NotNil(t, rt.Dao().Used)
+		return nil
+	})
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, rt *model.RefreshToken) error {
+		assert.Equal(t, "family", rt.FamilyID())
+		assert.Equal(t, "user", rt.UserReferenceID())
+		return nil
+	})
+
+	mockClient := usersmock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetClaimsByReference("user").Return(&users.GetClaimsResponse{
+		Claims: map[string]interface{}{"uid": "user"},
+	}, nil)
+
+	handler := NewRefreshHandler(mockClient, newMockSigner(ctrl), mockRepo, newMockUow(ctrl), testTokenOptions)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, refreshRequest())
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp TokenResponse
+	_ = json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NotEmpty(t, resp.Token)
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.NotEqual(t, testRefreshToken, resp.RefreshToken)
+	assert.True(t, resp.RefreshExpires > resp.Expires)
+}
+
+func TestRefreshHandler_GivenUsedToken_RevokesFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	used := time.Now().UTC().Add(-time.Minute)
+	current := model.RefreshTokenFromDao(&dao.RefreshToken{
+		ID:              1,
+		FamilyID:        "family",
+		UserReferenceID: "user",
+		Expires:         time.Now().UTC().Add(time.Hour),
+		Used:            &used,
+	})
+
+	mockRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testRefreshToken).Return(current, nil)
+	mockRepo.EXPECT().RevokeFamily(gomock.Any(), "family").Return(nil)
+
+	handler := NewRefreshHandler(nil, nil, mockRepo, newMockUow(ctrl), testTokenOptions)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, refreshRequest())
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Equal(t, "{\"message\":\"refresh token has already been used\"}\n", rr.Body.String())
+}
+
+func TestRefreshHandler_InvalidToken_ReturnsUnauthorized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now().UTC()
+	tests := map[string]struct {
+		token *model.RefreshToken
+		err   error
+	}{
+		"Not Found": {nil, repository.ErrRefreshTokenNotFound},
+		"Expired":   {model.RefreshTokenFromDao(&dao.RefreshToken{Expires: now.Add(-time.Second)}), nil},
+		"Revoked":   {model.RefreshTokenFromDao(&dao.RefreshToken{Expires: now.Add(time.Hour), Revoked: &now}), nil},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := mock.NewMockRefreshTokenRepository(ctrl)
+			mockRepo.EXPECT().Get(gomock.Any(), testRefreshToken).Return(test.token, test.err)
+
+			handler := NewRefreshHandler(nil, nil, mockRepo, newMockUow(ctrl), testTokenOptions)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, refreshRequest())
+
+			assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		})
+	}
+}
+
+func TestRefreshHandler_UserNotFound_ReturnsUnauthorized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	current := model.RefreshTokenFromDao(&dao.RefreshToken{
+		UserReferenceID: "user",
+		Expires:         time.Now().UTC().Add(time.Hour),
+	})
+
+	mockRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testRefreshToken).Return(current, nil)
+
+	mockClient := usersmock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetClaimsByReference("user").Return(nil, &client.Error{
+		StatusCode: http.StatusNotFound,
+		Message:    "user not found",
+	})
+
+	handler := NewRefreshHandler(mockClient, nil, mockRepo, newMockUow(ctrl), testTokenOptions)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, refreshRequest())
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestRefreshHandler_CreateFails_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+	current := model.RefreshTokenFromDao(&dao.RefreshToken{
+		UserReferenceID: "user",
+		Expires:         time.Now().UTC().Add(time.Hour),
+	})
+
+	mockRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testRefreshToken).Return(current, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), current).Return(nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(testError)
+
+	mockClient := usersmock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetClaimsByReference("user").Return(&users.GetClaimsResponse{
+		Claims: map[string]interface{}{"uid": "user"},
+	}, nil)
+
+	handler := NewRefreshHandler(mockClient, newMockSigner(ctrl), mockRepo, newMockUow(ctrl), testTokenOptions)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, refreshRequest())
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "{\"message\":\"an error occured\"}\n", rr.Body.String())
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/client"
+	"github.com/reecerussell/open-social/client/users"
+	"github.com/reecerussell/open-social/cmd/auth/keys"
+)
+
+// ReissueHandler handles HTTP POST requests to issue a new access token for a
+// user who's already authenticated, once their claims have changed, such as when
+// they change their username. Their refresh tokens are left as they are.
+type ReissueHandler struct {
+	core.Handler
+	client users.Client
+	signer keys.Signer
+	opts   TokenOptions
+}
+
+// NewReissueHandler returns a new instance of ReissueHandler.
+func NewReissueHandler(client users.Client, signer keys.Signer, opts TokenOptions) *ReissueHandler {
+	return &ReissueHandler{
+		client: client,
+		signer: signer,
+		opts:   opts,
+	}
+}
+
+// ServeHTTP handles requests to reissue a user's access token.
+func (h *ReissueHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	claims, err := h.client.GetClaimsByReference(userReferenceID)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	token, exp, err := buildAccessToken(h.signer, claims.Claims, h.opts)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	response := TokenResponse{
+		Token:   token,
+		Expires: exp.Unix(),
+	}
+
+	h.Respond(w, response)
+}
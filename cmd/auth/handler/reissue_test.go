@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/client"
+	usersmock "github.com/reecerussell/open-social/client/mock/users"
+	"github.com/reecerussell/open-social/client/users"
+)
+
+func reissueRequest() *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "/token/reissue/user", nil)
+
+	return mux.SetURLVars(req, map[string]string{"userReferenceID": "user"})
+}
+
+func TestReissueHandler_IssuesAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := usersmock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetClaimsByReference("user").Return(&users.GetClaimsResponse{
+		Claims: map[string]interface{}{"uid": "user"},
+	}, nil)
+
+	handler := NewReissueHandler(mockClient, newMockSigner(ctrl), testTokenOptions)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, reissueRequest())
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "\"token\":\"my-token\"")
+	assert.NotContains(t, rr.Body.String(), "refreshToken")
+}
+
+func TestReissueHandler_UserNotFound_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := usersmock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetClaimsByReference("user").Return(nil, &client.Error{
+		Message:    "user not found",
+		StatusCode: http.StatusNotFound,
+	})
+
+	handler := NewReissueHandler(mockClient, nil, testTokenOptions)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, reissueRequest())
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
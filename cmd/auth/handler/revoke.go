@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/auth/repository"
+)
+
+// RevokeHandler handles HTTP POST requests to revoke a refresh token, such as
+// when a user logs out. Every token in the refresh token's family is revoked.
+type RevokeHandler struct {
+	core.Handler
+	repo repository.RefreshTokenRepository
+}
+
+// NewRevokeHandler returns a new instance of RevokeHandler.
+func NewRevokeHandler(repo repository.RefreshTokenRepository) *RevokeHandler {
+	return &RevokeHandler{
+		repo: repo,
+	}
+}
+
+// ServeHTTP handles requests to revoke a refresh token. Unknown tokens are
+// ignored, as there is nothing to revoke.
+func (h *RevokeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var data RefreshRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	ctx := r.Context()
+	token, err := h.repo.Get(ctx, data.RefreshToken)
+	if err != nil {
+		if err == repository.ErrRefreshTokenNotFound {
+			h.Respond(w, nil)
+			return
+		}
+
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	err = h.repo.RevokeFamily(ctx, token.FamilyID())
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, nil)
+}
+
+// RevokeUserHandler handles HTTP POST requests to revoke all of a user's
+// refresh tokens, logging them out of every session.
+type RevokeUserHandler struct {
+	core.Handler
+	repo repository.RefreshTokenRepository
+}
+
+// NewRevokeUserHandler returns a new instance of RevokeUserHandler.
+func NewRevokeUserHandler(repo repository.RefreshTokenRepository) *RevokeUserHandler {
+	return &RevokeUserHandler{
+		repo: repo,
+	}
+}
+
+// ServeHTTP handles requests to revoke a user's refresh tokens.
+func (h *RevokeUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	err := h.repo.RevokeUser(r.Context(), userReferenceID)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, nil)
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/auth/dao"
+	mock "github.com/reecerussell/open-social/cmd/auth/mock/repository"
+	"github.com/reecerussell/open-social/cmd/auth/model"
+	"github.com/reecerussell/open-social/cmd/auth/repository"
+)
+
+func revokeRequest() *http.Request {
+	body := `{"refreshToken":"` + testRefreshToken + `"}`
+	req, _ := http.NewRequest(http.MethodPost, "/token/revoke", strings.NewReader(body))
+
+	return req
+}
+
+func TestRevokeHandler_RevokesFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	token := model.RefreshTokenFromDao(&dao.RefreshToken{
+		FamilyID: "family",
+		Expires:  time.Now().UTC().Add(time.Hour),
+	})
+
+	mockRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testRefreshToken).Return(token, nil)
+	mockRepo.EXPECT().RevokeFamily(gomock.Any(), "family").Return(nil)
+
+	rr := httptest.NewRecorder()
+	NewRevokeHandler(mockRepo).ServeHTTP(rr, revokeRequest())
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestRevokeHandler_TokenNotFound_ReturnsOK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testRefreshToken).Return(nil, repository.ErrRefreshTokenNotFound)
+
+	rr := httptest.NewRecorder()
+	NewRevokeHandler(mockRepo).ServeHTTP(rr, revokeRequest())
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestRevokeHandler_RevokeFails_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+	token := model.RefreshTokenFromDao(&dao.RefreshToken{FamilyID: "family"})
+
+	mockRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testRefreshToken).Return(token, nil)
+	mockRepo.EXPECT().RevokeFamily(gomock.Any(), "family").Return(testError)
+
+	rr := httptest.NewRecorder()
+	NewRevokeHandler(mockRepo).ServeHTTP(rr, revokeRequest())
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "{\"message\":\"an error occured\"}\n", rr.Body.String())
+}
+
+func TestRevokeUserHandler_RevokesUsersTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	mockRepo.EXPECT().RevokeUser(gomock.Any(), "user").Return(nil)
+
+	router := mux.NewRouter()
+	router.Handle("/token/revoke/{userReferenceID}", NewRevokeUserHandler(mockRepo))
+
+	req, _ := http.NewRequest(http.MethodPost, "/token/revoke/user", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
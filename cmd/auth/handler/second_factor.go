@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/client"
+	"github.com/reecerussell/open-social/client/users"
+	"github.com/reecerussell/open-social/cmd/auth/keys"
+	"github.com/reecerussell/open-social/cmd/auth/repository"
+)
+
+// SecondFactorHandler handles HTTP POST requests to complete a login challenge,
+// issued to users with two-factor authentication, in exchange for an access token.
+type SecondFactorHandler struct {
+	core.Handler
+	client users.Client
+	signer keys.Signer
+	repo   repository.RefreshTokenRepository
+	opts   TokenOptions
+}
+
+// NewSecondFactorHandler returns a new instance of SecondFactorHandler.
+func NewSecondFactorHandler(client users.Client, signer keys.Signer, repo repository.RefreshTokenRepository, opts TokenOptions) *SecondFactorHandler {
+	return &SecondFactorHandler{
+		client: client,
+		signer: signer,
+		repo:   repo,
+		opts:   opts,
+	}
+}
+
+// ServeHTTP handles requests to complete a login challenge.
+func (h *SecondFactorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var data users.VerifySecondFactorRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	claims, err := h.client.VerifySecondFactor(&data)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	response, err := issueTokens(r.Context(), h.signer, h.repo, claims.Claims, h.opts)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, response)
+}
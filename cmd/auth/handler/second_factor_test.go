@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/client"
+	usersmock "github.com/reecerussell/open-social/client/mock/users"
+	"github.com/reecerussell/open-social/client/users"
+	mock "github.com/reecerussell/open-social/cmd/auth/mock/repository"
+)
+
+func secondFactorRequest() *http.Request {
+	body := `{"challengeToken":"challenge","code":"123456","ipAddress":"10.0.0.1"}`
+	req, _ := http.NewRequest(http.MethodPost, "/token/2fa", strings.NewReader(body))
+
+	return req
+}
+
+func TestSecondFactorHandler_GivenValidCode_IssuesTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := usersmock.NewMockClient(ctrl)
+	mockClient.EXPECT().VerifySecondFactor(&users.VerifySecondFactorRequest{
+		ChallengeToken: "challenge",
+		Code:           "123456",
+		IPAddress:      "10.0.0.1",
+	}).Return(&users.GetClaimsResponse{Claims: map[string]interface{}{"uid": "user"}}, nil)
+
+	mockRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	handler := NewSecondFactorHandler(mockClient, newMockSigner(ctrl), mockRepo, testTokenOptions)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, secondFactorRequest())
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp TokenResponse
+	_ = json.NewDecoder(rr.Body).Decode(&resp)
+	assert.Equal(t, "my-token", resp.Token)
+	assert.NotEmpty(t, resp.RefreshToken)
+}
+
+func TestSecondFactorHandler_InvalidCode_ReturnsClientError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := usersmock.NewMockClient(ctrl)
+	mockClient.EXPECT().VerifySecondFactor(gomock.Any()).Return(nil, &client.Error{
+		Message:    "code is invalid",
+		StatusCode: http.StatusBadRequest,
+	})
+
+	handler := NewSecondFactorHandler(mockClient, nil, nil, testTokenOptions)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, secondFactorRequest())
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "{\"message\":\"code is invalid\"}\n", rr.Body.String())
+}
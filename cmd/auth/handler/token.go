@@ -1,37 +1,67 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
-	"github.com/reecerussell/gojwt"
-
 	core "github.com/reecerussell/open-social"
 	"github.com/reecerussell/open-social/client"
 	"github.com/reecerussell/open-social/client/users"
+	"github.com/reecerussell/open-social/cmd/auth/keys"
+	"github.com/reecerussell/open-social/cmd/auth/model"
+	"github.com/reecerussell/open-social/cmd/auth/repository"
 )
 
+// ErrMissingUserID is returned when a user's claims don't contain their user id,
+// as a refresh token can't be issued, or revoked, without it.
+var ErrMissingUserID = errors.New("claims are missing the user id")
+
 // TokenHandler handles HTTP POST requests to generate an access token.
 type TokenHandler struct {
 	core.Handler
-	client        users.Client
-	alg           gojwt.Algorithm
-	expiryMinutes int
+	client users.Client
+	signer keys.Signer
+	repo   repository.RefreshTokenRepository
+	opts   TokenOptions
+}
+
+// TokenOptions configures the access and refresh tokens which are issued.
+type TokenOptions struct {
+	// Issuer and Audience are set as the iss and aud claims of access tokens.
+	Issuer   string
+	Audience string
+
+	ExpiryMinutes     int
+	RefreshExpiryDays int
 }
 
-// TokenResponse contains a generated access token and it's expiry date.
+// TokenResponse contains a generated access token and it's expiry date, as well
+// as a refresh token, which can be used to obtain a new access token once it expires.
+//
+// If the user has two-factor authentication enabled, no tokens are issued. Instead,
+// SecondFactorRequired is set, along with a challenge token, which is exchanged for
+// the tokens with a code from the user's authenticator.
 type TokenResponse struct {
-	Token   string `json:"token"`
-	Expires int64  `json:"expires"`
+	Token          string `json:"token,omitempty"`
+	Expires        int64  `json:"expires,omitempty"`
+	RefreshToken   string `json:"refreshToken,omitempty"`
+	RefreshExpires int64  `json:"refreshExpires,omitempty"`
+
+	SecondFactorRequired bool   `json:"secondFactorRequired,omitempty"`
+	ChallengeToken       string `json:"challengeToken,omitempty"`
+	ChallengeExpires     int64  `json:"challengeExpires,omitempty"`
 }
 
 // NewTokenHandler returns a new instance of TokenHandler.
-func NewTokenHandler(client users.Client, alg gojwt.Algorithm, expiryMinutes int) *TokenHandler {
+func NewTokenHandler(client users.Client, signer keys.Signer, repo repository.RefreshTokenRepository, opts TokenOptions) *TokenHandler {
 	return &TokenHandler{
-		client:        client,
-		alg:           alg,
-		expiryMinutes: expiryMinutes,
+		client: client,
+		signer: signer,
+		repo:   repo,
+		opts:   opts,
 	}
 }
 
@@ -53,23 +83,83 @@ func (h *TokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	now := time.Now().UTC()
-	exp := now.Add(time.Duration(h.expiryMinutes) * time.Minute)
+	if claims.Challenge != nil {
+		response := TokenResponse{
+			SecondFactorRequired: true,
+			ChallengeToken:       claims.Challenge.Token,
+			ChallengeExpires:     claims.Challenge.Expires,
+		}
 
-	builder := gojwt.New(h.alg).
-		AddClaims(claims.Claims).
-		SetExpiry(exp)
+		h.Respond(w, response)
+		return
+	}
 
-	token, err := builder.Build()
+	response, err := issueTokens(r.Context(), h.signer, h.repo, claims.Claims, h.opts)
 	if err != nil {
 		h.RespondError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	response := TokenResponse{
-		Token:   token,
-		Expires: exp.Unix(),
+	h.Respond(w, response)
+}
+
+// issueTokens issues a new access token containing the user claims, along
+// with a new refresh token, which is persisted to the repository.
+func issueTokens(ctx context.Context, signer keys.Signer, repo repository.RefreshTokenRepository, claims map[string]interface{}, opts TokenOptions) (*TokenResponse, error) {
+	uid, ok := claims["uid"].(string)
+	if !ok || uid == "" {
+		return nil, ErrMissingUserID
 	}
 
-	h.Respond(w, response)
+	token, exp, err := buildAccessToken(signer, claims, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := model.NewRefreshToken(uid, refreshLifetime(opts.RefreshExpiryDays))
+	if err != nil {
+		return nil, err
+	}
+
+	err = repo.Create(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TokenResponse{
+		Token:          token,
+		Expires:        exp.Unix(),
+		RefreshToken:   refreshToken.Token(),
+		RefreshExpires: refreshToken.Expires().Unix(),
+	}
+
+	return response, nil
+}
+
+// buildAccessToken builds and signs an access token containing the given
+// user claims, as well as the registered iss, aud, iat, nbf and exp claims.
+func buildAccessToken(signer keys.Signer, claims map[string]interface{}, opts TokenOptions) (string, time.Time, error) {
+	now := time.Now().UTC()
+	exp := now.Add(time.Duration(opts.ExpiryMinutes) * time.Minute)
+
+	tokenClaims := make(map[string]interface{}, len(claims)+5)
+	for name, value := range claims {
+		tokenClaims[name] = value
+	}
+	tokenClaims["iss"] = opts.Issuer
+	tokenClaims["aud"] = opts.Audience
+	tokenClaims["iat"] = now.Unix()
+	tokenClaims["nbf"] = now.Unix()
+	tokenClaims["exp"] = exp.Unix()
+
+	token, err := signer.Sign(tokenClaims)
+	if err != nil {
+		return "", exp, err
+	}
+
+	return token, exp, nil
+}
+
+func refreshLifetime(refreshExpiryDays int) time.Duration {
+	return time.Duration(refreshExpiryDays) * 24 * time.Hour
 }
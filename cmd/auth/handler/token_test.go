@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	usersmock "github.com/reecerussell/open-social/client/mock/users"
+	"github.com/reecerussell/open-social/client/users"
+	keysmock "github.com/reecerussell/open-social/cmd/auth/mock/keys"
+	mock "github.com/reecerussell/open-social/cmd/auth/mock/repository"
+)
+
+func TestBuildAccessToken_SetsRegisteredClaims(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var signed map[string]interface{}
+	mockSigner := keysmock.NewMockSigner(ctrl)
+	mockSigner.EXPECT().Sign(gomock.Any()).
+		DoAndReturn(func(claims map[string]interface{}) (string, error) {
+			signed = claims
+			return "my-token", nil
+		})
+
+	claims := map[string]interface{}{"uid": "123"}
+	token, exp, err := buildAccessToken(mockSigner, claims, testTokenOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-token", token)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), exp, time.Minute)
+
+	assert.Equal(t, "123", signed["uid"])
+	assert.Equal(t, "open-social-auth", signed["iss"])
+	assert.Equal(t, "open-social", signed["aud"])
+	assert.Equal(t, exp.Unix(), signed["exp"])
+	assert.Equal(t, signed["iat"], signed["nbf"])
+
+	// The caller's claims must not be modified.
+	assert.Len(t, claims, 1)
+}
+
+func tokenRequest() *http.Request {
+	body := `{"username":"test","password":"password123"}`
+	req, _ := http.NewRequest(http.MethodPost, "/token", strings.NewReader(body))
+
+	return req
+}
+
+func TestTokenHandler_GivenValidCredentials_IssuesTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := usersmock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetClaims(&users.GetClaimsRequest{Username: "test", Password: "password123"}).
+		Return(&users.GetClaimsResponse{Claims: map[string]interface{}{"uid": "user"}}, nil)
+
+	mockRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	handler := NewTokenHandler(mockClient, newMockSigner(ctrl), mockRepo, testTokenOptions)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, tokenRequest())
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp TokenResponse
+	_ = json.NewDecoder(rr.Body).Decode(&resp)
+	assert.Equal(t, "my-token", resp.Token)
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.False(t, resp.SecondFactorRequired)
+}
+
+func TestTokenHandler_ClaimsMissingUserID_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := usersmock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetClaims(gomock.Any()).
+		Return(&users.GetClaimsResponse{Claims: map[string]interface{}{"username": "test"}}, nil)
+
+	// No refresh token is persisted.
+	handler := NewTokenHandler(mockClient, nil, mock.NewMockRefreshTokenRepository(ctrl), testTokenOptions)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, tokenRequest())
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "{\"message\":\""+ErrMissingUserID.Error()+"\"}\n", rr.Body.String())
+}
+
+func TestTokenHandler_SecondFactorRequired_ReturnsChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := usersmock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetClaims(gomock.Any()).Return(&users.GetClaimsResponse{
+		Challenge: &users.LoginChallenge{Token: "challenge", Expires: 12345},
+	}, nil)
+
+	// No tokens should be issued until the challenge is completed.
+	mockRepo := mock.NewMockRefreshTokenRepository(ctrl)
+
+	handler := NewTokenHandler(mockClient, nil, mockRepo, testTokenOptions)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, tokenRequest())
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "{\"secondFactorRequired\":true,\"challengeToken\":\"challenge\",\"challengeExpires\":12345}\n", rr.Body.String())
+}
@@ -0,0 +1,141 @@
+// Package keys manages the RSA keys used by the auth service to sign access tokens.
+package keys
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/reecerussell/gojwt"
+	"github.com/reecerussell/gojwt/rsa"
+
+	"github.com/reecerussell/open-social/jwks"
+)
+
+// Signer is used to sign access tokens.
+type Signer interface {
+	// Sign builds a token containing the claims, signed with the current signing key.
+	Sign(claims map[string]interface{}) (string, error)
+}
+
+type key struct {
+	id  string
+	alg gojwt.Algorithm
+	jwk *jwks.Key
+}
+
+// KeyRing holds the keys published in the key set, one of which is used to
+// sign new tokens. Keeping previous keys in the ring means tokens they signed
+// can still be verified, and adding the next key before it's used to sign
+// means verifiers can fetch it in advance, allowing keys to be rotated
+// without downtime.
+type KeyRing struct {
+	keys    map[string]*key
+	signing *key
+}
+
+// NewKeyRing returns a new, empty instance of KeyRing.
+func NewKeyRing() *KeyRing {
+	return &KeyRing{
+		keys: make(map[string]*key),
+	}
+}
+
+// Add adds a key to the key ring, where data is a PEM encoded RSA PKCS1 private key.
+func (r *KeyRing) Add(id string, data []byte) error {
+	if _, ok := r.keys[id]; ok {
+		return fmt.Errorf("key %s has already been added", id)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return fmt.Errorf("key %s: invalid key format", id)
+	}
+
+	pk, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("key %s: %v", id, err)
+	}
+
+	alg, err := rsa.New(data, crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("key %s: %v", id, err)
+	}
+
+	r.keys[id] = &key{
+		id:  id,
+		alg: alg,
+		jwk: jwks.NewKey(id, alg.Name(), &pk.PublicKey),
+	}
+
+	return nil
+}
+
+// SetSigningKey sets the key used to sign new tokens.
+func (r *KeyRing) SetSigningKey(id string) error {
+	k, ok := r.keys[id]
+	if !ok {
+		return fmt.Errorf("signing key %s has not been added", id)
+	}
+
+	r.signing = k
+
+	return nil
+}
+
+// Sign builds a token containing the claims, signed with the signing key,
+// whose id is set as the token's kid header.
+func (r *KeyRing) Sign(claims map[string]interface{}) (string, error) {
+	if r.signing == nil {
+		return "", errors.New("no signing key has been set")
+	}
+
+	header, err := marshalToBase64(&jwks.Header{
+		Type:      "JWT",
+		Algorithm: r.signing.alg.Name(),
+		KeyID:     r.signing.id,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := marshalToBase64(claims)
+	if err != nil {
+		return "", err
+	}
+
+	data := header + "." + payload
+	signature := r.signing.alg.Sign([]byte(data))
+
+	return data + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// KeySet returns the public key set, used to verify tokens signed by the ring.
+func (r *KeyRing) KeySet() *jwks.Set {
+	ids := make([]string, 0, len(r.keys))
+	for id := range r.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	set := &jwks.Set{Keys: make([]*jwks.Key, len(ids))}
+	for i, id := range ids {
+		set.Keys[i] = r.keys[id].jwk
+	}
+
+	return set
+}
+
+func marshalToBase64(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
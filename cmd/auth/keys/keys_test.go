@@ -0,0 +1,90 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/reecerussell/gojwt"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/jwks"
+)
+
+func generateKey(t *testing.T) []byte {
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(pk),
+	})
+}
+
+func TestKeyRing_Sign_CanBeVerifiedWithKeySet(t *testing.T) {
+	ring := NewKeyRing()
+	assert.NoError(t, ring.Add("old", generateKey(t)))
+	assert.NoError(t, ring.Add("new", generateKey(t)))
+	assert.NoError(t, ring.SetSigningKey("new"))
+
+	token, err := ring.Sign(map[string]interface{}{"uid": "123"})
+	assert.NoError(t, err)
+
+	kid, err := jwks.KeyID(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", kid)
+
+	set := ring.KeySet()
+	assert.Len(t, set.Keys, 2)
+	assert.Equal(t, "new", set.Keys[0].ID)
+	assert.Equal(t, "old", set.Keys[1].ID)
+	assert.Equal(t, "RS256", set.Keys[0].Algorithm)
+
+	alg, err := set.Keys[0].Verifier()
+	assert.NoError(t, err)
+
+	jwt, err := gojwt.Token(token)
+	assert.NoError(t, err)
+	assert.NoError(t, jwt.Verify(alg))
+	assert.Equal(t, "123", jwt.Claims["uid"])
+
+	// The token must not verify with a different key.
+	alg, err = set.Keys[1].Verifier()
+	assert.NoError(t, err)
+	assert.Error(t, jwt.Verify(alg))
+}
+
+func TestKeyRing_SignWithoutSigningKey_ReturnsError(t *testing.T) {
+	ring := NewKeyRing()
+	assert.NoError(t, ring.Add("key", generateKey(t)))
+
+	token, err := ring.Sign(map[string]interface{}{})
+	assert.Equal(t, "", token)
+	assert.Error(t, err)
+}
+
+func TestKeyRing_SetUnknownSigningKey_ReturnsError(t *testing.T) {
+	ring := NewKeyRing()
+
+	err := ring.SetSigningKey("key")
+	assert.Error(t, err)
+}
+
+func TestKeyRing_AddDuplicateKey_ReturnsError(t *testing.T) {
+	ring := NewKeyRing()
+	assert.NoError(t, ring.Add("key", generateKey(t)))
+
+	err := ring.Add("key", generateKey(t))
+	assert.Error(t, err)
+}
+
+func TestKeyRing_AddInvalidKey_ReturnsError(t *testing.T) {
+	ring := NewKeyRing()
+
+	err := ring.Add("key", []byte("not a key"))
+	assert.Error(t, err)
+}
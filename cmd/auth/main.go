@@ -1,38 +1,53 @@
 package main
 
 import (
-	"crypto"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"os/signal"
 
-	"github.com/reecerussell/gojwt"
-	"github.com/reecerussell/gojwt/rsa"
-
 	core "github.com/reecerussell/open-social"
 	"github.com/reecerussell/open-social/client/users"
 	"github.com/reecerussell/open-social/cmd/auth/handler"
+	"github.com/reecerussell/open-social/cmd/auth/keys"
+	"github.com/reecerussell/open-social/cmd/auth/repository"
+	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/jwks"
 	"github.com/reecerussell/open-social/util"
 )
 
 const (
-	usersAPIVar            = "USERS_API_URL"
-	configFileVar          = "CONFIG_FILE"
-	tokenPrivateKeyVar     = "TOKEN_PRIVATE_KEY"
-	tokenPrivateKeyDataVar = "TOKEN_PRIVATE_KEY_DATA"
+	connectionStringVar = "CONNECTION_STRING"
+	usersAPIVar         = "USERS_API_URL"
+	configFileVar       = "CONFIG_FILE"
 )
 
 func main() {
 	cnf := buildConfig()
 	ctn := buildServices(cnf)
 
-	userHandler := ctn.GetService("TokenHandler").(*handler.TokenHandler)
+	db := ctn.GetService("Database").(database.Database)
+
+	tokenHandler := ctn.GetService("TokenHandler").(*handler.TokenHandler)
+	secondFactorHandler := ctn.GetService("SecondFactorHandler").(*handler.SecondFactorHandler)
+	refreshHandler := ctn.GetService("RefreshHandler").(*handler.RefreshHandler)
+	revokeHandler := ctn.GetService("RevokeHandler").(*handler.RevokeHandler)
+	revokeUserHandler := ctn.GetService("RevokeUserHandler").(*handler.RevokeUserHandler)
+	reissueHandler := ctn.GetService("ReissueHandler").(*handler.ReissueHandler)
+	jwksHandler := ctn.GetService("JWKSHandler").(*handler.JWKSHandler)
 
 	app := core.NewApp()
+	app.AddHealthCheck(database.NewHealthCheck(db))
 	app.AddMiddleware(core.NewLoggingMiddleware())
 
-	app.Post("/token", userHandler)
+	app.Post("/token", tokenHandler)
+	app.Post("/token/2fa", secondFactorHandler)
+	app.Post("/token/refresh", refreshHandler)
+	app.Post("/token/revoke", revokeHandler)
+	app.Post("/token/revoke/{userReferenceID}", revokeUserHandler)
+	app.Post("/token/reissue/{userReferenceID}", reissueHandler)
+	app.Get(jwks.Path, jwksHandler)
 
 	go app.Serve()
 
@@ -48,9 +63,47 @@ type Config struct {
 	Token *TokenConfig `json:"token"`
 }
 
-// TokenConfig contains config for generating access tokens.
+// TokenConfig contains config for generating access and refresh tokens.
 type TokenConfig struct {
-	ExpiryMinutes int `json:"expiryMinutes"`
+	Issuer            string       `json:"issuer"`
+	Audience          string       `json:"audience"`
+	ExpiryMinutes     int          `json:"expiryMinutes"`
+	RefreshExpiryDays int          `json:"refreshExpiryDays"`
+	SigningKey        string       `json:"signingKey"`
+	Keys              []*KeyConfig `json:"keys"`
+}
+
+// KeyConfig contains config for a key used to sign access tokens. The PEM
+// encoded private key is read from the Env variable, if set, otherwise from File.
+type KeyConfig struct {
+	ID   string `json:"id"`
+	File string `json:"file"`
+	Env  string `json:"env"`
+}
+
+func (c *KeyConfig) read() ([]byte, error) {
+	if c.Env != "" {
+		if data, ok := os.LookupEnv(c.Env); ok {
+			log.Printf("Using token key %s from %s (length: %d)\n", c.ID, c.Env, len(data))
+			return []byte(data), nil
+		}
+	}
+
+	if c.File == "" {
+		return nil, fmt.Errorf("key %s has no file, and %s is not set", c.ID, c.Env)
+	}
+
+	log.Printf("Using token key %s from file (%s)\n", c.ID, c.File)
+	return ioutil.ReadFile(c.File)
+}
+
+func (c *TokenConfig) options() handler.TokenOptions {
+	return handler.TokenOptions{
+		Issuer:            c.Issuer,
+		Audience:          c.Audience,
+		ExpiryMinutes:     c.ExpiryMinutes,
+		RefreshExpiryDays: c.RefreshExpiryDays,
+	}
 }
 
 func buildConfig() *Config {
@@ -72,41 +125,102 @@ func buildServices(cnf *Config) *core.Container {
 		return cnf
 	})
 
+	ctn.AddSingleton("Database", func(ctn *core.Container) interface{} {
+		url := os.Getenv(connectionStringVar)
+		db, err := database.New(url)
+		if err != nil {
+			panic(err)
+		}
+
+		return db
+	})
+
+	ctn.AddService("RefreshTokenRepository", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return repository.NewRefreshTokenRepository(db)
+	})
+
 	ctn.AddService("UserClient", func(ctn *core.Container) interface{} {
 		url := os.Getenv(usersAPIVar)
 		client := users.New(url)
 		return client
 	})
 
-	ctn.AddService("TokenAlg", func(ctn *core.Container) interface{} {
-		var alg gojwt.Algorithm
-		var err error
-
-		if path, ok := os.LookupEnv(tokenPrivateKeyVar); ok {
-			log.Printf("Using token private key file (%s)\n", path)
-			alg, err = rsa.NewFromFile(path, crypto.SHA256)
-		} else {
-			data, ok := os.LookupEnv(tokenPrivateKeyDataVar)
-			if !ok {
-				panic("either a private key file path need to be given, or raw data")
+	ctn.AddSingleton("KeyRing", func(ctn *core.Container) interface{} {
+		cnf := ctn.GetService("Config").(*Config)
+		ring := keys.NewKeyRing()
+
+		for _, kc := range cnf.Token.Keys {
+			data, err := kc.read()
+			if err != nil {
+				panic(fmt.Errorf("failed to read token key: %v", err))
 			}
 
-			log.Printf("Using token private key data (length: %d)\n", len(data))
-			alg, err = rsa.New([]byte(data), crypto.SHA256)
+			err = ring.Add(kc.ID, data)
+			if err != nil {
+				panic(err)
+			}
 		}
 
+		err := ring.SetSigningKey(cnf.Token.SigningKey)
 		if err != nil {
 			panic(err)
 		}
 
-		return alg
+		return ring
 	})
 
 	ctn.AddService("TokenHandler", func(ctn *core.Container) interface{} {
 		client := ctn.GetService("UserClient").(users.Client)
-		alg := ctn.GetService("TokenAlg").(gojwt.Algorithm)
+		ring := ctn.GetService("KeyRing").(*keys.KeyRing)
+		repo := ctn.GetService("RefreshTokenRepository").(repository.RefreshTokenRepository)
 		cnf := ctn.GetService("Config").(*Config)
-		h := handler.NewTokenHandler(client, alg, cnf.Token.ExpiryMinutes)
+		h := handler.NewTokenHandler(client, ring, repo, cnf.Token.options())
+		return h
+	})
+
+	ctn.AddService("SecondFactorHandler", func(ctn *core.Container) interface{} {
+		client := ctn.GetService("UserClient").(users.Client)
+		ring := ctn.GetService("KeyRing").(*keys.KeyRing)
+		repo := ctn.GetService("RefreshTokenRepository").(repository.RefreshTokenRepository)
+		cnf := ctn.GetService("Config").(*Config)
+		h := handler.NewSecondFactorHandler(client, ring, repo, cnf.Token.options())
+		return h
+	})
+
+	ctn.AddService("RefreshHandler", func(ctn *core.Container) interface{} {
+		client := ctn.GetService("UserClient").(users.Client)
+		ring := ctn.GetService("KeyRing").(*keys.KeyRing)
+		repo := ctn.GetService("RefreshTokenRepository").(repository.RefreshTokenRepository)
+		db := ctn.GetService("Database").(database.Database)
+		cnf := ctn.GetService("Config").(*Config)
+		h := handler.NewRefreshHandler(client, ring, repo, db, cnf.Token.options())
+		return h
+	})
+
+	ctn.AddService("RevokeHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("RefreshTokenRepository").(repository.RefreshTokenRepository)
+		h := handler.NewRevokeHandler(repo)
+		return h
+	})
+
+	ctn.AddService("RevokeUserHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("RefreshTokenRepository").(repository.RefreshTokenRepository)
+		h := handler.NewRevokeUserHandler(repo)
+		return h
+	})
+
+	ctn.AddService("ReissueHandler", func(ctn *core.Container) interface{} {
+		client := ctn.GetService("UserClient").(users.Client)
+		ring := ctn.GetService("KeyRing").(*keys.KeyRing)
+		cnf := ctn.GetService("Config").(*Config)
+		h := handler.NewReissueHandler(client, ring, cnf.Token.options())
+		return h
+	})
+
+	ctn.AddService("JWKSHandler", func(ctn *core.Container) interface{} {
+		ring := ctn.GetService("KeyRing").(*keys.KeyRing)
+		h := handler.NewJWKSHandler(ring.KeySet())
 		return h
 	})
 
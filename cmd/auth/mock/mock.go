@@ -0,0 +1,4 @@
+//go:generate mockgen -package=mock -source=../repository/refresh_token_repository.go -destination=repository/refresh_token_repository.go
+//go:generate mockgen -package=mock -source=../keys/keys.go -destination=keys/keys.go
+
+package mock
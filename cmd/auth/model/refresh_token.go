@@ -0,0 +1,150 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/reecerussell/open-social/cmd/auth/dao"
+)
+
+// refreshTokenSize is the number of random bytes in a refresh token.
+const refreshTokenSize = 32
+
+// Refresh token errors.
+var (
+	ErrRefreshTokenExpired = errors.New("refresh token has expired")
+	ErrRefreshTokenRevoked = errors.New("refresh token has been revoked")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used")
+)
+
+// RefreshToken is a domain model for a long-lived token, used to obtain new
+// access tokens without the user's credentials. Each refresh token can only be
+// used once, as using it rotates it for a new token in the same family. Only a
+// hash of the token is persisted.
+type RefreshToken struct {
+	id              int64
+	token           string
+	tokenHash       string
+	familyID        string
+	userReferenceID string
+	created         time.Time
+	expires         time.Time
+	used            *time.Time
+	revoked         *time.Time
+}
+
+// NewRefreshToken returns a new RefreshToken for the user, starting a new
+// token family, which expires after the given lifetime.
+func NewRefreshToken(userReferenceID string, lifetime time.Duration) (*RefreshToken, error) {
+	return newRefreshToken(uuid.New().String(), userReferenceID, lifetime)
+}
+
+func newRefreshToken(familyID, userReferenceID string, lifetime time.Duration) (*RefreshToken, error) {
+	data := make([]byte, refreshTokenSize)
+	_, err := rand.Read(data)
+	if err != nil {
+		return nil, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(data)
+	now := time.Now().UTC()
+
+	return &RefreshToken{
+		token:           token,
+		tokenHash:       HashRefreshToken(token),
+		familyID:        familyID,
+		userReferenceID: userReferenceID,
+		created:         now,
+		expires:         now.Add(lifetime),
+	}, nil
+}
+
+// HashRefreshToken returns the hash of a refresh token, used to look it up.
+func HashRefreshToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// Token returns the refresh token's value. This is only available
+// for newly issued tokens, as the value itself is never persisted.
+func (t *RefreshToken) Token() string {
+	return t.token
+}
+
+// FamilyID returns the id of the token family the refresh token belongs to.
+func (t *RefreshToken) FamilyID() string {
+	return t.familyID
+}
+
+// UserReferenceID returns the reference id of the user the token was issued to.
+func (t *RefreshToken) UserReferenceID() string {
+	return t.userReferenceID
+}
+
+// Expires returns the time the refresh token expires.
+func (t *RefreshToken) Expires() time.Time {
+	return t.expires
+}
+
+// Rotate uses the refresh token, returning a new token in the same family, to
+// replace it. ErrRefreshTokenReused is returned if the token has already been
+// used, which indicates the token has been stolen, so the family should be revoked.
+func (t *RefreshToken) Rotate(lifetime time.Duration) (*RefreshToken, error) {
+	if t.revoked != nil {
+		return nil, ErrRefreshTokenRevoked
+	}
+
+	if t.used != nil {
+		return nil, ErrRefreshTokenReused
+	}
+
+	now := time.Now().UTC()
+	if !now.Before(t.expires) {
+		return nil, ErrRefreshTokenExpired
+	}
+
+	t.used = &now
+
+	return newRefreshToken(t.familyID, t.userReferenceID, lifetime)
+}
+
+// SetID sets the id of the refresh token.
+func (t *RefreshToken) SetID(id int64) {
+	t.id = id
+}
+
+// Dao returns a data access object populated with the refresh token's data.
+func (t *RefreshToken) Dao() *dao.RefreshToken {
+	return &dao.RefreshToken{
+		ID:              t.id,
+		TokenHash:       t.tokenHash,
+		FamilyID:        t.familyID,
+		UserReferenceID: t.userReferenceID,
+		Created:         t.created,
+		Expires:         t.expires,
+		Used:            t.used,
+		Revoked:         t.revoked,
+	}
+}
+
+// RefreshTokenFromDao returns a new instance of RefreshToken, populated with
+// the data from the data access object. This should only be used by the
+// RefreshTokenRepository, to instantiate new domain models.
+func RefreshTokenFromDao(d *dao.RefreshToken) *RefreshToken {
+	return &RefreshToken{
+		id:              d.ID,
+		tokenHash:       d.TokenHash,
+		familyID:        d.FamilyID,
+		userReferenceID: d.UserReferenceID,
+		created:         d.Created,
+		expires:         d.Expires,
+		used:            d.Used,
+		revoked:         d.Revoked,
+	}
+}
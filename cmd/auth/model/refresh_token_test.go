@@ -0,0 +1,77 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/auth/dao"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	rt, err := NewRefreshToken("user", time.Hour)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, rt.Token())
+	assert.NotEmpty(t, rt.FamilyID())
+	assert.Equal(t, "user", rt.UserReferenceID())
+	assert.Equal(t, HashRefreshToken(rt.Token()), rt.Dao().TokenHash)
+	assert.WithinDuration(t, time.Now().UTC().Add(time.Hour), rt.Expires(), time.Second)
+
+	other, _ := NewRefreshToken("user", time.Hour)
+	assert.NotEqual(t, rt.Token(), other.Token())
+	assert.NotEqual(t, rt.FamilyID(), other.FamilyID())
+}
+
+func TestRefreshToken_Rotate_ReturnsTokenInSameFamily(t *testing.T) {
+	rt, _ := NewRefreshToken("user", time.Hour)
+
+	next, err := rt.Rotate(time.Hour)
+	assert.NoError(t, err)
+	assert.NotEqual(t, rt.Token(), next.Token())
+	assert.Equal(t, rt.FamilyID(), next.FamilyID())
+	assert.Equal(t, "user", next.UserReferenceID())
+	assert.NotNil(t, rt.Dao().Used)
+	assert.Nil(t, next.Dao().Used)
+}
+
+func TestRefreshToken_Rotate_ReturnsError(t *testing.T) {
+	now := time.Now().UTC()
+
+	t.Run("Reused", func(t *testing.T) {
+		rt := RefreshTokenFromDao(&dao.RefreshToken{Expires: now.Add(time.Hour), Used: &now})
+		next, err := rt.Rotate(time.Hour)
+		assert.Nil(t, next)
+		assert.Equal(t, ErrRefreshTokenReused, err)
+	})
+
+	t.Run("Revoked", func(t *testing.T) {
+		rt := RefreshTokenFromDao(&dao.RefreshToken{Expires: now.Add(time.Hour), Used: &now, Revoked: &now})
+		next, err := rt.Rotate(time.Hour)
+		assert.Nil(t, next)
+		assert.Equal(t, ErrRefreshTokenRevoked, err)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		rt := RefreshTokenFromDao(&dao.RefreshToken{Expires: now.Add(-time.Second)})
+		next, err := rt.Rotate(time.Hour)
+		assert.Nil(t, next)
+		assert.Equal(t, ErrRefreshTokenExpired, err)
+		assert.Nil(t, rt.Dao().Used)
+	})
+}
+
+func TestRefreshTokenFromDao(t *testing.T) {
+	d := &dao.RefreshToken{
+		ID:              12,
+		TokenHash:       "hash",
+		FamilyID:        "family",
+		UserReferenceID: "user",
+		Created:         time.Now().UTC(),
+		Expires:         time.Now().UTC(),
+	}
+
+	rt := RefreshTokenFromDao(d)
+	assert.Equal(t, d, rt.Dao())
+	assert.Equal(t, "", rt.Token())
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/reecerussell/open-social/cmd/auth/dao"
+	"github.com/reecerussell/open-social/cmd/auth/model"
+	"github.com/reecerussell/open-social/database"
+)
+
+// Refresh token data errors
+var (
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+)
+
+// RefreshTokenRepository is a high level interface used to manipulate persisted refresh token data.
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, t *model.RefreshToken) error
+
+	// Get gets a refresh token by its value. Within a transaction, the token
+	// is locked until the transaction ends, so it can't be used concurrently.
+	Get(ctx context.Context, token string) (*model.RefreshToken, error)
+	Update(ctx context.Context, t *model.RefreshToken) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeUser(ctx context.Context, userReferenceID string) error
+}
+
+type refreshTokenRepository struct {
+	db database.Database
+}
+
+// NewRefreshTokenRepository returns a new instance of RefreshTokenRepository.
+func NewRefreshTokenRepository(db database.Database) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, t *model.RefreshToken) error {
+	const query = `INSERT INTO [RefreshTokens] ([TokenHash],[FamilyId],[UserReferenceId],[Created],[Expires])
+					VALUES (@tokenHash, @familyId, @userReferenceId, @created, @expires)
+				SELECT CAST(SCOPE_IDENTITY() AS BIGINT)`
+
+	token := t.Dao()
+	row, err := r.db.Single(ctx, query,
+		sql.Named("tokenHash", token.TokenHash),
+		sql.Named("familyId", token.FamilyID),
+		sql.Named("userReferenceId", token.UserReferenceID),
+		sql.Named("created", token.Created),
+		sql.Named("expires", token.Expires))
+	if err != nil {
+		return err
+	}
+
+	var id int64
+	err = row.Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	t.SetID(id)
+
+	return nil
+}
+
+func (r *refreshTokenRepository) Get(ctx context.Context, token string) (*model.RefreshToken, error) {
+	const query = `SELECT
+			[Id],
+			[TokenHash],
+			CAST([FamilyId] AS CHAR(36)),
+			CAST([UserReferenceId] AS CHAR(36)),
+			[Created],
+			[Expires],
+			[Used],
+			[Revoked]
+		FROM [RefreshTokens] WITH (UPDLOCK, ROWLOCK)
+		WHERE [TokenHash] = @tokenHash;`
+
+	row, err := r.db.Single(ctx, query, sql.Named("tokenHash", model.HashRefreshToken(token)))
+	if err != nil {
+		return nil, err
+	}
+
+	var refreshToken dao.RefreshToken
+	err = row.Scan(
+		&refreshToken.ID,
+		&refreshToken.TokenHash,
+		&refreshToken.FamilyID,
+		&refreshToken.UserReferenceID,
+		&refreshToken.Created,
+		&refreshToken.Expires,
+		&refreshToken.Used,
+		&refreshToken.Revoked,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrRefreshTokenNotFound
+		}
+
+		return nil, err
+	}
+
+	return model.RefreshTokenFromDao(&refreshToken), nil
+}
+
+func (r *refreshTokenRepository) Update(ctx context.Context, t *model.RefreshToken) error {
+	const query = `UPDATE [RefreshTokens] SET [Used] = @used, [Revoked] = @revoked WHERE [Id] = @id;`
+
+	token := t.Dao()
+	_, err := r.db.Execute(ctx, query,
+		sql.Named("used", token.Used),
+		sql.Named("revoked", token.Revoked),
+		sql.Named("id", token.ID))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	const query = `UPDATE [RefreshTokens] SET [Revoked] = @revoked
+					WHERE [FamilyId] = @familyId AND [Revoked] IS NULL;`
+
+	_, err := r.db.Execute(ctx, query,
+		sql.Named("revoked", time.Now().UTC()),
+		sql.Named("familyId", familyID))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeUser(ctx context.Context, userReferenceID string) error {
+	const query = `UPDATE [RefreshTokens] SET [Revoked] = @revoked
+					WHERE [UserReferenceId] = @userReferenceId AND [Revoked] IS NULL;`
+
+	_, err := r.db.Execute(ctx, query,
+		sql.Named("revoked", time.Now().UTC()),
+		sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
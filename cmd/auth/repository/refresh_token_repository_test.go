@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/auth/model"
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestRefreshTokenRepository_Create_SetsID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testToken, _ := model.NewRefreshToken("user", time.Hour)
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*int64)) = 12
+
+		return nil
+	})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, query string, args ...interface{}) (*mock.MockRow, error) {
+			assert.Equal(t, model.HashRefreshToken(testToken.Token()), args[0].(sql.NamedArg).Value)
+
+			return mockRow, nil
+		})
+
+	repo := NewRefreshTokenRepository(mockDatabase)
+	err := repo.Create(testCtx, testToken)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), testToken.Dao().ID)
+}
+
+func TestRefreshTokenRepository_CreateQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testToken, _ := model.NewRefreshToken("user", time.Hour)
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(nil, testError)
+
+	repo := NewRefreshTokenRepository(mockDatabase)
+	err := repo.Create(testCtx, testToken)
+	assert.Equal(t, testError, err)
+}
+
+func TestRefreshTokenRepository_Get_ReturnsToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testExpires := time.Now().UTC().Add(time.Hour)
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*int64)) = 12
+		*(dest[1].(*string)) = model.HashRefreshToken("token")
+		*(dest[2].(*string)) = "family"
+		*(dest[3].(*string)) = "user"
+		*(dest[5].(*time.Time)) = testExpires
+
+		return nil
+	})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), sql.Named("tokenHash", model.HashRefreshToken("token"))).Return(mockRow, nil)
+
+	repo := NewRefreshTokenRepository(mockDatabase)
+	token, err := repo.Get(testCtx, "token")
+	assert.NoError(t, err)
+	assert.Equal(t, "family", token.FamilyID())
+	assert.Equal(t, "user", token.UserReferenceID())
+	assert.Equal(t, testExpires, token.Expires())
+}
+
+func TestRefreshTokenRepository_GetNotFound_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).Return(sql.ErrNoRows)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	repo := NewRefreshTokenRepository(mockDatabase)
+	token, err := repo.Get(testCtx, "token")
+	assert.Nil(t, token)
+	assert.Equal(t, ErrRefreshTokenNotFound, err)
+}
+
+func TestRefreshTokenRepository_RevokeFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any(), sql.Named("familyId", "family")).Return(int64(2), nil)
+
+	repo := NewRefreshTokenRepository(mockDatabase)
+	err := repo.RevokeFamily(testCtx, "family")
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepository_RevokeUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any(), sql.Named("userReferenceId", "user")).Return(int64(-1), testError)
+
+	repo := NewRefreshTokenRepository(mockDatabase)
+	err := repo.RevokeUser(testCtx, "user")
+	assert.Equal(t, testError, err)
+}
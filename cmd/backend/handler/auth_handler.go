@@ -15,16 +15,20 @@ import (
 // the platform, including registrations and logging in.
 type AuthHandler struct {
 	core.Handler
-	users users.Client
-	auth  auth.Client
+	users          users.Client
+	auth           auth.Client
+	trustedProxies int
 }
 
 // NewAuthHandler returns a new instance of AuthHandler, taking
-// the following parameters as dependencies.
-func NewAuthHandler(users users.Client, auth auth.Client) *AuthHandler {
+// the following parameters as dependencies. trustedProxies is the
+// number of proxies in front of the backend, used to find the
+// address of the client.
+func NewAuthHandler(users users.Client, auth auth.Client, trustedProxies int) *AuthHandler {
 	return &AuthHandler{
-		users: users,
-		auth:  auth,
+		users:          users,
+		auth:           auth,
+		trustedProxies: trustedProxies,
 	}
 }
 
@@ -37,8 +41,10 @@ type RegisterUserResponse struct {
 
 // RegisterUserToken represents a user's access token in the register user response body.
 type RegisterUserToken struct {
-	Token   string `json:"token"`
-	Expires int64  `json:"expires"`
+	Token          string `json:"token"`
+	Expires        int64  `json:"expires"`
+	RefreshToken   string `json:"refreshToken"`
+	RefreshExpires int64  `json:"refreshExpires"`
 }
 
 // Register handles requests to register a user.
@@ -65,13 +71,16 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	token, err := h.auth.GenerateToken(&auth.GenerateTokenRequest{
-		Username: user.Username,
-		Password: data.Password,
+		Username:  user.Username,
+		Password:  data.Password,
+		IPAddress: clientIP(r, h.trustedProxies),
 	})
 	if err == nil {
 		response.AccessToken = &RegisterUserToken{
-			Token:   token.Token,
-			Expires: token.Expires,
+			Token:          token.Token,
+			Expires:        token.Expires,
+			RefreshToken:   token.RefreshToken,
+			RefreshExpires: token.RefreshExpires,
 		}
 	} else {
 		log.Printf("WARN: failed to generate token: %v\n", err)
@@ -94,8 +103,9 @@ func (h *AuthHandler) Token(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
 	token, err := h.auth.GenerateToken(&auth.GenerateTokenRequest{
-		Username: data.Username,
-		Password: data.Password,
+		Username:  data.Username,
+		Password:  data.Password,
+		IPAddress: clientIP(r, h.trustedProxies),
 	})
 	if err != nil {
 		switch e := err.(type) {
@@ -110,3 +120,132 @@ func (h *AuthHandler) Token(w http.ResponseWriter, r *http.Request) {
 
 	h.Respond(w, token)
 }
+
+// SecondFactorRequest is a type used to unmarshal a second factor request's body to.
+type SecondFactorRequest struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"`
+}
+
+// SecondFactor is a http.HandlerFunc used to provide an access token for a user with
+// two-factor authentication, given the challenge token returned by Token, and a code
+// from their authenticator, or one of their recovery codes.
+func (h *AuthHandler) SecondFactor(w http.ResponseWriter, r *http.Request) {
+	var data SecondFactorRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	token, err := h.auth.VerifySecondFactor(&auth.VerifySecondFactorRequest{
+		ChallengeToken: data.ChallengeToken,
+		Code:           data.Code,
+		IPAddress:      clientIP(r, h.trustedProxies),
+	})
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, token)
+}
+
+// RefreshRequest is a type used to unmarshal a refresh or logout request's body to.
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// Refresh is a http.HandlerFunc used to exchange a refresh token for a new
+// access token, and a new refresh token to replace the one given.
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var data RefreshRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	token, err := h.auth.RefreshToken(&auth.RefreshTokenRequest{
+		RefreshToken: data.RefreshToken,
+	})
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, token)
+}
+
+// Logout is a http.HandlerFunc used to revoke a refresh token, so it,
+// and any tokens it has been rotated for, can no longer be used.
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var data RefreshRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	err := h.auth.RevokeToken(&auth.RefreshTokenRequest{
+		RefreshToken: data.RefreshToken,
+	})
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, nil)
+}
+
+// RequestPasswordReset is a http.HandlerFunc used to send a user a link to reset their password.
+func (h *AuthHandler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var data users.RequestPasswordResetRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	err := h.users.RequestPasswordReset(&data)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, nil)
+}
+
+// ResetPassword is a http.HandlerFunc used to set a user's password with a
+// password reset token. All of the user's sessions are revoked by the users service.
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var data users.ResetPasswordRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	_, err := h.users.ResetPassword(&data)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, nil)
+}
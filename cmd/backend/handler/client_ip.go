@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// clientIP returns the IP address of the client making the request. Each of the
+// trusted proxies in front of the backend, such as the ingress, appends the address
+// it received the request from to X-Forwarded-For, so the entry appended by the
+// outermost of them is the client. Entries before it were sent by the client, and
+// can't be trusted. Without any trusted proxies, the address of the connection is used.
+func clientIP(r *http.Request, trustedProxies int) string {
+	if trustedProxies > 0 {
+		var addrs []string
+		for _, v := range r.Header.Values("X-Forwarded-For") {
+			for _, addr := range strings.Split(v, ",") {
+				addrs = append(addrs, strings.TrimSpace(addr))
+			}
+		}
+
+		if len(addrs) >= trustedProxies {
+			return addrs[len(addrs)-trustedProxies]
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
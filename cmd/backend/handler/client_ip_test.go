@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newClientIPRequest(forwarded ...string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "/auth/token", nil)
+	req.RemoteAddr = "10.0.0.5:51234"
+	for _, v := range forwarded {
+		req.Header.Add("X-Forwarded-For", v)
+	}
+
+	return req
+}
+
+func TestClientIP_WithoutTrustedProxies_ReturnsConnectionAddress(t *testing.T) {
+	req := newClientIPRequest("203.0.113.7")
+
+	assert.Equal(t, "10.0.0.5", clientIP(req, 0))
+}
+
+func TestClientIP_WithTrustedProxy_ReturnsAddressAddedByProxy(t *testing.T) {
+	req := newClientIPRequest("198.51.100.20")
+
+	assert.Equal(t, "198.51.100.20", clientIP(req, 1))
+}
+
+func TestClientIP_GivenSpoofedForwardedFor_ReturnsAddressAddedByProxy(t *testing.T) {
+	// The client sends its own X-Forwarded-For, which the proxy appends to.
+	spoofed := []*http.Request{
+		newClientIPRequest("1.2.3.4, 198.51.100.20"),
+		newClientIPRequest("5.6.7.8, 9.10.11.12, 198.51.100.20"),
+		newClientIPRequest("1.2.3.4", "198.51.100.20"),
+	}
+
+	for _, req := range spoofed {
+		assert.Equal(t, "198.51.100.20", clientIP(req, 1))
+	}
+}
+
+func TestClientIP_WithMultipleTrustedProxies_SkipsProxies(t *testing.T) {
+	req := newClientIPRequest("1.2.3.4, 198.51.100.20, 10.0.0.9")
+
+	assert.Equal(t, "198.51.100.20", clientIP(req, 2))
+}
+
+func TestClientIP_WithFewerAddressesThanProxies_ReturnsConnectionAddress(t *testing.T) {
+	assert.Equal(t, "10.0.0.5", clientIP(newClientIPRequest(), 1))
+	assert.Equal(t, "10.0.0.5", clientIP(newClientIPRequest("198.51.100.20"), 2))
+}
+
+func TestClientIP_BehindProxy_ReturnsAddressAppendedByProxy(t *testing.T) {
+	ips := make(chan string, 1)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ips <- clientIP(r, 1)
+	}))
+	defer backend.Close()
+
+	// The proxy appends the address of the connection to X-Forwarded-For,
+	// as nginx does with $proxy_add_x_forwarded_for.
+	target, _ := url.Parse(backend.URL)
+	proxy := httptest.NewServer(httputil.NewSingleHostReverseProxy(target))
+	defer proxy.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, proxy.URL+"/auth/token", nil)
+	req.Header.Set("X-Forwarded-For", "1.2.3.4")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	assert.Equal(t, "127.0.0.1", <-ips)
+}
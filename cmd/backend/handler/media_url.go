@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/reecerussell/open-social/client/posts"
+	"github.com/reecerussell/open-social/client/users"
+	"github.com/reecerussell/open-social/mediaurl"
+)
+
+// MediaURLs hands out signed URLs to download media from, which expire after
+// at least TTL. URLs expire at the end of the window of TTL after the one
+// they're signed in, so the same URL is handed out for a whole window, and
+// media can be cached by browsers. If BindUser is set, URLs are bound to the
+// user they're handed to, who's identified by the mediaurl.UserCookie.
+type MediaURLs struct {
+	signer   *mediaurl.Signer
+	baseURL  string
+	ttl      time.Duration
+	bindUser bool
+}
+
+// NewMediaURLs returns a new instance of MediaURLs, for media downloaded
+// from baseURL, followed by the media's reference id.
+func NewMediaURLs(signer *mediaurl.Signer, baseURL string, ttl time.Duration, bindUser bool) *MediaURLs {
+	return &MediaURLs{
+		signer:   signer,
+		baseURL:  baseURL,
+		ttl:      ttl,
+		bindUser: bindUser,
+	}
+}
+
+// URL returns a signed URL for the media, handed to the given user,
+// or nil if mediaID is nil.
+func (u *MediaURLs) URL(mediaID *string, userID string) *string {
+	if mediaID == nil {
+		return nil
+	}
+
+	if !u.bindUser {
+		userID = ""
+	}
+
+	query := u.signer.Sign(*mediaID, userID, u.expires())
+	s := u.baseURL + url.PathEscape(*mediaID) + "?" + query.Encode()
+
+	return &s
+}
+
+// SetUserCookie sets the mediaurl.UserCookie for the given user, when URLs are
+// bound to users. It's set whenever URLs are handed out, so it's valid for
+// at least as long as the URLs.
+func (u *MediaURLs) SetUserCookie(w http.ResponseWriter, userID string) {
+	if !u.bindUser {
+		return
+	}
+
+	path := "/"
+	if base, err := url.Parse(u.baseURL); err == nil && base.Path != "" {
+		path = base.Path
+	}
+
+	expires := u.expires()
+	http.SetCookie(w, &http.Cookie{
+		Name:     mediaurl.UserCookie,
+		Value:    u.signer.SignUser(userID, expires),
+		Path:     path,
+		Expires:  expires,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (u *MediaURLs) expires() time.Time {
+	return time.Now().Truncate(u.ttl).Add(2 * u.ttl)
+}
+
+// FeedItem is an item in a feed, with a signed URL for its media.
+type FeedItem struct {
+	*posts.FeedItem
+	MediaURL *string `json:"mediaUrl"`
+}
+
+// Feed is a page of feed items.
+type Feed struct {
+	Items      []*FeedItem `json:"items"`
+	NextCursor *string     `json:"nextCursor"`
+}
+
+// Feed returns the items, with URLs handed to the given user.
+func (u *MediaURLs) Feed(items []*posts.FeedItem, userID string) []*FeedItem {
+	feed := make([]*FeedItem, len(items))
+	for i, item := range items {
+		feed[i] = &FeedItem{
+			FeedItem: item,
+			MediaURL: u.URL(item.MediaID, userID),
+		}
+	}
+
+	return feed
+}
+
+// Post is a post, with a signed URL for its media.
+type Post struct {
+	*posts.Post
+	MediaURL *string `json:"mediaUrl"`
+}
+
+// UserInfo is a user's information, with a signed URL for their avatar.
+type UserInfo struct {
+	*users.Info
+	MediaURL *string `json:"mediaUrl"`
+}
+
+// Follow is an entry in a list of users, with a signed URL for their avatar.
+type Follow struct {
+	*users.Follow
+	MediaURL *string `json:"mediaUrl"`
+}
+
+// FollowList is a page of users.
+type FollowList struct {
+	Items      []*Follow `json:"items"`
+	NextCursor *string   `json:"nextCursor"`
+}
+
+// FollowList returns the list, with URLs handed to the given user.
+func (u *MediaURLs) FollowList(list *users.FollowList, userID string) *FollowList {
+	items := make([]*Follow, len(list.Items))
+	for i, item := range list.Items {
+		items[i] = &Follow{
+			Follow:   item,
+			MediaURL: u.URL(item.MediaID, userID),
+		}
+	}
+
+	return &FollowList{
+		Items:      items,
+		NextCursor: list.NextCursor,
+	}
+}
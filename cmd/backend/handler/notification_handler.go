@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/client"
+	"github.com/reecerussell/open-social/client/notifications"
+)
+
+// NotificationHandler handles requests to the notification domain.
+type NotificationHandler struct {
+	core.Handler
+	client notifications.Client
+}
+
+// NewNotificationHandler returns a new instance of NotificationHandler.
+func NewNotificationHandler(client notifications.Client) *NotificationHandler {
+	return &NotificationHandler{
+		client: client,
+	}
+}
+
+// UnreadCountResponse contains the number of unread notifications.
+type UnreadCountResponse struct {
+	Count int `json:"count"`
+}
+
+// MarkReadRequest is the body of the request. If IDs is empty,
+// all of the user's notifications are marked as read.
+type MarkReadRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// List returns the current user's notifications.
+func (h *NotificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	items, err := h.client.List(principal.UserID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.Respond(w, items)
+}
+
+// GetUnreadCount returns the number of notifications the current user has not read.
+func (h *NotificationHandler) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	count, err := h.client.GetUnreadCount(principal.UserID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.Respond(w, UnreadCountResponse{Count: count})
+}
+
+// MarkRead marks the current user's notifications as read.
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	var data MarkReadRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.client.MarkRead(principal.UserID, data.IDs)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.Respond(w, nil)
+}
+
+func (h *NotificationHandler) handleError(w http.ResponseWriter, err error) {
+	switch e := err.(type) {
+	case *client.Error:
+		h.RespondError(w, e, e.StatusCode)
+		return
+	default:
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// errInvalidLimit is returned when the limit query parameter is not a positive number.
+var errInvalidLimit = errors.New("limit must be a positive number")
+
+// pageFromRequest reads the page cursor and limit from the request's query string.
+// A limit of zero is returned if one was not given, leaving the default to the downstream API.
+func pageFromRequest(r *http.Request) (cursor string, limit int, err error) {
+	q := r.URL.Query()
+	cursor = q.Get("cursor")
+
+	if v := q.Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return "", 0, errInvalidLimit
+		}
+	}
+
+	return cursor, limit, nil
+}
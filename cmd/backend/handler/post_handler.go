@@ -2,7 +2,7 @@ package handler
 
 import (
 	"context"
-	"encoding/base64"
+	"encoding/json"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -18,13 +18,15 @@ type PostHandler struct {
 	core.Handler
 	client posts.Client
 	media  media.Client
+	urls   *MediaURLs
 }
 
 // NewPostHandler returns a new instance of PostHandler.
-func NewPostHandler(client posts.Client, media media.Client) *PostHandler {
+func NewPostHandler(client posts.Client, media media.Client, urls *MediaURLs) *PostHandler {
 	return &PostHandler{
 		client: client,
 		media:  media,
+		urls:   urls,
 	}
 }
 
@@ -40,7 +42,7 @@ type CreatePostResponse struct {
 
 // Create handles requests to create a post.
 func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
-	r.ParseMultipartForm(10 << 20)
+	r.ParseMultipartForm(maxFormMemory)
 
 	ctx := r.Context()
 	mediaID, success := h.uploadMedia(ctx, w, r)
@@ -48,11 +50,14 @@ func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID := ctx.Value(core.ContextKey("uid")).(string)
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
 	caption := r.FormValue("caption")
 
 	post, err := h.client.Create(&posts.CreateRequest{
-		UserReferenceID: userID,
+		UserReferenceID: principal.UserID,
 		MediaID:         mediaID,
 		Caption:         caption,
 	})
@@ -70,21 +75,23 @@ func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *PostHandler) uploadMedia(ctx context.Context, w http.ResponseWriter, r *http.Request) (*int, bool) {
-	file, header, err := r.FormFile("file")
-	if err != nil && err != http.ErrMissingFile {
+	file, _, err := r.FormFile("file")
+	if err == http.ErrMissingFile {
+		return nil, true
+	}
+	if err != nil {
 		h.RespondError(w, err, http.StatusInternalServerError)
 		return nil, false
 	}
 	defer file.Close()
 
-	fileData := make([]byte, header.Size)
-	file.Read(fileData)
-	contentType := http.DetectContentType(fileData)
+	contentType, content, err := sniffContentType(file)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return nil, false
+	}
 
-	m, err := h.media.Create(&media.CreateRequest{
-		ContentType: contentType,
-		Content:     base64.StdEncoding.EncodeToString(fileData),
-	})
+	m, err := h.media.Create(contentType, content)
 	if err != nil {
 		h.handleError(w, err)
 		return nil, false
@@ -95,16 +102,28 @@ func (h *PostHandler) uploadMedia(ctx context.Context, w http.ResponseWriter, r
 
 // GetFeed returns a user's feed.
 func (h *PostHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	userID := ctx.Value(core.ContextKey("uid")).(string)
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	cursor, limit, err := pageFromRequest(r)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
 
-	feed, err := h.client.GetFeed(userID)
+	feed, err := h.client.GetFeed(principal.UserID, cursor, limit)
 	if err != nil {
 		h.handleError(w, err)
 		return
 	}
 
-	h.Respond(w, feed)
+	h.urls.SetUserCookie(w, principal.UserID)
+	h.Respond(w, Feed{
+		Items:      h.urls.Feed(feed.Items, principal.UserID),
+		NextCursor: feed.NextCursor,
+	})
 }
 
 // GetPost returns a post.
@@ -112,16 +131,22 @@ func (h *PostHandler) GetPost(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
 
-	ctx := r.Context()
-	userID := ctx.Value(core.ContextKey("uid")).(string)
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
 
-	post, err := h.client.Get(id, userID)
+	post, err := h.client.Get(id, principal.UserID)
 	if err != nil {
 		h.handleError(w, err)
 		return
 	}
 
-	h.Respond(w, post)
+	h.urls.SetUserCookie(w, principal.UserID)
+	h.Respond(w, Post{
+		Post:     post,
+		MediaURL: h.urls.URL(post.MediaID, principal.UserID),
+	})
 }
 
 // Like marks a post as liked by the current user.
@@ -129,10 +154,12 @@ func (h *PostHandler) Like(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
 
-	ctx := r.Context()
-	userID := ctx.Value(core.ContextKey("uid")).(string)
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
 
-	err := h.client.LikePost(id, userID)
+	err := h.client.LikePost(id, principal.UserID)
 	if err != nil {
 		h.handleError(w, err)
 		return
@@ -146,10 +173,91 @@ func (h *PostHandler) Unlike(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
 
-	ctx := r.Context()
-	userID := ctx.Value(core.ContextKey("uid")).(string)
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.client.UnlikePost(id, principal.UserID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.Respond(w, nil)
+}
+
+// CreateCommentRequest is the body of the request to comment on a post.
+type CreateCommentRequest struct {
+	Text string `json:"text"`
+}
+
+// CreateCommentResponse contains the reference id of the newly created comment.
+type CreateCommentResponse struct {
+	ID string `json:"id"`
+}
+
+// CreateComment handles requests to comment on a post as the current user.
+func (h *PostHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id := params["id"]
+
+	var data CreateCommentRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	comment, err := h.client.CreateComment(id, &posts.CreateCommentRequest{
+		UserReferenceID: principal.UserID,
+		Text:            data.Text,
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response := CreateCommentResponse{
+		ID: comment.ReferenceID,
+	}
+
+	h.Respond(w, response)
+}
+
+// GetComments returns the comments on a post.
+func (h *PostHandler) GetComments(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id := params["id"]
+
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	comments, err := h.client.GetComments(id, principal.UserID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.Respond(w, comments)
+}
+
+// DeleteComment deletes a comment on a post, on behalf of the current user.
+func (h *PostHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	id := params["id"]
+	commentID := params["commentId"]
+
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
 
-	err := h.client.UnlikePost(id, userID)
+	err := h.client.DeleteComment(id, commentID, principal.UserID)
 	if err != nil {
 		h.handleError(w, err)
 		return
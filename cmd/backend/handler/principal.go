@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"net/http"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/backend/middleware"
+)
+
+// requirePrincipal returns the authenticated user making the request. If there
+// isn't one, an unauthorized response is written and false is returned.
+func requirePrincipal(w http.ResponseWriter, r *http.Request) (*middleware.Principal, bool) {
+	principal, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok {
+		new(core.Handler).RespondError(w, middleware.ErrUnauthenticated, http.StatusUnauthorized)
+	}
+
+	return principal, ok
+}
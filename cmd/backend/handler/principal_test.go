@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/backend/middleware"
+)
+
+func TestRequirePrincipal(t *testing.T) {
+	exp := &middleware.Principal{UserID: "23470324", Username: "testing"}
+	req, _ := http.NewRequest(http.MethodGet, "/me", nil)
+	req = req.WithContext(middleware.ContextWithPrincipal(req.Context(), exp))
+
+	rr := httptest.NewRecorder()
+	principal, ok := requirePrincipal(rr, req)
+
+	assert.True(t, ok)
+	assert.Equal(t, exp, principal)
+	assert.Equal(t, 0, rr.Body.Len())
+}
+
+func TestRequirePrincipal_Unauthenticated_ReturnsUnauthorized(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "/me", nil)
+
+	rr := httptest.NewRecorder()
+	principal, ok := requirePrincipal(rr, req)
+
+	assert.False(t, ok)
+	assert.Nil(t, principal)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", middleware.ErrUnauthenticated), rr.Body.String())
+}
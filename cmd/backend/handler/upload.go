@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// maxFormMemory is the number of bytes of a multipart form held in memory,
+// beyond which uploaded files are stored in temporary files.
+const maxFormMemory = 1 << 20
+
+// sniffContentType detects the content type of r from its first 512 bytes, without
+// reading the rest. The returned reader reads the whole of r, including those bytes.
+func sniffContentType(r io.Reader) (string, io.Reader, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", nil, err
+	}
+
+	head = head[:n]
+	return http.DetectContentType(head), io.MultiReader(bytes.NewReader(head), r), nil
+}
@@ -1,14 +1,25 @@
 package handler
 
 import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
+	"mime"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 
 	core "github.com/reecerussell/open-social"
 	"github.com/reecerussell/open-social/client"
 	"github.com/reecerussell/open-social/client/auth"
+	"github.com/reecerussell/open-social/client/media"
 	"github.com/reecerussell/open-social/client/posts"
 	"github.com/reecerussell/open-social/client/users"
 )
@@ -19,21 +30,33 @@ type UserHandler struct {
 	client users.Client
 	auth   auth.Client
 	posts  posts.Client
+	media  media.Client
+	urls   *MediaURLs
+
+	trustedProxies int
 }
 
+// ErrAvatarNotImage is returned when an avatar is uploaded which isn't an image.
+var ErrAvatarNotImage = errors.New("avatar must be an image")
+
 // NewUserHandler returns a new instance of UserHandler.
-func NewUserHandler(client users.Client, auth auth.Client, posts posts.Client) *UserHandler {
+func NewUserHandler(client users.Client, auth auth.Client, posts posts.Client, media media.Client, urls *MediaURLs, trustedProxies int) *UserHandler {
 	return &UserHandler{
-		client: client,
-		auth:   auth,
-		posts:  posts,
+		client:         client,
+		auth:           auth,
+		posts:          posts,
+		media:          media,
+		urls:           urls,
+		trustedProxies: trustedProxies,
 	}
 }
 
 // GetProfileResponse returns a user's profile data.
 type GetProfileResponse struct {
 	users.Profile
-	Feed []*posts.FeedItem `json:"feed"`
+	MediaURL   *string     `json:"mediaUrl"`
+	Feed       []*FeedItem `json:"feed"`
+	NextCursor *string     `json:"nextCursor"`
 }
 
 // GetProfile handles requests to get a user's profile.
@@ -41,10 +64,18 @@ func (h *UserHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	username := params["username"]
 
-	ctx := r.Context()
-	userID := ctx.Value(core.ContextKey("uid")).(string)
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	cursor, limit, err := pageFromRequest(r)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
 
-	profile, err := h.client.GetProfile(username, userID)
+	profile, err := h.client.GetProfile(username, principal.UserID)
 	if err != nil {
 		switch e := err.(type) {
 		case *client.Error:
@@ -56,7 +87,20 @@ func (h *UserHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	feed, err := h.posts.GetProfileFeed(username, userID)
+	mediaURL := h.urls.URL(profile.MediaID, principal.UserID)
+	h.urls.SetUserCookie(w, principal.UserID)
+
+	// Private profiles only show their header to users who don't follow them.
+	if profile.IsPrivate && !profile.IsFollowing && !profile.IsOwner {
+		h.Respond(w, GetProfileResponse{
+			Profile:  *profile,
+			MediaURL: mediaURL,
+			Feed:     []*FeedItem{},
+		})
+		return
+	}
+
+	feed, err := h.posts.GetProfileFeed(username, principal.UserID, cursor, limit)
 	if err != nil {
 		log.Printf("Error: %v\n", err)
 		switch e := err.(type) {
@@ -70,8 +114,10 @@ func (h *UserHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := GetProfileResponse{
-		Profile: *profile,
-		Feed:    feed,
+		Profile:    *profile,
+		MediaURL:   mediaURL,
+		Feed:       h.urls.Feed(feed.Items, principal.UserID),
+		NextCursor: feed.NextCursor,
 	}
 
 	h.Respond(w, resp)
@@ -79,10 +125,56 @@ func (h *UserHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 
 // GetInfo handles requests to get information for a user.
 func (h *UserHandler) GetInfo(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	userID := ctx.Value(core.ContextKey("uid")).(string)
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	info, err := h.client.GetInfo(principal.UserID)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.urls.SetUserCookie(w, principal.UserID)
+	h.Respond(w, UserInfo{
+		Info:     info,
+		MediaURL: h.urls.URL(info.MediaID, principal.UserID),
+	})
+}
 
-	info, err := h.client.GetInfo(userID)
+// GetFollowers handles requests to get a page of a user's followers.
+func (h *UserHandler) GetFollowers(w http.ResponseWriter, r *http.Request) {
+	h.getFollowList(w, r, h.client.GetFollowers)
+}
+
+// GetFollowing handles requests to get a page of the users a user follows.
+func (h *UserHandler) GetFollowing(w http.ResponseWriter, r *http.Request) {
+	h.getFollowList(w, r, h.client.GetFollowing)
+}
+
+func (h *UserHandler) getFollowList(w http.ResponseWriter, r *http.Request, get func(username, userReferenceID, cursor string, limit int) (*users.FollowList, error)) {
+	params := mux.Vars(r)
+	username := params["username"]
+
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	cursor, limit, err := pageFromRequest(r)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	list, err := get(username, principal.UserID, cursor, limit)
 	if err != nil {
 		switch e := err.(type) {
 		case *client.Error:
@@ -94,7 +186,72 @@ func (h *UserHandler) GetInfo(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	h.Respond(w, info)
+	h.urls.SetUserCookie(w, principal.UserID)
+	h.Respond(w, h.urls.FollowList(list, principal.UserID))
+}
+
+// GetFollowRequests handles requests to get a page of the users waiting
+// for the current user to approve their follow requests.
+func (h *UserHandler) GetFollowRequests(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	cursor, limit, err := pageFromRequest(r)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.client.GetFollowRequests(principal.UserID, cursor, limit)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.urls.SetUserCookie(w, principal.UserID)
+	h.Respond(w, h.urls.FollowList(list, principal.UserID))
+}
+
+// ApproveFollowRequest handles requests to approve the given user's request to follow the current user.
+func (h *UserHandler) ApproveFollowRequest(w http.ResponseWriter, r *http.Request) {
+	h.answerFollowRequest(w, r, h.client.ApproveFollowRequest)
+}
+
+// RejectFollowRequest handles requests to reject the given user's request to follow the current user.
+func (h *UserHandler) RejectFollowRequest(w http.ResponseWriter, r *http.Request) {
+	h.answerFollowRequest(w, r, h.client.RejectFollowRequest)
+}
+
+func (h *UserHandler) answerFollowRequest(w http.ResponseWriter, r *http.Request, answer func(userReferenceID, followerReferenceID string) error) {
+	params := mux.Vars(r)
+	followerReferenceID := params["userReferenceID"]
+
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	err := answer(principal.UserID, followerReferenceID)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, nil)
 }
 
 // Follow handles requests to make the current user follow the user with the given id.
@@ -102,10 +259,12 @@ func (h *UserHandler) Follow(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	userReferenceID := params["userReferenceID"]
 
-	ctx := r.Context()
-	userID := ctx.Value(core.ContextKey("uid")).(string)
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
 
-	err := h.client.Follow(userReferenceID, userID)
+	resp, err := h.client.Follow(userReferenceID, principal.UserID)
 	if err != nil {
 		switch e := err.(type) {
 		case *client.Error:
@@ -117,7 +276,7 @@ func (h *UserHandler) Follow(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	h.Respond(w, nil)
+	h.Respond(w, resp)
 }
 
 // Unfollow handles requests to make the current user unfollow the user with the given id.
@@ -125,10 +284,12 @@ func (h *UserHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	userReferenceID := params["userReferenceID"]
 
-	ctx := r.Context()
-	userID := ctx.Value(core.ContextKey("uid")).(string)
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
 
-	err := h.client.Unfollow(userReferenceID, userID)
+	err := h.client.Unfollow(userReferenceID, principal.UserID)
 	if err != nil {
 		switch e := err.(type) {
 		case *client.Error:
@@ -142,3 +303,512 @@ func (h *UserHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
 
 	h.Respond(w, nil)
 }
+
+// Block handles requests to make the current user block the given user.
+func (h *UserHandler) Block(w http.ResponseWriter, r *http.Request) {
+	h.actOnUser(w, r, h.client.Block)
+}
+
+// Unblock handles requests to make the current user unblock the given user.
+func (h *UserHandler) Unblock(w http.ResponseWriter, r *http.Request) {
+	h.actOnUser(w, r, h.client.Unblock)
+}
+
+// Mute handles requests to make the current user mute the given user.
+func (h *UserHandler) Mute(w http.ResponseWriter, r *http.Request) {
+	h.actOnUser(w, r, h.client.Mute)
+}
+
+// Unmute handles requests to make the current user unmute the given user.
+func (h *UserHandler) Unmute(w http.ResponseWriter, r *http.Request) {
+	h.actOnUser(w, r, h.client.Unmute)
+}
+
+func (h *UserHandler) actOnUser(w http.ResponseWriter, r *http.Request, act func(userReferenceID, actorReferenceID string) error) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	err := act(userReferenceID, principal.UserID)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, nil)
+}
+
+// GetBlockedUsers handles requests to get a page of the users blocked by the current user.
+func (h *UserHandler) GetBlockedUsers(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	cursor, limit, err := pageFromRequest(r)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.client.GetBlockedUsers(principal.UserID, cursor, limit)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.urls.SetUserCookie(w, principal.UserID)
+	h.Respond(w, h.urls.FollowList(list, principal.UserID))
+}
+
+// ChangePassword handles requests to change the current user's password.
+func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var data users.ChangePasswordRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	data.IPAddress = clientIP(r, h.trustedProxies)
+
+	err := h.client.ChangePassword(principal.UserID, &data)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, nil)
+}
+
+// EnrolTwoFactor handles requests to start enrolling the current user in two-factor
+// authentication. The secret returned must be confirmed before it's enabled.
+func (h *UserHandler) EnrolTwoFactor(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.client.EnrolTwoFactor(principal.UserID)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, resp)
+}
+
+// ConfirmTwoFactor handles requests to enable two-factor authentication for the
+// current user, given a code from their authenticator. The user's recovery codes
+// are returned, and can't be retrieved again.
+func (h *UserHandler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var data users.ConfirmTwoFactorRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.client.ConfirmTwoFactor(principal.UserID, &data)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, resp)
+}
+
+// DisableTwoFactor handles requests to disable two-factor authentication for
+// the current user, which requires both their password and a code.
+func (h *UserHandler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var data users.DisableTwoFactorRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	data.IPAddress = clientIP(r, h.trustedProxies)
+
+	err := h.client.DisableTwoFactor(principal.UserID, &data)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, nil)
+}
+
+// UpdateProfileResponse is the response body of an update profile request. If the
+// username was changed, a new access token is issued, as the current one holds the
+// old username.
+type UpdateProfileResponse struct {
+	Username    string                      `json:"username"`
+	Bio         *string                     `json:"bio"`
+	IsPrivate   bool                        `json:"isPrivate"`
+	AccessToken *auth.GenerateTokenResponse `json:"accessToken,omitempty"`
+}
+
+// UpdateProfile handles multipart requests to update the current user's profile. The
+// username, bio and isPrivate are only changed if their fields are given, and an avatar
+// can be uploaded as the avatar file.
+func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseMultipartForm(maxFormMemory)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	var data users.UpdateProfileRequest
+	if values, ok := r.MultipartForm.Value["username"]; ok && len(values) > 0 {
+		data.Username = &values[0]
+	}
+
+	if values, ok := r.MultipartForm.Value["bio"]; ok && len(values) > 0 {
+		data.Bio = &values[0]
+	}
+
+	if values, ok := r.MultipartForm.Value["isPrivate"]; ok && len(values) > 0 {
+		isPrivate, err := strconv.ParseBool(values[0])
+		if err != nil {
+			h.RespondError(w, errors.New("isPrivate must be true or false"), http.StatusBadRequest)
+			return
+		}
+
+		data.IsPrivate = &isPrivate
+	}
+
+	mediaID, ok := h.uploadAvatar(w, r)
+	if !ok {
+		return
+	}
+	data.MediaID = mediaID
+
+	profile, err := h.client.UpdateProfile(principal.UserID, &data)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	response := UpdateProfileResponse{
+		Username:  profile.Username,
+		Bio:       profile.Bio,
+		IsPrivate: profile.IsPrivate,
+	}
+
+	if profile.UsernameChanged {
+		token, err := h.auth.ReissueToken(principal.UserID)
+		if err == nil {
+			response.AccessToken = token
+		} else {
+			log.Printf("WARN: failed to reissue token: %v\n", err)
+		}
+	}
+
+	h.Respond(w, response)
+}
+
+// uploadAvatar uploads the request's avatar file, if one was given, to the media service.
+func (h *UserHandler) uploadAvatar(w http.ResponseWriter, r *http.Request) (*int, bool) {
+	file, _, err := r.FormFile("avatar")
+	if err == http.ErrMissingFile {
+		return nil, true
+	}
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return nil, false
+	}
+	defer file.Close()
+
+	contentType, content, err := sniffContentType(file)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if !strings.HasPrefix(contentType, "image/") {
+		h.RespondError(w, ErrAvatarNotImage, http.StatusBadRequest)
+		return nil, false
+	}
+
+	m, err := h.media.Create(contentType, content)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+		}
+
+		return nil, false
+	}
+
+	return &m.ID, true
+}
+
+// ExportData is the data.json file of a user's data export. The reference ids of any
+// media which couldn't be downloaded, and isn't in the export, are listed in MissingMedia.
+type ExportData struct {
+	Profile      *users.Export          `json:"profile"`
+	Posts        []*posts.ExportPost    `json:"posts"`
+	Likes        []*posts.ExportLike    `json:"likes"`
+	Comments     []*posts.ExportComment `json:"comments"`
+	MissingMedia []string               `json:"missingMedia"`
+}
+
+// Export handles requests to download all of the current user's data, as a zip
+// archive containing their data in data.json, and their media in media/.
+func (h *UserHandler) Export(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	profile, err := h.client.GetExport(principal.UserID)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	postData, err := h.posts.GetExport(principal.UserID)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.writeExport(w, &ExportData{
+		Profile:      profile,
+		Posts:        postData.Posts,
+		Likes:        postData.Likes,
+		Comments:     postData.Comments,
+		MissingMedia: []string{},
+	})
+}
+
+// writeExport streams the export's zip archive to w. As the response has started
+// by the time media is downloaded, media which fails to download is recorded in
+// the export's data, rather than failing the request.
+func (h *UserHandler) writeExport(w http.ResponseWriter, data *ExportData) {
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="open-social-export.zip"`)
+	w.WriteHeader(http.StatusOK)
+
+	archive := zip.NewWriter(w)
+
+	var mediaIDs []string
+	if data.Profile.MediaID != nil {
+		mediaIDs = append(mediaIDs, *data.Profile.MediaID)
+	}
+
+	for _, p := range data.Posts {
+		if p.MediaID != nil {
+			mediaIDs = append(mediaIDs, *p.MediaID)
+		}
+	}
+
+	for _, id := range mediaIDs {
+		err := h.writeExportMedia(archive, id)
+		if err != nil {
+			log.Printf("WARN: failed to export media '%s': %v\n", id, err)
+			data.MissingMedia = append(data.MissingMedia, id)
+		}
+	}
+
+	f, err := archive.Create("data.json")
+	if err == nil {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(data)
+	}
+	if err == nil {
+		err = archive.Close()
+	}
+	if err != nil {
+		log.Printf("Error: failed to write export: %v\n", err)
+	}
+}
+
+// writeExportMedia adds the media to the archive. It's downloaded to a temporary
+// file first, so media which fails part way through downloading isn't added. Writing
+// to the archive only fails if the response has, leaving the whole archive incomplete.
+func (h *UserHandler) writeExportMedia(archive *zip.Writer, referenceID string) error {
+	contentType, content, err := h.media.GetContent(referenceID, "", 0)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	tmp, err := ioutil.TempFile("", "export-media-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	_, err = io.Copy(tmp, content)
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		return fmt.Errorf("download: %v", err)
+	}
+
+	name := "media/" + referenceID
+	if exts, _ := mime.ExtensionsByType(contentType); len(exts) > 0 {
+		name += exts[0]
+	}
+
+	f, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("create: %v", err)
+	}
+
+	_, err = io.Copy(f, tmp)
+	if err != nil {
+		return fmt.Errorf("write: %v", err)
+	}
+
+	return nil
+}
+
+// RequestDeletion handles requests to delete the current user's account, given their password.
+// The account is deleted once a grace period has passed, until which it can be cancelled.
+func (h *UserHandler) RequestDeletion(w http.ResponseWriter, r *http.Request) {
+	var data users.RequestDeletionRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	data.IPAddress = clientIP(r, h.trustedProxies)
+
+	deletion, err := h.client.RequestDeletion(principal.UserID, &data)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, deletion)
+}
+
+// CancelDeletion handles requests to cancel the deletion of the current user's account.
+func (h *UserHandler) CancelDeletion(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	deletion, err := h.client.CancelDeletion(principal.UserID)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, deletion)
+}
+
+// GetDeletion handles requests to get the status of the deletion of the current user's account.
+func (h *UserHandler) GetDeletion(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requirePrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	deletion, err := h.client.GetDeletion(principal.UserID)
+	if err != nil {
+		switch e := err.(type) {
+		case *client.Error:
+			h.RespondError(w, e, e.StatusCode)
+			return
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, deletion)
+}
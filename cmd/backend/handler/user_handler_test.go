@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mediaMock "github.com/reecerussell/open-social/client/mock/media"
+)
+
+// failingReader returns an error once its content has been read.
+type failingReader struct {
+	io.Reader
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err == io.EOF {
+		err = errors.New("connection reset")
+	}
+
+	return n, err
+}
+
+func readArchive(t *testing.T, buf *bytes.Buffer) map[string]string {
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := make(map[string]string)
+	for _, f := range r.File {
+		rc, _ := f.Open()
+		data, _ := ioutil.ReadAll(rc)
+		rc.Close()
+
+		files[f.Name] = string(data)
+	}
+
+	return files
+}
+
+func TestUserHandler_WriteExportMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMedia := mediaMock.NewMockClient(ctrl)
+	mockMedia.EXPECT().GetContent("2389", "", int64(0)).
+		Return("image/png", ioutil.NopCloser(strings.NewReader("image data")), nil)
+
+	h := &UserHandler{media: mockMedia}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	err := h.writeExportMedia(archive, "2389")
+	assert.NoError(t, err)
+	archive.Close()
+
+	assert.Equal(t, map[string]string{"media/2389.png": "image data"}, readArchive(t, &buf))
+}
+
+func TestUserHandler_WriteExportMedia_DownloadFails_AddsNoFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMedia := mediaMock.NewMockClient(ctrl)
+	mockMedia.EXPECT().GetContent("2389", "", int64(0)).
+		Return("image/png", ioutil.NopCloser(&failingReader{strings.NewReader("image")}), nil)
+
+	h := &UserHandler{media: mockMedia}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	err := h.writeExportMedia(archive, "2389")
+	assert.Error(t, err)
+	archive.Close()
+
+	assert.Empty(t, readArchive(t, &buf))
+}
@@ -1,39 +1,54 @@
 package main
 
 import (
-	"crypto"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
-
-	"github.com/reecerussell/gojwt"
-	"github.com/reecerussell/gojwt/rsa"
+	"strconv"
+	"time"
 
 	core "github.com/reecerussell/open-social"
 	"github.com/reecerussell/open-social/client/auth"
 	"github.com/reecerussell/open-social/client/media"
+	"github.com/reecerussell/open-social/client/notifications"
 	"github.com/reecerussell/open-social/client/posts"
 	"github.com/reecerussell/open-social/client/users"
 	"github.com/reecerussell/open-social/cmd/backend/handler"
 	"github.com/reecerussell/open-social/cmd/backend/middleware"
+	"github.com/reecerussell/open-social/jwks"
+	"github.com/reecerussell/open-social/mediaurl"
+	"github.com/reecerussell/open-social/util"
 )
 
 const (
-	usersAPIVar           = "USERS_API_URL"
-	authAPIVar            = "AUTH_API_URL"
-	postsAPIVar           = "POSTS_API_URL"
-	mediaAPIVar           = "MEDIA_API_URL"
-	tokenPublicKeyVar     = "TOKEN_PUBLIC_KEY"
-	tokenPublicKeyDataVar = "TOKEN_PUBLIC_KEY_DATA"
-	bucketName            = "MEDIA_BUCKET"
+	usersAPIVar         = "USERS_API_URL"
+	authAPIVar          = "AUTH_API_URL"
+	postsAPIVar         = "POSTS_API_URL"
+	mediaAPIVar         = "MEDIA_API_URL"
+	notificationsAPIVar = "NOTIFICATIONS_API_URL"
+	jwksURLVar          = "JWKS_URL"
+	tokenIssuerVar      = "TOKEN_ISSUER"
+	tokenAudienceVar    = "TOKEN_AUDIENCE"
+	tokenClockSkewVar   = "TOKEN_CLOCK_SKEW"
+	trustedProxiesVar   = "TRUSTED_PROXIES"
+	bucketName          = "MEDIA_BUCKET"
+	mediaURLKeysVar     = "MEDIA_URL_KEYS"
+	mediaURLVar         = "MEDIA_URL"
+	mediaURLTTLVar      = "MEDIA_URL_TTL"
+	mediaURLBindVar     = "MEDIA_URL_BIND_USER"
 )
 
+// defaultMediaURLTTL is the default time signed media URLs are valid for.
+const defaultMediaURLTTL = time.Hour
+
 func main() {
 	ctn := buildServices()
 
 	userHandler := ctn.GetService("UserHandler").(*handler.UserHandler)
 	postHandler := ctn.GetService("PostHandler").(*handler.PostHandler)
 	authHandler := ctn.GetService("AuthHandler").(*handler.AuthHandler)
+	notificationHandler := ctn.GetService("NotificationHandler").(*handler.NotificationHandler)
 	authMiddleware := ctn.GetService("AuthMiddleware").(*middleware.Authentication)
 
 	app := core.NewApp()
@@ -44,20 +59,52 @@ func main() {
 	// User endpoints
 	app.PostFunc("/users/follow/{userReferenceID}", userHandler.Follow)
 	app.PostFunc("/users/unfollow/{userReferenceID}", userHandler.Unfollow)
+	app.PostFunc("/users/block/{userReferenceID}", userHandler.Block)
+	app.PostFunc("/users/unblock/{userReferenceID}", userHandler.Unblock)
+	app.PostFunc("/users/mute/{userReferenceID}", userHandler.Mute)
+	app.PostFunc("/users/unmute/{userReferenceID}", userHandler.Unmute)
+	app.GetFunc("/me/blocks", userHandler.GetBlockedUsers)
+	app.GetFunc("/me/follow-requests", userHandler.GetFollowRequests)
+	app.PostFunc("/me/follow-requests/{userReferenceID}/approve", userHandler.ApproveFollowRequest)
+	app.PostFunc("/me/follow-requests/{userReferenceID}/reject", userHandler.RejectFollowRequest)
+	app.PutFunc("/me", userHandler.UpdateProfile)
+	app.PostFunc("/me/password", userHandler.ChangePassword)
+	app.PostFunc("/me/2fa/enrol", userHandler.EnrolTwoFactor)
+	app.PostFunc("/me/2fa/confirm", userHandler.ConfirmTwoFactor)
+	app.PostFunc("/me/2fa/disable", userHandler.DisableTwoFactor)
+	app.GetFunc("/me/export", userHandler.Export)
+	app.PostFunc("/me/delete", userHandler.RequestDeletion)
+	app.PostFunc("/me/delete/cancel", userHandler.CancelDeletion)
+	app.GetFunc("/me/delete", userHandler.GetDeletion)
 
 	// Post endpoints
 	app.PostFunc("/posts/like/{id}", postHandler.Like)
 	app.PostFunc("/posts/unlike/{id}", postHandler.Unlike)
 	app.PostFunc("/posts", postHandler.Create)
 	app.GetFunc("/posts/{id}", postHandler.GetPost)
+	app.PostFunc("/posts/{id}/comments", postHandler.CreateComment)
+	app.GetFunc("/posts/{id}/comments", postHandler.GetComments)
+	app.DeleteFunc("/posts/{id}/comments/{commentId}", postHandler.DeleteComment)
+
+	// Notification endpoints
+	app.GetFunc("/notifications", notificationHandler.List)
+	app.GetFunc("/notifications/unread", notificationHandler.GetUnreadCount)
+	app.PostFunc("/notifications/read", notificationHandler.MarkRead)
 
 	// Auth endpoints
 	app.PostFunc("/auth/register", authHandler.Register)
 	app.PostFunc("/auth/token", authHandler.Token)
+	app.PostFunc("/auth/token/2fa", authHandler.SecondFactor)
+	app.PostFunc("/auth/refresh", authHandler.Refresh)
+	app.PostFunc("/auth/logout", authHandler.Logout)
+	app.PostFunc("/auth/password/reset", authHandler.RequestPasswordReset)
+	app.PostFunc("/auth/password/reset/confirm", authHandler.ResetPassword)
 
 	// Frontend endpoints
 	app.GetFunc("/feed", postHandler.GetFeed)
 	app.GetFunc("/profile/{username}", userHandler.GetProfile)
+	app.GetFunc("/profile/{username}/followers", userHandler.GetFollowers)
+	app.GetFunc("/profile/{username}/following", userHandler.GetFollowing)
 	app.GetFunc("/me", userHandler.GetInfo)
 
 	go app.Serve()
@@ -96,50 +143,108 @@ func buildServices() *core.Container {
 		return client
 	})
 
+	ctn.AddService("NotificationClient", func(ctn *core.Container) interface{} {
+		url := os.Getenv(notificationsAPIVar)
+		client := notifications.New(url)
+		return client
+	})
+
+	ctn.AddSingleton("KeyCache", func(ctn *core.Container) interface{} {
+		url := util.ReadEnv(jwksURLVar, os.Getenv(authAPIVar)+jwks.Path)
+		log.Printf("Using key set from %s\n", url)
+		return jwks.NewCache(url, jwks.DefaultTTL, jwks.DefaultRefreshInterval)
+	})
+
 	ctn.AddService("AuthMiddleware", func(ctn *core.Container) interface{} {
-		var alg gojwt.Algorithm
-		var err error
-
-		if path, ok := os.LookupEnv(tokenPublicKeyVar); ok {
-			log.Printf("Using token public key file (%s)\n", path)
-			alg, err = rsa.NewFromFile(path, crypto.SHA256)
-		} else {
-			data, ok := os.LookupEnv(tokenPublicKeyDataVar)
-			if !ok {
-				panic("either a public key file path need to be given, or raw data")
+		keys := ctn.GetService("KeyCache").(*jwks.Cache)
+
+		skew := middleware.DefaultClockSkew
+		if v := os.Getenv(tokenClockSkewVar); v != "" {
+			var err error
+			skew, err = time.ParseDuration(v)
+			if err != nil {
+				panic(fmt.Errorf("invalid %s: %v", tokenClockSkewVar, err))
 			}
+		}
 
-			log.Printf("Using token public key data (length: %d)\n", len(data))
-			alg, err = rsa.New([]byte(data), crypto.SHA256)
+		validator := &middleware.ClaimsValidator{
+			Issuer:    util.ReadEnv(tokenIssuerVar, "open-social-auth"),
+			Audience:  util.ReadEnv(tokenAudienceVar, "open-social"),
+			ClockSkew: skew,
 		}
 
+		h := middleware.NewAuthentication(keys, validator)
+		return h
+	})
+
+	ctn.AddSingleton("MediaURLs", func(ctn *core.Container) interface{} {
+		keys, err := mediaurl.ParseKeys(os.Getenv(mediaURLKeysVar))
 		if err != nil {
-			panic(err)
+			panic(fmt.Errorf("invalid %s: %v", mediaURLKeysVar, err))
 		}
 
-		h := middleware.NewAuthentication(alg)
-		return h
+		signer, err := mediaurl.NewSigner(keys)
+		if err != nil {
+			panic(fmt.Errorf("invalid %s: %v", mediaURLKeysVar, err))
+		}
+
+		ttl := defaultMediaURLTTL
+		if v := os.Getenv(mediaURLTTLVar); v != "" {
+			ttl, err = time.ParseDuration(v)
+			if err != nil || ttl <= 0 {
+				panic(fmt.Errorf("invalid %s: %s", mediaURLTTLVar, v))
+			}
+		}
+
+		baseURL := util.ReadEnv(mediaURLVar, "/media/")
+		bindUser := os.Getenv(mediaURLBindVar) == "true"
+
+		return handler.NewMediaURLs(signer, baseURL, ttl, bindUser)
+	})
+
+	ctn.AddSingleton("TrustedProxies", func(ctn *core.Container) interface{} {
+		var trustedProxies int
+		if v := os.Getenv(trustedProxiesVar); v != "" {
+			var err error
+			trustedProxies, err = strconv.Atoi(v)
+			if err != nil || trustedProxies < 0 {
+				panic(fmt.Errorf("invalid %s: %s", trustedProxiesVar, v))
+			}
+		}
+
+		return trustedProxies
 	})
 
 	ctn.AddService("UserHandler", func(ctn *core.Container) interface{} {
 		client := ctn.GetService("UserClient").(users.Client)
 		authClient := ctn.GetService("AuthClient").(auth.Client)
 		postsClient := ctn.GetService("PostClient").(posts.Client)
-		h := handler.NewUserHandler(client, authClient, postsClient)
+		mediaClient := ctn.GetService("MediaClient").(media.Client)
+		urls := ctn.GetService("MediaURLs").(*handler.MediaURLs)
+		trustedProxies := ctn.GetService("TrustedProxies").(int)
+		h := handler.NewUserHandler(client, authClient, postsClient, mediaClient, urls, trustedProxies)
 		return h
 	})
 
 	ctn.AddService("PostHandler", func(ctn *core.Container) interface{} {
 		client := ctn.GetService("PostClient").(posts.Client)
 		mediaClient := ctn.GetService("MediaClient").(media.Client)
-		h := handler.NewPostHandler(client, mediaClient)
+		urls := ctn.GetService("MediaURLs").(*handler.MediaURLs)
+		h := handler.NewPostHandler(client, mediaClient, urls)
 		return h
 	})
 
 	ctn.AddService("AuthHandler", func(ctn *core.Container) interface{} {
 		usersClient := ctn.GetService("UserClient").(users.Client)
 		authClient := ctn.GetService("AuthClient").(auth.Client)
-		h := handler.NewAuthHandler(usersClient, authClient)
+		trustedProxies := ctn.GetService("TrustedProxies").(int)
+		h := handler.NewAuthHandler(usersClient, authClient, trustedProxies)
+		return h
+	})
+
+	ctn.AddService("NotificationHandler", func(ctn *core.Container) interface{} {
+		client := ctn.GetService("NotificationClient").(notifications.Client)
+		h := handler.NewNotificationHandler(client)
 		return h
 	})
 
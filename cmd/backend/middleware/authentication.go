@@ -1,26 +1,38 @@
 package middleware
 
 import (
-	"context"
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/reecerussell/gojwt"
 
 	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/jwks"
 )
 
-var allowedPaths = []string{"/auth/register", "/auth/token", "/health"}
+var allowedPaths = []string{"/auth/register", "/auth/token", "/auth/token/2fa", "/auth/refresh", "/auth/logout",
+	"/auth/password/reset", "/auth/password/reset/confirm", "/health"}
+
+// KeySource provides the algorithms used to verify access tokens, by key id.
+type KeySource interface {
+	Verifier(kid string) (gojwt.Algorithm, error)
+}
 
 // Authentication is middleware used to authenticate HTTP requests.
 type Authentication struct {
 	core.Handler
-	alg gojwt.Algorithm
+	keys      KeySource
+	validator *ClaimsValidator
 }
 
-// NewAuthentication returns a new instance of Authentication.
-func NewAuthentication(alg gojwt.Algorithm) *Authentication {
-	return &Authentication{alg: alg}
+// NewAuthentication returns a new instance of Authentication, which verifies
+// tokens with keys from the KeySource, and validates their claims with validator.
+func NewAuthentication(keys KeySource, validator *ClaimsValidator) *Authentication {
+	return &Authentication{
+		keys:      keys,
+		validator: validator,
+	}
 }
 
 // Handle returns a new http.Handler, used to authenticate the given handler.
@@ -37,20 +49,43 @@ func (m *Authentication) Handle(h http.Handler) http.Handler {
 			return
 		}
 
+		kid, err := jwks.KeyID(token)
+		if err != nil {
+			m.RespondError(w, err, http.StatusUnauthorized)
+			return
+		}
+
+		alg, err := m.keys.Verifier(kid)
+		if err != nil {
+			m.RespondError(w, err, http.StatusUnauthorized)
+			return
+		}
+
+		err = jwks.VerifySignature(token, alg)
+		if err != nil {
+			m.RespondError(w, err, http.StatusUnauthorized)
+			return
+		}
+
 		jwt, err := gojwt.Token(token)
 		if err != nil {
 			m.RespondError(w, err, http.StatusUnauthorized)
 			return
 		}
 
-		err = jwt.Verify(m.alg)
+		err = m.validator.Validate(jwt.Claims)
 		if err != nil {
 			m.RespondError(w, err, http.StatusUnauthorized)
 			return
 		}
 
-		ctx := contextWithClaims(r.Context(), jwt.Claims)
-		r = r.WithContext(ctx)
+		principal, err := principalFromClaims(jwt.Claims)
+		if err != nil {
+			m.RespondError(w, err, http.StatusUnauthorized)
+			return
+		}
+
+		r = r.WithContext(ContextWithPrincipal(r.Context(), principal))
 
 		h.ServeHTTP(w, r)
 	})
@@ -72,17 +107,9 @@ func getAccessToken(r *http.Request) (string, error) {
 		return "", errors.New("no auth header present")
 	}
 
-	if len(value) < 6 || value[:6] != "Bearer" {
+	if !strings.HasPrefix(value, "Bearer ") {
 		return "", errors.New("invalid auth scheme")
 	}
 
 	return value[7:], nil
 }
-
-func contextWithClaims(ctx context.Context, claims gojwt.Claims) context.Context {
-	for k, v := range claims {
-		ctx = context.WithValue(ctx, core.ContextKey(k), v)
-	}
-
-	return ctx
-}
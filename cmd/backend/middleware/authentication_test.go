@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/reecerussell/gojwt"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/jwks"
+)
+
+type testKeySource struct {
+	keys map[string]gojwt.Algorithm
+}
+
+func (s *testKeySource) Verifier(kid string) (gojwt.Algorithm, error) {
+	alg, ok := s.keys[kid]
+	if !ok {
+		return nil, jwks.ErrKeyNotFound
+	}
+
+	return alg, nil
+}
+
+func signTestToken(t *testing.T, pk *rsa.PrivateKey, kid string, claims gojwt.Claims) string {
+	encode := func(v interface{}) string {
+		data, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	data := encode(&jwks.Header{Type: "JWT", Algorithm: "RS256", KeyID: kid}) + "." + encode(claims)
+	hash := sha256.Sum256([]byte(data))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, pk, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	return data + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestAuthentication(t *testing.T) {
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	alg, err := jwks.NewKey("key", "RS256", &pk.PublicKey).Verifier()
+	assert.NoError(t, err)
+
+	now := time.Now()
+	validator := testValidator()
+	validator.now = func() time.Time { return now }
+
+	m := NewAuthentication(&testKeySource{keys: map[string]gojwt.Algorithm{"key": alg}}, validator)
+
+	var principal *Principal
+	h := m.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, _ = PrincipalFromContext(r.Context())
+	}))
+
+	claims := func(remove string) gojwt.Claims {
+		c := gojwt.Claims{
+			"uid":      "123",
+			"username": "john",
+			"iss":      "open-social-auth",
+			"aud":      "open-social",
+			"iat":      now.Unix(),
+			"exp":      now.Add(time.Minute).Unix(),
+		}
+		delete(c, remove)
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		status int
+	}{
+		{"Valid", "Bearer " + signTestToken(t, pk, "key", claims("")), http.StatusOK},
+		{"NoHeader", "", http.StatusUnauthorized},
+		{"SchemeOnly", "Bearer", http.StatusUnauthorized},
+		{"UnknownKey", "Bearer " + signTestToken(t, pk, "other", claims("")), http.StatusUnauthorized},
+		{"MissingUserID", "Bearer " + signTestToken(t, pk, "key", claims("uid")), http.StatusUnauthorized},
+		{"MissingExpiry", "Bearer " + signTestToken(t, pk, "key", claims("exp")), http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			principal = nil
+
+			req, _ := http.NewRequest(http.MethodGet, "/me", nil)
+			if test.header != "" {
+				req.Header.Set("Authorization", test.header)
+			}
+
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			if test.status == http.StatusOK {
+				assert.Equal(t, &Principal{UserID: "123", Username: "john"}, principal)
+			} else {
+				assert.Nil(t, principal)
+			}
+		})
+	}
+}
+
+func TestPrincipalFromContext_NoPrincipal_ReturnsFalse(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+
+	p, ok := PrincipalFromContext(req.Context())
+	assert.Nil(t, p)
+	assert.False(t, ok)
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"errors"
+	"time"
+
+	"github.com/reecerussell/gojwt"
+)
+
+// Claim validation errors.
+var (
+	ErrTokenExpired     = errors.New("token has expired")
+	ErrTokenNotYetValid = errors.New("token is not yet valid")
+	ErrTokenIssuedLater = errors.New("token was issued in the future")
+	ErrInvalidIssuer    = errors.New("token has an invalid issuer")
+	ErrInvalidAudience  = errors.New("token has an invalid audience")
+	ErrMissingExpiry    = errors.New("token has no expiry")
+	ErrMissingSubject   = errors.New("token has no user id")
+)
+
+// DefaultClockSkew is the default leeway given when validating the time based claims.
+const DefaultClockSkew = 30 * time.Second
+
+// ClaimsValidator validates the registered claims of an access token.
+// Issuer and Audience are only checked when set.
+type ClaimsValidator struct {
+	Issuer    string
+	Audience  string
+	ClockSkew time.Duration
+
+	now func() time.Time
+}
+
+// Validate returns an error if the token is expired, not yet valid, was
+// issued in the future, or wasn't issued by the issuer for the audience.
+// Each time is allowed to be out by up to ClockSkew.
+func (v *ClaimsValidator) Validate(claims gojwt.Claims) error {
+	now := time.Now
+	if v.now != nil {
+		now = v.now
+	}
+	t := now().UTC()
+
+	exp, ok := claims.Expiry()
+	if !ok {
+		return ErrMissingExpiry
+	}
+
+	if !t.Before(exp.Add(v.ClockSkew)) {
+		return ErrTokenExpired
+	}
+
+	if nbf, ok := claims.NotBefore(); ok && t.Add(v.ClockSkew).Before(nbf) {
+		return ErrTokenNotYetValid
+	}
+
+	if iat, ok := claims.IssuedAt(); ok && t.Add(v.ClockSkew).Before(iat) {
+		return ErrTokenIssuedLater
+	}
+
+	if v.Issuer != "" {
+		if iss, _ := claims.String("iss"); iss != v.Issuer {
+			return ErrInvalidIssuer
+		}
+	}
+
+	if v.Audience != "" && !hasAudience(claims["aud"], v.Audience) {
+		return ErrInvalidAudience
+	}
+
+	return nil
+}
+
+// hasAudience determines if the aud claim contains the audience. As per
+// RFC 7519, the claim can either be a single string, or an array of strings.
+func hasAudience(aud interface{}, audience string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == audience
+	case []interface{}:
+		for _, a := range v {
+			if s, ok := a.(string); ok && s == audience {
+				return true
+			}
+		}
+	}
+
+	return false
+}
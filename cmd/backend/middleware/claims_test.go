@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reecerussell/gojwt"
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2021, 3, 6, 12, 0, 0, 0, time.UTC)
+
+func testValidator() *ClaimsValidator {
+	return &ClaimsValidator{
+		Issuer:    "open-social-auth",
+		Audience:  "open-social",
+		ClockSkew: 30 * time.Second,
+		now:       func() time.Time { return testNow },
+	}
+}
+
+func testClaims() gojwt.Claims {
+	return gojwt.Claims{
+		"uid": "123",
+		"iss": "open-social-auth",
+		"aud": "open-social",
+		"iat": float64(testNow.Add(-time.Minute).Unix()),
+		"nbf": float64(testNow.Add(-time.Minute).Unix()),
+		"exp": float64(testNow.Add(time.Minute).Unix()),
+	}
+}
+
+func TestClaimsValidator_Validate(t *testing.T) {
+	ts := func(d time.Duration) float64 {
+		return float64(testNow.Add(d).Unix())
+	}
+
+	tests := []struct {
+		name     string
+		claim    string
+		value    interface{}
+		expected error
+	}{
+		{"Valid", "", nil, nil},
+		{"ExpiredWithinSkew", "exp", ts(-20 * time.Second), nil},
+		{"Expired", "exp", ts(-40 * time.Second), ErrTokenExpired},
+		{"MissingExpiry", "exp", nil, ErrMissingExpiry},
+		{"NotYetValidWithinSkew", "nbf", ts(20 * time.Second), nil},
+		{"NotYetValid", "nbf", ts(40 * time.Second), ErrTokenNotYetValid},
+		{"IssuedLaterWithinSkew", "iat", ts(20 * time.Second), nil},
+		{"IssuedLater", "iat", ts(40 * time.Second), ErrTokenIssuedLater},
+		{"InvalidIssuer", "iss", "someone-else", ErrInvalidIssuer},
+		{"MissingIssuer", "iss", nil, ErrInvalidIssuer},
+		{"InvalidAudience", "aud", "someone-else", ErrInvalidAudience},
+		{"AudienceArray", "aud", []interface{}{"other", "open-social"}, nil},
+		{"AudienceArrayWithoutAudience", "aud", []interface{}{"other"}, ErrInvalidAudience},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claims := testClaims()
+			if test.claim != "" {
+				if test.value == nil {
+					delete(claims, test.claim)
+				} else {
+					claims[test.claim] = test.value
+				}
+			}
+
+			err := testValidator().Validate(claims)
+			assert.Equal(t, test.expected, err)
+		})
+	}
+}
+
+func TestClaimsValidator_NoIssuerOrAudience_SkipsChecks(t *testing.T) {
+	v := &ClaimsValidator{now: func() time.Time { return testNow }}
+
+	claims := testClaims()
+	delete(claims, "iss")
+	delete(claims, "aud")
+
+	assert.NoError(t, v.Validate(claims))
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reecerussell/gojwt"
+)
+
+// ErrUnauthenticated is returned when a request has no authenticated principal.
+var ErrUnauthenticated = errors.New("request is not authenticated")
+
+// principalContextKey is the context key used to store the Principal of a request.
+type principalContextKey struct{}
+
+// Principal is the authenticated user making a request.
+type Principal struct {
+	UserID   string
+	Username string
+}
+
+// principalFromClaims returns the Principal identified by the claims.
+func principalFromClaims(claims gojwt.Claims) (*Principal, error) {
+	uid, ok := claims.String("uid")
+	if !ok || uid == "" {
+		return nil, ErrMissingSubject
+	}
+
+	username, _ := claims.String("username")
+
+	return &Principal{
+		UserID:   uid,
+		Username: username,
+	}, nil
+}
+
+// ContextWithPrincipal returns a copy of ctx, containing the Principal.
+func ContextWithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+// PrincipalFromContext returns the Principal of the request, set by the
+// Authentication middleware. False is returned if there isn't one, such as
+// for requests to allowed paths.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return p, ok && p != nil
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"errors"
+	"io"
+
+	"github.com/reecerussell/open-social/client/media"
+)
+
+var (
+	errInvalidWhence    = errors.New("content: invalid whence")
+	errNegativePosition = errors.New("content: negative position")
+)
+
+// content is an io.ReadSeeker over a media's content, of a known length,
+// used to serve ranges of it. The content is only requested from the media
+// service once read, from the current offset, so conditional requests which
+// aren't served any content don't request it at all.
+type content struct {
+	client      media.Client
+	referenceID string
+	size        string
+	length      int64
+	offset      int64
+	body        io.ReadCloser
+}
+
+func (c *content) Read(p []byte) (int, error) {
+	if c.offset >= c.length {
+		return 0, io.EOF
+	}
+
+	if c.body == nil {
+		_, body, err := c.client.GetContent(c.referenceID, c.size, c.offset)
+		if err != nil {
+			return 0, err
+		}
+
+		c.body = body
+	}
+
+	n, err := c.body.Read(p)
+	c.offset += int64(n)
+
+	return n, err
+}
+
+func (c *content) Seek(offset int64, whence int) (int64, error) {
+	var pos int64
+	switch whence {
+	case io.SeekStart:
+		pos = offset
+	case io.SeekCurrent:
+		pos = c.offset + offset
+	case io.SeekEnd:
+		pos = c.length + offset
+	default:
+		return 0, errInvalidWhence
+	}
+
+	if pos < 0 {
+		return 0, errNegativePosition
+	}
+
+	if pos != c.offset {
+		c.Close()
+	}
+
+	c.offset = pos
+
+	return pos, nil
+}
+
+// Close closes the current request for content, if there is one.
+func (c *content) Close() error {
+	if c.body == nil {
+		return nil
+	}
+
+	err := c.body.Close()
+	c.body = nil
+
+	return err
+}
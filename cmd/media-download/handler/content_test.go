@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	media "github.com/reecerussell/open-social/client/mock/media"
+)
+
+func TestContent_Seek_ReopensAtOffset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := media.NewMockClient(ctrl)
+	gomock.InOrder(
+		mockClient.EXPECT().GetContent("2389", "full", int64(0)).
+			Return("text/plain", ioutil.NopCloser(strings.NewReader("Hello World")), nil),
+		mockClient.EXPECT().GetContent("2389", "full", int64(6)).
+			Return("text/plain", ioutil.NopCloser(strings.NewReader("World")), nil),
+	)
+
+	c := &content{client: mockClient, referenceID: "2389", size: "full", length: 11}
+	defer c.Close()
+
+	buf := make([]byte, 5)
+	_, err := io.ReadFull(c, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", string(buf))
+
+	pos, err := c.Seek(-5, io.SeekEnd)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), pos)
+
+	data, err := ioutil.ReadAll(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "World", string(data))
+}
+
+func TestContent_Seek_GivenInvalidPosition_ReturnsError(t *testing.T) {
+	c := &content{length: 11}
+
+	_, err := c.Seek(-1, io.SeekStart)
+	assert.Equal(t, errNegativePosition, err)
+
+	_, err = c.Seek(0, 3)
+	assert.Equal(t, errInvalidWhence, err)
+}
@@ -1,25 +1,43 @@
 package handler
 
 import (
+	"io"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/client"
 	"github.com/reecerussell/open-social/client/media"
+	"github.com/reecerussell/open-social/mediaurl"
 )
 
-// DownloadHandler is a http.Handler used to download media.
+// cacheControl is sent with all media, as content is never changed once
+// uploaded; new content is always given a new reference id.
+const cacheControl = "private, max-age=31536000, immutable"
+
+// DownloadHandler is a http.Handler used to download media. A size of
+// media, such as a thumbnail, can be requested with the size query parameter.
+//
+// Media is only served from URLs signed by the backend, which haven't expired.
+// URLs bound to a user are only served to the user identified by mediaurl.UserCookie.
+//
+// Media is served with an ETag and Last-Modified header, supporting conditional
+// and range requests. Media uploaded before its size and hash were recorded
+// is always served whole, and can only be validated by its modified time.
 type DownloadHandler struct {
 	core.Handler
 	client media.Client
+	signer *mediaurl.Signer
 }
 
-// NewDownloadHandler returns a new instance of DownloadHandler.
-func NewDownloadHandler(client media.Client) *DownloadHandler {
+// NewDownloadHandler returns a new instance of DownloadHandler, which verifies URLs with signer.
+func NewDownloadHandler(client media.Client, signer *mediaurl.Signer) *DownloadHandler {
 	return &DownloadHandler{
 		client: client,
+		signer: signer,
 	}
 }
 
@@ -27,14 +45,99 @@ func (h *DownloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	referenceID := params["referenceID"]
 
-	contentType, content, err := h.client.GetContent(referenceID)
+	query := r.URL.Query()
+	err := h.signer.Verify(referenceID, query, h.userID(r))
+	if err != nil {
+		h.RespondError(w, err, http.StatusForbidden)
+		return
+	}
+
+	size := query.Get("size")
+
+	info, err := h.client.GetInfo(referenceID, size)
+	if err != nil {
+		h.handleError(w, referenceID, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", info.ContentType)
+	w.Header().Set("Cache-Control", cacheControl)
+
+	if info.Size == 0 {
+		h.serveLegacy(w, r, referenceID, size, info)
+		return
+	}
+
+	w.Header().Set("ETag", "\""+info.ETag+"\"")
+
+	c := &content{
+		client:      h.client,
+		referenceID: referenceID,
+		size:        size,
+		length:      info.Size,
+	}
+	defer c.Close()
+
+	http.ServeContent(w, r, "", info.LastModified, c)
+}
+
+// userID returns the id of the user identified by the mediaurl.UserCookie,
+// or an empty string if there isn't a valid one.
+func (h *DownloadHandler) userID(r *http.Request) string {
+	cookie, err := r.Cookie(mediaurl.UserCookie)
 	if err != nil {
-		log.Printf("ERROR: %v\n", err)
-		w.WriteHeader(http.StatusNotFound)
+		return ""
+	}
+
+	userID, err := h.signer.VerifyUser(cookie.Value)
+	if err != nil {
+		return ""
+	}
+
+	return userID
+}
+
+// serveLegacy serves the whole of a media's content, which has no
+// known size or hash, unless it hasn't been modified.
+func (h *DownloadHandler) serveLegacy(w http.ResponseWriter, r *http.Request, referenceID, size string, info *media.Info) {
+	if !info.LastModified.IsZero() {
+		since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+		if err == nil && !info.LastModified.Truncate(time.Second).After(since) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	_, body, err := h.client.GetContent(referenceID, size, 0)
+	if err != nil {
+		h.handleError(w, referenceID, err)
 		return
 	}
+	defer body.Close()
+
+	if !info.LastModified.IsZero() {
+		w.Header().Set("Last-Modified", info.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	_, err = io.Copy(w, body)
+	if err != nil {
+		log.Printf("ERROR: failed to stream '%s': %v\n", referenceID, err)
+	}
+}
+
+// handleError writes the status of a failed request to the media service. Media
+// which doesn't exist is not found, any other client error is a fault of this
+// service, otherwise the media service has failed, or couldn't be reached.
+func (h *DownloadHandler) handleError(w http.ResponseWriter, referenceID string, err error) {
+	log.Printf("ERROR: failed to get '%s': %v\n", referenceID, err)
+
+	status := http.StatusBadGateway
+	if e, ok := err.(*client.Error); ok && e.StatusCode < 500 {
+		status = http.StatusInternalServerError
+		if e.StatusCode == http.StatusNotFound {
+			status = http.StatusNotFound
+		}
+	}
 
-	w.Header().Set("Content-Type", contentType)
-	w.Header().Set("Cache-Control", "private, max-age=3600")
-	w.Write(content)
+	w.WriteHeader(status)
 }
@@ -1,69 +1,332 @@
 package handler
 
 import (
-	"encoding/base64"
 	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/reecerussell/open-social/client"
+	mediaClient "github.com/reecerussell/open-social/client/media"
 	media "github.com/reecerussell/open-social/client/mock/media"
+	"github.com/reecerussell/open-social/mediaurl"
 )
 
-func TestDownloadHandler(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+const (
+	testReferenceID = "23984yks"
+	testContentType = "text/plain"
+	testContent     = "Hello World"
+	testETag        = "a591a6d40bf420404a011733cfb7b190d62c65bf0bcda32b57b277d9ad9f146e"
+)
 
-	const testReferenceID = "23984yks"
-	const testContentType = "text/plain"
-	const testContent = "SGVsbG8gV29ybGQ="
+var testLastModified = time.Date(2020, 11, 2, 15, 4, 5, 0, time.UTC)
 
-	mockClient := media.NewMockClient(ctrl)
-	mockClient.EXPECT().GetContent(testReferenceID).
-		DoAndReturn(func(key string) (string, []byte, error) {
-			bytes, _ := base64.StdEncoding.DecodeString(testContent)
-			return testContentType, bytes, nil
-		})
+var testSigner = newTestSigner()
+
+func newTestSigner() *mediaurl.Signer {
+	signer, err := mediaurl.NewSigner([]*mediaurl.Key{{ID: "1", Secret: []byte("secret")}})
+	if err != nil {
+		panic(err)
+	}
+
+	return signer
+}
+
+// signedURL returns the path of a URL for the media, signed for the given user.
+func signedURL(referenceID, userID string) string {
+	query := testSigner.Sign(referenceID, userID, time.Now().Add(time.Hour))
+	return "/" + referenceID + "?" + query.Encode()
+}
+
+func newTestInfo() *mediaClient.Info {
+	return &mediaClient.Info{
+		ContentType:  testContentType,
+		Size:         int64(len(testContent)),
+		ETag:         testETag,
+		LastModified: testLastModified,
+	}
+}
 
-	handler := NewDownloadHandler(mockClient)
+func serveTestRequest(h http.Handler, req *http.Request) *httptest.ResponseRecorder {
 	router := mux.NewRouter()
-	router.Handle("/{referenceID}", handler)
+	router.Handle("/{referenceID}", h)
 
 	rr := httptest.NewRecorder()
-	req, _ := http.NewRequest(http.MethodGet, "/"+testReferenceID, nil)
 	router.ServeHTTP(rr, req)
 
-	data := make([]byte, rr.Body.Len())
-	rr.Body.Read(data)
+	return rr
+}
+
+func TestDownloadHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := media.NewMockClient(ctrl)
+	mockClient.EXPECT().GetInfo(testReferenceID, "").Return(newTestInfo(), nil)
+	mockClient.EXPECT().GetContent(testReferenceID, "", int64(0)).
+		Return(testContentType, ioutil.NopCloser(strings.NewReader(testContent)), nil)
+
+	req, _ := http.NewRequest(http.MethodGet, signedURL(testReferenceID, ""), nil)
+	rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
 
-	expData, _ := base64.StdEncoding.DecodeString(testContent)
-	assert.Equal(t, expData, data)
+	assert.Equal(t, testContent, rr.Body.String())
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.Equal(t, testContentType, rr.Header().Get("Content-Type"))
-	assert.Equal(t, "private, max-age=3600", rr.Header().Get("Cache-Control"))
+	assert.Equal(t, "11", rr.Header().Get("Content-Length"))
+	assert.Equal(t, "\""+testETag+"\"", rr.Header().Get("ETag"))
+	assert.Equal(t, "Mon, 02 Nov 2020 15:04:05 GMT", rr.Header().Get("Last-Modified"))
+	assert.Equal(t, "private, max-age=31536000, immutable", rr.Header().Get("Cache-Control"))
 }
 
-func TestDownloadHandler_FailedGetContent_ReturnsNotFound(t *testing.T) {
+func TestDownloadHandler_GivenSize_RequestsSize(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	const testReferenceID = "23984yks"
-	const testErrorMessage = "an error occured"
+	mockClient := media.NewMockClient(ctrl)
+	mockClient.EXPECT().GetInfo(testReferenceID, "thumbnail").Return(newTestInfo(), nil)
+	mockClient.EXPECT().GetContent(testReferenceID, "thumbnail", int64(0)).
+		Return(testContentType, ioutil.NopCloser(strings.NewReader(testContent)), nil)
+
+	req, _ := http.NewRequest(http.MethodGet, signedURL(testReferenceID, "")+"&size=thumbnail", nil)
+	rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
+
+	assert.Equal(t, testContent, rr.Body.String())
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestDownloadHandler_GivenMatchingETag_ReturnsNotModified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
 	mockClient := media.NewMockClient(ctrl)
-	mockClient.EXPECT().GetContent(testReferenceID).Return("", nil, errors.New(testErrorMessage))
+	mockClient.EXPECT().GetInfo(testReferenceID, "").Return(newTestInfo(), nil)
 
-	handler := NewDownloadHandler(mockClient)
-	router := mux.NewRouter()
-	router.Handle("/{referenceID}", handler)
+	req, _ := http.NewRequest(http.MethodGet, signedURL(testReferenceID, ""), nil)
+	req.Header.Set("If-None-Match", "\""+testETag+"\"")
+	rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
 
-	rr := httptest.NewRecorder()
-	req, _ := http.NewRequest(http.MethodGet, "/"+testReferenceID, nil)
-	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+	assert.Empty(t, rr.Body.String())
+	assert.Equal(t, "\""+testETag+"\"", rr.Header().Get("ETag"))
+}
+
+func TestDownloadHandler_GivenDifferentETag_ReturnsContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := media.NewMockClient(ctrl)
+	mockClient.EXPECT().GetInfo(testReferenceID, "").Return(newTestInfo(), nil)
+	mockClient.EXPECT().GetContent(testReferenceID, "", int64(0)).
+		Return(testContentType, ioutil.NopCloser(strings.NewReader(testContent)), nil)
+
+	req, _ := http.NewRequest(http.MethodGet, signedURL(testReferenceID, ""), nil)
+	req.Header.Set("If-None-Match", "\"other\"")
+	rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, testContent, rr.Body.String())
+}
+
+func TestDownloadHandler_GivenIfModifiedSince_ReturnsNotModified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := media.NewMockClient(ctrl)
+	mockClient.EXPECT().GetInfo(testReferenceID, "").Return(newTestInfo(), nil)
+
+	req, _ := http.NewRequest(http.MethodGet, signedURL(testReferenceID, ""), nil)
+	req.Header.Set("If-Modified-Since", testLastModified.Format(http.TimeFormat))
+	rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
+
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+	assert.Empty(t, rr.Body.String())
+}
+
+func TestDownloadHandler_GivenRange_ReturnsPartialContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := media.NewMockClient(ctrl)
+	mockClient.EXPECT().GetInfo(testReferenceID, "").Return(newTestInfo(), nil)
+	mockClient.EXPECT().GetContent(testReferenceID, "", int64(6)).
+		Return(testContentType, ioutil.NopCloser(strings.NewReader(testContent[6:])), nil)
+
+	req, _ := http.NewRequest(http.MethodGet, signedURL(testReferenceID, ""), nil)
+	req.Header.Set("Range", "bytes=6-")
+	rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
+
+	assert.Equal(t, http.StatusPartialContent, rr.Code)
+	assert.Equal(t, "World", rr.Body.String())
+	assert.Equal(t, "bytes 6-10/11", rr.Header().Get("Content-Range"))
+}
+
+func TestDownloadHandler_GivenUnsatisfiableRange_ReturnsRangeNotSatisfiable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := media.NewMockClient(ctrl)
+	mockClient.EXPECT().GetInfo(testReferenceID, "").Return(newTestInfo(), nil)
+
+	req, _ := http.NewRequest(http.MethodGet, signedURL(testReferenceID, ""), nil)
+	req.Header.Set("Range", "bytes=20-")
+	rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
+
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, rr.Code)
+	assert.Equal(t, "bytes */11", rr.Header().Get("Content-Range"))
+}
+
+func TestDownloadHandler_WhereMediaHasNoSize_ReturnsWholeContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	info := &mediaClient.Info{ContentType: testContentType, LastModified: testLastModified}
+
+	mockClient := media.NewMockClient(ctrl)
+	mockClient.EXPECT().GetInfo(testReferenceID, "").Return(info, nil)
+	mockClient.EXPECT().GetContent(testReferenceID, "", int64(0)).
+		Return(testContentType, ioutil.NopCloser(strings.NewReader(testContent)), nil)
+
+	req, _ := http.NewRequest(http.MethodGet, signedURL(testReferenceID, ""), nil)
+	req.Header.Set("Range", "bytes=6-")
+	rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, testContent, rr.Body.String())
+	assert.Empty(t, rr.Header().Get("ETag"))
+	assert.Equal(t, "Mon, 02 Nov 2020 15:04:05 GMT", rr.Header().Get("Last-Modified"))
+}
+
+func TestDownloadHandler_WhereMediaHasNoSize_GivenIfModifiedSince_ReturnsNotModified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	info := &mediaClient.Info{ContentType: testContentType, LastModified: testLastModified}
+
+	mockClient := media.NewMockClient(ctrl)
+	mockClient.EXPECT().GetInfo(testReferenceID, "").Return(info, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, signedURL(testReferenceID, ""), nil)
+	req.Header.Set("If-Modified-Since", testLastModified.Add(time.Hour).Format(http.TimeFormat))
+	rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
+
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+}
+
+func TestDownloadHandler_FailedGetInfo_ReturnsStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"NotFound", client.NewError(http.StatusNotFound, "media not found"), http.StatusNotFound},
+		{"BadRequest", client.NewError(http.StatusBadRequest, "bad request"), http.StatusInternalServerError},
+		{"ServerError", client.NewError(http.StatusInternalServerError, "an error occured"), http.StatusBadGateway},
+		{"Unreachable", errors.New("connection refused"), http.StatusBadGateway},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := media.NewMockClient(ctrl)
+			mockClient.EXPECT().GetInfo(testReferenceID, "").Return(nil, test.err)
+
+			req, _ := http.NewRequest(http.MethodGet, signedURL(testReferenceID, ""), nil)
+			rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
+
+			assert.Equal(t, test.status, rr.Code)
+		})
+	}
+}
+
+func TestDownloadHandler_WhereMediaHasNoSize_FailedGetContent_ReturnsStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	info := &mediaClient.Info{ContentType: testContentType}
+
+	mockClient := media.NewMockClient(ctrl)
+	mockClient.EXPECT().GetInfo(testReferenceID, "").Return(info, nil)
+	mockClient.EXPECT().GetContent(testReferenceID, "", int64(0)).
+		Return("", nil, client.NewError(http.StatusNotFound, "media not found"))
+
+	req, _ := http.NewRequest(http.MethodGet, signedURL(testReferenceID, ""), nil)
+	rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
 
 	assert.Equal(t, http.StatusNotFound, rr.Code)
 }
+
+func TestDownloadHandler_GivenInvalidSignature_ReturnsForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expired := testSigner.Sign(testReferenceID, "", time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name string
+		url  string
+		err  error
+	}{
+		{"Unsigned", "/" + testReferenceID, mediaurl.ErrMissingSignature},
+		{"OtherMedia", "/other?" + testSigner.Sign(testReferenceID, "", time.Now().Add(time.Hour)).Encode(), mediaurl.ErrInvalidSignature},
+		{"Expired", "/" + testReferenceID + "?" + expired.Encode(), mediaurl.ErrExpired},
+		{"BoundToUser", signedURL(testReferenceID, "user"), mediaurl.ErrUserMismatch},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := media.NewMockClient(ctrl)
+
+			req, _ := http.NewRequest(http.MethodGet, test.url, nil)
+			rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
+
+			exp := fmt.Sprintf("{\"message\":\"%s\"}\n", test.err)
+			assert.Equal(t, exp, rr.Body.String())
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+		})
+	}
+}
+
+func TestDownloadHandler_GivenURLBoundToUser_ServesUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := media.NewMockClient(ctrl)
+	mockClient.EXPECT().GetInfo(testReferenceID, "").Return(newTestInfo(), nil)
+	mockClient.EXPECT().GetContent(testReferenceID, "", int64(0)).
+		Return(testContentType, ioutil.NopCloser(strings.NewReader(testContent)), nil)
+
+	req, _ := http.NewRequest(http.MethodGet, signedURL(testReferenceID, "user"), nil)
+	req.AddCookie(&http.Cookie{
+		Name:  mediaurl.UserCookie,
+		Value: testSigner.SignUser("user", time.Now().Add(time.Hour)),
+	})
+	rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, testContent, rr.Body.String())
+}
+
+func TestDownloadHandler_GivenURLBoundToUser_WhereCookieIsForOtherUser_ReturnsForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := media.NewMockClient(ctrl)
+
+	for _, value := range []string{testSigner.SignUser("other", time.Now().Add(time.Hour)), "uid=user"} {
+		req, _ := http.NewRequest(http.MethodGet, signedURL(testReferenceID, "user"), nil)
+		req.AddCookie(&http.Cookie{Name: mediaurl.UserCookie, Value: value})
+		rr := serveTestRequest(NewDownloadHandler(mockClient, testSigner), req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -8,10 +9,12 @@ import (
 	core "github.com/reecerussell/open-social"
 	"github.com/reecerussell/open-social/client/media"
 	"github.com/reecerussell/open-social/cmd/media-download/handler"
+	"github.com/reecerussell/open-social/mediaurl"
 )
 
 const (
-	mediaAPIVar = "MEDIA_API_URL"
+	mediaAPIVar     = "MEDIA_API_URL"
+	mediaURLKeysVar = "MEDIA_URL_KEYS"
 )
 
 func main() {
@@ -43,9 +46,24 @@ func buildServices() *core.Container {
 		return client
 	})
 
+	ctn.AddSingleton("MediaURLSigner", func(ctn *core.Container) interface{} {
+		keys, err := mediaurl.ParseKeys(os.Getenv(mediaURLKeysVar))
+		if err != nil {
+			panic(fmt.Errorf("invalid %s: %v", mediaURLKeysVar, err))
+		}
+
+		signer, err := mediaurl.NewSigner(keys)
+		if err != nil {
+			panic(fmt.Errorf("invalid %s: %v", mediaURLKeysVar, err))
+		}
+
+		return signer
+	})
+
 	ctn.AddService("DownloadHandler", func(ctn *core.Container) interface{} {
 		client := ctn.GetService("MediaClient").(media.Client)
-		h := handler.NewDownloadHandler(client)
+		signer := ctn.GetService("MediaURLSigner").(*mediaurl.Signer)
+		h := handler.NewDownloadHandler(client, signer)
 		return h
 	})
 
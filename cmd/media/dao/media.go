@@ -1,8 +1,21 @@
 package dao
 
+import "time"
+
 // Media is a data access object for the Media domain.
 type Media struct {
 	ID          int
 	ReferenceID string
 	ContentType string
+	Uploaded    time.Time
+	Renditions  []*Rendition
+}
+
+// Rendition is a data access object for a size of a Media's content.
+type Rendition struct {
+	Name   string
+	Width  int
+	Height int
+	Size   int64
+	ETag   string
 }
@@ -2,28 +2,30 @@ package handler
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/json"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"io"
 	"log"
 	"net/http"
 
 	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/media/imaging"
 	"github.com/reecerussell/open-social/cmd/media/model"
 	"github.com/reecerussell/open-social/cmd/media/repository"
 	"github.com/reecerussell/open-social/media"
 )
 
-// CreateMediaHandler is a http.Handler used to create a new Media record.
+// CreateMediaHandler is a http.Handler used to create a new Media record. The
+// request's body is an image, and its Content-Type header is the content type of
+// the media. The original image isn't kept; instead, each configured rendition of
+// it is encoded, without metadata, and streamed to storage under its own key,
+// before the media is stored.
 type CreateMediaHandler struct {
 	core.Handler
 	repo     repository.MediaRepository
 	uploader media.Service
-}
-
-// CreateMediaRequest is the body of the request.
-type CreateMediaRequest struct {
-	ContentType string `json:"contentType"`
-	Content     string `json:"content"`
+	images   *imaging.Processor
 }
 
 // CreateMediaResponse is the body of the response.
@@ -33,41 +35,56 @@ type CreateMediaResponse struct {
 }
 
 // NewCreateMediaHandler returns a new instance of CreateMediaHandler.
-func NewCreateMediaHandler(repo repository.MediaRepository, uploader media.Service) *CreateMediaHandler {
+func NewCreateMediaHandler(repo repository.MediaRepository, uploader media.Service, images *imaging.Processor) *CreateMediaHandler {
 	return &CreateMediaHandler{
 		repo:     repo,
 		uploader: uploader,
+		images:   images,
 	}
 }
 
 func (h *CreateMediaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	var data CreateMediaRequest
-	_ = json.NewDecoder(r.Body).Decode(&data)
 	defer r.Body.Close()
 
-	media, err := model.NewMedia(data.ContentType)
+	media, err := model.NewMedia(r.Header.Get("Content-Type"))
 	if err != nil {
 		h.RespondError(w, err, http.StatusBadRequest)
 		return
 	}
 
+	img, err := h.images.Decode(r.Body, media.ContentType())
+	if err != nil {
+		status := http.StatusBadRequest
+		if err == imaging.ErrImageTooLarge {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	b := img.Bounds()
+	for _, rc := range h.images.Renditions() {
+		width, height := imaging.Fit(b.Dx(), b.Dy(), rc.MaxSize)
+		media.AddRendition(rc.Name, width, height)
+	}
+
+	// The content is uploaded first, so the size and hash of
+	// each rendition can be stored along with the media.
 	ctx := r.Context()
-	save, err := h.repo.Create(ctx, media)
+	err = h.upload(ctx, media, img)
 	if err != nil {
 		h.RespondError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	err = h.upload(ctx, media.ReferenceID(), data.Content)
+	err = h.repo.Create(ctx, media)
 	if err != nil {
-		save(false)
+		h.deleteContent(ctx, media.ContentKeys())
 		h.RespondError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	// Commit media
-	save(true)
-
 	resp := CreateMediaResponse{
 		ID:          media.ID(),
 		ReferenceID: media.ReferenceID(),
@@ -76,18 +93,54 @@ func (h *CreateMediaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.Respond(w, resp)
 }
 
-func (h *CreateMediaHandler) upload(ctx context.Context, key, content string) error {
-	bytes, err := base64.StdEncoding.DecodeString(content)
-	if err != nil {
-		log.Printf("ERROR: failed to decode media base64 content: %v\n", err)
-		return err
+// upload encodes and uploads each of the media's renditions. If one fails,
+// those already uploaded are deleted.
+func (h *CreateMediaHandler) upload(ctx context.Context, m *model.Media, img *image.RGBA) error {
+	keys := m.ContentKeys()
+
+	for i, r := range m.Renditions() {
+		err := h.uploadRendition(ctx, keys[i], img, r, m.ContentType())
+		if err != nil {
+			log.Printf("ERROR: failed to upload the %s rendition: %v\n", r.Name(), err)
+			h.deleteContent(ctx, keys[:i])
+
+			return err
+		}
 	}
 
-	err = h.uploader.Upload(ctx, key, bytes)
+	return nil
+}
+
+// uploadRendition streams the rendition to storage as it's encoded,
+// then records the size and hash of its content.
+func (h *CreateMediaHandler) uploadRendition(ctx context.Context, key string, img *image.RGBA, r *model.Rendition, contentType string) error {
+	hash := sha256.New()
+	cw := &countingWriter{w: hash}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(h.images.Encode(io.MultiWriter(cw, pw), img, r.Width(), r.Height(), contentType))
+	}()
+
+	err := h.uploader.Upload(ctx, key, pr)
+
+	// Stops the encoder, if the upload ended before reading all of it.
+	pr.Close()
+
 	if err != nil {
-		log.Printf("ERROR: failed to upload: %v\n", err)
 		return err
 	}
 
+	r.SetContent(cw.n, hex.EncodeToString(hash.Sum(nil)))
+
 	return nil
 }
+
+// deleteContent deletes the content at each of the keys, as well as it can.
+func (h *CreateMediaHandler) deleteContent(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := h.uploader.Delete(ctx, key); err != nil {
+			log.Printf("WARN: failed to delete '%s': %v\n", key, err)
+		}
+	}
+}
@@ -1,9 +1,16 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -12,43 +19,93 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/reecerussell/open-social/cmd/media/imaging"
 	"github.com/reecerussell/open-social/cmd/media/mock/repository"
 	"github.com/reecerussell/open-social/cmd/media/model"
 	"github.com/reecerussell/open-social/mock/media"
 )
 
+func newTestProcessor(t *testing.T) *imaging.Processor {
+	p, err := imaging.NewProcessor(&imaging.Config{
+		MaxPixels: 10000,
+		Quality:   90,
+		Renditions: []*imaging.RenditionConfig{
+			{Name: "thumbnail", MaxSize: 10},
+			{Name: "full", MaxSize: 50},
+		},
+		DefaultRendition: "full",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+// newTestPNG returns a PNG image of the given size.
+func newTestPNG(width, height int) *bytes.Buffer {
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+
+	return &buf
+}
+
 func TestCreateMediaHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	const testID = 123
-	const testReferenceID = "247"
 
+	uploaded := make(map[string][]byte)
+	mockUploader := media.NewMockService(ctrl)
+	mockUploader.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(ctx context.Context, key string, r io.Reader) error {
+			uploaded[key], _ = ioutil.ReadAll(r)
+			return nil
+		})
+
+	var referenceID string
 	mockRepo := repository.NewMockMediaRepository(ctrl)
 	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(ctx context.Context, m *model.Media) (func(bool), error) {
-			m.SetID(testID)
-			m.SetReferenceID(testReferenceID)
+		DoAndReturn(func(ctx context.Context, m *model.Media) error {
+			referenceID = m.ReferenceID()
+			renditions := m.Renditions()
+			assert.Len(t, renditions, 2)
+
+			for i, exp := range []struct {
+				name          string
+				width, height int
+			}{{"thumbnail", 10, 5}, {"full", 40, 20}} {
+				r := renditions[i]
+				content := uploaded[referenceID+"/"+exp.name]
+				hash := sha256.Sum256(content)
+
+				cnf, err := png.DecodeConfig(bytes.NewReader(content))
+				assert.NoError(t, err)
+				assert.Equal(t, exp.width, cnf.Width)
+				assert.Equal(t, exp.height, cnf.Height)
+
+				assert.Equal(t, exp.name, r.Name())
+				assert.Equal(t, exp.width, r.Width())
+				assert.Equal(t, exp.height, r.Height())
+				assert.Equal(t, int64(len(content)), r.Size())
+				assert.Equal(t, hex.EncodeToString(hash[:]), r.ETag())
+			}
 
-			return func(ok bool) {}, nil
+			m.SetID(testID)
+			return nil
 		})
 
-	mockUploader := media.NewMockService(ctrl)
-	mockUploader.EXPECT().Upload(gomock.Any(), testReferenceID, gomock.Any()).Return(nil)
-
 	rr := httptest.NewRecorder()
 
-	body := `{"contentType":"image/jpeg"}`
-	req, _ := http.NewRequest(http.MethodGet, "/", strings.NewReader(body))
+	req, _ := http.NewRequest(http.MethodPost, "/", newTestPNG(40, 20))
+	req.Header.Set("Content-Type", "image/png")
 
-	handler := NewCreateMediaHandler(mockRepo, mockUploader)
+	handler := NewCreateMediaHandler(mockRepo, mockUploader, newTestProcessor(t))
 	handler.ServeHTTP(rr, req)
 
-	data := make([]byte, rr.Body.Len())
-	rr.Body.Read(data)
-
-	exp := fmt.Sprintf("{\"id\":%d,\"referenceId\":\"%s\"}\n", testID, testReferenceID)
-	assert.Equal(t, exp, string(data))
+	exp := fmt.Sprintf("{\"id\":%d,\"referenceId\":\"%s\"}\n", testID, referenceID)
+	assert.Equal(t, exp, rr.Body.String())
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
 }
@@ -61,10 +118,10 @@ func TestCreateMediaHandler_GivenInvalidData_ReturnsBadRequest(t *testing.T) {
 
 	rr := httptest.NewRecorder()
 
-	body := `` // invalid body
-	req, _ := http.NewRequest(http.MethodGet, "/", strings.NewReader(body))
+	// no Content-Type header
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader("Hello World"))
 
-	handler := NewCreateMediaHandler(mockRepo, nil)
+	handler := NewCreateMediaHandler(mockRepo, nil, newTestProcessor(t))
 	handler.ServeHTTP(rr, req)
 
 	data := make([]byte, rr.Body.Len())
@@ -76,62 +133,64 @@ func TestCreateMediaHandler_GivenInvalidData_ReturnsBadRequest(t *testing.T) {
 	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
 }
 
-func TestCreateMediaHandler_CreateReturnsError_ReturnsInternalServerError(t *testing.T) {
+func TestCreateMediaHandler_GivenInvalidImage_ReturnsBadRequest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	const testErrorMessage = "an error occured"
-
 	mockRepo := repository.NewMockMediaRepository(ctrl)
-	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
-		Return(func(ok bool) {}, errors.New(testErrorMessage))
 
 	rr := httptest.NewRecorder()
 
-	body := `{"contentType":"image/jpeg"}`
-	req, _ := http.NewRequest(http.MethodGet, "/", strings.NewReader(body))
+	// a PNG, claiming to be a JPEG
+	req, _ := http.NewRequest(http.MethodPost, "/", newTestPNG(40, 20))
+	req.Header.Set("Content-Type", "image/jpeg")
 
-	handler := NewCreateMediaHandler(mockRepo, nil)
+	handler := NewCreateMediaHandler(mockRepo, nil, newTestProcessor(t))
 	handler.ServeHTTP(rr, req)
 
-	data := make([]byte, rr.Body.Len())
-	rr.Body.Read(data)
-
-	exp := fmt.Sprintf("{\"message\":\"%s\"}\n", testErrorMessage)
-	assert.Equal(t, exp, string(data))
-	assert.Equal(t, http.StatusInternalServerError, rr.Code)
-	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	exp := fmt.Sprintf("{\"message\":\"%s\"}\n", imaging.ErrInvalidImage)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
 }
 
-func TestCreateMediaHandler_MediaUploadFails_ReturnsInternalServerError(t *testing.T) {
+func TestCreateMediaHandler_GivenTooLargeImage_ReturnsRequestEntityTooLarge(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	const testID = 123
-	const testReferenceID = "247"
-	const testErrorMessage = "an error occured"
-
 	mockRepo := repository.NewMockMediaRepository(ctrl)
-	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(ctx context.Context, m *model.Media) (func(bool), error) {
-			m.SetID(testID)
-			m.SetReferenceID(testReferenceID)
 
-			return func(ok bool) {
-				assert.False(t, ok)
-			}, nil
-		})
+	rr := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodPost, "/", newTestPNG(200, 200))
+	req.Header.Set("Content-Type", "image/png")
+
+	handler := NewCreateMediaHandler(mockRepo, nil, newTestProcessor(t))
+	handler.ServeHTTP(rr, req)
+
+	exp := fmt.Sprintf("{\"message\":\"%s\"}\n", imaging.ErrImageTooLarge)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+}
+
+func TestCreateMediaHandler_CreateReturnsError_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testErrorMessage = "an error occured"
 
 	mockUploader := media.NewMockService(ctrl)
-	mockUploader.EXPECT().Upload(gomock.Any(), testReferenceID, gomock.Any()).
-		Return(errors.New(testErrorMessage))
+	mockUploader.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	mockUploader.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	mockRepo := repository.NewMockMediaRepository(ctrl)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New(testErrorMessage))
 
 	rr := httptest.NewRecorder()
 
-	body := `{"contentType":"image/jpeg"}`
-	req, _ := http.NewRequest(http.MethodGet, "/", strings.NewReader(body))
+	req, _ := http.NewRequest(http.MethodPost, "/", newTestPNG(40, 20))
+	req.Header.Set("Content-Type", "image/png")
 
-	handler := NewCreateMediaHandler(mockRepo, mockUploader)
+	handler := NewCreateMediaHandler(mockRepo, mockUploader, newTestProcessor(t))
 	handler.ServeHTTP(rr, req)
 
 	data := make([]byte, rr.Body.Len())
@@ -143,36 +202,45 @@ func TestCreateMediaHandler_MediaUploadFails_ReturnsInternalServerError(t *testi
 	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
 }
 
-func TestCreateMediaHandler_MediaContentIsInvalid_ReturnsInternalServerError(t *testing.T) {
+func TestCreateMediaHandler_MediaUploadFails_ReturnsInternalServerError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	const testID = 123
-	const testReferenceID = "247"
 	const testErrorMessage = "an error occured"
 
 	mockRepo := repository.NewMockMediaRepository(ctrl)
-	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(ctx context.Context, m *model.Media) (func(bool), error) {
-			m.SetID(testID)
-			m.SetReferenceID(testReferenceID)
 
-			return func(ok bool) {
-				assert.False(t, ok)
-			}, nil
-		})
+	var uploaded []string
+	mockUploader := media.NewMockService(ctrl)
+	gomock.InOrder(
+		mockUploader.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key string, r io.Reader) error {
+				uploaded = append(uploaded, key)
+				return nil
+			}),
+		mockUploader.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(errors.New(testErrorMessage)),
+		mockUploader.EXPECT().Delete(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key string) error {
+				assert.Equal(t, uploaded[0], key)
+				assert.True(t, strings.HasSuffix(key, "/thumbnail"))
+				return nil
+			}),
+	)
 
 	rr := httptest.NewRecorder()
 
-	body := `{"contentType":"image/jpeg","content":"320+ 3jflsd"}` // invalid base64 content
-	req, _ := http.NewRequest(http.MethodGet, "/", strings.NewReader(body))
+	req, _ := http.NewRequest(http.MethodPost, "/", newTestPNG(40, 20))
+	req.Header.Set("Content-Type", "image/png")
 
-	handler := NewCreateMediaHandler(mockRepo, nil)
+	handler := NewCreateMediaHandler(mockRepo, mockUploader, newTestProcessor(t))
 	handler.ServeHTTP(rr, req)
 
 	data := make([]byte, rr.Body.Len())
 	rr.Body.Read(data)
 
+	exp := fmt.Sprintf("{\"message\":\"%s\"}\n", testErrorMessage)
+	assert.Equal(t, exp, string(data))
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
 	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
 }
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/media/repository"
+	"github.com/reecerussell/open-social/media"
+)
+
+// DeleteMediaHandler is a http.Handler used to delete media records, along with the
+// content of each of their renditions.
+type DeleteMediaHandler struct {
+	core.Handler
+	repo    repository.MediaRepository
+	service media.Service
+}
+
+// DeleteMediaRequest is the body of the request.
+type DeleteMediaRequest struct {
+	ReferenceIDs []string `json:"referenceIds"`
+}
+
+// NewDeleteMediaHandler returns a new instance of DeleteMediaHandler.
+func NewDeleteMediaHandler(repo repository.MediaRepository, service media.Service) *DeleteMediaHandler {
+	return &DeleteMediaHandler{
+		repo:    repo,
+		service: service,
+	}
+}
+
+func (h *DeleteMediaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var data DeleteMediaRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	ctx := r.Context()
+	for _, referenceID := range data.ReferenceIDs {
+		m, err := h.repo.Get(ctx, referenceID)
+		if err == repository.ErrMediaNotFound {
+			continue
+		}
+		if err != nil {
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		// The content is deleted first, so the record is left to
+		// retry with, if it fails.
+		for _, key := range m.ContentKeys() {
+			err = h.service.Delete(ctx, key)
+			if err != nil {
+				h.RespondError(w, err, http.StatusInternalServerError)
+				return
+			}
+		}
+
+		err = h.repo.Delete(ctx, referenceID)
+		if err != nil {
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.Respond(w, nil)
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/media/dao"
+	repoMock "github.com/reecerussell/open-social/cmd/media/mock/repository"
+	"github.com/reecerussell/open-social/cmd/media/model"
+	"github.com/reecerussell/open-social/cmd/media/repository"
+	"github.com/reecerussell/open-social/mock/media"
+)
+
+func TestDeleteMediaHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	withRenditions := model.MediaFromDao(&dao.Media{
+		ReferenceID: "9823",
+		Renditions: []*dao.Rendition{
+			{Name: "thumbnail"},
+			{Name: "full"},
+		},
+	})
+
+	mockService := media.NewMockService(ctrl)
+	mockRepo := repoMock.NewMockMediaRepository(ctrl)
+	gomock.InOrder(
+		mockRepo.EXPECT().Get(gomock.Any(), "2389").Return(newTestMedia("2389"), nil),
+		mockService.EXPECT().Delete(gomock.Any(), "2389").Return(nil),
+		mockRepo.EXPECT().Delete(gomock.Any(), "2389").Return(nil),
+		mockRepo.EXPECT().Get(gomock.Any(), "9823").Return(withRenditions, nil),
+		mockService.EXPECT().Delete(gomock.Any(), "9823/thumbnail").Return(nil),
+		mockService.EXPECT().Delete(gomock.Any(), "9823/full").Return(nil),
+		mockRepo.EXPECT().Delete(gomock.Any(), "9823").Return(nil),
+		mockRepo.EXPECT().Get(gomock.Any(), "1234").Return(nil, repository.ErrMediaNotFound),
+	)
+
+	handler := NewDeleteMediaHandler(mockRepo, mockService)
+
+	body := strings.NewReader(`{"referenceIds":["2389","9823","1234"]}`)
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/", body)
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestDeleteMediaHandler_WhereContentFailsToDelete_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockService := media.NewMockService(ctrl)
+	mockService.EXPECT().Delete(gomock.Any(), "2389").Return(testError)
+
+	mockRepo := repoMock.NewMockMediaRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), "2389").Return(newTestMedia("2389"), nil)
+
+	handler := NewDeleteMediaHandler(mockRepo, mockService)
+
+	body := strings.NewReader(`{"referenceIds":["2389","9823"]}`)
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/", body)
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	var data map[string]string
+	json.NewDecoder(rr.Body).Decode(&data)
+	assert.Equal(t, testError.Error(), data["message"])
+}
+
+func TestDeleteMediaHandler_WhereRecordFailsToDelete_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockService := media.NewMockService(ctrl)
+	mockService.EXPECT().Delete(gomock.Any(), "2389").Return(nil)
+
+	mockRepo := repoMock.NewMockMediaRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), "2389").Return(newTestMedia("2389"), nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), "2389").Return(testError)
+
+	handler := NewDeleteMediaHandler(mockRepo, mockService)
+
+	body := strings.NewReader(`{"referenceIds":["2389"]}`)
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/", body)
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestDeleteMediaHandler_WhereGetFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockRepo := repoMock.NewMockMediaRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), "2389").Return(nil, testError)
+
+	handler := NewDeleteMediaHandler(mockRepo, nil)
+
+	body := strings.NewReader(`{"referenceIds":["2389"]}`)
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/", body)
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+// newTestMedia returns media without renditions, as uploaded before they were introduced.
+func newTestMedia(referenceID string) *model.Media {
+	return model.MediaFromDao(&dao.Media{
+		ReferenceID: referenceID,
+		ContentType: "image/png",
+	})
+}
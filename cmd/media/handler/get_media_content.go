@@ -1,8 +1,11 @@
 package handler
 
 import (
-	"encoding/base64"
+	"errors"
+	"io"
+	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -11,24 +14,23 @@ import (
 	"github.com/reecerussell/open-social/media"
 )
 
-// GetMediaContentHandler is a http.Handler which serves a media's content.
+// GetMediaContentHandler is a http.Handler which serves a media's content,
+// streaming it from storage, with its content type. The rendition is chosen
+// with the size query parameter, or is the default rendition if it's not given.
+// The offset query parameter skips the given number of bytes of the content.
 type GetMediaContentHandler struct {
 	core.Handler
-	repo       repository.MediaRepository
-	downloader media.Service
-}
-
-// GetMediaContentResponse represents the response body of the request.
-type GetMediaContentResponse struct {
-	ContentType string `json:"contentType"`
-	Content     string `json:"content"`
+	repo             repository.MediaRepository
+	downloader       media.Service
+	defaultRendition string
 }
 
 // NewGetMediaContentHandler returns a new instance of GetMediaContentHandler.
-func NewGetMediaContentHandler(repo repository.MediaRepository, downloader media.Service) *GetMediaContentHandler {
+func NewGetMediaContentHandler(repo repository.MediaRepository, downloader media.Service, defaultRendition string) *GetMediaContentHandler {
 	return &GetMediaContentHandler{
-		repo:       repo,
-		downloader: downloader,
+		repo:             repo,
+		downloader:       downloader,
+		defaultRendition: defaultRendition,
 	}
 }
 
@@ -36,8 +38,20 @@ func (h *GetMediaContentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	params := mux.Vars(r)
 	referenceID := params["referenceID"]
 
+	var offset int64
+	if v := r.URL.Query().Get("offset"); v != "" {
+		var err error
+		offset, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || offset < 0 {
+			h.RespondError(w, errInvalidOffset, http.StatusBadRequest)
+			return
+		}
+	}
+
+	size := sizeFromRequest(r, h.defaultRendition)
+
 	ctx := r.Context()
-	contentType, err := h.repo.GetContentType(ctx, referenceID)
+	m, err := h.repo.Get(ctx, referenceID)
 	if err != nil {
 		status := http.StatusInternalServerError
 		if err == repository.ErrMediaNotFound {
@@ -48,17 +62,54 @@ func (h *GetMediaContentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	data, err := h.downloader.Download(ctx, referenceID)
+	key, err := m.ContentKey(size)
 	if err != nil {
-		h.RespondError(w, err, http.StatusInternalServerError)
+		h.RespondError(w, err, http.StatusNotFound)
 		return
 	}
 
-	b64 := base64.StdEncoding.EncodeToString(data)
-	resp := GetMediaContentResponse{
-		ContentType: contentType,
-		Content:     b64,
+	w.Header().Set("Content-Type", m.ContentType())
+
+	cw := &countingWriter{w: w}
+	err = h.downloader.Download(ctx, key, offset, cw)
+	if err != nil {
+		// Once content has been written, the status can't be changed,
+		// so the response is cut short, for the client to see.
+		if cw.n > 0 {
+			log.Printf("ERROR: failed to download '%s': %v\n", referenceID, err)
+			return
+		}
+
+		status := http.StatusInternalServerError
+		if err == media.ErrNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
 	}
+}
+
+// errInvalidOffset is returned when the offset query parameter isn't a non-negative number.
+var errInvalidOffset = errors.New("offset must be a non-negative number")
+
+// sizeFromRequest returns the name of the rendition requested with the size
+// query parameter, or the default rendition, if one wasn't requested.
+func sizeFromRequest(r *http.Request, defaultRendition string) string {
+	if size := r.URL.Query().Get("size"); size != "" {
+		return size
+	}
+
+	return defaultRendition
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
 
-	h.Respond(w, resp)
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
 }
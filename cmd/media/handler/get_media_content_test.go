@@ -2,9 +2,9 @@ package handler
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,8 +13,11 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/reecerussell/open-social/cmd/media/dao"
 	repoMock "github.com/reecerussell/open-social/cmd/media/mock/repository"
+	"github.com/reecerussell/open-social/cmd/media/model"
 	"github.com/reecerussell/open-social/cmd/media/repository"
+	mediaService "github.com/reecerussell/open-social/media"
 	"github.com/reecerussell/open-social/mock/media"
 )
 
@@ -24,18 +27,19 @@ func TestGetMediaContentHandler(t *testing.T) {
 
 	const testReferenceID = "23984yks"
 	const testContentType = "text/plain"
-	const testContent = "SGVsbG8gV29ybGQ="
+	const testContent = "Hello World"
 
 	mockRepo := repoMock.NewMockMediaRepository(ctrl)
-	mockRepo.EXPECT().GetContentType(gomock.Any(), testReferenceID).Return(testContentType, nil)
+	mockRepo.EXPECT().Get(gomock.Any(), testReferenceID).Return(model.MediaFromDao(&dao.Media{ReferenceID: testReferenceID, ContentType: testContentType}), nil)
 
 	mockDownloader := media.NewMockService(ctrl)
-	mockDownloader.EXPECT().Download(gomock.Any(), testReferenceID).
-		DoAndReturn(func(ctx context.Context, key string) ([]byte, error) {
-			return base64.StdEncoding.DecodeString(testContent)
+	mockDownloader.EXPECT().Download(gomock.Any(), testReferenceID, int64(0), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, offset int64, w io.Writer) error {
+			_, err := io.WriteString(w, testContent)
+			return err
 		})
 
-	handler := NewGetMediaContentHandler(mockRepo, mockDownloader)
+	handler := NewGetMediaContentHandler(mockRepo, mockDownloader, "full")
 	router := mux.NewRouter()
 	router.Handle("/{referenceID}", handler)
 
@@ -46,10 +50,9 @@ func TestGetMediaContentHandler(t *testing.T) {
 	data := make([]byte, rr.Body.Len())
 	rr.Body.Read(data)
 
-	exp := fmt.Sprintf("{\"contentType\":\"%s\",\"content\":\"%s\"}\n", testContentType, testContent)
-	assert.Equal(t, exp, string(data))
+	assert.Equal(t, testContent, string(data))
 	assert.Equal(t, http.StatusOK, rr.Code)
-	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.Equal(t, testContentType, rr.Header().Get("Content-Type"))
 }
 
 func TestGetMediaContentHandler_GivenInvalidReferenceID_ReturnsNotFound(t *testing.T) {
@@ -59,9 +62,9 @@ func TestGetMediaContentHandler_GivenInvalidReferenceID_ReturnsNotFound(t *testi
 	const testReferenceID = "23984yks"
 
 	mockRepo := repoMock.NewMockMediaRepository(ctrl)
-	mockRepo.EXPECT().GetContentType(gomock.Any(), testReferenceID).Return("", repository.ErrMediaNotFound)
+	mockRepo.EXPECT().Get(gomock.Any(), testReferenceID).Return(nil, repository.ErrMediaNotFound)
 
-	handler := NewGetMediaContentHandler(mockRepo, nil)
+	handler := NewGetMediaContentHandler(mockRepo, nil, "full")
 	router := mux.NewRouter()
 	router.Handle("/{referenceID}", handler)
 
@@ -86,9 +89,9 @@ func TestGetMediaContentHandler_RepoReturnsError_ReturnsInternalServerError(t *t
 	const testErrorMessage = "an error occured"
 
 	mockRepo := repoMock.NewMockMediaRepository(ctrl)
-	mockRepo.EXPECT().GetContentType(gomock.Any(), testReferenceID).Return("", errors.New(testErrorMessage))
+	mockRepo.EXPECT().Get(gomock.Any(), testReferenceID).Return(nil, errors.New(testErrorMessage))
 
-	handler := NewGetMediaContentHandler(mockRepo, nil)
+	handler := NewGetMediaContentHandler(mockRepo, nil, "full")
 	router := mux.NewRouter()
 	router.Handle("/{referenceID}", handler)
 
@@ -114,12 +117,12 @@ func TestGetMediaContentHandler_DownloaderReturnsError_ReturnsInternalServerErro
 	const testErrorMessage = "an error occured"
 
 	mockRepo := repoMock.NewMockMediaRepository(ctrl)
-	mockRepo.EXPECT().GetContentType(gomock.Any(), testReferenceID).Return(testContentType, nil)
+	mockRepo.EXPECT().Get(gomock.Any(), testReferenceID).Return(model.MediaFromDao(&dao.Media{ReferenceID: testReferenceID, ContentType: testContentType}), nil)
 
 	mockDownloader := media.NewMockService(ctrl)
-	mockDownloader.EXPECT().Download(gomock.Any(), testReferenceID).Return(nil, errors.New(testErrorMessage))
+	mockDownloader.EXPECT().Download(gomock.Any(), testReferenceID, int64(0), gomock.Any()).Return(errors.New(testErrorMessage))
 
-	handler := NewGetMediaContentHandler(mockRepo, mockDownloader)
+	handler := NewGetMediaContentHandler(mockRepo, mockDownloader, "full")
 	router := mux.NewRouter()
 	router.Handle("/{referenceID}", handler)
 
@@ -135,3 +138,167 @@ func TestGetMediaContentHandler_DownloaderReturnsError_ReturnsInternalServerErro
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
 	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
 }
+
+func TestGetMediaContentHandler_ContentNotFound_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23984yks"
+
+	mockRepo := repoMock.NewMockMediaRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testReferenceID).Return(model.MediaFromDao(&dao.Media{ReferenceID: testReferenceID, ContentType: "text/plain"}), nil)
+
+	mockDownloader := media.NewMockService(ctrl)
+	mockDownloader.EXPECT().Download(gomock.Any(), testReferenceID, int64(0), gomock.Any()).Return(mediaService.ErrNotFound)
+
+	handler := NewGetMediaContentHandler(mockRepo, mockDownloader, "full")
+	router := mux.NewRouter()
+	router.Handle("/{referenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/"+testReferenceID, nil)
+	router.ServeHTTP(rr, req)
+
+	exp := fmt.Sprintf("{\"message\":\"%s\"}\n", mediaService.ErrNotFound)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+}
+
+func TestGetMediaContentHandler_DownloadFailsPartway_EndsResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23984yks"
+
+	mockRepo := repoMock.NewMockMediaRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testReferenceID).Return(model.MediaFromDao(&dao.Media{ReferenceID: testReferenceID, ContentType: "text/plain"}), nil)
+
+	mockDownloader := media.NewMockService(ctrl)
+	mockDownloader.EXPECT().Download(gomock.Any(), testReferenceID, int64(0), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, offset int64, w io.Writer) error {
+			_, _ = io.WriteString(w, "Hello")
+			return errors.New("connection reset")
+		})
+
+	handler := NewGetMediaContentHandler(mockRepo, mockDownloader, "full")
+	router := mux.NewRouter()
+	router.Handle("/{referenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/"+testReferenceID, nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, "Hello", rr.Body.String())
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/plain", rr.Header().Get("Content-Type"))
+}
+
+func TestGetMediaContentHandler_ServesRequestedRendition(t *testing.T) {
+	const testReferenceID = "23984yks"
+
+	testMedia := model.MediaFromDao(&dao.Media{
+		ReferenceID: testReferenceID,
+		ContentType: "image/jpeg",
+		Renditions: []*dao.Rendition{
+			{Name: "thumbnail", Width: 150, Height: 100},
+			{Name: "full", Width: 1500, Height: 1000},
+		},
+	})
+
+	tests := map[string]string{
+		"/" + testReferenceID:                     testReferenceID + "/full",
+		"/" + testReferenceID + "?size=thumbnail": testReferenceID + "/thumbnail",
+	}
+
+	for url, key := range tests {
+		t.Run(url, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repoMock.NewMockMediaRepository(ctrl)
+			mockRepo.EXPECT().Get(gomock.Any(), testReferenceID).Return(testMedia, nil)
+
+			mockDownloader := media.NewMockService(ctrl)
+			mockDownloader.EXPECT().Download(gomock.Any(), key, int64(0), gomock.Any()).Return(nil)
+
+			handler := NewGetMediaContentHandler(mockRepo, mockDownloader, "full")
+			router := mux.NewRouter()
+			router.Handle("/{referenceID}", handler)
+
+			rr := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, url, nil)
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, "image/jpeg", rr.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func TestGetMediaContentHandler_GivenUnknownSize_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23984yks"
+
+	testMedia := model.MediaFromDao(&dao.Media{
+		ReferenceID: testReferenceID,
+		ContentType: "image/jpeg",
+		Renditions:  []*dao.Rendition{{Name: "full", Width: 1500, Height: 1000}},
+	})
+
+	mockRepo := repoMock.NewMockMediaRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testReferenceID).Return(testMedia, nil)
+
+	handler := NewGetMediaContentHandler(mockRepo, nil, "full")
+	router := mux.NewRouter()
+	router.Handle("/{referenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/"+testReferenceID+"?size=huge", nil)
+	router.ServeHTTP(rr, req)
+
+	exp := fmt.Sprintf("{\"message\":\"%s\"}\n", model.ErrRenditionNotFound)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGetMediaContentHandler_GivenOffset_DownloadsFromOffset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23984yks"
+
+	mockRepo := repoMock.NewMockMediaRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testReferenceID).Return(model.MediaFromDao(&dao.Media{ReferenceID: testReferenceID, ContentType: "text/plain"}), nil)
+
+	mockDownloader := media.NewMockService(ctrl)
+	mockDownloader.EXPECT().Download(gomock.Any(), testReferenceID, int64(6), gomock.Any()).Return(nil)
+
+	handler := NewGetMediaContentHandler(mockRepo, mockDownloader, "full")
+	router := mux.NewRouter()
+	router.Handle("/{referenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/"+testReferenceID+"?offset=6", nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestGetMediaContentHandler_GivenInvalidOffset_ReturnsBadRequest(t *testing.T) {
+	for _, offset := range []string{"-1", "abc"} {
+		handler := NewGetMediaContentHandler(nil, nil, "full")
+		router := mux.NewRouter()
+		router.Handle("/{referenceID}", handler)
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/23984yks?offset="+offset, nil)
+		router.ServeHTTP(rr, req)
+
+		exp := fmt.Sprintf("{\"message\":\"%s\"}\n", errInvalidOffset)
+		assert.Equal(t, exp, rr.Body.String())
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	}
+}
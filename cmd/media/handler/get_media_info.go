@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/media/repository"
+)
+
+// GetMediaInfoHandler is a http.Handler which serves the metadata of a media's
+// content, used to serve it with caching headers. The rendition is chosen the
+// same way as GetMediaContentHandler.
+type GetMediaInfoHandler struct {
+	core.Handler
+	repo             repository.MediaRepository
+	defaultRendition string
+}
+
+// GetMediaInfoResponse is the body of the response. Size and ETag are
+// empty for media uploaded before they were recorded.
+type GetMediaInfoResponse struct {
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// NewGetMediaInfoHandler returns a new instance of GetMediaInfoHandler.
+func NewGetMediaInfoHandler(repo repository.MediaRepository, defaultRendition string) *GetMediaInfoHandler {
+	return &GetMediaInfoHandler{
+		repo:             repo,
+		defaultRendition: defaultRendition,
+	}
+}
+
+func (h *GetMediaInfoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	referenceID := params["referenceID"]
+
+	m, err := h.repo.Get(r.Context(), referenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrMediaNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	rendition, err := m.Rendition(sizeFromRequest(r, h.defaultRendition))
+	if err != nil {
+		h.RespondError(w, err, http.StatusNotFound)
+		return
+	}
+
+	resp := GetMediaInfoResponse{
+		ContentType:  m.ContentType(),
+		LastModified: m.Uploaded(),
+	}
+
+	if rendition != nil {
+		resp.Size = rendition.Size()
+		resp.ETag = rendition.ETag()
+	}
+
+	h.Respond(w, resp)
+}
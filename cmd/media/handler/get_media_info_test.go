@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/media/dao"
+	repoMock "github.com/reecerussell/open-social/cmd/media/mock/repository"
+	"github.com/reecerussell/open-social/cmd/media/model"
+	"github.com/reecerussell/open-social/cmd/media/repository"
+)
+
+func TestGetMediaInfoHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23984yks"
+	testUploaded := time.Date(2020, 11, 2, 15, 4, 5, 0, time.UTC)
+
+	m := model.MediaFromDao(&dao.Media{
+		ReferenceID: testReferenceID,
+		ContentType: "image/png",
+		Uploaded:    testUploaded,
+		Renditions: []*dao.Rendition{
+			{Name: "thumbnail", Size: 120, ETag: "abc"},
+			{Name: "full", Size: 4096, ETag: "def"},
+		},
+	})
+
+	mockRepo := repoMock.NewMockMediaRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testReferenceID).Return(m, nil).Times(2)
+
+	handler := NewGetMediaInfoHandler(mockRepo, "full")
+	router := mux.NewRouter()
+	router.Handle("/{referenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/"+testReferenceID, nil)
+	router.ServeHTTP(rr, req)
+
+	exp := "{\"contentType\":\"image/png\",\"size\":4096,\"etag\":\"def\",\"lastModified\":\"2020-11-02T15:04:05Z\"}\n"
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/"+testReferenceID+"?size=thumbnail", nil)
+	router.ServeHTTP(rr, req)
+
+	exp = "{\"contentType\":\"image/png\",\"size\":120,\"etag\":\"abc\",\"lastModified\":\"2020-11-02T15:04:05Z\"}\n"
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestGetMediaInfoHandler_WhereMediaHasNoRenditions_ReturnsNoSizeOrETag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repoMock.NewMockMediaRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), "2389").Return(newTestMedia("2389"), nil)
+
+	handler := NewGetMediaInfoHandler(mockRepo, "full")
+	router := mux.NewRouter()
+	router.Handle("/{referenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/2389", nil)
+	router.ServeHTTP(rr, req)
+
+	exp := "{\"contentType\":\"image/png\",\"size\":0,\"etag\":\"\",\"lastModified\":\"0001-01-01T00:00:00Z\"}\n"
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestGetMediaInfoHandler_WhereRenditionDoesNotExist_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := model.MediaFromDao(&dao.Media{
+		ReferenceID: "2389",
+		ContentType: "image/png",
+		Renditions:  []*dao.Rendition{{Name: "full"}},
+	})
+
+	mockRepo := repoMock.NewMockMediaRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), "2389").Return(m, nil)
+
+	handler := NewGetMediaInfoHandler(mockRepo, "full")
+	router := mux.NewRouter()
+	router.Handle("/{referenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/2389?size=huge", nil)
+	router.ServeHTTP(rr, req)
+
+	exp := fmt.Sprintf("{\"message\":\"%s\"}\n", model.ErrRenditionNotFound)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGetMediaInfoHandler_WhereRepoFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{repository.ErrMediaNotFound, http.StatusNotFound},
+		{errors.New("an error occured"), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		mockRepo := repoMock.NewMockMediaRepository(ctrl)
+		mockRepo.EXPECT().Get(gomock.Any(), "2389").Return(nil, test.err)
+
+		handler := NewGetMediaInfoHandler(mockRepo, "full")
+		router := mux.NewRouter()
+		router.Handle("/{referenceID}", handler)
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/2389", nil)
+		router.ServeHTTP(rr, req)
+
+		exp := fmt.Sprintf("{\"message\":\"%s\"}\n", test.err)
+		assert.Equal(t, exp, rr.Body.String())
+		assert.Equal(t, test.status, rr.Code)
+	}
+}
@@ -0,0 +1,168 @@
+// Package imaging decodes uploaded images and encodes renditions of them, at
+// configured sizes. Images are upright and carry no metadata once re-encoded,
+// so details such as the location a photo was taken at are never stored.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// Common errors
+var (
+	ErrInvalidImage  = errors.New("content is not a valid image of its content type")
+	ErrImageTooLarge = errors.New("image has too many pixels")
+)
+
+// Config contains config for processing images.
+type Config struct {
+	// MaxPixels is the largest number of pixels an image can have, as images
+	// are held in memory, uncompressed, while they're processed.
+	MaxPixels int `json:"maxPixels"`
+
+	// Quality is the quality JPEG renditions are encoded with, from 1 to 100.
+	Quality int `json:"quality"`
+
+	// Renditions are the sizes each image is stored in, and DefaultRendition
+	// is the name of the one served when no size is requested.
+	Renditions       []*RenditionConfig `json:"renditions"`
+	DefaultRendition string             `json:"defaultRendition"`
+}
+
+// RenditionConfig contains config for a size of image. Images are scaled
+// down to fit within a square of MaxSize pixels, but never scaled up.
+type RenditionConfig struct {
+	Name    string `json:"name"`
+	MaxSize int    `json:"maxSize"`
+}
+
+// Processor decodes and encodes images, as configured.
+type Processor struct {
+	cnf *Config
+}
+
+// NewProcessor returns a new instance of Processor, given valid config.
+func NewProcessor(cnf *Config) (*Processor, error) {
+	if cnf.MaxPixels < 1 {
+		return nil, errors.New("maxPixels must be a positive number")
+	}
+
+	if cnf.Quality < 1 || cnf.Quality > 100 {
+		return nil, errors.New("quality must be between 1 and 100")
+	}
+
+	if len(cnf.Renditions) == 0 {
+		return nil, errors.New("at least one rendition is required")
+	}
+
+	names := make(map[string]bool)
+	for _, r := range cnf.Renditions {
+		if r.Name == "" || strings.Contains(r.Name, "/") {
+			return nil, fmt.Errorf("invalid rendition name: '%s'", r.Name)
+		}
+
+		if names[r.Name] {
+			return nil, fmt.Errorf("duplicate rendition: %s", r.Name)
+		}
+
+		if r.MaxSize < 1 {
+			return nil, fmt.Errorf("the %s rendition's maxSize must be a positive number", r.Name)
+		}
+
+		names[r.Name] = true
+	}
+
+	if !names[cnf.DefaultRendition] {
+		return nil, fmt.Errorf("unknown default rendition: '%s'", cnf.DefaultRendition)
+	}
+
+	return &Processor{cnf: cnf}, nil
+}
+
+// Renditions returns the configured renditions.
+func (p *Processor) Renditions() []*RenditionConfig {
+	return p.cnf.Renditions
+}
+
+// DefaultRendition returns the name of the rendition served when no size is requested.
+func (p *Processor) DefaultRendition() string {
+	return p.cnf.DefaultRendition
+}
+
+// Decode reads an image of the given content type from r, turning it upright
+// if it has an EXIF orientation. The image's size is checked before it's decoded.
+func (p *Processor) Decode(r io.Reader, contentType string) (*image.RGBA, error) {
+	// The header is kept as it's read, to decode the image from, once its
+	// size has been checked, and to read its orientation from.
+	var head bytes.Buffer
+	cnf, format, err := image.DecodeConfig(io.TeeReader(r, &head))
+	if err != nil || "image/"+format != contentType {
+		return nil, ErrInvalidImage
+	}
+
+	if cnf.Width*cnf.Height > p.cnf.MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	orientation := 1
+	if format == "jpeg" {
+		orientation = readOrientation(head.Bytes())
+	}
+
+	img, _, err := image.Decode(io.MultiReader(&head, r))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+
+	return orient(rgba, orientation), nil
+}
+
+// Encode scales img to the given dimensions, then writes it to w, as the given
+// content type. No metadata is written.
+func (p *Processor) Encode(w io.Writer, img *image.RGBA, width, height int, contentType string) error {
+	img = resize(img, width, height)
+
+	switch contentType {
+	case "image/jpeg":
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: p.cnf.Quality})
+	case "image/png":
+		return png.Encode(w, img)
+	default:
+		return fmt.Errorf("unsupported content type: %s", contentType)
+	}
+}
+
+// Fit returns the dimensions of an image of the given size, once it's scaled down
+// to fit within a square of maxSize pixels, keeping its aspect ratio.
+func Fit(width, height, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return width, height
+	}
+
+	if width >= height {
+		return maxSize, scale(height, maxSize, width)
+	}
+
+	return scale(width, maxSize, height), maxSize
+}
+
+// scale returns n*num/den, rounded, but never less than one.
+func scale(n, num, den int) int {
+	v := (n*num + den/2) / den
+	if v < 1 {
+		return 1
+	}
+
+	return v
+}
@@ -0,0 +1,193 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestProcessor(t *testing.T) *Processor {
+	p, err := NewProcessor(&Config{
+		MaxPixels: 10000,
+		Quality:   90,
+		Renditions: []*RenditionConfig{
+			{Name: "thumbnail", MaxSize: 10},
+			{Name: "full", MaxSize: 50},
+		},
+		DefaultRendition: "full",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+func newTestImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 10), G: uint8(y * 10), B: 100, A: 255})
+		}
+	}
+
+	return img
+}
+
+// withOrientation returns a JPEG of img, with an EXIF segment holding the orientation.
+func withOrientation(t *testing.T, img image.Image, orientation byte) []byte {
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, img, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tiff := []byte{
+		'M', 'M', 0, 42, 0, 0, 0, 8, // header
+		0, 1, // one entry
+		0x01, 0x12, 0, 3, 0, 0, 0, 1, 0, orientation, 0, 0, // orientation
+		0, 0, 0, 0, // no next IFD
+	}
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+
+	b := buf.Bytes()
+	out := append([]byte{}, b[:2]...)
+	out = append(out, 0xFF, 0xE1, 0, byte(len(payload)+2))
+	out = append(out, payload...)
+
+	return append(out, b[2:]...)
+}
+
+func TestNewProcessor_GivenInvalidConfig_ReturnsError(t *testing.T) {
+	valid := func() *Config {
+		return &Config{
+			MaxPixels:        10000,
+			Quality:          90,
+			Renditions:       []*RenditionConfig{{Name: "full", MaxSize: 50}},
+			DefaultRendition: "full",
+		}
+	}
+
+	tests := map[string]func(c *Config){
+		"No MaxPixels":     func(c *Config) { c.MaxPixels = 0 },
+		"Invalid Quality":  func(c *Config) { c.Quality = 101 },
+		"No Renditions":    func(c *Config) { c.Renditions = nil },
+		"Invalid Name":     func(c *Config) { c.Renditions[0].Name = "a/b" },
+		"Duplicate Name":   func(c *Config) { c.Renditions = append(c.Renditions, c.Renditions[0]) },
+		"Invalid MaxSize":  func(c *Config) { c.Renditions[0].MaxSize = 0 },
+		"Unknown Default":  func(c *Config) { c.DefaultRendition = "feed" },
+		"No Default Given": func(c *Config) { c.DefaultRendition = "" },
+	}
+
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			cnf := valid()
+			modify(cnf)
+
+			p, err := NewProcessor(cnf)
+			assert.Nil(t, p)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestProcessor_Decode_GivenPNG_ReturnsImage(t *testing.T) {
+	p := newTestProcessor(t)
+
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, newTestImage(20, 10))
+
+	img, err := p.Decode(&buf, "image/png")
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 20, 10), img.Bounds())
+	assert.Equal(t, color.RGBA{R: 50, G: 30, B: 100, A: 255}, img.At(5, 3))
+}
+
+func TestProcessor_Decode_GivenOrientedJPEG_ReturnsUprightImage(t *testing.T) {
+	p := newTestProcessor(t)
+
+	data := withOrientation(t, newTestImage(20, 10), 6)
+
+	img, err := p.Decode(bytes.NewReader(data), "image/jpeg")
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 10, 20), img.Bounds())
+}
+
+func TestProcessor_Decode_GivenMismatchedContentType_ReturnsError(t *testing.T) {
+	p := newTestProcessor(t)
+
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, newTestImage(20, 10))
+
+	img, err := p.Decode(&buf, "image/jpeg")
+	assert.Nil(t, img)
+	assert.Equal(t, ErrInvalidImage, err)
+}
+
+func TestProcessor_Decode_GivenInvalidImage_ReturnsError(t *testing.T) {
+	p := newTestProcessor(t)
+
+	img, err := p.Decode(bytes.NewReader([]byte("Hello World")), "image/png")
+	assert.Nil(t, img)
+	assert.Equal(t, ErrInvalidImage, err)
+}
+
+func TestProcessor_Decode_GivenTooManyPixels_ReturnsError(t *testing.T) {
+	p := newTestProcessor(t)
+
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, newTestImage(101, 100))
+
+	img, err := p.Decode(&buf, "image/png")
+	assert.Nil(t, img)
+	assert.Equal(t, ErrImageTooLarge, err)
+}
+
+func TestProcessor_Encode_StripsMetadata(t *testing.T) {
+	p := newTestProcessor(t)
+
+	data := withOrientation(t, newTestImage(20, 10), 1)
+	img, _ := p.Decode(bytes.NewReader(data), "image/jpeg")
+
+	var buf bytes.Buffer
+	err := p.Encode(&buf, img, 10, 5, "image/jpeg")
+	assert.NoError(t, err)
+	assert.False(t, bytes.Contains(buf.Bytes(), []byte("Exif")))
+
+	cnf, format, err := image.DecodeConfig(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, 10, cnf.Width)
+	assert.Equal(t, 5, cnf.Height)
+}
+
+func TestProcessor_Encode_GivenUnsupportedContentType_ReturnsError(t *testing.T) {
+	p := newTestProcessor(t)
+
+	var buf bytes.Buffer
+	err := p.Encode(&buf, newTestImage(20, 10), 20, 10, "image/gif")
+	assert.Equal(t, "unsupported content type: image/gif", err.Error())
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		width, height, maxSize int
+		expWidth, expHeight    int
+	}{
+		{4000, 3000, 1080, 1080, 810},
+		{3000, 4000, 1080, 810, 1080},
+		{800, 600, 1080, 800, 600},
+		{4000, 1, 100, 100, 1},
+	}
+
+	for _, test := range tests {
+		w, h := Fit(test.width, test.height, test.maxSize)
+		assert.Equal(t, test.expWidth, w)
+		assert.Equal(t, test.expHeight, h)
+	}
+}
@@ -0,0 +1,147 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+// orientationTag is the EXIF tag of an image's orientation.
+const orientationTag = 0x0112
+
+// readOrientation returns the EXIF orientation of a JPEG, from its header, which
+// is a number from 1 to 8. 1, meaning upright, is returned if it has none.
+func readOrientation(b []byte) int {
+	if len(b) < 2 || b[0] != 0xFF || b[1] != 0xD8 {
+		return 1
+	}
+
+	i := 2
+	for i+4 <= len(b) {
+		if b[i] != 0xFF {
+			return 1
+		}
+
+		marker := b[i+1]
+		switch {
+		case marker == 0xFF:
+			// Fill byte
+			i++
+			continue
+		case marker == 0x01 || marker >= 0xD0 && marker <= 0xD9:
+			// Markers without a length
+			i += 2
+			continue
+		case marker == 0xDA:
+			// Start of scan, after which there is no more metadata
+			return 1
+		}
+
+		n := int(binary.BigEndian.Uint16(b[i+2:]))
+		if n < 2 || i+2+n > len(b) {
+			return 1
+		}
+
+		segment := b[i+4 : i+2+n]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			if o := exifOrientation(segment[6:]); o != 0 {
+				return o
+			}
+		}
+
+		i += 2 + n
+	}
+
+	return 1
+}
+
+// exifOrientation reads the orientation from the first IFD of EXIF data,
+// returning zero if it doesn't have a valid one.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	if order.Uint16(tiff[2:]) != 42 {
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+
+	count := int(order.Uint16(tiff[ifd:]))
+	for j := 0; j < count; j++ {
+		e := ifd + 2 + j*12
+		if e+12 > len(tiff) {
+			return 0
+		}
+
+		// The orientation is a single SHORT, held in the entry's value.
+		if order.Uint16(tiff[e:]) == orientationTag && order.Uint16(tiff[e+2:]) == 3 {
+			o := int(order.Uint16(tiff[e+8:]))
+			if o < 1 || o > 8 {
+				return 0
+			}
+
+			return o
+		}
+	}
+
+	return 0
+}
+
+// orient transforms src, which has the given EXIF orientation, to be upright.
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	// Orientations 5 to 8 are rotated by 90 degrees, so swap the dimensions.
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := 0; dy < dh; dy++ {
+		for dx := 0; dx < dw; dx++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // Flipped horizontally
+				sx, sy = w-1-dx, dy
+			case 3: // Rotated 180 degrees
+				sx, sy = w-1-dx, h-1-dy
+			case 4: // Flipped vertically
+				sx, sy = dx, h-1-dy
+			case 5: // Transposed
+				sx, sy = dy, dx
+			case 6: // Needs rotating 90 degrees clockwise
+				sx, sy = dy, h-1-dx
+			case 7: // Transversed
+				sx, sy = w-1-dy, h-1-dx
+			case 8: // Needs rotating 90 degrees anti-clockwise
+				sx, sy = w-1-dy, dx
+			}
+
+			i := src.PixOffset(b.Min.X+sx, b.Min.Y+sy)
+			copy(dst.Pix[dst.PixOffset(dx, dy):], src.Pix[i:i+4])
+		}
+	}
+
+	return dst
+}
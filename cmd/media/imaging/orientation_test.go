@@ -0,0 +1,69 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOrientation(t *testing.T) {
+	img := newTestImage(2, 2)
+
+	for o := byte(1); o <= 8; o++ {
+		data := withOrientation(t, img, o)
+		assert.Equal(t, int(o), readOrientation(data))
+	}
+}
+
+func TestReadOrientation_GivenNoEXIF_ReturnsUpright(t *testing.T) {
+	assert.Equal(t, 1, readOrientation([]byte{0xFF, 0xD8, 0xFF, 0xDA, 0, 2}))
+	assert.Equal(t, 1, readOrientation([]byte("Hello World")))
+}
+
+func TestReadOrientation_GivenInvalidEXIF_ReturnsUpright(t *testing.T) {
+	data := withOrientation(t, newTestImage(2, 2), 9)
+	assert.Equal(t, 1, readOrientation(data))
+
+	data = withOrientation(t, newTestImage(2, 2), 6)
+	i := bytes.Index(data, []byte("MM"))
+	data[i+7] = 200 // IFD offset out of range
+	assert.Equal(t, 1, readOrientation(data))
+}
+
+func TestOrient(t *testing.T) {
+	// A 3x2 image, with distinct corners.
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	topLeft := color.RGBA{R: 255, A: 255}
+	topRight := color.RGBA{G: 255, A: 255}
+	bottomLeft := color.RGBA{B: 255, A: 255}
+	src.Set(0, 0, topLeft)
+	src.Set(2, 0, topRight)
+	src.Set(0, 1, bottomLeft)
+
+	tests := []struct {
+		orientation   int
+		width, height int
+		// The coordinates topLeft, topRight and bottomLeft are moved to.
+		tl, tr, bl image.Point
+	}{
+		{1, 3, 2, image.Pt(0, 0), image.Pt(2, 0), image.Pt(0, 1)},
+		{2, 3, 2, image.Pt(2, 0), image.Pt(0, 0), image.Pt(2, 1)},
+		{3, 3, 2, image.Pt(2, 1), image.Pt(0, 1), image.Pt(2, 0)},
+		{4, 3, 2, image.Pt(0, 1), image.Pt(2, 1), image.Pt(0, 0)},
+		{5, 2, 3, image.Pt(0, 0), image.Pt(0, 2), image.Pt(1, 0)},
+		{6, 2, 3, image.Pt(1, 0), image.Pt(1, 2), image.Pt(0, 0)},
+		{7, 2, 3, image.Pt(1, 2), image.Pt(1, 0), image.Pt(0, 2)},
+		{8, 2, 3, image.Pt(0, 2), image.Pt(0, 0), image.Pt(1, 2)},
+	}
+
+	for _, test := range tests {
+		dst := orient(src, test.orientation)
+		assert.Equal(t, image.Rect(0, 0, test.width, test.height), dst.Bounds(), test.orientation)
+		assert.Equal(t, topLeft, dst.At(test.tl.X, test.tl.Y), test.orientation)
+		assert.Equal(t, topRight, dst.At(test.tr.X, test.tr.Y), test.orientation)
+		assert.Equal(t, bottomLeft, dst.At(test.bl.X, test.bl.Y), test.orientation)
+	}
+}
@@ -0,0 +1,54 @@
+package imaging
+
+import "image"
+
+// resize scales src to the given dimensions, averaging the block of source
+// pixels each pixel covers. It's intended for scaling images down.
+func resize(src *image.RGBA, width, height int) *image.RGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	if sw == width && sh == height {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := span(y, sh, height)
+
+		for x := 0; x < width; x++ {
+			x0, x1 := span(x, sw, width)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				i := src.PixOffset(b.Min.X+x0, b.Min.Y+sy)
+				for sx := x0; sx < x1; sx++ {
+					sum[0] += int(src.Pix[i])
+					sum[1] += int(src.Pix[i+1])
+					sum[2] += int(src.Pix[i+2])
+					sum[3] += int(src.Pix[i+3])
+					i += 4
+				}
+			}
+
+			n := (x1 - x0) * (y1 - y0)
+			j := dst.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				dst.Pix[j+c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+
+	return dst
+}
+
+// span returns the range of source pixels, along one axis, covered by
+// the destination pixel i, when scaling a length of n to m.
+func span(i, n, m int) (int, int) {
+	start := i * n / m
+	end := (i + 1) * n / m
+	if end <= start {
+		end = start + 1
+	}
+
+	return start, end
+}
@@ -0,0 +1,48 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResize_AveragesCoveredPixels(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+
+	// Left half red, right half blue.
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if x < 2 {
+				src.Set(x, y, red)
+			} else {
+				src.Set(x, y, blue)
+			}
+		}
+	}
+
+	dst := resize(src, 2, 1)
+	assert.Equal(t, image.Rect(0, 0, 2, 1), dst.Bounds())
+	assert.Equal(t, red, dst.At(0, 0))
+	assert.Equal(t, blue, dst.At(1, 0))
+
+	dst = resize(src, 1, 1)
+	assert.Equal(t, color.RGBA{R: 128, B: 128, A: 255}, dst.At(0, 0))
+}
+
+func TestResize_GivenSameSize_ReturnsSource(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	assert.Same(t, src, resize(src, 4, 2))
+}
+
+func TestResize_GivenSubImage_ReadsWithinBounds(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	src.Set(2, 2, color.RGBA{G: 255, A: 255})
+
+	sub := src.SubImage(image.Rect(2, 2, 4, 4)).(*image.RGBA)
+	dst := resize(sub, 1, 1)
+	assert.Equal(t, color.RGBA{G: 64, A: 64}, dst.At(0, 0))
+}
@@ -2,30 +2,41 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
 
 	core "github.com/reecerussell/open-social"
 	"github.com/reecerussell/open-social/cmd/media/handler"
+	"github.com/reecerussell/open-social/cmd/media/imaging"
 	"github.com/reecerussell/open-social/cmd/media/repository"
 	"github.com/reecerussell/open-social/database"
 	"github.com/reecerussell/open-social/media"
+	"github.com/reecerussell/open-social/media/filesystem"
 	"github.com/reecerussell/open-social/media/gcp"
+	"github.com/reecerussell/open-social/media/s3"
+	"github.com/reecerussell/open-social/util"
 )
 
 const (
 	connectionStringVar = "CONNECTION_STRING"
 	mediaBucketVar      = "MEDIA_BUCKET"
+	configFileVar       = "CONFIG_FILE"
+	s3AccessKeyIDVar    = "S3_ACCESS_KEY_ID"
+	s3SecretKeyVar      = "S3_SECRET_ACCESS_KEY"
 )
 
 func main() {
 	ctx, cancel := context.WithCancel(context.Background())
-	ctn := buildServices(ctx)
+	cnf := buildConfig()
+	ctn := buildServices(ctx, cnf)
 	db := ctn.GetService("Database").(database.Database)
 
 	createMedia := ctn.GetService("CreateMediaHandler").(*handler.CreateMediaHandler)
 	getMediaContent := ctn.GetService("GetMediaContentHandler").(*handler.GetMediaContentHandler)
+	getMediaInfo := ctn.GetService("GetMediaInfoHandler").(*handler.GetMediaInfoHandler)
+	deleteMedia := ctn.GetService("DeleteMediaHandler").(*handler.DeleteMediaHandler)
 
 	app := core.NewApp()
 	app.AddHealthCheck(database.NewHealthCheck(db))
@@ -33,6 +44,8 @@ func main() {
 
 	app.Post("/media", createMedia)
 	app.Get("/media/content/{referenceID}", getMediaContent)
+	app.Get("/media/info/{referenceID}", getMediaInfo)
+	app.Post("/media/delete", deleteMedia)
 
 	go app.Serve()
 
@@ -45,9 +58,76 @@ func main() {
 	log.Println("App stopped.")
 }
 
-func buildServices(ctx context.Context) *core.Container {
+// Config is a configuration model for the service.
+type Config struct {
+	Storage *StorageConfig  `json:"storage"`
+	Images  *imaging.Config `json:"images"`
+}
+
+// StorageConfig contains config for the store media content is kept in. Type is
+// either "gcp", "filesystem" or "s3". The gcp and s3 stores use the bucket named
+// by the MEDIA_BUCKET environment variable, and s3 credentials are read from the
+// S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY environment variables. A filesystem
+// store keeps content under Root.
+type StorageConfig struct {
+	Type string    `json:"type"`
+	Root string    `json:"root"`
+	S3   *S3Config `json:"s3"`
+}
+
+// S3Config contains config for an S3-compatible store, such as MinIO, at Endpoint.
+type S3Config struct {
+	Endpoint string `json:"endpoint"`
+	Region   string `json:"region"`
+}
+
+func buildConfig() *Config {
+	filename := util.ReadEnv(configFileVar, "config.json")
+
+	var cnf Config
+	err := core.ReadConfig(filename, &cnf)
+	if err != nil {
+		panic(fmt.Errorf("failed to read config: %v", err))
+	}
+
+	return &cnf
+}
+
+// newMediaService returns the media.Service for the configured store.
+func newMediaService(ctx context.Context, cnf *StorageConfig) (media.Service, error) {
+	if cnf == nil {
+		cnf = &StorageConfig{}
+	}
+
+	switch cnf.Type {
+	case "gcp", "":
+		return gcp.New(ctx, os.Getenv(mediaBucketVar))
+	case "filesystem":
+		return filesystem.New(cnf.Root)
+	case "s3":
+		if cnf.S3 == nil {
+			return nil, fmt.Errorf("s3 config is required for an s3 store")
+		}
+
+		return s3.New(&s3.Config{
+			Endpoint:        cnf.S3.Endpoint,
+			Region:          cnf.S3.Region,
+			Bucket:          os.Getenv(mediaBucketVar),
+			AccessKeyID:     os.Getenv(s3AccessKeyIDVar),
+			SecretAccessKey: os.Getenv(s3SecretKeyVar),
+		})
+	default:
+		return nil, fmt.Errorf("unsupported storage type: %s", cnf.Type)
+	}
+}
+
+func buildServices(ctx context.Context, cnf *Config) *core.Container {
 	ctn := core.NewContainer()
 
+	ctn.AddSingleton("Config", func(ctn *core.Container) interface{} {
+		return cnf
+	})
+
 	ctn.AddSingleton("Database", func(ctn *core.Container) interface{} {
 		url := os.Getenv(connectionStringVar)
 		db, err := database.New(url)
@@ -63,27 +143,58 @@ func buildServices(ctx context.Context) *core.Container {
 		return repository.NewMediaRepository(url)
 	})
 
-	ctn.AddService("MediaService", func(ctn *core.Container) interface{} {
-		uploader, err := gcp.New(ctx, os.Getenv(mediaBucketVar))
+	ctn.AddSingleton("MediaService", func(ctn *core.Container) interface{} {
+		cnf := ctn.GetService("Config").(*Config)
+		service, err := newMediaService(ctx, cnf.Storage)
 		if err != nil {
-			panic(err)
+			panic(fmt.Errorf("failed to init media service: %v", err))
+		}
+
+		return service
+	})
+
+	ctn.AddSingleton("ImageProcessor", func(ctn *core.Container) interface{} {
+		cnf := ctn.GetService("Config").(*Config)
+		if cnf.Images == nil {
+			panic("images config is required")
+		}
+
+		images, err := imaging.NewProcessor(cnf.Images)
+		if err != nil {
+			panic(fmt.Errorf("invalid images config: %v", err))
 		}
 
-		return uploader
+		return images
 	})
 
 	ctn.AddService("CreateMediaHandler", func(ctn *core.Container) interface{} {
 		repo := ctn.GetService("MediaRepository").(repository.MediaRepository)
 		uploader := ctn.GetService("MediaService").(media.Service)
+		images := ctn.GetService("ImageProcessor").(*imaging.Processor)
 
-		return handler.NewCreateMediaHandler(repo, uploader)
+		return handler.NewCreateMediaHandler(repo, uploader, images)
 	})
 
 	ctn.AddService("GetMediaContentHandler", func(ctn *core.Container) interface{} {
 		repo := ctn.GetService("MediaRepository").(repository.MediaRepository)
 		downloader := ctn.GetService("MediaService").(media.Service)
+		images := ctn.GetService("ImageProcessor").(*imaging.Processor)
+
+		return handler.NewGetMediaContentHandler(repo, downloader, images.DefaultRendition())
+	})
+
+	ctn.AddService("GetMediaInfoHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("MediaRepository").(repository.MediaRepository)
+		images := ctn.GetService("ImageProcessor").(*imaging.Processor)
+
+		return handler.NewGetMediaInfoHandler(repo, images.DefaultRendition())
+	})
+
+	ctn.AddService("DeleteMediaHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("MediaRepository").(repository.MediaRepository)
+		service := ctn.GetService("MediaService").(media.Service)
 
-		return handler.NewGetMediaContentHandler(repo, downloader)
+		return handler.NewDeleteMediaHandler(repo, service)
 	})
 
 	return ctn
@@ -35,12 +35,11 @@ func (m *MockMediaRepository) EXPECT() *MockMediaRepositoryMockRecorder {
 }
 
 // Create mocks base method.
-func (m_2 *MockMediaRepository) Create(ctx context.Context, m *model.Media) (func(bool), error) {
+func (m_2 *MockMediaRepository) Create(ctx context.Context, m *model.Media) error {
 	m_2.ctrl.T.Helper()
 	ret := m_2.ctrl.Call(m_2, "Create", ctx, m)
-	ret0, _ := ret[0].(func(bool))
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Create indicates an expected call of Create.
@@ -49,17 +48,31 @@ func (mr *MockMediaRepositoryMockRecorder) Create(ctx, m interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMediaRepository)(nil).Create), ctx, m)
 }
 
-// GetContentType mocks base method.
-func (m *MockMediaRepository) GetContentType(ctx context.Context, referenceID string) (string, error) {
+// Get mocks base method.
+func (m *MockMediaRepository) Get(ctx context.Context, referenceID string) (*model.Media, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContentType", ctx, referenceID)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "Get", ctx, referenceID)
+	ret0, _ := ret[0].(*model.Media)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetContentType indicates an expected call of GetContentType.
-func (mr *MockMediaRepositoryMockRecorder) GetContentType(ctx, referenceID interface{}) *gomock.Call {
+// Get indicates an expected call of Get.
+func (mr *MockMediaRepositoryMockRecorder) Get(ctx, referenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMediaRepository)(nil).Get), ctx, referenceID)
+}
+
+// Delete mocks base method.
+func (m *MockMediaRepository) Delete(ctx context.Context, referenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, referenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMediaRepositoryMockRecorder) Delete(ctx, referenceID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContentType", reflect.TypeOf((*MockMediaRepository)(nil).GetContentType), ctx, referenceID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMediaRepository)(nil).Delete), ctx, referenceID)
 }
@@ -4,10 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/reecerussell/open-social/cmd/media/dao"
 )
 
+// ErrRenditionNotFound is returned when media has no rendition of a given name.
+var ErrRenditionNotFound = errors.New("media has no rendition of that size")
+
 var validContentTypes = [...]string{
 	"image/jpeg",
 	"image/png",
@@ -18,11 +24,29 @@ type Media struct {
 	id          int
 	referenceID string
 	contentType string
+	uploaded    time.Time
+	renditions  []*Rendition
+}
+
+// Rendition is a size of a Media's content, which is stored under its own key.
+// Its size, in bytes, and ETag, a hash of its content, are recorded once it's
+// been uploaded.
+type Rendition struct {
+	name   string
+	width  int
+	height int
+	size   int64
+	etag   string
 }
 
-// NewMedia returns a new instance of the Media domain model.
+// NewMedia returns a new instance of the Media domain model, with a new
+// reference id, so its content can be stored before the media is.
 func NewMedia(contentType string) (*Media, error) {
-	m := &Media{}
+	m := &Media{
+		// Upper case, to match the reference ids of media created by the database.
+		referenceID: strings.ToUpper(uuid.New().String()),
+		uploaded:    time.Now().UTC(),
+	}
 
 	err := m.setContentType(contentType)
 	if err != nil {
@@ -42,6 +66,81 @@ func (m *Media) ReferenceID() string {
 	return m.referenceID
 }
 
+// ContentType returns the media's content type.
+func (m *Media) ContentType() string {
+	return m.contentType
+}
+
+// Uploaded returns the time the media was uploaded. As media is never
+// changed, this is also the time it was last modified.
+func (m *Media) Uploaded() time.Time {
+	return m.uploaded
+}
+
+// Renditions returns the media's renditions.
+func (m *Media) Renditions() []*Rendition {
+	return m.renditions
+}
+
+// AddRendition records a rendition of the media's content, with the given dimensions.
+func (m *Media) AddRendition(name string, width, height int) {
+	m.renditions = append(m.renditions, &Rendition{
+		name:   name,
+		width:  width,
+		height: height,
+	})
+}
+
+// ContentKey returns the key the named rendition's content is stored under. Media
+// uploaded before renditions were introduced only has its original content, which
+// is served for every size. ErrRenditionNotFound is returned for an unknown rendition.
+func (m *Media) ContentKey(rendition string) (string, error) {
+	r, err := m.Rendition(rendition)
+	if err != nil {
+		return "", err
+	}
+
+	if r == nil {
+		return m.referenceID, nil
+	}
+
+	return m.renditionKey(r), nil
+}
+
+// Rendition returns the named rendition, or ErrRenditionNotFound if the media doesn't
+// have it. Nil is returned for media uploaded before renditions were introduced.
+func (m *Media) Rendition(name string) (*Rendition, error) {
+	if len(m.renditions) == 0 {
+		return nil, nil
+	}
+
+	for _, r := range m.renditions {
+		if r.name == name {
+			return r, nil
+		}
+	}
+
+	return nil, ErrRenditionNotFound
+}
+
+// ContentKeys returns the keys all of the media's content is stored under.
+func (m *Media) ContentKeys() []string {
+	if len(m.renditions) == 0 {
+		return []string{m.referenceID}
+	}
+
+	keys := make([]string, len(m.renditions))
+	for i, r := range m.renditions {
+		keys[i] = m.renditionKey(r)
+	}
+
+	return keys
+}
+
+func (m *Media) renditionKey(r *Rendition) string {
+	return m.referenceID + "/" + r.name
+}
+
 func (m *Media) setContentType(contentType string) error {
 	if contentType == "" {
 		return errors.New("contentType is a required field")
@@ -71,9 +170,79 @@ func (m *Media) SetReferenceID(referenceID string) {
 
 // Dao returns a data access object for the media instance.
 func (m *Media) Dao() *dao.Media {
+	renditions := make([]*dao.Rendition, len(m.renditions))
+	for i, r := range m.renditions {
+		renditions[i] = &dao.Rendition{
+			Name:   r.name,
+			Width:  r.width,
+			Height: r.height,
+			Size:   r.size,
+			ETag:   r.etag,
+		}
+	}
+
 	return &dao.Media{
 		ID:          m.id,
 		ReferenceID: m.referenceID,
 		ContentType: m.contentType,
+		Uploaded:    m.uploaded,
+		Renditions:  renditions,
+	}
+}
+
+// MediaFromDao returns a new instance of Media, populated with the
+// data from the data access object. This should only be used
+// by the MediaRepository, to instantiate new domain models.
+func MediaFromDao(d *dao.Media) *Media {
+	m := &Media{
+		id:          d.ID,
+		referenceID: d.ReferenceID,
+		contentType: d.ContentType,
+		uploaded:    d.Uploaded,
+	}
+
+	for _, r := range d.Renditions {
+		m.renditions = append(m.renditions, &Rendition{
+			name:   r.Name,
+			width:  r.Width,
+			height: r.Height,
+			size:   r.Size,
+			etag:   r.ETag,
+		})
 	}
+
+	return m
+}
+
+// Name returns the rendition's name.
+func (r *Rendition) Name() string {
+	return r.name
+}
+
+// Width returns the rendition's width, in pixels.
+func (r *Rendition) Width() int {
+	return r.width
+}
+
+// Height returns the rendition's height, in pixels.
+func (r *Rendition) Height() int {
+	return r.height
+}
+
+// Size returns the size of the rendition's content, in bytes, or
+// zero if it was uploaded before sizes were recorded.
+func (r *Rendition) Size() int64 {
+	return r.size
+}
+
+// ETag returns a hash of the rendition's content, or an empty
+// string if it was uploaded before hashes were recorded.
+func (r *Rendition) ETag() string {
+	return r.etag
+}
+
+// SetContent records the size and hash of the rendition's content, once it's been uploaded.
+func (r *Rendition) SetContent(size int64, etag string) {
+	r.size = size
+	r.etag = etag
 }
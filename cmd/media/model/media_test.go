@@ -2,9 +2,13 @@ package model
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/media/dao"
 )
 
 func TestNewMedia(t *testing.T) {
@@ -13,6 +17,9 @@ func TestNewMedia(t *testing.T) {
 	media, err := NewMedia(testContentType)
 	assert.NoError(t, err)
 	assert.Equal(t, testContentType, media.contentType)
+	assert.Len(t, media.referenceID, 36)
+	assert.Equal(t, strings.ToUpper(media.referenceID), media.referenceID)
+	assert.WithinDuration(t, time.Now(), media.Uploaded(), time.Minute)
 }
 
 func TestNewMedia_GivenInvalidData_ReturnsError(t *testing.T) {
@@ -75,12 +82,74 @@ func TestMedia_Dao(t *testing.T) {
 		referenceID: "2913",
 		contentType: "image/jpeg",
 	}
+	m.AddRendition("thumbnail", 150, 100)
+	m.Renditions()[0].SetContent(2048, "ab12")
 
 	d := m.Dao()
 
 	assert.Equal(t, 123, d.ID)
 	assert.Equal(t, "2913", d.ReferenceID)
 	assert.Equal(t, "image/jpeg", d.ContentType)
+	assert.Equal(t, []*dao.Rendition{{Name: "thumbnail", Width: 150, Height: 100, Size: 2048, ETag: "ab12"}}, d.Renditions)
+}
+
+func TestMediaFromDao(t *testing.T) {
+	d := &dao.Media{
+		ID:          123,
+		ReferenceID: "2913",
+		ContentType: "image/jpeg",
+		Uploaded:    time.Date(2021, 3, 4, 12, 0, 0, 0, time.UTC),
+		Renditions: []*dao.Rendition{
+			{Name: "thumbnail", Width: 150, Height: 100, Size: 2048, ETag: "ab12"},
+		},
+	}
+
+	m := MediaFromDao(d)
+
+	assert.Equal(t, 123, m.ID())
+	assert.Equal(t, "2913", m.ReferenceID())
+	assert.Equal(t, "image/jpeg", m.ContentType())
+	assert.Len(t, m.Renditions(), 1)
+	assert.Equal(t, "thumbnail", m.Renditions()[0].Name())
+	assert.Equal(t, 150, m.Renditions()[0].Width())
+	assert.Equal(t, 100, m.Renditions()[0].Height())
+	assert.Equal(t, int64(2048), m.Renditions()[0].Size())
+	assert.Equal(t, "ab12", m.Renditions()[0].ETag())
+	assert.Equal(t, d.Uploaded, m.Uploaded())
+}
+
+func TestMedia_ContentKey(t *testing.T) {
+	m := &Media{referenceID: "2913"}
+	m.AddRendition("thumbnail", 150, 100)
+	m.AddRendition("full", 1500, 1000)
+
+	key, err := m.ContentKey("full")
+	assert.NoError(t, err)
+	assert.Equal(t, "2913/full", key)
+
+	key, err = m.ContentKey("feed")
+	assert.Empty(t, key)
+	assert.Equal(t, ErrRenditionNotFound, err)
+
+	r, err := m.Rendition("thumbnail")
+	assert.NoError(t, err)
+	assert.Equal(t, 150, r.Width())
+
+	assert.Equal(t, []string{"2913/thumbnail", "2913/full"}, m.ContentKeys())
+}
+
+func TestMedia_ContentKey_WithoutRenditions_ReturnsOriginal(t *testing.T) {
+	m := &Media{referenceID: "2913"}
+
+	key, err := m.ContentKey("full")
+	assert.NoError(t, err)
+	assert.Equal(t, "2913", key)
+
+	r, err := m.Rendition("full")
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+
+	assert.Equal(t, []string{"2913"}, m.ContentKeys())
 }
 
 func TestMedia_ID(t *testing.T) {
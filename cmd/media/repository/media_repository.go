@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/reecerussell/open-social/cmd/media/dao"
 	"github.com/reecerussell/open-social/cmd/media/model"
 
 	// MSSQL driver
@@ -18,8 +19,9 @@ var (
 
 // MediaRepository is used to interface with the media data store.
 type MediaRepository interface {
-	Create(ctx context.Context, m *model.Media) (func(bool), error)
-	GetContentType(ctx context.Context, referenceID string) (string, error)
+	Create(ctx context.Context, m *model.Media) error
+	Get(ctx context.Context, referenceID string) (*model.Media, error)
+	Delete(ctx context.Context, referenceID string) error
 }
 
 type mediaRepository struct {
@@ -31,82 +33,137 @@ func NewMediaRepository(url string) MediaRepository {
 	return &mediaRepository{url: url}
 }
 
-func (r *mediaRepository) Create(ctx context.Context, m *model.Media) (func(bool), error) {
+// Create inserts the media, along with its renditions.
+func (r *mediaRepository) Create(ctx context.Context, m *model.Media) error {
 	db, err := sql.Open("sqlserver", r.url)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadUncommitted})
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer tx.Rollback()
 
-	const query = `INSERT INTO [Media] ([ReferenceId],[ContentType])
-					VALUES (NEWID(), @contentType)
-				SELECT [Id], CAST([ReferenceId] AS CHAR(36)) FROM [Media] WHERE [Id] = SCOPE_IDENTITY()`
+	const query = `INSERT INTO [Media] ([ReferenceId],[ContentType],[Uploaded])
+					VALUES (@referenceId, @contentType, @uploaded)
+				SELECT CAST(SCOPE_IDENTITY() AS INT)`
 
-	stmt, err := tx.PrepareContext(ctx, query)
+	media := m.Dao()
+	row := tx.QueryRowContext(ctx, query,
+		sql.Named("referenceId", media.ReferenceID),
+		sql.Named("contentType", media.ContentType),
+		sql.Named("uploaded", media.Uploaded))
+
+	// Read the media's id
+	err = row.Scan(&media.ID)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer stmt.Close()
 
-	media := m.Dao()
-	row := stmt.QueryRowContext(ctx,
-		sql.Named("contentType", media.ContentType))
+	const renditionQuery = `INSERT INTO [MediaRenditions] ([MediaId],[Name],[Width],[Height],[Size],[ETag])
+					VALUES (@mediaId, @name, @width, @height, @size, @etag);`
+
+	for _, r := range media.Renditions {
+		_, err = tx.ExecContext(ctx, renditionQuery,
+			sql.Named("mediaId", media.ID),
+			sql.Named("name", r.Name),
+			sql.Named("width", r.Width),
+			sql.Named("height", r.Height),
+			sql.Named("size", r.Size),
+			sql.Named("etag", r.ETag))
+		if err != nil {
+			return err
+		}
+	}
 
-	// Read the media's ids
-	err = row.Scan(&media.ID, &media.ReferenceID)
+	err = tx.Commit()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// Set the media's ids
 	m.SetID(media.ID)
-	m.SetReferenceID(media.ReferenceID)
 
-	return save(tx), nil
+	return nil
 }
 
-func save(tx *sql.Tx) func(bool) {
-	return func(save bool) {
-		var err error
-		if save {
-			err = tx.Commit()
-		} else {
-			err = tx.Rollback()
-		}
+// Get returns the media with the given reference id, along with its renditions.
+func (r *mediaRepository) Get(ctx context.Context, referenceID string) (*model.Media, error) {
+	db, err := sql.Open("sqlserver", r.url)
+	if err != nil {
+		return nil, err
+	}
+
+	const query = `SELECT [M].[Id], CAST([M].[ReferenceId] AS CHAR(36)), [M].[ContentType], [M].[Uploaded],
+						[R].[Name], [R].[Width], [R].[Height], [R].[Size], [R].[ETag]
+					FROM [Media] AS [M]
+					LEFT JOIN [MediaRenditions] AS [R] ON [R].[MediaId] = [M].[Id]
+					WHERE [M].[ReferenceId] = @referenceId;`
 
+	rows, err := db.QueryContext(ctx, query, sql.Named("referenceId", referenceID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var media *dao.Media
+	for rows.Next() {
+		var d dao.Media
+		var name, etag sql.NullString
+		var width, height, size sql.NullInt64
+
+		err = rows.Scan(&d.ID, &d.ReferenceID, &d.ContentType, &d.Uploaded,
+			&name, &width, &height, &size, &etag)
 		if err != nil {
-			panic(err)
+			return nil, err
+		}
+
+		if media == nil {
+			media = &d
 		}
+
+		if name.Valid {
+			media.Renditions = append(media.Renditions, &dao.Rendition{
+				Name:   name.String,
+				Width:  int(width.Int64),
+				Height: int(height.Int64),
+				Size:   size.Int64,
+				ETag:   etag.String,
+			})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if media == nil {
+		return nil, ErrMediaNotFound
 	}
+
+	return model.MediaFromDao(media), nil
 }
 
-func (r *mediaRepository) GetContentType(ctx context.Context, referenceID string) (string, error) {
+// Delete deletes the media record with the given reference id, and its renditions.
+// Deleting media which doesn't exist isn't an error.
+func (r *mediaRepository) Delete(ctx context.Context, referenceID string) error {
 	db, err := sql.Open("sqlserver", r.url)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	const query = `SELECT [ContentType] FROM [Media] WHERE [ReferenceId] = @referenceId;`
+	const query = `DELETE [R] FROM [MediaRenditions] AS [R]
+						INNER JOIN [Media] AS [M] ON [M].[Id] = [R].[MediaId]
+						WHERE [M].[ReferenceId] = @referenceId;
+					DELETE FROM [Media] WHERE [ReferenceId] = @referenceId;`
 
 	stmt, err := db.PrepareContext(ctx, query)
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer stmt.Close()
 
-	var contentType string
-	err = stmt.QueryRowContext(ctx, sql.Named("referenceId", referenceID)).Scan(&contentType)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return "", ErrMediaNotFound
-		}
-
-		return "", err
-	}
-
-	return contentType, nil
+	_, err = stmt.ExecContext(ctx, sql.Named("referenceId", referenceID))
+	return err
 }
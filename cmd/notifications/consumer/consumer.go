@@ -0,0 +1,62 @@
+// Package consumer contains the event handlers which create notifications
+// from the events published by the posts and users services.
+package consumer
+
+import (
+	"context"
+
+	"github.com/reecerussell/open-social/cmd/notifications/model"
+	"github.com/reecerussell/open-social/cmd/notifications/repository"
+	"github.com/reecerussell/open-social/eventing"
+)
+
+type handlers struct {
+	repo repository.NotificationRepository
+}
+
+// Register registers the notification event handlers with the consumer.
+func Register(c *eventing.Consumer, repo repository.NotificationRepository) {
+	h := &handlers{repo: repo}
+
+	c.Handle(eventing.UserFollowed, h.userFollowed)
+	c.Handle(eventing.FollowRequested, h.followRequested)
+	c.Handle(eventing.FollowRequestApproved, h.followRequestApproved)
+	c.Handle(eventing.PostLiked, h.postLiked)
+	c.Handle(eventing.CommentCreated, h.commentCreated)
+}
+
+func (h *handlers) userFollowed(ctx context.Context, event *eventing.Event) error {
+	payload := event.Payload.(*eventing.UserFollowedPayload)
+	n := model.NewFollowNotification(event.ID, payload.FollowerReferenceID, payload.UserReferenceID)
+
+	return h.repo.Create(ctx, n)
+}
+
+func (h *handlers) followRequested(ctx context.Context, event *eventing.Event) error {
+	payload := event.Payload.(*eventing.FollowRequestedPayload)
+	n := model.NewFollowRequestNotification(event.ID, payload.FollowerReferenceID, payload.UserReferenceID)
+
+	return h.repo.Create(ctx, n)
+}
+
+// followRequestApproved notifies the follower, so the roles are the reverse of a follow.
+func (h *handlers) followRequestApproved(ctx context.Context, event *eventing.Event) error {
+	payload := event.Payload.(*eventing.FollowRequestApprovedPayload)
+	n := model.NewFollowApprovedNotification(event.ID, payload.UserReferenceID, payload.FollowerReferenceID)
+
+	return h.repo.Create(ctx, n)
+}
+
+func (h *handlers) postLiked(ctx context.Context, event *eventing.Event) error {
+	payload := event.Payload.(*eventing.PostLikedPayload)
+	n := model.NewLikeNotification(event.ID, payload.UserReferenceID, payload.PostReferenceID)
+
+	return h.repo.Create(ctx, n)
+}
+
+func (h *handlers) commentCreated(ctx context.Context, event *eventing.Event) error {
+	payload := event.Payload.(*eventing.CommentCreatedPayload)
+	n := model.NewCommentNotification(event.ID, payload.UserReferenceID, payload.PostReferenceID)
+
+	return h.repo.Create(ctx, n)
+}
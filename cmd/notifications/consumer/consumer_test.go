@@ -0,0 +1,190 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock "github.com/reecerussell/open-social/cmd/notifications/mock/repository"
+	"github.com/reecerussell/open-social/cmd/notifications/model"
+	"github.com/reecerussell/open-social/eventing"
+	"github.com/reecerussell/open-social/eventing/memory"
+)
+
+const (
+	testTopic   = "posts"
+	testGroupID = "notifications"
+)
+
+var testRetryPolicy = eventing.RetryPolicy{
+	MaxAttempts:    2,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     time.Millisecond,
+}
+
+// consume publishes event, then runs a consumer with the handlers registered
+// until the event has been committed.
+func consume(t *testing.T, repo *mock.MockNotificationRepository, event *eventing.Event) *memory.Broker {
+	broker := memory.NewBroker()
+	err := broker.Publisher(testTopic).Publish(context.Background(), "key", event)
+	assert.NoError(t, err)
+
+	sub := broker.Subscriber(testTopic, testGroupID)
+	c := eventing.NewConsumer(sub, broker.Publisher(eventing.DeadLetterTopic(testTopic)), testRetryPolicy)
+	Register(c, repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+
+	deadline := time.Now().Add(time.Second)
+	for broker.Committed(testTopic, testGroupID) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the event to be committed")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+
+	return broker
+}
+
+func TestUserFollowed_CreatesFollowNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := eventing.NewEvent(eventing.UserFollowed, &eventing.UserFollowedPayload{
+		UserReferenceID:     "user",
+		FollowerReferenceID: "follower",
+	})
+
+	mockRepo := mock.NewMockNotificationRepository(ctrl)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, n *model.Notification) error {
+		d := n.Dao()
+		assert.Equal(t, model.TypeFollow, d.Type)
+		assert.Equal(t, event.ID, d.IdempotencyKey)
+		assert.Equal(t, "follower", d.ActorReferenceID)
+		assert.Equal(t, "user", d.UserReferenceID)
+
+		return nil
+	})
+
+	consume(t, mockRepo, event)
+}
+
+func TestFollowRequested_CreatesFollowRequestNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := eventing.NewEvent(eventing.FollowRequested, &eventing.FollowRequestedPayload{
+		UserReferenceID:     "user",
+		FollowerReferenceID: "follower",
+	})
+
+	mockRepo := mock.NewMockNotificationRepository(ctrl)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, n *model.Notification) error {
+		d := n.Dao()
+		assert.Equal(t, model.TypeFollowRequest, d.Type)
+		assert.Equal(t, event.ID, d.IdempotencyKey)
+		assert.Equal(t, "follower", d.ActorReferenceID)
+		assert.Equal(t, "user", d.UserReferenceID)
+
+		return nil
+	})
+
+	consume(t, mockRepo, event)
+}
+
+func TestFollowRequestApproved_NotifiesFollower(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := eventing.NewEvent(eventing.FollowRequestApproved, &eventing.FollowRequestApprovedPayload{
+		UserReferenceID:     "user",
+		FollowerReferenceID: "follower",
+	})
+
+	mockRepo := mock.NewMockNotificationRepository(ctrl)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, n *model.Notification) error {
+		d := n.Dao()
+		assert.Equal(t, model.TypeFollowApproved, d.Type)
+		assert.Equal(t, event.ID, d.IdempotencyKey)
+		assert.Equal(t, "user", d.ActorReferenceID)
+		assert.Equal(t, "follower", d.UserReferenceID)
+
+		return nil
+	})
+
+	consume(t, mockRepo, event)
+}
+
+func TestPostLiked_CreatesLikeNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := eventing.NewEvent(eventing.PostLiked, &eventing.PostLikedPayload{
+		PostReferenceID: "post",
+		UserReferenceID: "user",
+	})
+
+	mockRepo := mock.NewMockNotificationRepository(ctrl)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, n *model.Notification) error {
+		d := n.Dao()
+		assert.Equal(t, model.TypeLike, d.Type)
+		assert.Equal(t, event.ID, d.IdempotencyKey)
+		assert.Equal(t, "user", d.ActorReferenceID)
+		assert.Equal(t, "post", d.PostReferenceID)
+
+		return nil
+	})
+
+	consume(t, mockRepo, event)
+}
+
+func TestCommentCreated_CreatesCommentNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := eventing.NewEvent(eventing.CommentCreated, &eventing.CommentCreatedPayload{
+		CommentReferenceID: "comment",
+		PostReferenceID:    "post",
+		UserReferenceID:    "user",
+		Text:               "Nice post!",
+	})
+
+	mockRepo := mock.NewMockNotificationRepository(ctrl)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, n *model.Notification) error {
+		d := n.Dao()
+		assert.Equal(t, model.TypeComment, d.Type)
+		assert.Equal(t, event.ID, d.IdempotencyKey)
+		assert.Equal(t, "user", d.ActorReferenceID)
+		assert.Equal(t, "post", d.PostReferenceID)
+
+		return nil
+	})
+
+	consume(t, mockRepo, event)
+}
+
+func TestPostLiked_RepoFails_DeadLettersEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := eventing.NewEvent(eventing.PostLiked, &eventing.PostLikedPayload{
+		PostReferenceID: "post",
+		UserReferenceID: "user",
+	})
+
+	mockRepo := mock.NewMockNotificationRepository(ctrl)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("an error occured")).Times(testRetryPolicy.MaxAttempts)
+
+	broker := consume(t, mockRepo, event)
+	assert.Equal(t, 1, len(broker.Messages(eventing.DeadLetterTopic(testTopic))))
+}
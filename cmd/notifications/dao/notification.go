@@ -0,0 +1,16 @@
+package dao
+
+import "time"
+
+// Notification is a data access object for the notification domain.
+type Notification struct {
+	ID               int
+	ReferenceID      string
+	IdempotencyKey   string
+	Type             string
+	UserReferenceID  string
+	ActorReferenceID string
+	PostReferenceID  string
+	Created          time.Time
+	IsRead           bool
+}
@@ -0,0 +1,7 @@
+package dto
+
+// MarkRead is a data transfer object used to mark a user's notifications as read.
+// If IDs is empty, all of the user's notifications are marked as read.
+type MarkRead struct {
+	IDs []string `json:"ids"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+// Notification is a data transfer object used to read a user's notification.
+type Notification struct {
+	ID       string    `json:"id"`
+	Type     string    `json:"type"`
+	Username string    `json:"username"`
+	PostID   *string   `json:"postId"`
+	Created  time.Time `json:"created"`
+	IsRead   bool      `json:"isRead"`
+}
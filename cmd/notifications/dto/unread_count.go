@@ -0,0 +1,6 @@
+package dto
+
+// UnreadCount is a data transfer object used to read the number of unread notifications a user has.
+type UnreadCount struct {
+	Count int `json:"count"`
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/notifications/repository"
+)
+
+// DeleteUserNotificationsHandler is a http.Handler used to delete all of the
+// notifications relating to a user, when their account is deleted.
+type DeleteUserNotificationsHandler struct {
+	core.Handler
+	repo repository.NotificationRepository
+}
+
+// NewDeleteUserNotificationsHandler returns a new instance of DeleteUserNotificationsHandler.
+func NewDeleteUserNotificationsHandler(repo repository.NotificationRepository) *DeleteUserNotificationsHandler {
+	return &DeleteUserNotificationsHandler{
+		repo: repo,
+	}
+}
+
+func (h *DeleteUserNotificationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	err := h.repo.DeleteForUser(r.Context(), userReferenceID)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, nil)
+}
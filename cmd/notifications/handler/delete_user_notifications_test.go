@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	mock "github.com/reecerussell/open-social/cmd/notifications/mock/repository"
+)
+
+func TestDeleteUserNotificationsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2398yhlwd"
+
+	mockRepo := mock.NewMockNotificationRepository(ctrl)
+	mockRepo.EXPECT().DeleteForUser(gomock.Any(), testUserReferenceID).Return(nil)
+
+	handler := NewDeleteUserNotificationsHandler(mockRepo)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler).Methods(http.MethodDelete)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/"+testUserReferenceID, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestDeleteUserNotificationsHandler_RepoReturnsError_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2398yhlwd"
+	testError := errors.New("an error occured")
+
+	mockRepo := mock.NewMockNotificationRepository(ctrl)
+	mockRepo.EXPECT().DeleteForUser(gomock.Any(), testUserReferenceID).Return(testError)
+
+	handler := NewDeleteUserNotificationsHandler(mockRepo)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler).Methods(http.MethodDelete)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/"+testUserReferenceID, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/notifications/provider"
+)
+
+// GetNotificationsHandler is a http.Handler used to get a user's notifications.
+type GetNotificationsHandler struct {
+	core.Handler
+	provider provider.NotificationProvider
+}
+
+// NewGetNotificationsHandler returns a new instance of GetNotificationsHandler.
+func NewGetNotificationsHandler(provider provider.NotificationProvider) *GetNotificationsHandler {
+	return &GetNotificationsHandler{
+		provider: provider,
+	}
+}
+
+func (h *GetNotificationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	ctx := r.Context()
+	notifications, err := h.provider.GetNotifications(ctx, userReferenceID)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, notifications)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/notifications/dto"
+	mock "github.com/reecerussell/open-social/cmd/notifications/mock/provider"
+)
+
+func TestGetNotificationsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2398yhlwd"
+	testPostID := "23894"
+
+	mockProvider := mock.NewMockNotificationProvider(ctrl)
+	mockProvider.EXPECT().GetNotifications(gomock.Any(), testUserReferenceID).Return([]*dto.Notification{
+		{
+			ID:       "23123",
+			Type:     "like",
+			Username: "User123",
+			PostID:   &testPostID,
+			Created:  time.Now(),
+			IsRead:   false,
+		},
+	}, nil)
+
+	handler := NewGetNotificationsHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler).Methods(http.MethodGet)
+
+	req, _ := http.NewRequest(http.MethodGet, "/"+testUserReferenceID, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.HeaderMap.Get("Content-Type"))
+
+	var data []map[string]interface{}
+	err := json.NewDecoder(rr.Body).Decode(&data)
+	if err != nil {
+		panic(err)
+	}
+
+	assert.Equal(t, 1, len(data))
+	assert.Equal(t, "23123", data[0]["id"])
+	assert.Equal(t, "like", data[0]["type"])
+	assert.Equal(t, "User123", data[0]["username"])
+	assert.Equal(t, testPostID, data[0]["postId"])
+	assert.Equal(t, false, data[0]["isRead"])
+}
+
+func TestGetNotificationsHandler_ProviderReturnsError_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2398yhlwd"
+	testError := errors.New("an error occured")
+
+	mockProvider := mock.NewMockNotificationProvider(ctrl)
+	mockProvider.EXPECT().GetNotifications(gomock.Any(), testUserReferenceID).Return(nil, testError)
+
+	handler := NewGetNotificationsHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler).Methods(http.MethodGet)
+
+	req, _ := http.NewRequest(http.MethodGet, "/"+testUserReferenceID, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "{\"message\":\"an error occured\"}\n", rr.Body.String())
+}
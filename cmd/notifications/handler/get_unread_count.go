@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/notifications/provider"
+)
+
+// GetUnreadCountHandler is a http.Handler used to get the number of unread notifications a user has.
+type GetUnreadCountHandler struct {
+	core.Handler
+	provider provider.NotificationProvider
+}
+
+// NewGetUnreadCountHandler returns a new instance of GetUnreadCountHandler.
+func NewGetUnreadCountHandler(provider provider.NotificationProvider) *GetUnreadCountHandler {
+	return &GetUnreadCountHandler{
+		provider: provider,
+	}
+}
+
+func (h *GetUnreadCountHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	ctx := r.Context()
+	count, err := h.provider.GetUnreadCount(ctx, userReferenceID)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, count)
+}
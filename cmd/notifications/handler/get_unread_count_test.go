@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/notifications/dto"
+	mock "github.com/reecerussell/open-social/cmd/notifications/mock/provider"
+)
+
+func TestGetUnreadCountHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2398yhlwd"
+
+	mockProvider := mock.NewMockNotificationProvider(ctrl)
+	mockProvider.EXPECT().GetUnreadCount(gomock.Any(), testUserReferenceID).Return(&dto.UnreadCount{Count: 3}, nil)
+
+	handler := NewGetUnreadCountHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}/unread", handler).Methods(http.MethodGet)
+
+	req, _ := http.NewRequest(http.MethodGet, "/"+testUserReferenceID+"/unread", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "{\"count\":3}\n", rr.Body.String())
+}
+
+func TestGetUnreadCountHandler_ProviderReturnsError_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2398yhlwd"
+	testError := errors.New("an error occured")
+
+	mockProvider := mock.NewMockNotificationProvider(ctrl)
+	mockProvider.EXPECT().GetUnreadCount(gomock.Any(), testUserReferenceID).Return(nil, testError)
+
+	handler := NewGetUnreadCountHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}/unread", handler).Methods(http.MethodGet)
+
+	req, _ := http.NewRequest(http.MethodGet, "/"+testUserReferenceID+"/unread", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "{\"message\":\"an error occured\"}\n", rr.Body.String())
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/notifications/dto"
+	"github.com/reecerussell/open-social/cmd/notifications/repository"
+)
+
+// MaxMarkReadIDs is the most notifications which can be marked as read by id in one request,
+// keeping the query well within the number of parameters SQL Server allows.
+const MaxMarkReadIDs = 100
+
+// Errors returned for invalid ids.
+var (
+	ErrTooManyIDs = fmt.Errorf("no more than %d notifications can be marked as read at once", MaxMarkReadIDs)
+	ErrInvalidID  = errors.New("notification ids must be valid guids")
+)
+
+// MarkReadHandler is a http.Handler used to mark a user's notifications as read.
+type MarkReadHandler struct {
+	core.Handler
+	repo repository.NotificationRepository
+}
+
+// NewMarkReadHandler returns a new instance of MarkReadHandler.
+func NewMarkReadHandler(repo repository.NotificationRepository) *MarkReadHandler {
+	return &MarkReadHandler{
+		repo: repo,
+	}
+}
+
+func (h *MarkReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	var data dto.MarkRead
+	err := json.NewDecoder(r.Body).Decode(&data)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if len(data.IDs) > MaxMarkReadIDs {
+		h.RespondError(w, ErrTooManyIDs, http.StatusBadRequest)
+		return
+	}
+
+	for _, id := range data.IDs {
+		if _, err := uuid.Parse(id); err != nil {
+			h.RespondError(w, ErrInvalidID, http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx := r.Context()
+	err = h.repo.MarkRead(ctx, userReferenceID, data.IDs)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, nil)
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	mock "github.com/reecerussell/open-social/cmd/notifications/mock/repository"
+)
+
+func TestMarkReadHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2398yhlwd"
+
+	mockRepo := mock.NewMockNotificationRepository(ctrl)
+	testIDs := []string{"9d3a4c1e-0b5f-4e7a-8c2d-1f6b3a9e7d40", "2f8e6b1a-7c4d-4a9e-b3f5-0d1c8e2a6b97"}
+	mockRepo.EXPECT().MarkRead(gomock.Any(), testUserReferenceID, testIDs).Return(nil)
+
+	handler := NewMarkReadHandler(mockRepo)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}/read", handler).Methods(http.MethodPost)
+
+	body, _ := json.Marshal(map[string]interface{}{"ids": testIDs})
+	req, _ := http.NewRequest(http.MethodPost, "/"+testUserReferenceID+"/read", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestMarkReadHandler_InvalidBody_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewMarkReadHandler(nil)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}/read", handler).Methods(http.MethodPost)
+
+	body := bytes.NewBufferString(`{"ids":`)
+	req, _ := http.NewRequest(http.MethodPost, "/2398yhlwd/read", body)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestMarkReadHandler_RepoReturnsError_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2398yhlwd"
+	testError := errors.New("an error occured")
+
+	mockRepo := mock.NewMockNotificationRepository(ctrl)
+	mockRepo.EXPECT().MarkRead(gomock.Any(), testUserReferenceID, []string{}).Return(testError)
+
+	handler := NewMarkReadHandler(mockRepo)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}/read", handler).Methods(http.MethodPost)
+
+	body := bytes.NewBufferString(`{"ids":[]}`)
+	req, _ := http.NewRequest(http.MethodPost, "/"+testUserReferenceID+"/read", body)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "{\"message\":\"an error occured\"}\n", rr.Body.String())
+}
+
+func TestMarkReadHandler_TooManyIDs_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ids := make([]string, MaxMarkReadIDs+1)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+
+	handler := NewMarkReadHandler(nil)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}/read", handler).Methods(http.MethodPost)
+
+	body, _ := json.Marshal(map[string]interface{}{"ids": ids})
+	req, _ := http.NewRequest(http.MethodPost, "/2398yhlwd/read", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", ErrTooManyIDs), rr.Body.String())
+}
+
+func TestMarkReadHandler_InvalidID_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewMarkReadHandler(nil)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}/read", handler).Methods(http.MethodPost)
+
+	body := bytes.NewBufferString(`{"ids":["9d3a4c1e-0b5f-4e7a-8c2d-1f6b3a9e7d40","1"]}`)
+	req, _ := http.NewRequest(http.MethodPost, "/2398yhlwd/read", body)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", ErrInvalidID), rr.Body.String())
+}
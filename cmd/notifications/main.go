@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/notifications/consumer"
+	"github.com/reecerussell/open-social/cmd/notifications/handler"
+	"github.com/reecerussell/open-social/cmd/notifications/provider"
+	"github.com/reecerussell/open-social/cmd/notifications/repository"
+	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/eventing"
+	"github.com/reecerussell/open-social/eventing/kafka"
+	"github.com/reecerussell/open-social/util"
+)
+
+const (
+	connectionStringVar = "CONNECTION_STRING"
+	kafkaHostVar        = "KAFKA_HOST"
+	kafkaGroupIDVar     = "KAFKA_GROUP_ID"
+	postsTopicVar       = "POSTS_TOPIC"
+	usersTopicVar       = "USERS_TOPIC"
+)
+
+func main() {
+	ctn := buildServices()
+	db := ctn.GetService("Database").(database.Database)
+
+	getNotifications := ctn.GetService("GetNotificationsHandler").(*handler.GetNotificationsHandler)
+	getUnreadCount := ctn.GetService("GetUnreadCountHandler").(*handler.GetUnreadCountHandler)
+	markRead := ctn.GetService("MarkReadHandler").(*handler.MarkReadHandler)
+	deleteUserNotifications := ctn.GetService("DeleteUserNotificationsHandler").(*handler.DeleteUserNotificationsHandler)
+
+	postsConsumer := ctn.GetService("PostsConsumer").(*eventing.Consumer)
+	usersConsumer := ctn.GetService("UsersConsumer").(*eventing.Consumer)
+
+	app := core.NewApp()
+	app.AddHealthCheck(database.NewHealthCheck(db))
+	app.AddHealthCheck(kafka.NewHealthCheck(os.Getenv(kafkaHostVar)))
+	app.AddHealthCheck(postsConsumer)
+	app.AddHealthCheck(usersConsumer)
+	app.AddMiddleware(core.NewLoggingMiddleware())
+
+	app.Get("/notifications/{userReferenceID}", getNotifications)
+	app.Get("/notifications/{userReferenceID}/unread", getUnreadCount)
+	app.Post("/notifications/{userReferenceID}/read", markRead)
+	app.Delete("/notifications/{userReferenceID}", deleteUserNotifications)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for _, c := range []*eventing.Consumer{postsConsumer, usersConsumer} {
+		wg.Add(1)
+		go func(c *eventing.Consumer) {
+			defer wg.Done()
+			_ = c.Run(ctx)
+		}(c)
+	}
+
+	go app.Serve()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, os.Kill)
+	<-stop
+
+	cancel()
+	wg.Wait()
+
+	for _, name := range []string{"PostsSubscriber", "UsersSubscriber", "PostsDeadLetterPublisher", "UsersDeadLetterPublisher"} {
+		err := ctn.GetService(name).(io.Closer).Close()
+		if err != nil {
+			log.Printf("Error: failed to close %s: %v\n", name, err)
+		}
+	}
+
+	log.Println("App stopped.")
+}
+
+func buildServices() *core.Container {
+	ctn := core.NewContainer()
+
+	ctn.AddSingleton("Database", func(ctn *core.Container) interface{} {
+		url := os.Getenv(connectionStringVar)
+		db, err := database.New(url)
+		if err != nil {
+			panic(err)
+		}
+
+		return db
+	})
+
+	ctn.AddSingleton("PostsSubscriber", func(ctn *core.Container) interface{} {
+		host := os.Getenv(kafkaHostVar)
+		topic := util.ReadEnv(postsTopicVar, "posts")
+		groupID := util.ReadEnv(kafkaGroupIDVar, "notifications")
+		return kafka.NewSubscriber(host, topic, groupID)
+	})
+
+	ctn.AddSingleton("UsersSubscriber", func(ctn *core.Container) interface{} {
+		host := os.Getenv(kafkaHostVar)
+		topic := util.ReadEnv(usersTopicVar, "users")
+		groupID := util.ReadEnv(kafkaGroupIDVar, "notifications")
+		return kafka.NewSubscriber(host, topic, groupID)
+	})
+
+	ctn.AddSingleton("PostsDeadLetterPublisher", func(ctn *core.Container) interface{} {
+		host := os.Getenv(kafkaHostVar)
+		topic := util.ReadEnv(postsTopicVar, "posts")
+		return kafka.NewPublisher(host, eventing.DeadLetterTopic(topic))
+	})
+
+	ctn.AddSingleton("UsersDeadLetterPublisher", func(ctn *core.Container) interface{} {
+		host := os.Getenv(kafkaHostVar)
+		topic := util.ReadEnv(usersTopicVar, "users")
+		return kafka.NewPublisher(host, eventing.DeadLetterTopic(topic))
+	})
+
+	ctn.AddSingleton("PostsConsumer", func(ctn *core.Container) interface{} {
+		sub := ctn.GetService("PostsSubscriber").(*kafka.Subscriber)
+		deadLetter := ctn.GetService("PostsDeadLetterPublisher").(*kafka.Publisher)
+		repo := ctn.GetService("NotificationRepository").(repository.NotificationRepository)
+
+		c := eventing.NewConsumer(sub, deadLetter, eventing.DefaultRetryPolicy)
+		consumer.Register(c, repo)
+
+		return c
+	})
+
+	ctn.AddSingleton("UsersConsumer", func(ctn *core.Container) interface{} {
+		sub := ctn.GetService("UsersSubscriber").(*kafka.Subscriber)
+		deadLetter := ctn.GetService("UsersDeadLetterPublisher").(*kafka.Publisher)
+		repo := ctn.GetService("NotificationRepository").(repository.NotificationRepository)
+
+		c := eventing.NewConsumer(sub, deadLetter, eventing.DefaultRetryPolicy)
+		consumer.Register(c, repo)
+
+		return c
+	})
+
+	ctn.AddService("NotificationRepository", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return repository.NewNotificationRepository(db)
+	})
+
+	ctn.AddService("NotificationProvider", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return provider.NewNotificationProvider(db)
+	})
+
+	ctn.AddService("GetNotificationsHandler", func(ctn *core.Container) interface{} {
+		provider := ctn.GetService("NotificationProvider").(provider.NotificationProvider)
+
+		return handler.NewGetNotificationsHandler(provider)
+	})
+
+	ctn.AddService("GetUnreadCountHandler", func(ctn *core.Container) interface{} {
+		provider := ctn.GetService("NotificationProvider").(provider.NotificationProvider)
+
+		return handler.NewGetUnreadCountHandler(provider)
+	})
+
+	ctn.AddService("MarkReadHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("NotificationRepository").(repository.NotificationRepository)
+
+		return handler.NewMarkReadHandler(repo)
+	})
+
+	ctn.AddService("DeleteUserNotificationsHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("NotificationRepository").(repository.NotificationRepository)
+
+		return handler.NewDeleteUserNotificationsHandler(repo)
+	})
+
+	return ctn
+}
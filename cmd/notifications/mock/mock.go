@@ -0,0 +1,4 @@
+//go:generate mockgen -package=mock -source=../repository/notification_repository.go -destination=repository/notification_repository.go
+//go:generate mockgen -package=mock -source=../provider/notification_provider.go -destination=provider/notification_provider.go
+
+package mock
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../provider/notification_provider.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/reecerussell/open-social/cmd/notifications/dto"
+	reflect "reflect"
+)
+
+// MockNotificationProvider is a mock of NotificationProvider interface.
+type MockNotificationProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationProviderMockRecorder
+}
+
+// MockNotificationProviderMockRecorder is the mock recorder for MockNotificationProvider.
+type MockNotificationProviderMockRecorder struct {
+	mock *MockNotificationProvider
+}
+
+// NewMockNotificationProvider creates a new mock instance.
+func NewMockNotificationProvider(ctrl *gomock.Controller) *MockNotificationProvider {
+	mock := &MockNotificationProvider{ctrl: ctrl}
+	mock.recorder = &MockNotificationProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationProvider) EXPECT() *MockNotificationProviderMockRecorder {
+	return m.recorder
+}
+
+// GetNotifications mocks base method.
+func (m *MockNotificationProvider) GetNotifications(ctx context.Context, userReferenceID string) ([]*dto.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, userReferenceID)
+	ret0, _ := ret[0].([]*dto.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockNotificationProviderMockRecorder) GetNotifications(ctx, userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockNotificationProvider)(nil).GetNotifications), ctx, userReferenceID)
+}
+
+// GetUnreadCount mocks base method.
+func (m *MockNotificationProvider) GetUnreadCount(ctx context.Context, userReferenceID string) (*dto.UnreadCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadCount", ctx, userReferenceID)
+	ret0, _ := ret[0].(*dto.UnreadCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadCount indicates an expected call of GetUnreadCount.
+func (mr *MockNotificationProviderMockRecorder) GetUnreadCount(ctx, userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadCount", reflect.TypeOf((*MockNotificationProvider)(nil).GetUnreadCount), ctx, userReferenceID)
+}
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"time"
+
+	"github.com/reecerussell/open-social/cmd/notifications/dao"
+)
+
+// Notification types.
+const (
+	TypeFollow         = "follow"
+	TypeLike           = "like"
+	TypeComment        = "comment"
+	TypeFollowRequest  = "followRequest"
+	TypeFollowApproved = "followApproved"
+)
+
+// Notification is a domain model for a notification sent to a user, about
+// something another user, the actor, has done.
+//
+// The recipient of a notification about a post is the post's author, which
+// is resolved when the notification is persisted.
+type Notification struct {
+	id               int
+	referenceID      string
+	idempotencyKey   string
+	notificationType string
+	userReferenceID  string
+	actorReferenceID string
+	postReferenceID  string
+	created          time.Time
+	isRead           bool
+}
+
+// NewFollowNotification returns a new Notification, notifying the user with
+// reference id userReferenceID that the actor has followed them. The idempotency
+// key ensures a notification is only created once for the same event.
+func NewFollowNotification(idempotencyKey, actorReferenceID, userReferenceID string) *Notification {
+	return &Notification{
+		idempotencyKey:   idempotencyKey,
+		notificationType: TypeFollow,
+		userReferenceID:  userReferenceID,
+		actorReferenceID: actorReferenceID,
+		created:          time.Now().UTC(),
+	}
+}
+
+// NewFollowRequestNotification returns a new Notification, notifying the user with
+// reference id userReferenceID that the actor has requested to follow them.
+func NewFollowRequestNotification(idempotencyKey, actorReferenceID, userReferenceID string) *Notification {
+	return &Notification{
+		idempotencyKey:   idempotencyKey,
+		notificationType: TypeFollowRequest,
+		userReferenceID:  userReferenceID,
+		actorReferenceID: actorReferenceID,
+		created:          time.Now().UTC(),
+	}
+}
+
+// NewFollowApprovedNotification returns a new Notification, notifying the user with
+// reference id userReferenceID that the actor has approved their follow request.
+func NewFollowApprovedNotification(idempotencyKey, actorReferenceID, userReferenceID string) *Notification {
+	return &Notification{
+		idempotencyKey:   idempotencyKey,
+		notificationType: TypeFollowApproved,
+		userReferenceID:  userReferenceID,
+		actorReferenceID: actorReferenceID,
+		created:          time.Now().UTC(),
+	}
+}
+
+// NewLikeNotification returns a new Notification, notifying the author of
+// the post that the actor has liked it.
+func NewLikeNotification(idempotencyKey, actorReferenceID, postReferenceID string) *Notification {
+	return &Notification{
+		idempotencyKey:   idempotencyKey,
+		notificationType: TypeLike,
+		actorReferenceID: actorReferenceID,
+		postReferenceID:  postReferenceID,
+		created:          time.Now().UTC(),
+	}
+}
+
+// NewCommentNotification returns a new Notification, notifying the author of
+// the post that the actor has commented on it.
+func NewCommentNotification(idempotencyKey, actorReferenceID, postReferenceID string) *Notification {
+	return &Notification{
+		idempotencyKey:   idempotencyKey,
+		notificationType: TypeComment,
+		actorReferenceID: actorReferenceID,
+		postReferenceID:  postReferenceID,
+		created:          time.Now().UTC(),
+	}
+}
+
+// Type returns the type of the notification.
+func (n *Notification) Type() string {
+	return n.notificationType
+}
+
+// Dao returns a data access object populated with the notification's data.
+func (n *Notification) Dao() *dao.Notification {
+	return &dao.Notification{
+		ID:               n.id,
+		ReferenceID:      n.referenceID,
+		IdempotencyKey:   n.idempotencyKey,
+		Type:             n.notificationType,
+		UserReferenceID:  n.userReferenceID,
+		ActorReferenceID: n.actorReferenceID,
+		PostReferenceID:  n.postReferenceID,
+		Created:          n.created,
+		IsRead:           n.isRead,
+	}
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFollowNotification(t *testing.T) {
+	n := NewFollowNotification("key", "actor", "user")
+	assert.Equal(t, TypeFollow, n.Type())
+
+	d := n.Dao()
+	assert.Equal(t, "key", d.IdempotencyKey)
+	assert.Equal(t, "actor", d.ActorReferenceID)
+	assert.Equal(t, "user", d.UserReferenceID)
+	assert.Equal(t, "", d.PostReferenceID)
+	assert.False(t, d.Created.IsZero())
+	assert.False(t, d.IsRead)
+}
+
+func TestNewFollowRequestNotification(t *testing.T) {
+	n := NewFollowRequestNotification("key", "actor", "user")
+	assert.Equal(t, TypeFollowRequest, n.Type())
+
+	d := n.Dao()
+	assert.Equal(t, "actor", d.ActorReferenceID)
+	assert.Equal(t, "user", d.UserReferenceID)
+}
+
+func TestNewFollowApprovedNotification(t *testing.T) {
+	n := NewFollowApprovedNotification("key", "actor", "user")
+	assert.Equal(t, TypeFollowApproved, n.Type())
+
+	d := n.Dao()
+	assert.Equal(t, "actor", d.ActorReferenceID)
+	assert.Equal(t, "user", d.UserReferenceID)
+}
+
+func TestNewLikeNotification(t *testing.T) {
+	n := NewLikeNotification("key", "actor", "post")
+	assert.Equal(t, TypeLike, n.Type())
+
+	d := n.Dao()
+	assert.Equal(t, "key", d.IdempotencyKey)
+	assert.Equal(t, "actor", d.ActorReferenceID)
+	assert.Equal(t, "", d.UserReferenceID)
+	assert.Equal(t, "post", d.PostReferenceID)
+}
+
+func TestNewCommentNotification(t *testing.T) {
+	n := NewCommentNotification("key", "actor", "post")
+	assert.Equal(t, TypeComment, n.Type())
+
+	d := n.Dao()
+	assert.Equal(t, "key", d.IdempotencyKey)
+	assert.Equal(t, "actor", d.ActorReferenceID)
+	assert.Equal(t, "", d.UserReferenceID)
+	assert.Equal(t, "post", d.PostReferenceID)
+}
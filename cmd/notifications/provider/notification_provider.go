@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/reecerussell/open-social/cmd/notifications/dto"
+	"github.com/reecerussell/open-social/database"
+)
+
+// maxNotifications is the number of notifications returned by GetNotifications.
+const maxNotifications = 50
+
+// NotificationProvider is used to read notification data.
+type NotificationProvider interface {
+	GetNotifications(ctx context.Context, userReferenceID string) ([]*dto.Notification, error)
+	GetUnreadCount(ctx context.Context, userReferenceID string) (*dto.UnreadCount, error)
+}
+
+type notificationProvider struct {
+	db database.Database
+}
+
+// NewNotificationProvider returns a new instance of NotificationProvider.
+func NewNotificationProvider(db database.Database) NotificationProvider {
+	return &notificationProvider{db: db}
+}
+
+// GetNotifications returns the user's most recent notifications, newest first.
+func (p *notificationProvider) GetNotifications(ctx context.Context, userReferenceID string) ([]*dto.Notification, error) {
+	const query = `SELECT TOP (@limit)
+		CAST([N].[ReferenceId] AS CHAR(36)) AS [Id],
+		[N].[Type],
+		[A].[Username],
+		CAST([P].[ReferenceId] AS CHAR(36)) AS [PostId],
+		[N].[Created],
+		[N].[IsRead]
+	FROM [Notifications] AS [N]
+	INNER JOIN [Users] AS [U] ON [U].[Id] = [N].[UserId]
+	INNER JOIN [Users] AS [A] ON [A].[Id] = [N].[ActorId]
+	LEFT JOIN [Posts] AS [P] ON [P].[Id] = [N].[PostId]
+	WHERE [U].[ReferenceId] = @userReferenceId
+	ORDER BY [N].[Created] DESC, [N].[Id] DESC;`
+
+	rows, err := p.db.Multiple(ctx, query,
+		sql.Named("limit", maxNotifications),
+		sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := []*dto.Notification{}
+
+	for rows.Next() {
+		var notification dto.Notification
+		err := rows.Scan(
+			&notification.ID,
+			&notification.Type,
+			&notification.Username,
+			&notification.PostID,
+			&notification.Created,
+			&notification.IsRead,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, &notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// GetUnreadCount returns the number of notifications the user has not read.
+func (p *notificationProvider) GetUnreadCount(ctx context.Context, userReferenceID string) (*dto.UnreadCount, error) {
+	const query = `SELECT COUNT(*)
+	FROM [Notifications] AS [N]
+	INNER JOIN [Users] AS [U] ON [U].[Id] = [N].[UserId]
+	WHERE [U].[ReferenceId] = @userReferenceId AND [N].[IsRead] = 0;`
+
+	row, err := p.db.Single(ctx, query, sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return nil, err
+	}
+
+	var count dto.UnreadCount
+	err = row.Scan(&count.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &count, nil
+}
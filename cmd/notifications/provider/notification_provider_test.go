@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestNotificationProvider_GetNotifications_ReturnsNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "2389"
+	testPostID := "3290"
+	testCreated := time.Now().UTC()
+	testCtx := context.Background()
+
+	readCount := 0
+
+	mockRows := mock.NewMockRows(ctrl)
+	mockRows.EXPECT().Next().DoAndReturn(func() bool {
+		if readCount > 0 {
+			return false
+		}
+
+		readCount++
+		return true
+	}).Times(2)
+	mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*string)) = "12"
+		*(dest[1].(*string)) = "like"
+		*(dest[2].(*string)) = "test"
+		*(dest[3].(**string)) = &testPostID
+		*(dest[4].(*time.Time)) = testCreated
+		*(dest[5].(*bool)) = true
+
+		return nil
+	})
+	mockRows.EXPECT().Err().Return(nil)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
+
+	p := NewNotificationProvider(mockDatabase)
+	notifications, err := p.GetNotifications(testCtx, testUserReferenceID)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(notifications))
+	assert.Equal(t, "12", notifications[0].ID)
+	assert.Equal(t, "like", notifications[0].Type)
+	assert.Equal(t, "test", notifications[0].Username)
+	assert.Equal(t, testPostID, *notifications[0].PostID)
+	assert.Equal(t, testCreated, notifications[0].Created)
+	assert.True(t, notifications[0].IsRead)
+}
+
+func TestNotificationProvider_GetNotificationsQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(nil, testError)
+
+	p := NewNotificationProvider(mockDatabase)
+	notifications, err := p.GetNotifications(testCtx, "2389")
+	assert.Nil(t, notifications)
+	assert.Equal(t, testError, err)
+}
+
+func TestNotificationProvider_GetNotificationsScanFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockRows := mock.NewMockRows(ctrl)
+	mockRows.EXPECT().Next().Return(true)
+	mockRows.EXPECT().Scan(gomock.Any()).Return(testError)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
+
+	p := NewNotificationProvider(mockDatabase)
+	notifications, err := p.GetNotifications(testCtx, "2389")
+	assert.Nil(t, notifications)
+	assert.Equal(t, testError, err)
+}
+
+func TestNotificationProvider_GetUnreadCount_ReturnsCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*int)) = 4
+
+		return nil
+	})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	p := NewNotificationProvider(mockDatabase)
+	count, err := p.GetUnreadCount(testCtx, "2389")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count.Count)
+}
+
+func TestNotificationProvider_GetUnreadCountQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(nil, testError)
+
+	p := NewNotificationProvider(mockDatabase)
+	count, err := p.GetUnreadCount(testCtx, "2389")
+	assert.Nil(t, count)
+	assert.Equal(t, testError, err)
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/reecerussell/open-social/cmd/notifications/model"
+	"github.com/reecerussell/open-social/database"
+)
+
+// NotificationRepository is a high level interface used to manipulate persisted notification data.
+type NotificationRepository interface {
+	Create(ctx context.Context, n *model.Notification) error
+	MarkRead(ctx context.Context, userReferenceID string, ids []string) error
+	DeleteForUser(ctx context.Context, userReferenceID string) error
+}
+
+type notificationRepository struct {
+	db database.Database
+}
+
+// NewNotificationRepository returns a new instance of NotificationRepository.
+func NewNotificationRepository(db database.Database) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// Create persists the notification. Notifications about a post are sent to the
+// post's author. A notification is not created if its idempotency key has already
+// been used, or if the actor is the recipient, so users are not notified of their
+// own actions, and events can be handled more than once.
+func (r *notificationRepository) Create(ctx context.Context, n *model.Notification) error {
+	const query = `INSERT INTO [Notifications] ([ReferenceId],[IdempotencyKey],[UserId],[ActorId],[Type],[PostId],[Created],[IsRead])
+		SELECT NEWID(), @idempotencyKey, [R].[Id], [A].[Id], @type, [P].[Id], @created, 0
+		FROM [Users] AS [A]
+		LEFT JOIN [Posts] AS [P] ON [P].[ReferenceId] = @postReferenceId
+		INNER JOIN [Users] AS [R] ON [R].[Id] = COALESCE([P].[UserId],
+			(SELECT [Id] FROM [Users] WHERE [ReferenceId] = @userReferenceId))
+		WHERE [A].[ReferenceId] = @actorReferenceId
+		AND [R].[Id] != [A].[Id]
+		AND NOT EXISTS (SELECT 1 FROM [Notifications] WHERE [IdempotencyKey] = @idempotencyKey);`
+
+	notification := n.Dao()
+	_, err := r.db.Execute(ctx, query,
+		sql.Named("idempotencyKey", notification.IdempotencyKey),
+		sql.Named("type", notification.Type),
+		sql.Named("created", notification.Created),
+		sql.Named("actorReferenceId", notification.ActorReferenceID),
+		sql.Named("postReferenceId", nullString(notification.PostReferenceID)),
+		sql.Named("userReferenceId", nullString(notification.UserReferenceID)))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarkRead marks the user's notifications with the given reference ids as read.
+// If ids is empty, all of the user's notifications are marked as read.
+func (r *notificationRepository) MarkRead(ctx context.Context, userReferenceID string, ids []string) error {
+	query := `UPDATE [N] SET [N].[IsRead] = 1
+		FROM [Notifications] AS [N]
+		INNER JOIN [Users] AS [U] ON [U].[Id] = [N].[UserId]
+		WHERE [U].[ReferenceId] = @userReferenceId AND [N].[IsRead] = 0`
+
+	args := []interface{}{sql.Named("userReferenceId", userReferenceID)}
+
+	if len(ids) > 0 {
+		params := make([]string, len(ids))
+		for i, id := range ids {
+			name := fmt.Sprintf("id%d", i)
+			params[i] = "@" + name
+			args = append(args, sql.Named(name, id))
+		}
+
+		query += " AND [N].[ReferenceId] IN (" + strings.Join(params, ",") + ")"
+	}
+
+	_, err := r.db.Execute(ctx, query+";", args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteForUser deletes the notifications sent to or caused by the user, as well as
+// those about their posts, so their account can be deleted. It succeeds if the user
+// doesn't exist, so it can be retried.
+func (r *notificationRepository) DeleteForUser(ctx context.Context, userReferenceID string) error {
+	const query = `DECLARE @userId INT = (SELECT [Id] FROM [Users] WHERE [ReferenceId] = @userReferenceId);
+
+		DELETE FROM [Notifications]
+		WHERE [UserId] = @userId OR [ActorId] = @userId
+			OR [PostId] IN (SELECT [Id] FROM [Posts] WHERE [UserId] = @userId);`
+
+	_, err := r.db.Execute(ctx, query, sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{
+		String: s,
+		Valid:  s != "",
+	}
+}
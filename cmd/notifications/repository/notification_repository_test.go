@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/notifications/model"
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestNotificationRepository_Create_ResolvesRecipientFromPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testNotification := model.NewLikeNotification("key", "actor", "post")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, query string, args ...interface{}) (int64, error) {
+			assert.Equal(t, "key", args[0].(sql.NamedArg).Value)
+			assert.Equal(t, model.TypeLike, args[1].(sql.NamedArg).Value)
+			assert.Equal(t, "actor", args[3].(sql.NamedArg).Value)
+			assert.Equal(t, sql.NullString{String: "post", Valid: true}, args[4].(sql.NamedArg).Value)
+			assert.Equal(t, sql.NullString{}, args[5].(sql.NamedArg).Value)
+
+			return 1, nil
+		})
+
+	repo := NewNotificationRepository(mockDatabase)
+	err := repo.Create(testCtx, testNotification)
+	assert.NoError(t, err)
+}
+
+func TestNotificationRepository_Create_ResolvesRecipientFromUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testNotification := model.NewFollowNotification("key", "actor", "user")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, query string, args ...interface{}) (int64, error) {
+			assert.Equal(t, sql.NullString{}, args[4].(sql.NamedArg).Value)
+			assert.Equal(t, sql.NullString{String: "user", Valid: true}, args[5].(sql.NamedArg).Value)
+
+			return 0, nil
+		})
+
+	repo := NewNotificationRepository(mockDatabase)
+	err := repo.Create(testCtx, testNotification)
+	assert.NoError(t, err)
+}
+
+func TestNotificationRepository_CreateQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(-1), testError)
+
+	repo := NewNotificationRepository(mockDatabase)
+	err := repo.Create(testCtx, model.NewFollowNotification("key", "actor", "user"))
+	assert.Equal(t, testError, err)
+}
+
+func TestNotificationRepository_MarkRead_MarksAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, query string, args ...interface{}) (int64, error) {
+			assert.NotContains(t, query, "IN (")
+			assert.Equal(t, 1, len(args))
+
+			return 3, nil
+		})
+
+	repo := NewNotificationRepository(mockDatabase)
+	err := repo.MarkRead(testCtx, "user", nil)
+	assert.NoError(t, err)
+}
+
+func TestNotificationRepository_MarkRead_MarksGivenIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, query string, args ...interface{}) (int64, error) {
+			assert.Contains(t, query, "IN (@id0,@id1)")
+			assert.Equal(t, 3, len(args))
+			assert.Equal(t, "a", args[1].(sql.NamedArg).Value)
+			assert.Equal(t, "b", args[2].(sql.NamedArg).Value)
+
+			return 2, nil
+		})
+
+	repo := NewNotificationRepository(mockDatabase)
+	err := repo.MarkRead(testCtx, "user", []string{"a", "b"})
+	assert.NoError(t, err)
+}
+
+func TestNotificationRepository_MarkReadQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(-1), testError)
+
+	repo := NewNotificationRepository(mockDatabase)
+	err := repo.MarkRead(testCtx, "user", nil)
+	assert.Equal(t, testError, err)
+}
+
+func TestNotificationRepository_DeleteForUser_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, query string, args ...interface{}) (int64, error) {
+			assert.Equal(t, "user", args[0].(sql.NamedArg).Value)
+
+			return 3, nil
+		})
+
+	repo := NewNotificationRepository(mockDatabase)
+	err := repo.DeleteForUser(testCtx, "user")
+	assert.NoError(t, err)
+}
+
+func TestNotificationRepository_DeleteForUserQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(-1), testError)
+
+	repo := NewNotificationRepository(mockDatabase)
+	err := repo.DeleteForUser(testCtx, "user")
+	assert.Equal(t, testError, err)
+}
@@ -0,0 +1,15 @@
+package dao
+
+import "time"
+
+// Comment is a data access object for the comment domain.
+type Comment struct {
+	ID              int
+	ReferenceID     string
+	PostID          int
+	PostReferenceID string
+	PostUserID      int
+	UserID          int
+	Posted          time.Time
+	Text            string
+}
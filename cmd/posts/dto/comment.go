@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// Comment is a data transfer object used to read a comment on a post.
+type Comment struct {
+	ID       string    `json:"id"`
+	Text     string    `json:"text"`
+	Posted   time.Time `json:"posted"`
+	Username string    `json:"username"`
+	IsAuthor bool      `json:"isAuthor"`
+}
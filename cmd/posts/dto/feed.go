@@ -0,0 +1,10 @@
+package dto
+
+// Feed is a page of posts in a feed.
+type Feed struct {
+	Items []*FeedItem `json:"items"`
+
+	// NextCursor is used to read the next page of the feed,
+	// and is nil if there are no more items.
+	NextCursor *string `json:"nextCursor"`
+}
@@ -10,5 +10,6 @@ type Post struct {
 	Username string    `json:"username"`
 	Caption  string    `json:"caption"`
 	Likes    int       `json:"likes"`
+	Comments int       `json:"comments"`
 	HasLiked bool      `json:"hasLiked"`
 }
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/client/users"
+	"github.com/reecerussell/open-social/cmd/posts/model"
+	"github.com/reecerussell/open-social/cmd/posts/repository"
+	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/eventing"
+)
+
+// CreateCommentHandler is a http.Handler used to handle POST requests to comment on a post.
+type CreateCommentHandler struct {
+	core.Handler
+	repo     repository.PostRepository
+	comments repository.CommentRepository
+	users    users.Client
+	uow      database.UnitOfWork
+	events   eventing.Publisher
+}
+
+// CreateCommentRequest is the body of the request.
+type CreateCommentRequest struct {
+	UserReferenceID string `json:"userReferenceId"`
+	Text            string `json:"text"`
+}
+
+// CreateCommentResponse is the body of the response.
+type CreateCommentResponse struct {
+	ReferenceID string `json:"referenceId"`
+}
+
+// NewCreateCommentHandler returns a new instance of CreateCommentHandler.
+func NewCreateCommentHandler(repo repository.PostRepository, comments repository.CommentRepository, users users.Client, uow database.UnitOfWork, events eventing.Publisher) *CreateCommentHandler {
+	return &CreateCommentHandler{
+		repo:     repo,
+		comments: comments,
+		users:    users,
+		uow:      uow,
+		events:   events,
+	}
+}
+
+func (h *CreateCommentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	postReferenceID := params["postReferenceID"]
+
+	var data CreateCommentRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	ctx := r.Context()
+	post, err := h.repo.Get(ctx, postReferenceID, data.UserReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrPostNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	userID, err := h.users.GetIDByReference(data.UserReferenceID)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	comment, err := model.NewComment(post.ID(), *userID, data.Text)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	err = h.uow.Transaction(ctx, func(ctx context.Context) error {
+		err := h.comments.Create(ctx, comment)
+		if err != nil {
+			return err
+		}
+
+		event := eventing.NewEvent(eventing.CommentCreated, &eventing.CommentCreatedPayload{
+			CommentReferenceID: comment.ReferenceID(),
+			PostReferenceID:    postReferenceID,
+			UserReferenceID:    data.UserReferenceID,
+			Text:               comment.Text(),
+		})
+		return h.events.Publish(ctx, postReferenceID, event)
+	})
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	response := CreateCommentResponse{
+		ReferenceID: comment.ReferenceID(),
+	}
+
+	h.Respond(w, response)
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	clientMock "github.com/reecerussell/open-social/client/mock/users"
+	"github.com/reecerussell/open-social/cmd/posts/dao"
+	repoMock "github.com/reecerussell/open-social/cmd/posts/mock/repository"
+	"github.com/reecerussell/open-social/cmd/posts/model"
+	"github.com/reecerussell/open-social/cmd/posts/repository"
+	"github.com/reecerussell/open-social/eventing"
+	"github.com/reecerussell/open-social/eventing/memory"
+	dbMock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestCreateCommentHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		testPostID             = 12
+		testPostReferenceID    = "5234934"
+		testUserReferenceID    = "1740398"
+		testCommentReferenceID = "2389"
+		testText               = "Nice post!"
+	)
+	testUserID := 3
+	testPost := model.PostFromDao(&dao.Post{ID: testPostID})
+
+	mockRepo := repoMock.NewMockPostRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testPostReferenceID, testUserReferenceID).Return(testPost, nil)
+
+	mockClient := clientMock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetIDByReference(testUserReferenceID).Return(&testUserID, nil)
+
+	mockComments := repoMock.NewMockCommentRepository(ctrl)
+	mockComments.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, c *model.Comment) error {
+			d := c.Dao()
+			assert.Equal(t, testPostID, d.PostID)
+			assert.Equal(t, testUserID, d.UserID)
+			assert.Equal(t, testText, d.Text)
+
+			c.SetReferenceID(testCommentReferenceID)
+
+			return nil
+		})
+
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	events := memory.NewPublisher()
+	handler := NewCreateCommentHandler(mockRepo, mockComments, mockClient, mockUow, events)
+	router := mux.NewRouter()
+	router.Handle("/{postReferenceID}", handler).Methods(http.MethodPost)
+
+	body := fmt.Sprintf(`{"userReferenceId": "%s", "text": "%s"}`, testUserReferenceID, testText)
+	req, _ := http.NewRequest(http.MethodPost, "/"+testPostReferenceID, strings.NewReader(body))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	exp := fmt.Sprintf("{\"referenceId\":\"%s\"}\n", testCommentReferenceID)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.HeaderMap.Get("Content-Type"))
+
+	messages := events.Messages()
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, testPostReferenceID, messages[0].Key)
+
+	var event struct {
+		Type    string                         `json:"type"`
+		Payload eventing.CommentCreatedPayload `json:"payload"`
+	}
+	_ = json.Unmarshal(messages[0].Value, &event)
+	assert.Equal(t, eventing.CommentCreated, event.Type)
+	assert.Equal(t, testCommentReferenceID, event.Payload.CommentReferenceID)
+	assert.Equal(t, testPostReferenceID, event.Payload.PostReferenceID)
+	assert.Equal(t, testUserReferenceID, event.Payload.UserReferenceID)
+	assert.Equal(t, testText, event.Payload.Text)
+}
+
+func TestCreateCommentHandler_GivenNonExistantPost_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		testPostReferenceID = "5234934"
+		testUserReferenceID = "1740398"
+	)
+
+	mockRepo := repoMock.NewMockPostRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testPostReferenceID, testUserReferenceID).Return(nil, repository.ErrPostNotFound)
+
+	handler := NewCreateCommentHandler(mockRepo, nil, nil, nil, nil)
+	router := mux.NewRouter()
+	router.Handle("/{postReferenceID}", handler).Methods(http.MethodPost)
+
+	body := fmt.Sprintf(`{"userReferenceId": "%s", "text": "Hello"}`, testUserReferenceID)
+	req, _ := http.NewRequest(http.MethodPost, "/"+testPostReferenceID, strings.NewReader(body))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "application/json", rr.HeaderMap.Get("Content-Type"))
+}
+
+func TestCreateCommentHandler_GivenInvalidText_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		testPostReferenceID = "5234934"
+		testUserReferenceID = "1740398"
+	)
+	testUserID := 3
+	testPost := model.PostFromDao(&dao.Post{ID: 12})
+
+	mockRepo := repoMock.NewMockPostRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testPostReferenceID, testUserReferenceID).Return(testPost, nil)
+
+	mockClient := clientMock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetIDByReference(testUserReferenceID).Return(&testUserID, nil)
+
+	handler := NewCreateCommentHandler(mockRepo, nil, mockClient, nil, nil)
+	router := mux.NewRouter()
+	router.Handle("/{postReferenceID}", handler).Methods(http.MethodPost)
+
+	body := fmt.Sprintf(`{"userReferenceId": "%s", "text": ""}`, testUserReferenceID)
+	req, _ := http.NewRequest(http.MethodPost, "/"+testPostReferenceID, strings.NewReader(body))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, "{\"message\":\"comment cannot be empty\"}\n", rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestCreateCommentHandler_RepoReturnsError_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		testPostReferenceID = "5234934"
+		testUserReferenceID = "1740398"
+	)
+	testUserID := 3
+	testPost := model.PostFromDao(&dao.Post{ID: 12})
+	testError := errors.New("an error occured")
+
+	mockRepo := repoMock.NewMockPostRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), testPostReferenceID, testUserReferenceID).Return(testPost, nil)
+
+	mockClient := clientMock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetIDByReference(testUserReferenceID).Return(&testUserID, nil)
+
+	mockComments := repoMock.NewMockCommentRepository(ctrl)
+	mockComments.EXPECT().Create(gomock.Any(), gomock.Any()).Return(testError)
+
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewCreateCommentHandler(mockRepo, mockComments, mockClient, mockUow, nil)
+	router := mux.NewRouter()
+	router.Handle("/{postReferenceID}", handler).Methods(http.MethodPost)
+
+	body := fmt.Sprintf(`{"userReferenceId": "%s", "text": "Hello"}`, testUserReferenceID)
+	req, _ := http.NewRequest(http.MethodPost, "/"+testPostReferenceID, strings.NewReader(body))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, "{\"message\":\"an error occured\"}\n", rr.Body.String())
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
@@ -8,13 +9,17 @@ import (
 	"github.com/reecerussell/open-social/client/users"
 	"github.com/reecerussell/open-social/cmd/posts/model"
 	"github.com/reecerussell/open-social/cmd/posts/repository"
+	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/eventing"
 )
 
 // CreatePostHandler is a http.Handler used to handle POSt requests to create post records.
 type CreatePostHandler struct {
 	core.Handler
-	repo  repository.PostRepository
-	users users.Client
+	repo   repository.PostRepository
+	users  users.Client
+	uow    database.UnitOfWork
+	events eventing.Publisher
 }
 
 // CreatePostRequest is the body of a request.
@@ -30,10 +35,12 @@ type CreatePostResponse struct {
 }
 
 // NewCreatePostHandler returns a new instance of CreatePostHandler.
-func NewCreatePostHandler(repo repository.PostRepository, users users.Client) *CreatePostHandler {
+func NewCreatePostHandler(repo repository.PostRepository, users users.Client, uow database.UnitOfWork, events eventing.Publisher) *CreatePostHandler {
 	return &CreatePostHandler{
-		repo:  repo,
-		users: users,
+		repo:   repo,
+		users:  users,
+		uow:    uow,
+		events: events,
 	}
 }
 
@@ -54,7 +61,20 @@ func (h *CreatePostHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.repo.Create(r.Context(), post)
+	err = h.uow.Transaction(r.Context(), func(ctx context.Context) error {
+		err := h.repo.Create(ctx, post)
+		if err != nil {
+			return err
+		}
+
+		event := eventing.NewEvent(eventing.PostCreated, &eventing.PostCreatedPayload{
+			PostReferenceID: post.ReferenceID(),
+			UserReferenceID: data.UserReferenceID,
+			Caption:         post.Caption(),
+			HasMedia:        data.MediaID != nil,
+		})
+		return h.events.Publish(ctx, post.ReferenceID(), event)
+	})
 	if err != nil {
 		h.RespondError(w, err, http.StatusInternalServerError)
 		return
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -15,6 +16,9 @@ import (
 	clientMock "github.com/reecerussell/open-social/client/mock/users"
 	repoMock "github.com/reecerussell/open-social/cmd/posts/mock/repository"
 	"github.com/reecerussell/open-social/cmd/posts/model"
+	"github.com/reecerussell/open-social/eventing"
+	"github.com/reecerussell/open-social/eventing/memory"
+	dbMock "github.com/reecerussell/open-social/mock/database"
 )
 
 func TestCreatePostHandler(t *testing.T) {
@@ -41,7 +45,14 @@ func TestCreatePostHandler(t *testing.T) {
 			return nil
 		})
 
-	handler := NewCreatePostHandler(mockRepo, mockClient)
+	events := memory.NewPublisher()
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewCreatePostHandler(mockRepo, mockClient, mockUow, events)
 
 	body := fmt.Sprintf(`{"userReferenceId": "%s", "caption": "%s"}`, testUserReferenceID, testCaption)
 	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
@@ -56,6 +67,22 @@ func TestCreatePostHandler(t *testing.T) {
 	assert.Equal(t, exp, string(data))
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.Equal(t, "application/json", rr.HeaderMap.Get("Content-Type"))
+
+	messages := events.Messages()
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, testReferenceID, messages[0].Key)
+
+	var event struct {
+		Type    string                      `json:"type"`
+		Version int                         `json:"version"`
+		Payload eventing.PostCreatedPayload `json:"payload"`
+	}
+	_ = json.Unmarshal(messages[0].Value, &event)
+	assert.Equal(t, eventing.PostCreated, event.Type)
+	assert.Equal(t, eventing.EventVersion, event.Version)
+	assert.Equal(t, testReferenceID, event.Payload.PostReferenceID)
+	assert.Equal(t, testUserReferenceID, event.Payload.UserReferenceID)
+	assert.Equal(t, testCaption, event.Payload.Caption)
 }
 
 func TestCreatePostHandler_GivenInvalidUser_ReturnsBadRequest(t *testing.T) {
@@ -70,7 +97,7 @@ func TestCreatePostHandler_GivenInvalidUser_ReturnsBadRequest(t *testing.T) {
 
 	mockRepo := repoMock.NewMockPostRepository(ctrl)
 
-	handler := NewCreatePostHandler(mockRepo, mockClient)
+	handler := NewCreatePostHandler(mockRepo, mockClient, nil, nil)
 
 	body := fmt.Sprintf(`{"userReferenceId": "%s", "caption": "Hello World"}`, testUserReferenceID)
 	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
@@ -101,7 +128,7 @@ func TestCreatePostHandler_GivenInvalidPostData_ReturnsBadRequest(t *testing.T)
 	mockClient.EXPECT().GetIDByReference(testUserReferenceID).Return(&testUserID, nil)
 
 	mockRepo := repoMock.NewMockPostRepository(ctrl)
-	handler := NewCreatePostHandler(mockRepo, mockClient)
+	handler := NewCreatePostHandler(mockRepo, mockClient, nil, nil)
 
 	body := fmt.Sprintf(`{"userReferenceId": "%s", "caption": "%s"}`, testUserReferenceID, testCaption)
 	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
@@ -132,7 +159,13 @@ func TestCreatePostHandler_RepoReturnsError_ReturnsInternalServerError(t *testin
 	mockRepo := repoMock.NewMockPostRepository(ctrl)
 	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New(testErrorMessage))
 
-	handler := NewCreatePostHandler(mockRepo, mockClient)
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewCreatePostHandler(mockRepo, mockClient, mockUow, nil)
 
 	body := fmt.Sprintf(`{"userReferenceId": "%s", "caption": "Hello World"}`, testUserReferenceID)
 	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
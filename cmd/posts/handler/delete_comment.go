@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/client/users"
+	"github.com/reecerussell/open-social/cmd/posts/repository"
+)
+
+// DeleteCommentHandler is a http.Handler used to delete a comment on a post.
+type DeleteCommentHandler struct {
+	core.Handler
+	comments repository.CommentRepository
+	users    users.Client
+}
+
+// NewDeleteCommentHandler returns a new instance of DeleteCommentHandler.
+func NewDeleteCommentHandler(comments repository.CommentRepository, users users.Client) *DeleteCommentHandler {
+	return &DeleteCommentHandler{
+		comments: comments,
+		users:    users,
+	}
+}
+
+func (h *DeleteCommentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	postReferenceID := params["postReferenceID"]
+	commentReferenceID := params["commentReferenceID"]
+	userReferenceID := params["userReferenceID"]
+
+	ctx := r.Context()
+	comment, err := h.comments.Get(ctx, commentReferenceID)
+	if err == nil && comment.PostReferenceID() != postReferenceID {
+		err = repository.ErrCommentNotFound
+	}
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrCommentNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	userID, err := h.users.GetIDByReference(userReferenceID)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	err = comment.CanDelete(*userID)
+	if err != nil {
+		h.RespondError(w, err, http.StatusForbidden)
+		return
+	}
+
+	err = h.comments.Delete(ctx, comment.ID())
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, nil)
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	clientMock "github.com/reecerussell/open-social/client/mock/users"
+	"github.com/reecerussell/open-social/cmd/posts/dao"
+	repoMock "github.com/reecerussell/open-social/cmd/posts/mock/repository"
+	"github.com/reecerussell/open-social/cmd/posts/model"
+	"github.com/reecerussell/open-social/cmd/posts/repository"
+)
+
+const (
+	testDeletePostReferenceID    = "2398"
+	testDeleteCommentReferenceID = "7382"
+	testDeleteUserReferenceID    = "1740398"
+)
+
+func serveDeleteComment(handler http.Handler) *httptest.ResponseRecorder {
+	router := mux.NewRouter()
+	router.Handle("/{postReferenceID}/{commentReferenceID}/{userReferenceID}", handler).Methods(http.MethodDelete)
+
+	url := "/" + testDeletePostReferenceID + "/" + testDeleteCommentReferenceID + "/" + testDeleteUserReferenceID
+	req, _ := http.NewRequest(http.MethodDelete, url, nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestDeleteCommentHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserID := 3
+	testComment := model.CommentFromDao(&dao.Comment{
+		ID:              12,
+		PostReferenceID: testDeletePostReferenceID,
+		UserID:          testUserID,
+	})
+
+	mockComments := repoMock.NewMockCommentRepository(ctrl)
+	mockComments.EXPECT().Get(gomock.Any(), testDeleteCommentReferenceID).Return(testComment, nil)
+	mockComments.EXPECT().Delete(gomock.Any(), 12).Return(nil)
+
+	mockClient := clientMock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetIDByReference(testDeleteUserReferenceID).Return(&testUserID, nil)
+
+	rr := serveDeleteComment(NewDeleteCommentHandler(mockComments, mockClient))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestDeleteCommentHandler_GivenNonExistantComment_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockComments := repoMock.NewMockCommentRepository(ctrl)
+	mockComments.EXPECT().Get(gomock.Any(), testDeleteCommentReferenceID).Return(nil, repository.ErrCommentNotFound)
+
+	rr := serveDeleteComment(NewDeleteCommentHandler(mockComments, nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "{\"message\":\"comment not found\"}\n", rr.Body.String())
+}
+
+func TestDeleteCommentHandler_GivenCommentOnDifferentPost_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testComment := model.CommentFromDao(&dao.Comment{
+		ID:              12,
+		PostReferenceID: "3289",
+	})
+
+	mockComments := repoMock.NewMockCommentRepository(ctrl)
+	mockComments.EXPECT().Get(gomock.Any(), testDeleteCommentReferenceID).Return(testComment, nil)
+
+	rr := serveDeleteComment(NewDeleteCommentHandler(mockComments, nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestDeleteCommentHandler_WhereUserCannotDelete_ReturnsForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserID := 3
+	testComment := model.CommentFromDao(&dao.Comment{
+		ID:              12,
+		PostReferenceID: testDeletePostReferenceID,
+		UserID:          4,
+		PostUserID:      5,
+	})
+
+	mockComments := repoMock.NewMockCommentRepository(ctrl)
+	mockComments.EXPECT().Get(gomock.Any(), testDeleteCommentReferenceID).Return(testComment, nil)
+
+	mockClient := clientMock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetIDByReference(testDeleteUserReferenceID).Return(&testUserID, nil)
+
+	rr := serveDeleteComment(NewDeleteCommentHandler(mockComments, mockClient))
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestDeleteCommentHandler_DeleteFails_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserID := 3
+	testError := errors.New("an error occured")
+	testComment := model.CommentFromDao(&dao.Comment{
+		ID:              12,
+		PostReferenceID: testDeletePostReferenceID,
+		UserID:          testUserID,
+	})
+
+	mockComments := repoMock.NewMockCommentRepository(ctrl)
+	mockComments.EXPECT().Get(gomock.Any(), testDeleteCommentReferenceID).Return(testComment, nil)
+	mockComments.EXPECT().Delete(gomock.Any(), 12).Return(testError)
+
+	mockClient := clientMock.NewMockClient(ctrl)
+	mockClient.EXPECT().GetIDByReference(testDeleteUserReferenceID).Return(&testUserID, nil)
+
+	rr := serveDeleteComment(NewDeleteCommentHandler(mockComments, mockClient))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "{\"message\":\"an error occured\"}\n", rr.Body.String())
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/posts/repository"
+	"github.com/reecerussell/open-social/database"
+)
+
+// DeleteUserPostsHandler is a http.Handler used to delete all of a user's
+// posts, likes and comments, when their account is deleted.
+type DeleteUserPostsHandler struct {
+	core.Handler
+	repo repository.PostRepository
+	uow  database.UnitOfWork
+}
+
+// NewDeleteUserPostsHandler returns a new instance of DeleteUserPostsHandler.
+func NewDeleteUserPostsHandler(repo repository.PostRepository, uow database.UnitOfWork) *DeleteUserPostsHandler {
+	return &DeleteUserPostsHandler{
+		repo: repo,
+		uow:  uow,
+	}
+}
+
+// DeleteUserPostsResponse contains the reference ids of the media which
+// was attached to the user's posts, which is left to be deleted by the caller.
+type DeleteUserPostsResponse struct {
+	MediaIDs []string `json:"mediaIds"`
+}
+
+func (h *DeleteUserPostsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	var mediaIDs []string
+	err := h.uow.Transaction(r.Context(), func(ctx context.Context) error {
+		var err error
+		mediaIDs, err = h.repo.DeleteForUser(ctx, userReferenceID)
+		return err
+	})
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, &DeleteUserPostsResponse{MediaIDs: mediaIDs})
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	repoMock "github.com/reecerussell/open-social/cmd/posts/mock/repository"
+	dbMock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestDeleteUserPostsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "3297423"
+	testMediaIDs := []string{"2384", "9823"}
+
+	mockRepo := repoMock.NewMockPostRepository(ctrl)
+	mockRepo.EXPECT().DeleteForUser(gomock.Any(), testUserReferenceID).Return(testMediaIDs, nil)
+
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewDeleteUserPostsHandler(mockRepo, mockUow)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/"+testUserReferenceID, nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var data DeleteUserPostsResponse
+	json.NewDecoder(rr.Body).Decode(&data)
+	assert.Equal(t, testMediaIDs, data.MediaIDs)
+}
+
+func TestDeleteUserPostsHandler_WhereDeleteFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "3297423"
+	testError := errors.New("an error occured")
+
+	mockRepo := repoMock.NewMockPostRepository(ctrl)
+	mockRepo.EXPECT().DeleteForUser(gomock.Any(), testUserReferenceID).Return(nil, testError)
+
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewDeleteUserPostsHandler(mockRepo, mockUow)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/"+testUserReferenceID, nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	var data map[string]string
+	json.NewDecoder(rr.Body).Decode(&data)
+	assert.Equal(t, testError.Error(), data["message"])
+}
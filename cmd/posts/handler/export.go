@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/posts/provider"
+)
+
+// ExportHandler is a http.Handler used to get all of a user's post data,
+// for their personal data export.
+type ExportHandler struct {
+	core.Handler
+	provider provider.ExportProvider
+}
+
+// NewExportHandler returns a new instance of ExportHandler.
+func NewExportHandler(provider provider.ExportProvider) *ExportHandler {
+	return &ExportHandler{
+		provider: provider,
+	}
+}
+
+func (h *ExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	export, err := h.provider.GetExport(r.Context(), userReferenceID)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, export)
+}
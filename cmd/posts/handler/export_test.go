@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/posts/dto"
+	mock "github.com/reecerussell/open-social/cmd/posts/mock/provider"
+)
+
+func TestExportHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "3297423"
+	testExport := &dto.Export{
+		Posts: []*dto.ExportPost{
+			{ID: "2834", Posted: time.Now().UTC(), Caption: "Hello World"},
+		},
+		Likes: []*dto.ExportLike{
+			{PostID: "9382"},
+		},
+		Comments: []*dto.ExportComment{},
+	}
+
+	mockProvider := mock.NewMockExportProvider(ctrl)
+	mockProvider.EXPECT().GetExport(gomock.Any(), testUserReferenceID).Return(testExport, nil)
+
+	handler := NewExportHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/"+testUserReferenceID, nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var data dto.Export
+	json.NewDecoder(rr.Body).Decode(&data)
+	assert.Equal(t, "2834", data.Posts[0].ID)
+	assert.Equal(t, "Hello World", data.Posts[0].Caption)
+	assert.Equal(t, "9382", data.Likes[0].PostID)
+	assert.Len(t, data.Comments, 0)
+}
+
+func TestExportHandler_WhereProviderFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "3297423"
+	testError := errors.New("an error occured")
+
+	mockProvider := mock.NewMockExportProvider(ctrl)
+	mockProvider.EXPECT().GetExport(gomock.Any(), testUserReferenceID).Return(nil, testError)
+
+	handler := NewExportHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/"+testUserReferenceID, nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gorilla/mux"
 
 	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/posts/pagination"
 	"github.com/reecerussell/open-social/cmd/posts/repository"
 )
 
@@ -24,8 +25,14 @@ func (h *FeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	userReferenceID := params["userReferenceId"]
 
+	page, err := pagination.PageFromRequest(r)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
 	ctx := r.Context()
-	feed, err := h.repo.GetFeed(ctx, userReferenceID)
+	feed, err := h.repo.GetFeed(ctx, userReferenceID, page)
 	if err != nil {
 		h.RespondError(w, err, http.StatusInternalServerError)
 		return
@@ -25,7 +25,7 @@ func TestFeedHandler(t *testing.T) {
 	testPostedDate := time.Now()
 
 	mockRepo := repository.NewMockPostRepository(ctrl)
-	mockRepo.EXPECT().GetFeed(gomock.Any(), testUserReferenceID).Return([]*dto.FeedItem{
+	mockRepo.EXPECT().GetFeed(gomock.Any(), testUserReferenceID, gomock.Any()).Return(&dto.Feed{Items: []*dto.FeedItem{
 		{
 			ID:       "23123",
 			Caption:  "Hello World",
@@ -34,7 +34,7 @@ func TestFeedHandler(t *testing.T) {
 			Likes:    1,
 			HasLiked: false,
 		},
-	}, nil)
+	}}, nil)
 
 	handler := NewFeedHandler(mockRepo)
 	router := mux.NewRouter()
@@ -47,15 +47,19 @@ func TestFeedHandler(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.Equal(t, "application/json", rr.HeaderMap.Get("Content-Type"))
 
-	var data []map[string]interface{}
+	var data struct {
+		Items      []map[string]interface{} `json:"items"`
+		NextCursor *string                  `json:"nextCursor"`
+	}
 	err := json.NewDecoder(rr.Body).Decode(&data)
 	if err != nil {
 		panic(err)
 	}
 
-	assert.Equal(t, 1, len(data))
+	assert.Equal(t, 1, len(data.Items))
+	assert.Nil(t, data.NextCursor)
 
-	item := data[0]
+	item := data.Items[0]
 	expPostedDate, _ := testPostedDate.MarshalText()
 
 	assert.Equal(t, "23123", item["id"])
@@ -74,7 +78,7 @@ func TestFeedHandler_RepoReturnsError_ReturnsInternalServerError(t *testing.T) {
 	testErrorMessage := "an error occured"
 
 	mockRepo := repository.NewMockPostRepository(ctrl)
-	mockRepo.EXPECT().GetFeed(gomock.Any(), testUserReferenceID).Return(nil, errors.New(testErrorMessage))
+	mockRepo.EXPECT().GetFeed(gomock.Any(), testUserReferenceID, gomock.Any()).Return(nil, errors.New(testErrorMessage))
 
 	handler := NewFeedHandler(mockRepo)
 	router := mux.NewRouter()
@@ -92,3 +96,18 @@ func TestFeedHandler_RepoReturnsError_ReturnsInternalServerError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
 	assert.Equal(t, "application/json", rr.HeaderMap.Get("Content-Type"))
 }
+
+func TestFeedHandler_GivenInvalidCursor_ReturnsBadRequest(t *testing.T) {
+	testUserReferenceID := "2398yhlwd"
+
+	handler := NewFeedHandler(nil)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceId}", handler).Methods("GET")
+
+	req, _ := http.NewRequest(http.MethodGet, "/"+testUserReferenceID+"?cursor=invalid", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, "{\"message\":\"cursor is invalid\"}\n", rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/posts/provider"
+)
+
+// GetCommentsHandler is a http.Handler used to get the comments on a post.
+type GetCommentsHandler struct {
+	core.Handler
+	provider provider.CommentProvider
+}
+
+// NewGetCommentsHandler returns a new instance of GetCommentsHandler.
+func NewGetCommentsHandler(provider provider.CommentProvider) *GetCommentsHandler {
+	return &GetCommentsHandler{
+		provider: provider,
+	}
+}
+
+func (h *GetCommentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	postReferenceID := params["postReferenceID"]
+	userReferenceID := params["userReferenceID"]
+
+	ctx := r.Context()
+	comments, err := h.provider.GetComments(ctx, postReferenceID, userReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == provider.ErrPostNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	h.Respond(w, comments)
+}
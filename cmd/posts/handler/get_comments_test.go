@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/posts/dto"
+	mock "github.com/reecerussell/open-social/cmd/posts/mock/provider"
+	"github.com/reecerussell/open-social/cmd/posts/provider"
+)
+
+func TestGetCommentsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testPostReferenceID := "23894"
+	testUserReferenceID := "2398yhlwd"
+
+	mockProvider := mock.NewMockCommentProvider(ctrl)
+	mockProvider.EXPECT().GetComments(gomock.Any(), testPostReferenceID, testUserReferenceID).Return([]*dto.Comment{
+		{
+			ID:       "23123",
+			Text:     "Hello World",
+			Posted:   time.Now(),
+			Username: "User123",
+			IsAuthor: true,
+		},
+	}, nil)
+
+	handler := NewGetCommentsHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{postReferenceID}/{userReferenceID}", handler).Methods(http.MethodGet)
+
+	req, _ := http.NewRequest(http.MethodGet, "/"+testPostReferenceID+"/"+testUserReferenceID, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.HeaderMap.Get("Content-Type"))
+
+	var data []map[string]interface{}
+	err := json.NewDecoder(rr.Body).Decode(&data)
+	if err != nil {
+		panic(err)
+	}
+
+	assert.Equal(t, 1, len(data))
+	assert.Equal(t, "23123", data[0]["id"])
+	assert.Equal(t, "Hello World", data[0]["text"])
+	assert.Equal(t, "User123", data[0]["username"])
+	assert.Equal(t, true, data[0]["isAuthor"])
+}
+
+func TestGetCommentsHandler_ProviderReturnsError_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testPostReferenceID := "23894"
+	testUserReferenceID := "2398yhlwd"
+	testError := errors.New("an error occured")
+
+	mockProvider := mock.NewMockCommentProvider(ctrl)
+	mockProvider.EXPECT().GetComments(gomock.Any(), testPostReferenceID, testUserReferenceID).Return(nil, testError)
+
+	handler := NewGetCommentsHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{postReferenceID}/{userReferenceID}", handler).Methods(http.MethodGet)
+
+	req, _ := http.NewRequest(http.MethodGet, "/"+testPostReferenceID+"/"+testUserReferenceID, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "{\"message\":\"an error occured\"}\n", rr.Body.String())
+}
+
+func TestGetCommentsHandler_PostNotFound_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testPostReferenceID := "23894"
+	testUserReferenceID := "2398yhlwd"
+
+	mockProvider := mock.NewMockCommentProvider(ctrl)
+	mockProvider.EXPECT().GetComments(gomock.Any(), testPostReferenceID, testUserReferenceID).Return(nil, provider.ErrPostNotFound)
+
+	handler := NewGetCommentsHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{postReferenceID}/{userReferenceID}", handler).Methods(http.MethodGet)
+
+	req, _ := http.NewRequest(http.MethodGet, "/"+testPostReferenceID+"/"+testUserReferenceID, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "{\"message\":\"post not found\"}\n", rr.Body.String())
+}
+
+func TestGetCommentsHandler_NoComments_ReturnsEmptyArray(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProvider := mock.NewMockCommentProvider(ctrl)
+	mockProvider.EXPECT().GetComments(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*dto.Comment{}, nil)
+
+	handler := NewGetCommentsHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{postReferenceID}/{userReferenceID}", handler).Methods(http.MethodGet)
+
+	req, _ := http.NewRequest(http.MethodGet, "/23894/2398yhlwd", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "[]\n", rr.Body.String())
+}
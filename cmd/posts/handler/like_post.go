@@ -1,18 +1,23 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
 	core "github.com/reecerussell/open-social"
 	"github.com/reecerussell/open-social/cmd/posts/repository"
+	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/eventing"
 )
 
 // LikePostHandler is a http.Handler used to mark a post as liked by a user.
 type LikePostHandler struct {
 	core.Handler
-	repo  repository.PostRepository
-	likes repository.LikeRepository
+	repo   repository.PostRepository
+	likes  repository.LikeRepository
+	uow    database.UnitOfWork
+	events eventing.Publisher
 }
 
 // LikePostRequest is the request body structure.
@@ -22,10 +27,12 @@ type LikePostRequest struct {
 }
 
 // NewLikePostHandler returns a new instance of LikePostHandler.
-func NewLikePostHandler(repo repository.PostRepository, likes repository.LikeRepository) *LikePostHandler {
+func NewLikePostHandler(repo repository.PostRepository, likes repository.LikeRepository, uow database.UnitOfWork, events eventing.Publisher) *LikePostHandler {
 	return &LikePostHandler{
-		repo:  repo,
-		likes: likes,
+		repo:   repo,
+		likes:  likes,
+		uow:    uow,
+		events: events,
 	}
 }
 
@@ -52,7 +59,18 @@ func (h *LikePostHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.likes.Create(ctx, post.ID(), data.UserReferenceID)
+	err = h.uow.Transaction(ctx, func(ctx context.Context) error {
+		err := h.likes.Create(ctx, post.ID(), data.UserReferenceID)
+		if err != nil {
+			return err
+		}
+
+		event := eventing.NewEvent(eventing.PostLiked, &eventing.PostLikedPayload{
+			PostReferenceID: data.PostReferenceID,
+			UserReferenceID: data.UserReferenceID,
+		})
+		return h.events.Publish(ctx, data.PostReferenceID, event)
+	})
 	if err != nil {
 		h.RespondError(w, err, http.StatusInternalServerError)
 		return
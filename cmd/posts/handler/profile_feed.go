@@ -8,6 +8,7 @@ import (
 	"github.com/gorilla/mux"
 
 	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/posts/pagination"
 	"github.com/reecerussell/open-social/cmd/posts/provider"
 )
 
@@ -34,8 +35,14 @@ func (h *ProfileFeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	page, err := pagination.PageFromRequest(r)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
 	ctx := r.Context()
-	feed, err := h.provider.GetProfileFeed(ctx, username, userReferenceID)
+	feed, err := h.provider.GetProfileFeed(ctx, username, userReferenceID, page)
 	if err != nil {
 		h.RespondError(w, err, http.StatusInternalServerError)
 		return
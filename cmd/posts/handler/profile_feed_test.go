@@ -27,7 +27,7 @@ func TestProfileFeedHandler(t *testing.T) {
 	testPostedDate := time.Now()
 
 	mockProvider := mock.NewMockPostProvider(ctrl)
-	mockProvider.EXPECT().GetProfileFeed(gomock.Any(), testUsername, testUserReferenceID).Return([]*dto.FeedItem{
+	mockProvider.EXPECT().GetProfileFeed(gomock.Any(), testUsername, testUserReferenceID, gomock.Any()).Return(&dto.Feed{Items: []*dto.FeedItem{
 		{
 			ID:       "23123",
 			Caption:  "Hello World",
@@ -37,7 +37,7 @@ func TestProfileFeedHandler(t *testing.T) {
 			HasLiked: false,
 			IsAuthor: true,
 		},
-	}, nil)
+	}}, nil)
 
 	handler := NewProfileFeedHandler(mockProvider)
 	router := mux.NewRouter()
@@ -50,15 +50,19 @@ func TestProfileFeedHandler(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.Equal(t, "application/json", rr.HeaderMap.Get("Content-Type"))
 
-	var data []map[string]interface{}
+	var data struct {
+		Items      []map[string]interface{} `json:"items"`
+		NextCursor *string                  `json:"nextCursor"`
+	}
 	err := json.NewDecoder(rr.Body).Decode(&data)
 	if err != nil {
 		panic(err)
 	}
 
-	assert.Equal(t, 1, len(data))
+	assert.Equal(t, 1, len(data.Items))
+	assert.Nil(t, data.NextCursor)
 
-	item := data[0]
+	item := data.Items[0]
 	expPostedDate, _ := testPostedDate.MarshalText()
 
 	assert.Equal(t, "23123", item["id"])
@@ -79,7 +83,7 @@ func TestProfileFeedHandler_ProviderReturnsError_ReturnsInternalServerError(t *t
 	testErrorMessage := "an error occured"
 
 	mockProvider := mock.NewMockPostProvider(ctrl)
-	mockProvider.EXPECT().GetProfileFeed(gomock.Any(), testUsername, testUserReferenceID).Return(nil, errors.New(testErrorMessage))
+	mockProvider.EXPECT().GetProfileFeed(gomock.Any(), testUsername, testUserReferenceID, gomock.Any()).Return(nil, errors.New(testErrorMessage))
 
 	handler := NewProfileFeedHandler(mockProvider)
 	router := mux.NewRouter()
@@ -121,3 +125,19 @@ func TestProfileFeedHandler_ProviderGivenInvalidUserID_ReturnsBadRequest(t *test
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 	assert.Equal(t, "application/json", rr.HeaderMap.Get("Content-Type"))
 }
+
+func TestProfileFeedHandler_GivenInvalidLimit_ReturnsBadRequest(t *testing.T) {
+	testUsername := "test"
+	testUserReferenceID := uuid.New()
+
+	handler := NewProfileFeedHandler(nil)
+	router := mux.NewRouter()
+	router.Handle("/{username}/{userReferenceID}", handler).Methods("GET")
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/%s?limit=0", testUsername, testUserReferenceID.String()), nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, "{\"message\":\"limit must be a positive number\"}\n", rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
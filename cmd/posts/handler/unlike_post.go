@@ -1,18 +1,23 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
 	core "github.com/reecerussell/open-social"
 	"github.com/reecerussell/open-social/cmd/posts/repository"
+	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/eventing"
 )
 
 // UnlikePostHandler is a http.Handler used to mark a post as unliked by a user.
 type UnlikePostHandler struct {
 	core.Handler
-	repo  repository.PostRepository
-	likes repository.LikeRepository
+	repo   repository.PostRepository
+	likes  repository.LikeRepository
+	uow    database.UnitOfWork
+	events eventing.Publisher
 }
 
 // UnlikePostRequest is the request body structure.
@@ -22,10 +27,12 @@ type UnlikePostRequest struct {
 }
 
 // NewUnlikePostHandler returns a new instance of UnlikePostHandler.
-func NewUnlikePostHandler(repo repository.PostRepository, likes repository.LikeRepository) *UnlikePostHandler {
+func NewUnlikePostHandler(repo repository.PostRepository, likes repository.LikeRepository, uow database.UnitOfWork, events eventing.Publisher) *UnlikePostHandler {
 	return &UnlikePostHandler{
-		repo:  repo,
-		likes: likes,
+		repo:   repo,
+		likes:  likes,
+		uow:    uow,
+		events: events,
 	}
 }
 
@@ -52,7 +59,18 @@ func (h *UnlikePostHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.likes.Delete(ctx, post.ID(), data.UserReferenceID)
+	err = h.uow.Transaction(ctx, func(ctx context.Context) error {
+		err := h.likes.Delete(ctx, post.ID(), data.UserReferenceID)
+		if err != nil {
+			return err
+		}
+
+		event := eventing.NewEvent(eventing.PostUnliked, &eventing.PostUnlikedPayload{
+			PostReferenceID: data.PostReferenceID,
+			UserReferenceID: data.UserReferenceID,
+		})
+		return h.events.Publish(ctx, data.PostReferenceID, event)
+	})
 	if err != nil {
 		h.RespondError(w, err, http.StatusInternalServerError)
 		return
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,6 +17,9 @@ import (
 	mock "github.com/reecerussell/open-social/cmd/posts/mock/repository"
 	"github.com/reecerussell/open-social/cmd/posts/model"
 	"github.com/reecerussell/open-social/cmd/posts/repository"
+	"github.com/reecerussell/open-social/eventing"
+	"github.com/reecerussell/open-social/eventing/memory"
+	dbMock "github.com/reecerussell/open-social/mock/database"
 )
 
 func TestUnlikePostHandler(t *testing.T) {
@@ -36,7 +40,14 @@ func TestUnlikePostHandler(t *testing.T) {
 	mockLikes := mock.NewMockLikeRepository(ctrl)
 	mockLikes.EXPECT().Delete(gomock.Any(), testPostID, testUserReferenceID).Return(nil)
 
-	handler := NewUnlikePostHandler(mockRepo, mockLikes)
+	events := memory.NewPublisher()
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewUnlikePostHandler(mockRepo, mockLikes, mockUow, events)
 	rr := httptest.NewRecorder()
 
 	body := fmt.Sprintf("{\"postReferenceId\":\"%s\",\"userReferenceId\":\"%s\"}", testPostReferenceID, testUserReferenceID)
@@ -44,6 +55,21 @@ func TestUnlikePostHandler(t *testing.T) {
 	handler.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Code)
+
+	messages := events.Messages()
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, testPostReferenceID, messages[0].Key)
+
+	var event struct {
+		Type    string                      `json:"type"`
+		Version int                         `json:"version"`
+		Payload eventing.PostUnlikedPayload `json:"payload"`
+	}
+	_ = json.Unmarshal(messages[0].Value, &event)
+	assert.Equal(t, eventing.PostUnliked, event.Type)
+	assert.Equal(t, eventing.EventVersion, event.Version)
+	assert.Equal(t, testPostReferenceID, event.Payload.PostReferenceID)
+	assert.Equal(t, testUserReferenceID, event.Payload.UserReferenceID)
 }
 
 func TestUnlikePostHandler_GivenNonExistantPost_ReturnsNotFound(t *testing.T) {
@@ -56,7 +82,7 @@ func TestUnlikePostHandler_GivenNonExistantPost_ReturnsNotFound(t *testing.T) {
 	mockRepo := mock.NewMockPostRepository(ctrl)
 	mockRepo.EXPECT().Get(gomock.Any(), testPostReferenceID, testUserReferenceID).Return(nil, repository.ErrPostNotFound)
 
-	handler := NewUnlikePostHandler(mockRepo, nil)
+	handler := NewUnlikePostHandler(mockRepo, nil, nil, nil)
 	rr := httptest.NewRecorder()
 
 	body := fmt.Sprintf("{\"postReferenceId\":\"%s\",\"userReferenceId\":\"%s\"}", testPostReferenceID, testUserReferenceID)
@@ -88,7 +114,7 @@ func TestLikePostHandler_WhereUserHasNotLikedPost_ReturnsBadRequest(t *testing.T
 	mockRepo := mock.NewMockPostRepository(ctrl)
 	mockRepo.EXPECT().Get(gomock.Any(), testPostReferenceID, testUserReferenceID).Return(testPost, nil)
 
-	handler := NewUnlikePostHandler(mockRepo, nil)
+	handler := NewUnlikePostHandler(mockRepo, nil, nil, nil)
 	rr := httptest.NewRecorder()
 
 	body := fmt.Sprintf("{\"postReferenceId\":\"%s\",\"userReferenceId\":\"%s\"}", testPostReferenceID, testUserReferenceID)
@@ -118,7 +144,13 @@ func TestLikePostHandler_DeleteLikeFails_ReturnsInternalServerError(t *testing.T
 	mockLikes := mock.NewMockLikeRepository(ctrl)
 	mockLikes.EXPECT().Delete(gomock.Any(), testPostID, testUserReferenceID).Return(testError)
 
-	handler := NewUnlikePostHandler(mockRepo, mockLikes)
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewUnlikePostHandler(mockRepo, mockLikes, mockUow, nil)
 	rr := httptest.NewRecorder()
 
 	body := fmt.Sprintf("{\"postReferenceId\":\"%s\",\"userReferenceId\":\"%s\"}", testPostReferenceID, testUserReferenceID)
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
@@ -11,12 +12,17 @@ import (
 	"github.com/reecerussell/open-social/cmd/posts/provider"
 	"github.com/reecerussell/open-social/cmd/posts/repository"
 	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/eventing"
+	"github.com/reecerussell/open-social/eventing/kafka"
+	"github.com/reecerussell/open-social/eventing/outbox"
+	"github.com/reecerussell/open-social/util"
 )
 
 const (
 	connectionStringVar = "CONNECTION_STRING"
 	usersAPIVar         = "USERS_API_URL"
 	kafkaHostVar        = "KAFKA_HOST"
+	kafkaTopicVar       = "KAFKA_TOPIC"
 )
 
 func main() {
@@ -29,6 +35,11 @@ func main() {
 	likePost := ctn.GetService("LikePostHandler").(*handler.LikePostHandler)
 	unlikePost := ctn.GetService("UnlikePostHandler").(*handler.UnlikePostHandler)
 	getPost := ctn.GetService("GetPostHandler").(*handler.GetPostHandler)
+	createComment := ctn.GetService("CreateCommentHandler").(*handler.CreateCommentHandler)
+	getComments := ctn.GetService("GetCommentsHandler").(*handler.GetCommentsHandler)
+	deleteComment := ctn.GetService("DeleteCommentHandler").(*handler.DeleteCommentHandler)
+	deleteUserPosts := ctn.GetService("DeleteUserPostsHandler").(*handler.DeleteUserPostsHandler)
+	export := ctn.GetService("ExportHandler").(*handler.ExportHandler)
 
 	app := core.NewApp()
 	app.AddHealthCheck(database.NewHealthCheck(db))
@@ -38,8 +49,21 @@ func main() {
 	app.Get("/posts/{postReferenceID}/{userReferenceID}", getPost)
 	app.Post("/posts/like", likePost)
 	app.Post("/posts/unlike", unlikePost)
+	app.Post("/posts/{postReferenceID}/comments", createComment)
+	app.Get("/posts/{postReferenceID}/comments/{userReferenceID}", getComments)
+	app.Delete("/posts/{postReferenceID}/comments/{commentReferenceID}/{userReferenceID}", deleteComment)
 	app.Get("/feed/{userReferenceId}", feedhandler)
 	app.Get("/profile/feed/{username}/{userReferenceID}", profileFeedHandler)
+	app.Post("/users/delete/{userReferenceID}", deleteUserPosts)
+	app.Get("/users/export/{userReferenceID}", export)
+
+	relay := ctn.GetService("OutboxRelay").(*outbox.Relay)
+	ctx, cancel := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(relayDone)
+	}()
 
 	go app.Serve()
 
@@ -47,6 +71,15 @@ func main() {
 	signal.Notify(stop, os.Interrupt, os.Kill)
 	<-stop
 
+	cancel()
+	<-relayDone
+
+	kafkaPublisher := ctn.GetService("KafkaPublisher").(*kafka.Publisher)
+	err := kafkaPublisher.Close()
+	if err != nil {
+		log.Printf("Error: failed to close event publisher: %v\n", err)
+	}
+
 	log.Println("App stopped.")
 }
 
@@ -63,14 +96,43 @@ func buildServices() *core.Container {
 		return db
 	})
 
+	ctn.AddSingleton("KafkaPublisher", func(ctn *core.Container) interface{} {
+		host := os.Getenv(kafkaHostVar)
+		topic := util.ReadEnv(kafkaTopicVar, "posts")
+		return kafka.NewPublisher(host, topic)
+	})
+
+	ctn.AddService("EventPublisher", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		topic := util.ReadEnv(kafkaTopicVar, "posts")
+		return outbox.NewPublisher(db, topic)
+	})
+
+	ctn.AddSingleton("OutboxRelay", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		pub := ctn.GetService("KafkaPublisher").(*kafka.Publisher)
+		topic := util.ReadEnv(kafkaTopicVar, "posts")
+		return outbox.NewRelay(db, topic, pub, outbox.DefaultInterval)
+	})
+
 	ctn.AddService("PostProvider", func(ctn *core.Container) interface{} {
 		db := ctn.GetService("Database").(database.Database)
 		return provider.NewPostProvider(db)
 	})
 
+	ctn.AddService("CommentProvider", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return provider.NewCommentProvider(db)
+	})
+
+	ctn.AddService("ExportProvider", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return provider.NewExportProvider(db)
+	})
+
 	ctn.AddService("PostRepository", func(ctn *core.Container) interface{} {
-		url := os.Getenv(connectionStringVar)
-		return repository.NewPostRepository(url)
+		db := ctn.GetService("Database").(database.Database)
+		return repository.NewPostRepository(db)
 	})
 
 	ctn.AddService("LikeRepository", func(ctn *core.Container) interface{} {
@@ -78,6 +140,11 @@ func buildServices() *core.Container {
 		return repository.NewLikeRepository(db)
 	})
 
+	ctn.AddService("CommentRepository", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return repository.NewCommentRepository(db)
+	})
+
 	ctn.AddSingleton("UserClient", func(ctn *core.Container) interface{} {
 		url := os.Getenv(usersAPIVar)
 		return users.New(url)
@@ -86,8 +153,10 @@ func buildServices() *core.Container {
 	ctn.AddService("CreatePostHandler", func(ctn *core.Container) interface{} {
 		repo := ctn.GetService("PostRepository").(repository.PostRepository)
 		client := ctn.GetService("UserClient").(users.Client)
+		db := ctn.GetService("Database").(database.Database)
+		events := ctn.GetService("EventPublisher").(eventing.Publisher)
 
-		return handler.NewCreatePostHandler(repo, client)
+		return handler.NewCreatePostHandler(repo, client, db, events)
 	})
 
 	ctn.AddService("FeedHandler", func(ctn *core.Container) interface{} {
@@ -105,15 +174,19 @@ func buildServices() *core.Container {
 	ctn.AddService("LikePostHandler", func(ctn *core.Container) interface{} {
 		repo := ctn.GetService("PostRepository").(repository.PostRepository)
 		likes := ctn.GetService("LikeRepository").(repository.LikeRepository)
+		db := ctn.GetService("Database").(database.Database)
+		events := ctn.GetService("EventPublisher").(eventing.Publisher)
 
-		return handler.NewLikePostHandler(repo, likes)
+		return handler.NewLikePostHandler(repo, likes, db, events)
 	})
 
 	ctn.AddService("UnlikePostHandler", func(ctn *core.Container) interface{} {
 		repo := ctn.GetService("PostRepository").(repository.PostRepository)
 		likes := ctn.GetService("LikeRepository").(repository.LikeRepository)
+		db := ctn.GetService("Database").(database.Database)
+		events := ctn.GetService("EventPublisher").(eventing.Publisher)
 
-		return handler.NewUnlikePostHandler(repo, likes)
+		return handler.NewUnlikePostHandler(repo, likes, db, events)
 	})
 
 	ctn.AddService("GetPostHandler", func(ctn *core.Container) interface{} {
@@ -122,5 +195,41 @@ func buildServices() *core.Container {
 		return handler.NewGetPostHandler(provider)
 	})
 
+	ctn.AddService("CreateCommentHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("PostRepository").(repository.PostRepository)
+		comments := ctn.GetService("CommentRepository").(repository.CommentRepository)
+		client := ctn.GetService("UserClient").(users.Client)
+		db := ctn.GetService("Database").(database.Database)
+		events := ctn.GetService("EventPublisher").(eventing.Publisher)
+
+		return handler.NewCreateCommentHandler(repo, comments, client, db, events)
+	})
+
+	ctn.AddService("GetCommentsHandler", func(ctn *core.Container) interface{} {
+		provider := ctn.GetService("CommentProvider").(provider.CommentProvider)
+
+		return handler.NewGetCommentsHandler(provider)
+	})
+
+	ctn.AddService("DeleteCommentHandler", func(ctn *core.Container) interface{} {
+		comments := ctn.GetService("CommentRepository").(repository.CommentRepository)
+		client := ctn.GetService("UserClient").(users.Client)
+
+		return handler.NewDeleteCommentHandler(comments, client)
+	})
+
+	ctn.AddService("DeleteUserPostsHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("PostRepository").(repository.PostRepository)
+		db := ctn.GetService("Database").(database.Database)
+
+		return handler.NewDeleteUserPostsHandler(repo, db)
+	})
+
+	ctn.AddService("ExportHandler", func(ctn *core.Container) interface{} {
+		provider := ctn.GetService("ExportProvider").(provider.ExportProvider)
+
+		return handler.NewExportHandler(provider)
+	})
+
 	return ctn
 }
@@ -1,5 +1,8 @@
 //go:generate mockgen -package=mock -source=../repository/post_repository.go -destination=repository/post_repository.go
 //go:generate mockgen -package=mock -source=../repository/like_repository.go -destination=repository/like_repository.go
+//go:generate mockgen -package=mock -source=../repository/comment_repository.go -destination=repository/comment_repository.go
 //go:generate mockgen -package=mock -source=../provider/post_provider.go -destination=provider/post_provider.go
+//go:generate mockgen -package=mock -source=../provider/comment_provider.go -destination=provider/comment_provider.go
+//go:generate mockgen -package=mock -source=../provider/export_provider.go -destination=provider/export_provider.go
 
 package mock
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../provider/comment_provider.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/reecerussell/open-social/cmd/posts/dto"
+	reflect "reflect"
+)
+
+// MockCommentProvider is a mock of CommentProvider interface.
+type MockCommentProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentProviderMockRecorder
+}
+
+// MockCommentProviderMockRecorder is the mock recorder for MockCommentProvider.
+type MockCommentProviderMockRecorder struct {
+	mock *MockCommentProvider
+}
+
+// NewMockCommentProvider creates a new mock instance.
+func NewMockCommentProvider(ctrl *gomock.Controller) *MockCommentProvider {
+	mock := &MockCommentProvider{ctrl: ctrl}
+	mock.recorder = &MockCommentProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentProvider) EXPECT() *MockCommentProviderMockRecorder {
+	return m.recorder
+}
+
+// GetComments mocks base method.
+func (m *MockCommentProvider) GetComments(ctx context.Context, postReferenceID, userReferenceID string) ([]*dto.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ctx, postReferenceID, userReferenceID)
+	ret0, _ := ret[0].([]*dto.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentProviderMockRecorder) GetComments(ctx, postReferenceID, userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockCommentProvider)(nil).GetComments), ctx, postReferenceID, userReferenceID)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../provider/export_provider.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/reecerussell/open-social/cmd/posts/dto"
+	reflect "reflect"
+)
+
+// MockExportProvider is a mock of ExportProvider interface.
+type MockExportProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportProviderMockRecorder
+}
+
+// MockExportProviderMockRecorder is the mock recorder for MockExportProvider.
+type MockExportProviderMockRecorder struct {
+	mock *MockExportProvider
+}
+
+// NewMockExportProvider creates a new mock instance.
+func NewMockExportProvider(ctrl *gomock.Controller) *MockExportProvider {
+	mock := &MockExportProvider{ctrl: ctrl}
+	mock.recorder = &MockExportProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportProvider) EXPECT() *MockExportProviderMockRecorder {
+	return m.recorder
+}
+
+// GetExport mocks base method.
+func (m *MockExportProvider) GetExport(ctx context.Context, userReferenceID string) (*dto.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, userReferenceID)
+	ret0, _ := ret[0].(*dto.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockExportProviderMockRecorder) GetExport(ctx, userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockExportProvider)(nil).GetExport), ctx, userReferenceID)
+}
@@ -9,6 +9,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
 	dto "github.com/reecerussell/open-social/cmd/posts/dto"
+	pagination "github.com/reecerussell/open-social/cmd/posts/pagination"
 	reflect "reflect"
 )
 
@@ -51,16 +52,16 @@ func (mr *MockPostProviderMockRecorder) Get(ctx, postReferenceID, userReferenceI
 }
 
 // GetProfileFeed mocks base method.
-func (m *MockPostProvider) GetProfileFeed(ctx context.Context, username string, userReferenceID uuid.UUID) ([]*dto.FeedItem, error) {
+func (m *MockPostProvider) GetProfileFeed(ctx context.Context, username string, userReferenceID uuid.UUID, page *pagination.Page) (*dto.Feed, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProfileFeed", ctx, username, userReferenceID)
-	ret0, _ := ret[0].([]*dto.FeedItem)
+	ret := m.ctrl.Call(m, "GetProfileFeed", ctx, username, userReferenceID, page)
+	ret0, _ := ret[0].(*dto.Feed)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProfileFeed indicates an expected call of GetProfileFeed.
-func (mr *MockPostProviderMockRecorder) GetProfileFeed(ctx, username, userReferenceID interface{}) *gomock.Call {
+func (mr *MockPostProviderMockRecorder) GetProfileFeed(ctx, username, userReferenceID, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileFeed", reflect.TypeOf((*MockPostProvider)(nil).GetProfileFeed), ctx, username, userReferenceID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileFeed", reflect.TypeOf((*MockPostProvider)(nil).GetProfileFeed), ctx, username, userReferenceID, page)
 }
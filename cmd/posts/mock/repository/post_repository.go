@@ -9,6 +9,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	dto "github.com/reecerussell/open-social/cmd/posts/dto"
 	model "github.com/reecerussell/open-social/cmd/posts/model"
+	pagination "github.com/reecerussell/open-social/cmd/posts/pagination"
 	reflect "reflect"
 )
 
@@ -50,18 +51,18 @@ func (mr *MockPostRepositoryMockRecorder) Create(ctx, p interface{}) *gomock.Cal
 }
 
 // GetFeed mocks base method.
-func (m *MockPostRepository) GetFeed(ctx context.Context, userReferenceID string) ([]*dto.FeedItem, error) {
+func (m *MockPostRepository) GetFeed(ctx context.Context, userReferenceID string, page *pagination.Page) (*dto.Feed, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFeed", ctx, userReferenceID)
-	ret0, _ := ret[0].([]*dto.FeedItem)
+	ret := m.ctrl.Call(m, "GetFeed", ctx, userReferenceID, page)
+	ret0, _ := ret[0].(*dto.Feed)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFeed indicates an expected call of GetFeed.
-func (mr *MockPostRepositoryMockRecorder) GetFeed(ctx, userReferenceID interface{}) *gomock.Call {
+func (mr *MockPostRepositoryMockRecorder) GetFeed(ctx, userReferenceID, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockPostRepository)(nil).GetFeed), ctx, userReferenceID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockPostRepository)(nil).GetFeed), ctx, userReferenceID, page)
 }
 
 // Get mocks base method.
@@ -78,3 +79,18 @@ func (mr *MockPostRepositoryMockRecorder) Get(ctx, referenceID, userReferenceID
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPostRepository)(nil).Get), ctx, referenceID, userReferenceID)
 }
+
+// DeleteForUser mocks base method.
+func (m *MockPostRepository) DeleteForUser(ctx context.Context, userReferenceID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForUser", ctx, userReferenceID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteForUser indicates an expected call of DeleteForUser.
+func (mr *MockPostRepositoryMockRecorder) DeleteForUser(ctx, userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForUser", reflect.TypeOf((*MockPostRepository)(nil).DeleteForUser), ctx, userReferenceID)
+}
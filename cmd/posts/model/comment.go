@@ -0,0 +1,130 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/reecerussell/open-social/cmd/posts/dao"
+)
+
+const (
+	maxCommentLength = 255
+)
+
+// Comment is a domain model for a comment on a post.
+type Comment struct {
+	id              int
+	referenceID     string
+	postID          int
+	postReferenceID string
+	postUserID      int
+	userID          int
+	posted          time.Time
+	text            string
+}
+
+// NewComment returns a new instance of the Comment domain model,
+// providing the given text is valid. This function assumes that
+// both postID and userID are valid ids.
+func NewComment(postID, userID int, text string) (*Comment, error) {
+	c := &Comment{
+		postID: postID,
+		userID: userID,
+		posted: time.Now().UTC(),
+	}
+
+	err := c.updateText(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// ID returns the comment's id.
+func (c *Comment) ID() int {
+	return c.id
+}
+
+// ReferenceID returns the comment's reference id.
+func (c *Comment) ReferenceID() string {
+	return c.referenceID
+}
+
+// PostReferenceID returns the reference id of the post the comment was made on.
+func (c *Comment) PostReferenceID() string {
+	return c.postReferenceID
+}
+
+// Text returns the comment's text.
+func (c *Comment) Text() string {
+	return c.text
+}
+
+func (c *Comment) updateText(text string) error {
+	text = strings.TrimSpace(text)
+
+	if text == "" {
+		return errors.New("comment cannot be empty")
+	}
+
+	if len(text) > maxCommentLength {
+		return fmt.Errorf("comment cannot be greater than %d characters long", maxCommentLength)
+	}
+
+	c.text = text
+
+	return nil
+}
+
+// SetID sets the id of the comment.
+func (c *Comment) SetID(id int) {
+	c.id = id
+}
+
+// SetReferenceID sets the reference id of the comment.
+func (c *Comment) SetReferenceID(referenceID string) {
+	c.referenceID = referenceID
+}
+
+// CanDelete determines if the user with the given id can delete this comment.
+// Comments can only be deleted by their author, or the author of the post.
+func (c *Comment) CanDelete(userID int) error {
+	if c.userID != userID && c.postUserID != userID {
+		return errors.New("user cannot delete this comment")
+	}
+
+	return nil
+}
+
+// Dao returns a data access object populated with the comment's data.
+func (c *Comment) Dao() *dao.Comment {
+	return &dao.Comment{
+		ID:              c.id,
+		ReferenceID:     c.referenceID,
+		PostID:          c.postID,
+		PostReferenceID: c.postReferenceID,
+		PostUserID:      c.postUserID,
+		UserID:          c.userID,
+		Posted:          c.posted,
+		Text:            c.text,
+	}
+}
+
+// CommentFromDao returns a new instance of Comment, populated with the
+// data from the data access object. This should only be used
+// by the CommentRepository, to instantiate new domain models.
+func CommentFromDao(d *dao.Comment) *Comment {
+	return &Comment{
+		id:              d.ID,
+		referenceID:     d.ReferenceID,
+		postID:          d.PostID,
+		postReferenceID: d.PostReferenceID,
+		postUserID:      d.PostUserID,
+		userID:          d.UserID,
+		posted:          d.Posted,
+		text:            d.Text,
+	}
+}
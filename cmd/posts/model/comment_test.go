@@ -0,0 +1,137 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/posts/dao"
+)
+
+func TestNewComment(t *testing.T) {
+	c, err := NewComment(12, 123, "  Nice post!")
+	assert.NoError(t, err)
+	assert.Equal(t, 12, c.postID)
+	assert.Equal(t, 123, c.userID)
+	assert.Equal(t, "Nice post!", c.text)
+}
+
+func TestComment_UpdateText_ReturnsError(t *testing.T) {
+	t.Run("Empty Text", func(t *testing.T) {
+		c, err := NewComment(12, 123, "   ")
+		assert.Nil(t, c)
+		assert.Equal(t, "comment cannot be empty", err.Error())
+	})
+
+	t.Run("Long Text", func(t *testing.T) {
+		str := make([]rune, maxCommentLength+1)
+		for i := range str {
+			str[i] = 'a'
+		}
+
+		c, err := NewComment(12, 123, string(str))
+		assert.Nil(t, c)
+
+		exp := fmt.Sprintf("comment cannot be greater than %d characters long", maxCommentLength)
+		assert.Equal(t, exp, err.Error())
+	})
+}
+
+func TestComment_SetID(t *testing.T) {
+	const testID = 123
+
+	var comment Comment
+	comment.SetID(testID)
+
+	assert.Equal(t, testID, comment.ID())
+}
+
+func TestComment_SetReferenceID(t *testing.T) {
+	const testReferenceID = "273021"
+
+	var comment Comment
+	comment.SetReferenceID(testReferenceID)
+
+	assert.Equal(t, testReferenceID, comment.ReferenceID())
+}
+
+func TestComment_CanDelete(t *testing.T) {
+	comment := &Comment{
+		userID:     1,
+		postUserID: 2,
+	}
+
+	t.Run("Comment Author", func(t *testing.T) {
+		err := comment.CanDelete(1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Post Author", func(t *testing.T) {
+		err := comment.CanDelete(2)
+		assert.NoError(t, err)
+	})
+}
+
+func TestComment_CanDelete_ReturnsError(t *testing.T) {
+	comment := &Comment{
+		userID:     1,
+		postUserID: 2,
+	}
+
+	err := comment.CanDelete(3)
+	assert.Equal(t, "user cannot delete this comment", err.Error())
+}
+
+func TestComment_Dao(t *testing.T) {
+	testPosted := time.Now().UTC()
+
+	comment := &Comment{
+		id:              1,
+		referenceID:     "3y294",
+		postID:          2,
+		postReferenceID: "2384",
+		postUserID:      3,
+		userID:          4,
+		posted:          testPosted,
+		text:            "Hello World",
+	}
+
+	d := comment.Dao()
+
+	assert.Equal(t, 1, d.ID)
+	assert.Equal(t, "3y294", d.ReferenceID)
+	assert.Equal(t, 2, d.PostID)
+	assert.Equal(t, "2384", d.PostReferenceID)
+	assert.Equal(t, 3, d.PostUserID)
+	assert.Equal(t, 4, d.UserID)
+	assert.Equal(t, testPosted, d.Posted)
+	assert.Equal(t, "Hello World", d.Text)
+}
+
+func TestCommentFromDao(t *testing.T) {
+	testPosted := time.Now().UTC()
+
+	d := &dao.Comment{
+		ID:              1,
+		ReferenceID:     "3y294",
+		PostID:          2,
+		PostReferenceID: "2384",
+		PostUserID:      3,
+		UserID:          4,
+		Posted:          testPosted,
+		Text:            "Hello World",
+	}
+
+	comment := CommentFromDao(d)
+
+	assert.Equal(t, 1, comment.id)
+	assert.Equal(t, "3y294", comment.referenceID)
+	assert.Equal(t, 2, comment.postID)
+	assert.Equal(t, "2384", comment.PostReferenceID())
+	assert.Equal(t, 3, comment.postUserID)
+	assert.Equal(t, 4, comment.userID)
+	assert.Equal(t, testPosted, comment.posted)
+	assert.Equal(t, "Hello World", comment.text)
+}
@@ -54,6 +54,11 @@ func (p *Post) ReferenceID() string {
 	return p.referenceID
 }
 
+// Caption returns the post's caption.
+func (p *Post) Caption() string {
+	return p.caption
+}
+
 func (p *Post) updateCaption(caption string) error {
 	caption = strings.TrimSpace(caption)
 
@@ -0,0 +1,150 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/reecerussell/open-social/cmd/posts/dto"
+)
+
+const (
+	// DefaultLimit is the number of items in a page, if a limit is not given.
+	DefaultLimit = 20
+
+	// MaxLimit is the maximum number of items that can be requested in a page.
+	MaxLimit = 50
+)
+
+// Common errors
+var (
+	ErrInvalidCursor = errors.New("cursor is invalid")
+	ErrInvalidLimit  = errors.New("limit must be a positive number")
+)
+
+// Cursor is a keyset position within a feed. Feeds are ordered by Posted and
+// then ReferenceID, both descending, so a cursor points to the last item read.
+type Cursor struct {
+	Posted      time.Time
+	ReferenceID string
+}
+
+// String returns an opaque, url-safe representation of the cursor.
+func (c *Cursor) String() string {
+	value := c.Posted.UTC().Format(time.RFC3339Nano) + "|" + c.ReferenceID
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// ParseCursor reads a cursor from a value returned by Cursor.String.
+func ParseCursor(value string) (*Cursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(bytes), "|", 2)
+	if len(parts) != 2 {
+		return nil, ErrInvalidCursor
+	}
+
+	posted, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if _, err := uuid.Parse(parts[1]); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{
+		Posted:      posted,
+		ReferenceID: parts[1],
+	}, nil
+}
+
+// Page describes which page of a feed to read.
+type Page struct {
+	// Cursor is the position of the last item read. If nil,
+	// the first page is read.
+	Cursor *Cursor
+
+	// Limit is the maximum number of items in the page.
+	Limit int
+}
+
+// CursorPosted returns the cursor's posted date, or nil if there is no cursor.
+// This is useful as a nullable query parameter.
+func (p *Page) CursorPosted() interface{} {
+	if p.Cursor == nil {
+		return nil
+	}
+
+	return p.Cursor.Posted
+}
+
+// CursorReferenceID returns the cursor's reference id, or nil if there
+// is no cursor. This is useful as a nullable query parameter.
+func (p *Page) CursorReferenceID() interface{} {
+	if p.Cursor == nil {
+		return nil
+	}
+
+	return p.Cursor.ReferenceID
+}
+
+// PageFromRequest reads a Page from the "cursor" and "limit" query parameters.
+func PageFromRequest(r *http.Request) (*Page, error) {
+	query := r.URL.Query()
+	page := &Page{Limit: DefaultLimit}
+
+	if value := query.Get("cursor"); value != "" {
+		cursor, err := ParseCursor(value)
+		if err != nil {
+			return nil, err
+		}
+
+		page.Cursor = cursor
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return nil, ErrInvalidLimit
+		}
+
+		if limit > MaxLimit {
+			limit = MaxLimit
+		}
+
+		page.Limit = limit
+	}
+
+	return page, nil
+}
+
+// NewFeed builds a feed from items read for the given page. The items should
+// contain up to one more item than the page's limit, in which case the extra
+// item is dropped and the feed's next cursor is set.
+func NewFeed(items []*dto.FeedItem, page *Page) *dto.Feed {
+	feed := &dto.Feed{
+		Items: items,
+	}
+
+	if len(items) > page.Limit {
+		feed.Items = items[:page.Limit]
+
+		last := feed.Items[page.Limit-1]
+		cursor := (&Cursor{
+			Posted:      last.Posted,
+			ReferenceID: last.ID,
+		}).String()
+		feed.NextCursor = &cursor
+	}
+
+	return feed
+}
@@ -0,0 +1,106 @@
+package pagination
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/posts/dto"
+)
+
+func TestCursor_String_CanBeParsed(t *testing.T) {
+	cursor := &Cursor{
+		Posted:      time.Date(2021, 3, 4, 12, 30, 15, 123000000, time.UTC),
+		ReferenceID: uuid.New().String(),
+	}
+
+	parsed, err := ParseCursor(cursor.String())
+	assert.NoError(t, err)
+	assert.True(t, cursor.Posted.Equal(parsed.Posted))
+	assert.Equal(t, cursor.ReferenceID, parsed.ReferenceID)
+}
+
+func TestParseCursor_GivenInvalidValue_ReturnsError(t *testing.T) {
+	t.Run("Invalid Encoding", func(t *testing.T) {
+		cursor, err := ParseCursor("!!!")
+		assert.Nil(t, cursor)
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+
+	t.Run("Missing Separator", func(t *testing.T) {
+		cursor, err := ParseCursor("aGVsbG8")
+		assert.Nil(t, cursor)
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+
+	t.Run("Invalid Reference", func(t *testing.T) {
+		value := (&Cursor{Posted: time.Now(), ReferenceID: "123"}).String()
+		cursor, err := ParseCursor(value)
+		assert.Nil(t, cursor)
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+}
+
+func TestPageFromRequest(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodGet, "/", nil)
+		page, err := PageFromRequest(r)
+		assert.NoError(t, err)
+		assert.Nil(t, page.Cursor)
+		assert.Equal(t, DefaultLimit, page.Limit)
+		assert.Nil(t, page.CursorPosted())
+		assert.Nil(t, page.CursorReferenceID())
+	})
+
+	t.Run("Cursor And Limit", func(t *testing.T) {
+		cursor := &Cursor{Posted: time.Now().UTC(), ReferenceID: uuid.New().String()}
+		r, _ := http.NewRequest(http.MethodGet, "/?limit=5&cursor="+cursor.String(), nil)
+		page, err := PageFromRequest(r)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, page.Limit)
+		assert.Equal(t, cursor.ReferenceID, page.CursorReferenceID())
+	})
+
+	t.Run("Limit Is Capped", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodGet, "/?limit=1000", nil)
+		page, err := PageFromRequest(r)
+		assert.NoError(t, err)
+		assert.Equal(t, MaxLimit, page.Limit)
+	})
+}
+
+func TestPageFromRequest_GivenInvalidLimit_ReturnsError(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodGet, "/?limit=abc", nil)
+	page, err := PageFromRequest(r)
+	assert.Nil(t, page)
+	assert.Equal(t, ErrInvalidLimit, err)
+}
+
+func TestNewFeed(t *testing.T) {
+	testPosted := time.Now().UTC()
+	testReferenceID := uuid.New().String()
+	items := []*dto.FeedItem{
+		{ID: uuid.New().String()},
+		{ID: testReferenceID, Posted: testPosted},
+		{ID: uuid.New().String()},
+	}
+
+	t.Run("Has Next Page", func(t *testing.T) {
+		feed := NewFeed(items, &Page{Limit: 2})
+		assert.Equal(t, 2, len(feed.Items))
+
+		cursor, err := ParseCursor(*feed.NextCursor)
+		assert.NoError(t, err)
+		assert.True(t, testPosted.Equal(cursor.Posted))
+		assert.Equal(t, testReferenceID, cursor.ReferenceID)
+	})
+
+	t.Run("Last Page", func(t *testing.T) {
+		feed := NewFeed(items, &Page{Limit: 3})
+		assert.Equal(t, 3, len(feed.Items))
+		assert.Nil(t, feed.NextCursor)
+	})
+}
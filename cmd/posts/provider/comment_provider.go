@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/reecerussell/open-social/cmd/posts/dto"
+	"github.com/reecerussell/open-social/database"
+)
+
+// CommentProvider is used to read post comment data.
+type CommentProvider interface {
+	// GetComments gets the comments on a post. If the post doesn't exist, or isn't
+	// visible to the user with userReferenceID, ErrPostNotFound is returned. Comments
+	// by users who blocked, or were blocked by, the user are left out.
+	GetComments(ctx context.Context, postReferenceID, userReferenceID string) ([]*dto.Comment, error)
+}
+
+type commentProvider struct {
+	db database.Database
+}
+
+// NewCommentProvider returns a new instance of CommentProvider.
+func NewCommentProvider(db database.Database) CommentProvider {
+	return &commentProvider{db: db}
+}
+
+func (p *commentProvider) GetComments(ctx context.Context, postReferenceID, userReferenceID string) ([]*dto.Comment, error) {
+	const query = `SELECT
+		CAST([C].[ReferenceId] AS CHAR(36)) AS [Id],
+		[C].[Text],
+		[C].[Posted],
+		[U].[Username],
+		CASE [U].[ReferenceId]
+			WHEN @userReferenceId THEN CAST(1 AS BIT)
+			ELSE CAST(0 AS BIT)
+		END AS [IsAuthor]
+	FROM [PostComments] AS [C]
+	INNER JOIN [Posts] AS [P] ON [P].[Id] = [C].[PostId]
+	INNER JOIN [Users] AS [U] ON [U].[Id] = [C].[UserId]
+	LEFT JOIN [Users] AS [CU] ON [CU].[ReferenceId] = @userReferenceId
+	WHERE [P].[ReferenceId] = @postReferenceId
+		AND [dbo].CanViewProfile([P].[UserId], @userReferenceId) = 1
+		AND [dbo].IsBlocked([C].[UserId], [CU].[Id]) = 0
+	ORDER BY [C].[Posted] ASC;`
+
+	rows, err := p.db.Multiple(ctx, query,
+		sql.Named("postReferenceId", postReferenceID),
+		sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return nil, err
+	}
+
+	comments := []*dto.Comment{}
+
+	for rows.Next() {
+		var comment dto.Comment
+		err := rows.Scan(
+			&comment.ID,
+			&comment.Text,
+			&comment.Posted,
+			&comment.Username,
+			&comment.IsAuthor,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, &comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// No comments is ambiguous, so check the post exists, and can be viewed.
+	if len(comments) == 0 {
+		err = p.checkPost(ctx, postReferenceID, userReferenceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return comments, nil
+}
+
+func (p *commentProvider) checkPost(ctx context.Context, postReferenceID, userReferenceID string) error {
+	const query = `SELECT [P].[Id]
+	FROM [Posts] AS [P]
+	WHERE [P].[ReferenceId] = @postReferenceId
+		AND [dbo].CanViewProfile([P].[UserId], @userReferenceId) = 1;`
+
+	row, err := p.db.Single(ctx, query,
+		sql.Named("postReferenceId", postReferenceID),
+		sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return err
+	}
+
+	var id int
+	err = row.Scan(&id)
+	if err == sql.ErrNoRows {
+		return ErrPostNotFound
+	}
+
+	return err
+}
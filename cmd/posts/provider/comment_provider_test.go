@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestCommentProvider_GetComments_ReturnsComments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testPostReferenceID := "3290"
+	testUserReferenceID := "2389"
+	testPosted := time.Now().UTC()
+	testCtx := context.Background()
+
+	readCount := 0
+
+	mockRows := mock.NewMockRows(ctrl)
+	mockRows.EXPECT().Next().DoAndReturn(func() bool {
+		if readCount > 0 {
+			return false
+		}
+
+		readCount++
+		return true
+	}).Times(2)
+	mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*string)) = "12"
+		*(dest[1].(*string)) = "Hello World"
+		*(dest[2].(*time.Time)) = testPosted
+		*(dest[3].(*string)) = "test"
+		*(dest[4].(*bool)) = true
+
+		return nil
+	})
+	mockRows.EXPECT().Err().Return(nil)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
+
+	p := NewCommentProvider(mockDatabase)
+	comments, err := p.GetComments(testCtx, testPostReferenceID, testUserReferenceID)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(comments))
+	assert.Equal(t, "12", comments[0].ID)
+	assert.Equal(t, "Hello World", comments[0].Text)
+	assert.Equal(t, testPosted, comments[0].Posted)
+	assert.Equal(t, "test", comments[0].Username)
+	assert.True(t, comments[0].IsAuthor)
+}
+
+func TestCommentProvider_GetCommentsQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(nil, testError)
+
+	p := NewCommentProvider(mockDatabase)
+	comments, err := p.GetComments(testCtx, "3290", "2389")
+	assert.Nil(t, comments)
+	assert.Equal(t, testError, err)
+}
+
+func TestCommentProvider_GetCommentsScanFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockRows := mock.NewMockRows(ctrl)
+	mockRows.EXPECT().Next().Return(true)
+	mockRows.EXPECT().Scan(gomock.Any()).Return(testError)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
+
+	p := NewCommentProvider(mockDatabase)
+	comments, err := p.GetComments(testCtx, "3290", "2389")
+	assert.Nil(t, comments)
+	assert.Equal(t, testError, err)
+}
+
+func TestCommentProvider_GetComments_NoComments_ReturnsEmpty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRows := mock.NewMockRows(ctrl)
+	mockRows.EXPECT().Next().Return(false)
+	mockRows.EXPECT().Err().Return(nil)
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).Return(nil)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	p := NewCommentProvider(mockDatabase)
+	comments, err := p.GetComments(testCtx, "3290", "2389")
+	assert.NoError(t, err)
+	assert.NotNil(t, comments)
+	assert.Empty(t, comments)
+}
+
+func TestCommentProvider_GetComments_PostNotFound_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRows := mock.NewMockRows(ctrl)
+	mockRows.EXPECT().Next().Return(false)
+	mockRows.EXPECT().Err().Return(nil)
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).Return(sql.ErrNoRows)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	p := NewCommentProvider(mockDatabase)
+	comments, err := p.GetComments(testCtx, "3290", "2389")
+	assert.Nil(t, comments)
+	assert.Equal(t, ErrPostNotFound, err)
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/reecerussell/open-social/cmd/posts/dto"
+	"github.com/reecerussell/open-social/database"
+)
+
+// ExportProvider is used to read all of a user's post data, for their personal data export.
+type ExportProvider interface {
+	GetExport(ctx context.Context, userReferenceID string) (*dto.Export, error)
+}
+
+type exportProvider struct {
+	db database.Database
+}
+
+// NewExportProvider returns a new instance of ExportProvider.
+func NewExportProvider(db database.Database) ExportProvider {
+	return &exportProvider{db: db}
+}
+
+func (p *exportProvider) GetExport(ctx context.Context, userReferenceID string) (*dto.Export, error) {
+	posts, err := p.getPosts(ctx, userReferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	likes, err := p.getLikes(ctx, userReferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := p.getComments(ctx, userReferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &dto.Export{
+		Posts:    posts,
+		Likes:    likes,
+		Comments: comments,
+	}
+
+	return export, nil
+}
+
+func (p *exportProvider) getPosts(ctx context.Context, userReferenceID string) ([]*dto.ExportPost, error) {
+	const query = `SELECT
+		CAST([P].[ReferenceId] AS CHAR(36)),
+		CAST([M].[ReferenceId] AS CHAR(36)),
+		[P].[Posted],
+		[P].[Caption]
+	FROM [Posts] AS [P]
+	INNER JOIN [Users] AS [U] ON [U].[Id] = [P].[UserId]
+	LEFT JOIN [Media] AS [M] ON [M].[Id] = [P].[MediaId]
+	WHERE [U].[ReferenceId] = @userReferenceId
+	ORDER BY [P].[Posted];`
+
+	rows, err := p.db.Multiple(ctx, query, sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return nil, err
+	}
+
+	posts := []*dto.ExportPost{}
+
+	for rows.Next() {
+		var post dto.ExportPost
+		err := rows.Scan(&post.ID, &post.MediaID, &post.Posted, &post.Caption)
+		if err != nil {
+			return nil, err
+		}
+
+		posts = append(posts, &post)
+	}
+
+	return posts, rows.Err()
+}
+
+func (p *exportProvider) getLikes(ctx context.Context, userReferenceID string) ([]*dto.ExportLike, error) {
+	const query = `SELECT CAST([P].[ReferenceId] AS CHAR(36))
+	FROM [PostLikes] AS [L]
+	INNER JOIN [Posts] AS [P] ON [P].[Id] = [L].[PostId]
+	INNER JOIN [Users] AS [U] ON [U].[Id] = [L].[UserId]
+	WHERE [U].[ReferenceId] = @userReferenceId;`
+
+	rows, err := p.db.Multiple(ctx, query, sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return nil, err
+	}
+
+	likes := []*dto.ExportLike{}
+
+	for rows.Next() {
+		var like dto.ExportLike
+		err := rows.Scan(&like.PostID)
+		if err != nil {
+			return nil, err
+		}
+
+		likes = append(likes, &like)
+	}
+
+	return likes, rows.Err()
+}
+
+func (p *exportProvider) getComments(ctx context.Context, userReferenceID string) ([]*dto.ExportComment, error) {
+	const query = `SELECT
+		CAST([C].[ReferenceId] AS CHAR(36)),
+		CAST([P].[ReferenceId] AS CHAR(36)),
+		[C].[Posted],
+		[C].[Text]
+	FROM [PostComments] AS [C]
+	INNER JOIN [Posts] AS [P] ON [P].[Id] = [C].[PostId]
+	INNER JOIN [Users] AS [U] ON [U].[Id] = [C].[UserId]
+	WHERE [U].[ReferenceId] = @userReferenceId
+	ORDER BY [C].[Posted];`
+
+	rows, err := p.db.Multiple(ctx, query, sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return nil, err
+	}
+
+	comments := []*dto.ExportComment{}
+
+	for rows.Next() {
+		var comment dto.ExportComment
+		err := rows.Scan(&comment.ID, &comment.PostID, &comment.Posted, &comment.Text)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, &comment)
+	}
+
+	return comments, rows.Err()
+}
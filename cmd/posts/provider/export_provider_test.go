@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+func newSingleMockRows(ctrl *gomock.Controller, scan func(dest ...interface{}) error) *mock.MockRows {
+	rows := mock.NewMockRows(ctrl)
+	gomock.InOrder(
+		rows.EXPECT().Next().Return(true),
+		rows.EXPECT().Scan(gomock.Any()).DoAndReturn(scan),
+		rows.EXPECT().Next().Return(false),
+	)
+	rows.EXPECT().Err().Return(nil)
+
+	return rows
+}
+
+func TestExportProvider_GetExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testUserReferenceID := "2389"
+
+	postRows := newSingleMockRows(ctrl, func(dest ...interface{}) error {
+		*(dest[0].(*string)) = "1"
+		*(dest[3].(*string)) = "Hello World"
+		return nil
+	})
+	likeRows := newSingleMockRows(ctrl, func(dest ...interface{}) error {
+		*(dest[0].(*string)) = "2"
+		return nil
+	})
+	commentRows := newSingleMockRows(ctrl, func(dest ...interface{}) error {
+		*(dest[0].(*string)) = "3"
+		*(dest[1].(*string)) = "2"
+		*(dest[3].(*string)) = "Nice"
+		return nil
+	})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	gomock.InOrder(
+		mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(postRows, nil),
+		mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(likeRows, nil),
+		mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(commentRows, nil),
+	)
+
+	provider := NewExportProvider(mockDatabase)
+	export, err := provider.GetExport(testCtx, testUserReferenceID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello World", export.Posts[0].Caption)
+	assert.Equal(t, "2", export.Likes[0].PostID)
+	assert.Equal(t, "Nice", export.Comments[0].Text)
+	assert.Equal(t, "2", export.Comments[0].PostID)
+}
+
+func TestExportProvider_GetExportQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(nil, testError)
+
+	provider := NewExportProvider(mockDatabase)
+	export, err := provider.GetExport(testCtx, "2389")
+	assert.Nil(t, export)
+	assert.Equal(t, testError, err)
+}
@@ -10,6 +10,7 @@ import (
 	mssql "github.com/denisenkom/go-mssqldb"
 
 	"github.com/reecerussell/open-social/cmd/posts/dto"
+	"github.com/reecerussell/open-social/cmd/posts/pagination"
 	"github.com/reecerussell/open-social/database"
 )
 
@@ -18,10 +19,16 @@ var (
 	ErrPostNotFound = errors.New("post not found")
 )
 
-// PostProvider is used to read post data.
+// PostProvider is used to read post data. Posts by private users are only
+// visible to the users themselves, and their approved followers. Posts by, and
+// likes by, users who have blocked or been blocked by a user are hidden from them.
 type PostProvider interface {
 	Get(ctx context.Context, postReferenceID, userReferenceID string) (*dto.Post, error)
-	GetProfileFeed(ctx context.Context, username string, userReferenceID uuid.UUID) ([]*dto.FeedItem, error)
+
+	// GetProfileFeed gets a page of a user's posts. If the user is private, and isn't
+	// followed by the user with userReferenceID, or either has blocked the other, the
+	// feed is empty.
+	GetProfileFeed(ctx context.Context, username string, userReferenceID uuid.UUID, page *pagination.Page) (*dto.Feed, error)
 }
 
 type postProvider struct {
@@ -47,7 +54,8 @@ func (p *postProvider) Get(ctx context.Context, postReferenceID, userReferenceID
 			[P].[Posted],
 			[U].[Username],
 			[P].[Caption],
-			(SELECT COUNT(*) FROM [PostLikes] WHERE [PostId] = [P].[Id]) AS [LikeCount],
+			[dbo].GetVisiblePostLikes([P].[Id], [CU].[Id]) AS [LikeCount],
+			[dbo].GetVisiblePostComments([P].[Id], [CU].[Id]) AS [CommentCount],
 			CASE (SELECT COUNT(*) 
 					FROM [Likes] WHERE [UserReferenceId] = @userReferenceId) 
 				WHEN 1 THEN CAST(1 AS BIT) 
@@ -55,9 +63,10 @@ func (p *postProvider) Get(ctx context.Context, postReferenceID, userReferenceID
 			END AS [HasLiked]
 		FROM [Posts] AS [P]
 		INNER JOIN [Users] AS [U] ON [U].[Id] = [P].[UserId]
+		LEFT JOIN [Users] AS [CU] ON [CU].[ReferenceId] = @userReferenceId
 		LEFT JOIN [Media] AS [M] ON [M].[Id] = [P].[MediaId]
-		WHERE [P].[ReferenceId] = @postReferenceId 
-			AND [U].[ReferenceId] = @userReferenceId;`
+		WHERE [P].[ReferenceId] = @postReferenceId
+			AND [dbo].CanViewProfile([U].[Id], @userReferenceId) = 1;`
 
 	row, err := p.db.Single(ctx, query, sql.Named("postReferenceId", postReferenceID), sql.Named("userReferenceId", userReferenceID))
 	if err != nil {
@@ -72,6 +81,7 @@ func (p *postProvider) Get(ctx context.Context, postReferenceID, userReferenceID
 		&post.Username,
 		&post.Caption,
 		&post.Likes,
+		&post.Comments,
 		&post.HasLiked,
 	)
 	if err != nil {
@@ -85,14 +95,15 @@ func (p *postProvider) Get(ctx context.Context, postReferenceID, userReferenceID
 	return &post, nil
 }
 
-func (p *postProvider) GetProfileFeed(ctx context.Context, username string, userReferenceID uuid.UUID) ([]*dto.FeedItem, error) {
-	const query = `SELECT 
+func (p *postProvider) GetProfileFeed(ctx context.Context, username string, userReferenceID uuid.UUID, page *pagination.Page) (*dto.Feed, error) {
+	const query = `SELECT TOP (@limit)
 		CAST([P].[ReferenceId] AS CHAR(36)) AS [ReferenceId],
 		CAST([M].[ReferenceId] AS CHAR(36)) AS [MediaReferenceId],
 		[P].[Caption], 
 		[P].[Posted],
 		[U].[Username],
-		[dbo].GetPostLikes([P].[Id]) AS [Likes],
+		[dbo].GetVisiblePostLikes([P].[Id], [CU].[Id]) AS [Likes],
+		[dbo].GetVisiblePostComments([P].[Id], [CU].[Id]) AS [Comments],
 		[dbo].HasUserLikedPost([P].[Id], [CU].[Id]) AS [HasLiked],
 		CASE [U].[Id]
 			WHEN [CU].[Id] THEN CAST(1 AS BIT)
@@ -103,11 +114,19 @@ func (p *postProvider) GetProfileFeed(ctx context.Context, username string, user
 	INNER JOIN [Users] AS [CU] ON [CU].[ReferenceId] = @userReferenceId
 	LEFT JOIN [Media] AS [M] ON [M].[Id] = [P].[MediaId]
 	WHERE [U].[Username] = @username
-	ORDER BY [P].[Posted] DESC;`
+		AND [dbo].CanViewProfile([U].[Id], @userReferenceId) = 1
+		AND (@cursorPosted IS NULL
+			OR [P].[Posted] < CAST(@cursorPosted AS DATETIME)
+			OR ([P].[Posted] = CAST(@cursorPosted AS DATETIME) AND [P].[ReferenceId] < @cursorReferenceId))
+	ORDER BY [P].[Posted] DESC, [P].[ReferenceId] DESC;`
 
+	// Read one more item than the limit, to determine if there is a next page.
 	rows, err := p.db.Multiple(ctx, query,
 		sql.Named("username", username),
-		sql.Named("userReferenceId", mssql.UniqueIdentifier(userReferenceID)))
+		sql.Named("userReferenceId", mssql.UniqueIdentifier(userReferenceID)),
+		sql.Named("limit", page.Limit+1),
+		sql.Named("cursorPosted", page.CursorPosted()),
+		sql.Named("cursorReferenceId", page.CursorReferenceID()))
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +142,7 @@ func (p *postProvider) GetProfileFeed(ctx context.Context, username string, user
 			&item.Posted,
 			&item.Username,
 			&item.Likes,
+			&item.Comments,
 			&item.HasLiked,
 			&item.IsAuthor,
 		)
@@ -137,5 +157,5 @@ func (p *postProvider) GetProfileFeed(ctx context.Context, username string, user
 		return nil, err
 	}
 
-	return items, nil
+	return pagination.NewFeed(items, page), nil
 }
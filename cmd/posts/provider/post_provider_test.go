@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/reecerussell/open-social/cmd/posts/pagination"
 	mock "github.com/reecerussell/open-social/mock/database"
 )
 
@@ -26,6 +27,7 @@ func TestPostProvider_Get_ReturnsPostSuccessfully(t *testing.T) {
 	testUsername := "test"
 	testCaption := "Hello World"
 	testLikes := 12
+	testComments := 3
 	testHasLiked := true
 	testCtx := context.Background()
 
@@ -37,7 +39,8 @@ func TestPostProvider_Get_ReturnsPostSuccessfully(t *testing.T) {
 		*(dest[3].(*string)) = testUsername
 		*(dest[4].(*string)) = testCaption
 		*(dest[5].(*int)) = testLikes
-		*(dest[6].(*bool)) = testHasLiked
+		*(dest[6].(*int)) = testComments
+		*(dest[7].(*bool)) = testHasLiked
 
 		return nil
 	})
@@ -54,6 +57,7 @@ func TestPostProvider_Get_ReturnsPostSuccessfully(t *testing.T) {
 	assert.Equal(t, testUsername, post.Username)
 	assert.Equal(t, testCaption, post.Caption)
 	assert.Equal(t, testLikes, post.Likes)
+	assert.Equal(t, testComments, post.Comments)
 	assert.Equal(t, testHasLiked, post.HasLiked)
 }
 
@@ -129,6 +133,7 @@ func TestPostProvider_GetProfileFeed_ReturnsProfileFeed(t *testing.T) {
 	testPosted := time.Now().UTC()
 	testUsername := "test"
 	testLikes := 12
+	testComments := 3
 	testHasLiked := true
 	testIsAuthor := false
 	testCtx := context.Background()
@@ -151,8 +156,9 @@ func TestPostProvider_GetProfileFeed_ReturnsProfileFeed(t *testing.T) {
 		*(dest[3].(*time.Time)) = testPosted
 		*(dest[4].(*string)) = testUsername
 		*(dest[5].(*int)) = testLikes
-		*(dest[6].(*bool)) = testHasLiked
-		*(dest[7].(*bool)) = testIsAuthor
+		*(dest[6].(*int)) = testComments
+		*(dest[7].(*bool)) = testHasLiked
+		*(dest[8].(*bool)) = testIsAuthor
 
 		return nil
 	})
@@ -162,9 +168,12 @@ func TestPostProvider_GetProfileFeed_ReturnsProfileFeed(t *testing.T) {
 	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
 
 	provider := NewPostProvider(mockDatabase)
-	feedItems, err := provider.GetProfileFeed(testCtx, testUsername, testUserReferenceID)
+	feed, err := provider.GetProfileFeed(testCtx, testUsername, testUserReferenceID, &pagination.Page{Limit: 20})
 	assert.NoError(t, err)
 
+	assert.Nil(t, feed.NextCursor)
+
+	feedItems := feed.Items
 	assert.Equal(t, 1, len(feedItems))
 	assert.Equal(t, testPostID, feedItems[0].ID)
 	assert.Equal(t, &testMediaID, feedItems[0].MediaID)
@@ -172,6 +181,7 @@ func TestPostProvider_GetProfileFeed_ReturnsProfileFeed(t *testing.T) {
 	assert.Equal(t, testPosted, feedItems[0].Posted)
 	assert.Equal(t, testUsername, feedItems[0].Username)
 	assert.Equal(t, testLikes, feedItems[0].Likes)
+	assert.Equal(t, testComments, feedItems[0].Comments)
 	assert.Equal(t, testHasLiked, feedItems[0].HasLiked)
 	assert.Equal(t, testIsAuthor, feedItems[0].IsAuthor)
 }
@@ -189,8 +199,8 @@ func TestPostProvider_GetProfileFeedQueryFails_ReturnsError(t *testing.T) {
 	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(nil, testError)
 
 	provider := NewPostProvider(mockDatabase)
-	feedItems, err := provider.GetProfileFeed(testCtx, testUsername, testUserReferenceID)
-	assert.Nil(t, feedItems)
+	feed, err := provider.GetProfileFeed(testCtx, testUsername, testUserReferenceID, &pagination.Page{Limit: 20})
+	assert.Nil(t, feed)
 	assert.Equal(t, testError, err)
 }
 
@@ -211,8 +221,8 @@ func TestPostProvider_GetProfileFeedScanFails_ReturnsError(t *testing.T) {
 	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
 
 	provider := NewPostProvider(mockDatabase)
-	feedItems, err := provider.GetProfileFeed(testCtx, testUsername, testUserReferenceID)
-	assert.Nil(t, feedItems)
+	feed, err := provider.GetProfileFeed(testCtx, testUsername, testUserReferenceID, &pagination.Page{Limit: 20})
+	assert.Nil(t, feed)
 	assert.Equal(t, testError, err)
 }
 
@@ -233,7 +243,7 @@ func TestPostProvider_GetProfileFeedRowsErrors_ReturnsError(t *testing.T) {
 	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
 
 	provider := NewPostProvider(mockDatabase)
-	feedItems, err := provider.GetProfileFeed(testCtx, testUsername, testUserReferenceID)
-	assert.Nil(t, feedItems)
+	feed, err := provider.GetProfileFeed(testCtx, testUsername, testUserReferenceID, &pagination.Page{Limit: 20})
+	assert.Nil(t, feed)
 	assert.Equal(t, testError, err)
 }
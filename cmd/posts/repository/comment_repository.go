@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/reecerussell/open-social/cmd/posts/dao"
+	"github.com/reecerussell/open-social/cmd/posts/model"
+	"github.com/reecerussell/open-social/database"
+)
+
+// Comment data errors
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+)
+
+// CommentRepository is a high level interface used to manipulate persisted post comment data.
+type CommentRepository interface {
+	Create(ctx context.Context, c *model.Comment) error
+	Get(ctx context.Context, referenceID string) (*model.Comment, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type commentRepository struct {
+	db database.Database
+}
+
+// NewCommentRepository returns a new instance of CommentRepository.
+func NewCommentRepository(db database.Database) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+func (r *commentRepository) Create(ctx context.Context, c *model.Comment) error {
+	const query = `INSERT INTO [PostComments] ([ReferenceId],[PostId],[UserId],[Posted],[Text])
+					VALUES (NEWID(), @postId, @userId, @posted, @text)
+				SELECT [Id], CAST([ReferenceId] AS CHAR(36)) FROM [PostComments] WHERE [Id] = SCOPE_IDENTITY()`
+
+	comment := c.Dao()
+	row, err := r.db.Single(ctx, query,
+		sql.Named("postId", comment.PostID),
+		sql.Named("userId", comment.UserID),
+		sql.Named("posted", comment.Posted),
+		sql.Named("text", comment.Text))
+	if err != nil {
+		return err
+	}
+
+	// Read the comment's ids
+	err = row.Scan(&comment.ID, &comment.ReferenceID)
+	if err != nil {
+		return err
+	}
+
+	// Set the comment's ids
+	c.SetID(comment.ID)
+	c.SetReferenceID(comment.ReferenceID)
+
+	return nil
+}
+
+func (r *commentRepository) Get(ctx context.Context, referenceID string) (*model.Comment, error) {
+	const query = `SELECT
+			[C].[Id],
+			CAST([C].[ReferenceId] AS CHAR(36)),
+			[C].[PostId],
+			CAST([P].[ReferenceId] AS CHAR(36)),
+			[P].[UserId],
+			[C].[UserId],
+			[C].[Posted],
+			[C].[Text]
+		FROM [PostComments] AS [C]
+		INNER JOIN [Posts] AS [P] ON [P].[Id] = [C].[PostId]
+		WHERE [C].[ReferenceId] = @referenceId;`
+
+	row, err := r.db.Single(ctx, query, sql.Named("referenceId", referenceID))
+	if err != nil {
+		return nil, err
+	}
+
+	var comment dao.Comment
+	err = row.Scan(
+		&comment.ID,
+		&comment.ReferenceID,
+		&comment.PostID,
+		&comment.PostReferenceID,
+		&comment.PostUserID,
+		&comment.UserID,
+		&comment.Posted,
+		&comment.Text,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCommentNotFound
+		}
+
+		return nil, err
+	}
+
+	return model.CommentFromDao(&comment), nil
+}
+
+func (r *commentRepository) Delete(ctx context.Context, id int) error {
+	const query = `DELETE FROM [PostComments] WHERE [Id] = @id;`
+
+	_, err := r.db.Execute(ctx, query, sql.Named("id", id))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/posts/model"
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestCommentRepository_Create_SetsIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testComment, _ := model.NewComment(1, 2, "Hello World")
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*int)) = 12
+		*(dest[1].(*string)) = "2389"
+
+		return nil
+	})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	repo := NewCommentRepository(mockDatabase)
+	err := repo.Create(testCtx, testComment)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, testComment.ID())
+	assert.Equal(t, "2389", testComment.ReferenceID())
+}
+
+func TestCommentRepository_CreateQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testComment, _ := model.NewComment(1, 2, "Hello World")
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(nil, testError)
+
+	repo := NewCommentRepository(mockDatabase)
+	err := repo.Create(testCtx, testComment)
+	assert.Equal(t, testError, err)
+}
+
+func TestCommentRepository_Get_ReturnsComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testReferenceID := "2389"
+	testPosted := time.Now().UTC()
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*int)) = 12
+		*(dest[1].(*string)) = testReferenceID
+		*(dest[2].(*int)) = 1
+		*(dest[3].(*string)) = "3290"
+		*(dest[4].(*int)) = 2
+		*(dest[5].(*int)) = 3
+		*(dest[6].(*time.Time)) = testPosted
+		*(dest[7].(*string)) = "Hello World"
+
+		return nil
+	})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	repo := NewCommentRepository(mockDatabase)
+	comment, err := repo.Get(testCtx, testReferenceID)
+	assert.NoError(t, err)
+
+	d := comment.Dao()
+	assert.Equal(t, 12, d.ID)
+	assert.Equal(t, testReferenceID, d.ReferenceID)
+	assert.Equal(t, 1, d.PostID)
+	assert.Equal(t, "3290", d.PostReferenceID)
+	assert.Equal(t, 2, d.PostUserID)
+	assert.Equal(t, 3, d.UserID)
+	assert.Equal(t, testPosted, d.Posted)
+	assert.Equal(t, "Hello World", d.Text)
+}
+
+func TestCommentRepository_GetNonExistantComment_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).Return(sql.ErrNoRows)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	repo := NewCommentRepository(mockDatabase)
+	comment, err := repo.Get(testCtx, "2389")
+	assert.Nil(t, comment)
+	assert.Equal(t, ErrCommentNotFound, err)
+}
+
+func TestCommentRepository_Delete_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+	repo := NewCommentRepository(mockDatabase)
+	err := repo.Delete(testCtx, 12)
+	assert.NoError(t, err)
+}
+
+func TestCommentRepository_DeleteExecuteFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(-1), testError)
+
+	repo := NewCommentRepository(mockDatabase)
+	err := repo.Delete(testCtx, 12)
+	assert.Equal(t, testError, err)
+}
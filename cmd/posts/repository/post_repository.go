@@ -8,9 +8,8 @@ import (
 	"github.com/reecerussell/open-social/cmd/posts/dao"
 	"github.com/reecerussell/open-social/cmd/posts/dto"
 	"github.com/reecerussell/open-social/cmd/posts/model"
-
-	// MSSQL driver
-	_ "github.com/denisenkom/go-mssqldb"
+	"github.com/reecerussell/open-social/cmd/posts/pagination"
+	"github.com/reecerussell/open-social/database"
 )
 
 // Post data errors
@@ -21,41 +20,41 @@ var (
 // PostRepository is a high level interface used to manipulate post data.
 type PostRepository interface {
 	Create(ctx context.Context, p *model.Post) error
-	GetFeed(ctx context.Context, userReferenceID string) ([]*dto.FeedItem, error)
+
+	// GetFeed gets a page of the posts by the user, and the users they follow,
+	// leaving out the users they've muted or blocked.
+	GetFeed(ctx context.Context, userReferenceID string, page *pagination.Page) (*dto.Feed, error)
+
+	// Get gets a post, if it's visible to the user with userReferenceID. Posts by
+	// private users are only visible to their approved followers, and posts by
+	// blocked users aren't visible at all.
 	Get(ctx context.Context, referenceID, userReferenceID string) (*model.Post, error)
+	DeleteForUser(ctx context.Context, userReferenceID string) ([]string, error)
 }
 
 type postRepository struct {
-	url string
+	db database.Database
 }
 
 // NewPostRepository returns a new instance of PostRepository.
-func NewPostRepository(url string) PostRepository {
-	return &postRepository{url: url}
+func NewPostRepository(db database.Database) PostRepository {
+	return &postRepository{db: db}
 }
 
 func (r *postRepository) Create(ctx context.Context, p *model.Post) error {
-	db, err := sql.Open("sqlserver", r.url)
-	if err != nil {
-		return err
-	}
-
 	const query = `INSERT INTO [Posts] ([ReferenceId],[UserId],[MediaId],[Posted],[Caption])
 					VALUES (NEWID(), @userId, @mediaId, @posted, @caption)
 				SELECT [Id], CAST([ReferenceId] AS CHAR(36)) FROM [Posts] WHERE [Id] = SCOPE_IDENTITY()`
 
-	stmt, err := db.PrepareContext(ctx, query)
-	if err != nil {
-		return err
-	}
-	defer stmt.Close()
-
 	post := p.Dao()
-	row := stmt.QueryRowContext(ctx,
+	row, err := r.db.Single(ctx, query,
 		sql.Named("userId", post.UserID),
 		sql.Named("mediaId", post.MediaID),
 		sql.Named("posted", post.Posted),
 		sql.Named("caption", post.Caption))
+	if err != nil {
+		return err
+	}
 
 	// Read the post's ids
 	err = row.Scan(&post.ID, &post.ReferenceID)
@@ -70,12 +69,10 @@ func (r *postRepository) Create(ctx context.Context, p *model.Post) error {
 	return nil
 }
 
-func (r *postRepository) GetFeed(ctx context.Context, userReferenceID string) ([]*dto.FeedItem, error) {
-	db, err := sql.Open("sqlserver", r.url)
-	if err != nil {
-		return nil, err
-	}
-
+func (r *postRepository) GetFeed(ctx context.Context, userReferenceID string, page *pagination.Page) (*dto.Feed, error) {
+	// Follows of private users only exist once they've been approved, so posts by
+	// private users are only included in the feeds of their approved followers.
+	// Posts by muted users are left out, as are likes and comments by blocked users.
 	const query = `;WITH [Feed] AS (
 		SELECT 
 			[P].[ReferenceId] AS [ReferenceId],
@@ -83,51 +80,46 @@ func (r *postRepository) GetFeed(ctx context.Context, userReferenceID string) ([
 			[P].[Caption], 
 			[P].[Posted],
 			[U].[Username],
-			[dbo].GetPostLikes([P].[Id]) AS [Likes],
-			[dbo].HasUserLikedPost([P].[Id], [U].[Id]) AS [HasLiked]
+			[dbo].GetVisiblePostLikes([P].[Id], [CU].[Id]) AS [Likes],
+			[dbo].GetVisiblePostComments([P].[Id], [CU].[Id]) AS [Comments],
+			[dbo].HasUserLikedPost([P].[Id], [CU].[Id]) AS [HasLiked]
 		FROM [Posts] AS [P]
 		INNER JOIN [Users] AS [U] ON [U].[Id] = [P].[UserId]
+		INNER JOIN [Users] AS [CU] ON [CU].[ReferenceId] = @userReference
 		LEFT JOIN [Media] AS [M] ON [M].[Id] = [P].[MediaId]
-		WHERE [U].[ReferenceId] = @userReference
-		UNION
-		SELECT
-			[P].[ReferenceId] AS [ReferenceId],
-			[M].[ReferenceId] AS [MediaReferenceId],
-			[P].[Caption],
-			[P].[Posted],
-			[U].[Username],
-			[dbo].GetPostLikes([P].[Id]) AS [Likes],
-			[dbo].HasUserLikedPost([P].[Id], [U].[Id]) AS [HasLiked]
-		FROM [UserFollowers] AS [UF]
-		INNER JOIN [Posts] AS [P] ON [P].[UserId] = [UF].[FollowerId]
-		INNER JOIN [Users] AS [U] ON [U].[Id] = [P].[UserId]
-		LEFT JOIN [Media] AS [M] ON [M].[Id] = [P].[MediaId]
-		WHERE [U].[ReferenceId] = @userReference)
+		WHERE [U].[Id] = [CU].[Id]
+			OR (EXISTS (SELECT 1 FROM [UserFollowers]
+					WHERE [UserId] = [U].[Id] AND [FollowerId] = [CU].[Id])
+				AND NOT EXISTS (SELECT 1 FROM [UserMutes]
+					WHERE [UserId] = [CU].[Id] AND [MutedUserId] = [U].[Id])
+				AND [dbo].IsBlocked([U].[Id], [CU].[Id]) = 0))
 		
-		SELECT 
+		SELECT TOP (@limit)
 			CAST([ReferenceId] AS CHAR(36)),
 			CAST([MediaReferenceId] AS CHAR(36)),
 			[Caption],
 			[Posted],
 			[Username],
 			[Likes],
+			[Comments],
 			[HasLiked] 
 		FROM [Feed]
-		ORDER BY [Posted] DESC`
-
-	stmt, err := db.PrepareContext(ctx, query)
-	if err != nil {
-		return nil, err
-	}
-	defer stmt.Close()
-
-	rows, err := stmt.QueryContext(ctx, sql.Named("userReference", userReferenceID))
+		WHERE @cursorPosted IS NULL
+			OR [Posted] < CAST(@cursorPosted AS DATETIME)
+			OR ([Posted] = CAST(@cursorPosted AS DATETIME) AND [ReferenceId] < @cursorReferenceId)
+		ORDER BY [Posted] DESC, [ReferenceId] DESC`
+
+	// Read one more item than the limit, to determine if there is a next page.
+	rows, err := r.db.Multiple(ctx, query,
+		sql.Named("userReference", userReferenceID),
+		sql.Named("limit", page.Limit+1),
+		sql.Named("cursorPosted", page.CursorPosted()),
+		sql.Named("cursorReferenceId", page.CursorReferenceID()))
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var feed []*dto.FeedItem
+	var items []*dto.FeedItem
 
 	for rows.Next() {
 		var item dto.FeedItem
@@ -138,12 +130,13 @@ func (r *postRepository) GetFeed(ctx context.Context, userReferenceID string) ([
 			&item.Posted,
 			&item.Username,
 			&item.Likes,
+			&item.Comments,
 			&item.HasLiked)
 		if err != nil {
 			return nil, err
 		}
 
-		feed = append(feed, &item)
+		items = append(items, &item)
 	}
 
 	err = rows.Err()
@@ -151,15 +144,10 @@ func (r *postRepository) GetFeed(ctx context.Context, userReferenceID string) ([
 		return nil, err
 	}
 
-	return feed, nil
+	return pagination.NewFeed(items, page), nil
 }
 
 func (r *postRepository) Get(ctx context.Context, referenceID, userReferenceID string) (*model.Post, error) {
-	db, err := sql.Open("sqlserver", r.url)
-	if err != nil {
-		return nil, err
-	}
-
 	const query = `;WITH [Likes] AS (
 			SELECT [U].[ReferenceId] FROM [PostLikes] AS [L]
 				INNER JOIN [Posts] AS [P] ON [P].[Id] = [L].[PostId]
@@ -181,28 +169,27 @@ func (r *postRepository) Get(ctx context.Context, referenceID, userReferenceID s
 				ELSE CAST(0 AS BIT) 
 			END AS [HasLiked]
 		FROM [Posts]
-		WHERE [ReferenceId] = @postReferenceId;`
+		WHERE [ReferenceId] = @postReferenceId
+			AND [dbo].CanViewProfile([UserId], @userReferenceId) = 1;`
 
-	stmt, err := db.PrepareContext(ctx, query)
+	row, err := r.db.Single(ctx, query,
+		sql.Named("postReferenceId", referenceID),
+		sql.Named("userReferenceId", userReferenceID))
 	if err != nil {
 		return nil, err
 	}
-	defer stmt.Close()
 
 	var post dao.Post
-	err = stmt.QueryRowContext(ctx,
-		sql.Named("postReferenceId", referenceID),
-		sql.Named("userReferenceId", userReferenceID)).
-		Scan(
-			&post.ID,
-			&post.ReferenceID,
-			&post.MediaID,
-			&post.UserID,
-			&post.Posted,
-			&post.Caption,
-			&post.LikeCount,
-			&post.HasLiked,
-		)
+	err = row.Scan(
+		&post.ID,
+		&post.ReferenceID,
+		&post.MediaID,
+		&post.UserID,
+		&post.Posted,
+		&post.Caption,
+		&post.LikeCount,
+		&post.HasLiked,
+	)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrPostNotFound
@@ -213,3 +200,52 @@ func (r *postRepository) Get(ctx context.Context, referenceID, userReferenceID s
 
 	return model.PostFromDao(&post), nil
 }
+
+// DeleteForUser deletes the user's posts, likes and comments, along with the likes and
+// comments on their posts, so their account can be deleted. The reference ids of the
+// media attached to their posts are returned, as it's left to be deleted by the media
+// service. This should be called in a transaction, and succeeds if the user doesn't
+// exist, so it can be retried.
+func (r *postRepository) DeleteForUser(ctx context.Context, userReferenceID string) ([]string, error) {
+	const query = `SET NOCOUNT ON;
+
+		DECLARE @userId INT = (SELECT [Id] FROM [Users] WHERE [ReferenceId] = @userReferenceId);
+		DECLARE @media TABLE ([ReferenceId] CHAR(36));
+
+		INSERT INTO @media SELECT CAST([M].[ReferenceId] AS CHAR(36))
+			FROM [Posts] AS [P]
+			INNER JOIN [Media] AS [M] ON [M].[Id] = [P].[MediaId]
+			WHERE [P].[UserId] = @userId;
+
+		DELETE FROM [PostLikes]
+			WHERE [UserId] = @userId OR [PostId] IN (SELECT [Id] FROM [Posts] WHERE [UserId] = @userId);
+		DELETE FROM [PostComments]
+			WHERE [UserId] = @userId OR [PostId] IN (SELECT [Id] FROM [Posts] WHERE [UserId] = @userId);
+		DELETE FROM [Posts] WHERE [UserId] = @userId;
+
+		SELECT [ReferenceId] FROM @media;`
+
+	rows, err := r.db.Multiple(ctx, query, sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return nil, err
+	}
+
+	mediaIDs := []string{}
+
+	for rows.Next() {
+		var id string
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		mediaIDs = append(mediaIDs, id)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return mediaIDs, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/posts/model"
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestPostRepository_Create_SetsIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testPost, _ := model.NewPost(1, nil, "Hello World")
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*int)) = 12
+		*(dest[1].(*string)) = "2389"
+
+		return nil
+	})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	repo := NewPostRepository(mockDatabase)
+	err := repo.Create(testCtx, testPost)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, testPost.ID())
+	assert.Equal(t, "2389", testPost.ReferenceID())
+}
+
+func TestPostRepository_CreateQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testPost, _ := model.NewPost(1, nil, "Hello World")
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(nil, testError)
+
+	repo := NewPostRepository(mockDatabase)
+	err := repo.Create(testCtx, testPost)
+	assert.Equal(t, testError, err)
+}
+
+func TestPostRepository_GetNonExistantPost_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).Return(sql.ErrNoRows)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	repo := NewPostRepository(mockDatabase)
+	post, err := repo.Get(testCtx, "2389", "3290")
+	assert.Nil(t, post)
+	assert.Equal(t, ErrPostNotFound, err)
+}
+
+func TestPostRepository_DeleteForUser_ReturnsMediaIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testMediaIDs := []string{"2384", "9823"}
+
+	mockRows := mock.NewMockRows(ctrl)
+	for _, id := range testMediaIDs {
+		id := id
+		mockRows.EXPECT().Next().Return(true)
+		mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*string)) = id
+			return nil
+		})
+	}
+	mockRows.EXPECT().Next().Return(false)
+	mockRows.EXPECT().Err().Return(nil)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), sql.Named("userReferenceId", "3290")).Return(mockRows, nil)
+
+	repo := NewPostRepository(mockDatabase)
+	mediaIDs, err := repo.DeleteForUser(testCtx, "3290")
+	assert.NoError(t, err)
+	assert.Equal(t, testMediaIDs, mediaIDs)
+}
+
+func TestPostRepository_DeleteForUserQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(nil, testError)
+
+	repo := NewPostRepository(mockDatabase)
+	mediaIDs, err := repo.DeleteForUser(testCtx, "3290")
+	assert.Nil(t, mediaIDs)
+	assert.Equal(t, testError, err)
+}
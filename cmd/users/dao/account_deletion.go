@@ -0,0 +1,18 @@
+package dao
+
+import "time"
+
+// AccountDeletion is a data access object for the account deletion domain.
+type AccountDeletion struct {
+	ID              int64
+	UserID          int
+	UserReferenceID string
+	Requested       time.Time
+	Scheduled       time.Time
+	Cancelled       *time.Time
+	Completed       *time.Time
+	Attempts        int
+	NextAttempt     time.Time
+	LastError       *string
+	MediaIDs        string
+}
@@ -0,0 +1,15 @@
+package dao
+
+import "time"
+
+// LoginChallenge is a data access object for the login challenge domain.
+type LoginChallenge struct {
+	ID              int64
+	TokenHash       string
+	UserID          int
+	UserReferenceID string
+	Created         time.Time
+	Expires         time.Time
+	Attempts        int
+	Used            *time.Time
+}
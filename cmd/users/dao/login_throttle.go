@@ -0,0 +1,12 @@
+package dao
+
+import "time"
+
+// LoginThrottle is a data access object for the login throttle domain model.
+type LoginThrottle struct {
+	Key         string
+	Failures    int
+	Lockouts    int
+	LastFailure *time.Time
+	LockedUntil *time.Time
+}
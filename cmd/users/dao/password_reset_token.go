@@ -0,0 +1,14 @@
+package dao
+
+import "time"
+
+// PasswordResetToken is a data access object for the password reset token domain.
+type PasswordResetToken struct {
+	ID              int64
+	TokenHash       string
+	UserID          int
+	UserReferenceID string
+	Created         time.Time
+	Expires         time.Time
+	Used            *time.Time
+}
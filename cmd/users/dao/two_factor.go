@@ -0,0 +1,22 @@
+package dao
+
+import "time"
+
+// TwoFactor is a data access object for the two-factor authentication domain.
+type TwoFactor struct {
+	ID              int
+	UserID          int
+	UserReferenceID string
+	Secret          string
+	Enabled         bool
+	LastUsedStep    int64
+	Created         time.Time
+}
+
+// RecoveryCode is a data access object for the recovery code domain.
+type RecoveryCode struct {
+	ID       int64
+	UserID   int
+	CodeHash string
+	Used     *time.Time
+}
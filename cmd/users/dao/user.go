@@ -6,8 +6,27 @@ type User struct {
 	ReferenceID  string
 	Username     string
 	PasswordHash string
+	MediaID      *int
+	Bio          *string
+	IsPrivate    bool
 
 	// IsFollowing indicates wether the requesting user
 	// is following this user or not.
 	IsFollowing bool
+
+	// HasRequested indicates wether the requesting user has
+	// a pending request to follow this user or not.
+	HasRequested bool
+
+	// IsBlocking indicates wether the requesting user
+	// has blocked this user or not.
+	IsBlocking bool
+
+	// IsBlockedBy indicates wether this user has blocked
+	// the requesting user or not.
+	IsBlockedBy bool
+
+	// IsMuting indicates wether the requesting user
+	// has muted this user or not.
+	IsMuting bool
 }
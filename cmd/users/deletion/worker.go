@@ -0,0 +1,205 @@
+package deletion
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/reecerussell/open-social/client/auth"
+	"github.com/reecerussell/open-social/client/media"
+	"github.com/reecerussell/open-social/client/notifications"
+	"github.com/reecerussell/open-social/client/posts"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+)
+
+// Worker defaults.
+const (
+	DefaultBatchSize = 10
+
+	// DefaultLease is how long a claimed deletion is left, before it's
+	// attempted again, if the worker stops part way through an attempt.
+	DefaultLease = 15 * time.Minute
+)
+
+// Worker attempts the account deletions which are due, deleting the user's data from
+// each service, then their media. Every step is idempotent, so each attempt starts from
+// the beginning; failed attempts are retried, backing off exponentially from retry.
+type Worker struct {
+	deletions     repository.AccountDeletionRepository
+	users         repository.UserRepository
+	uow           database.UnitOfWork
+	auth          auth.Client
+	notifications notifications.Client
+	posts         posts.Client
+	media         media.Client
+	interval      time.Duration
+	retry         time.Duration
+	lease         time.Duration
+	batchSize     int
+}
+
+// NewWorker returns a new instance of Worker, which polls for due deletions every interval.
+func NewWorker(deletions repository.AccountDeletionRepository, users repository.UserRepository, uow database.UnitOfWork,
+	auth auth.Client, notifications notifications.Client, posts posts.Client, media media.Client,
+	interval, retry time.Duration) *Worker {
+	return &Worker{
+		deletions:     deletions,
+		users:         users,
+		uow:           uow,
+		auth:          auth,
+		notifications: notifications,
+		posts:         posts,
+		media:         media,
+		interval:      interval,
+		retry:         retry,
+		lease:         DefaultLease,
+		batchSize:     DefaultBatchSize,
+	}
+}
+
+// Run attempts the due deletions every interval, until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := w.Drain(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Error: failed to process account deletions: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain attempts all of the due deletions, returning the number of deletions completed.
+func (w *Worker) Drain(ctx context.Context) (int, error) {
+	total := 0
+
+	for {
+		now := time.Now().UTC()
+		deletions, err := w.deletions.Claim(ctx, now, now.Add(w.lease), w.batchSize)
+		if err != nil {
+			return total, err
+		}
+
+		for _, d := range deletions {
+			if ctx.Err() != nil {
+				// The remaining deletions will be attempted again once their lease ends.
+				return total, ctx.Err()
+			}
+
+			completed, err := w.attempt(ctx, d)
+			if err != nil {
+				return total, err
+			}
+
+			if completed {
+				total++
+			}
+		}
+
+		if len(deletions) < w.batchSize {
+			return total, nil
+		}
+	}
+}
+
+// attempt attempts the deletion, recording the outcome. An error is
+// only returned if the outcome of the attempt can't be recorded.
+func (w *Worker) attempt(ctx context.Context, d *model.AccountDeletion) (bool, error) {
+	err := w.delete(ctx, d)
+	if err != nil {
+		log.Printf("Error: failed to delete account '%s' (attempt %d): %v\n", d.UserReferenceID(), d.Attempts(), err)
+		d.Fail(err, w.retry)
+	} else {
+		d.Complete()
+	}
+
+	updateErr := w.deletions.Update(ctx, d)
+	if updateErr != nil {
+		return false, updateErr
+	}
+
+	return err == nil, nil
+}
+
+func (w *Worker) delete(ctx context.Context, d *model.AccountDeletion) error {
+	userReferenceID := d.UserReferenceID()
+
+	err := w.auth.RevokeUserTokens(userReferenceID)
+	if err != nil {
+		return fmt.Errorf("revoke tokens: %v", err)
+	}
+
+	err = w.notifications.DeleteUser(userReferenceID)
+	if err != nil {
+		return fmt.Errorf("delete notifications: %v", err)
+	}
+
+	err = w.deletePosts(ctx, d)
+	if err != nil {
+		return fmt.Errorf("delete posts: %v", err)
+	}
+
+	// The user's avatar is recorded in the same transaction it's
+	// deleted in, so it can't be lost if a later step fails.
+	err = w.uow.Transaction(ctx, func(ctx context.Context) error {
+		mediaIDs, err := w.users.Delete(ctx, userReferenceID)
+		if err != nil {
+			return err
+		}
+
+		d.AddMedia(mediaIDs...)
+
+		return w.deletions.Update(ctx, d)
+	})
+	if err != nil {
+		return fmt.Errorf("delete user: %v", err)
+	}
+
+	if len(d.MediaIDs()) > 0 {
+		err = w.media.Delete(d.MediaIDs())
+		if err != nil {
+			return fmt.Errorf("delete media: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// deletePosts deletes the user's posts, likes and comments. The media attached to their
+// posts is recorded before they're deleted, so it can't be lost if a later step fails.
+func (w *Worker) deletePosts(ctx context.Context, d *model.AccountDeletion) error {
+	export, err := w.posts.GetExport(d.UserReferenceID())
+	if err != nil {
+		return err
+	}
+
+	for _, p := range export.Posts {
+		if p.MediaID != nil {
+			d.AddMedia(*p.MediaID)
+		}
+	}
+
+	err = w.deletions.Update(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	resp, err := w.posts.DeleteUser(d.UserReferenceID())
+	if err != nil {
+		return err
+	}
+
+	d.AddMedia(resp.MediaIDs...)
+
+	return nil
+}
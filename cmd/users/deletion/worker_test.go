@@ -0,0 +1,130 @@
+package deletion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	authMock "github.com/reecerussell/open-social/client/mock/auth"
+	mediaMock "github.com/reecerussell/open-social/client/mock/media"
+	notificationsMock "github.com/reecerussell/open-social/client/mock/notifications"
+	postsMock "github.com/reecerussell/open-social/client/mock/posts"
+	postsClient "github.com/reecerussell/open-social/client/posts"
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	repoMock "github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	dbMock "github.com/reecerussell/open-social/mock/database"
+)
+
+type testWorker struct {
+	worker        *Worker
+	deletions     *repoMock.MockAccountDeletionRepository
+	users         *repoMock.MockUserRepository
+	uow           *dbMock.MockUnitOfWork
+	auth          *authMock.MockClient
+	notifications *notificationsMock.MockClient
+	posts         *postsMock.MockClient
+	media         *mediaMock.MockClient
+}
+
+func newTestWorker(ctrl *gomock.Controller) *testWorker {
+	w := &testWorker{
+		deletions:     repoMock.NewMockAccountDeletionRepository(ctrl),
+		users:         repoMock.NewMockUserRepository(ctrl),
+		uow:           dbMock.NewMockUnitOfWork(ctrl),
+		auth:          authMock.NewMockClient(ctrl),
+		notifications: notificationsMock.NewMockClient(ctrl),
+		posts:         postsMock.NewMockClient(ctrl),
+		media:         mediaMock.NewMockClient(ctrl),
+	}
+	w.worker = NewWorker(w.deletions, w.users, w.uow, w.auth, w.notifications, w.posts, w.media, time.Minute, time.Minute)
+
+	return w
+}
+
+func newTestDeletion() *model.AccountDeletion {
+	d, _ := model.AccountDeletionFromDao(&dao.AccountDeletion{
+		ID:              1,
+		UserReferenceID: "2389",
+		Scheduled:       time.Now().UTC().Add(-time.Hour),
+		Attempts:        1,
+	})
+
+	return d
+}
+
+func TestWorker_Drain_DeletesAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testDeletion := newTestDeletion()
+	testMediaID := "3298"
+
+	w := newTestWorker(ctrl)
+	w.deletions.EXPECT().Claim(testCtx, gomock.Any(), gomock.Any(), DefaultBatchSize).
+		Return([]*model.AccountDeletion{testDeletion}, nil)
+	gomock.InOrder(
+		w.auth.EXPECT().RevokeUserTokens("2389").Return(nil),
+		w.notifications.EXPECT().DeleteUser("2389").Return(nil),
+		w.posts.EXPECT().GetExport("2389").Return(&postsClient.Export{
+			Posts: []*postsClient.ExportPost{{ID: "1", MediaID: &testMediaID}, {ID: "2"}},
+		}, nil),
+		w.deletions.EXPECT().Update(testCtx, testDeletion).Return(nil),
+		w.posts.EXPECT().DeleteUser("2389").Return(&postsClient.DeleteUserResponse{MediaIDs: []string{testMediaID}}, nil),
+		w.uow.EXPECT().Transaction(testCtx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			}),
+		w.users.EXPECT().Delete(testCtx, "2389").Return([]string{"9823"}, nil),
+		w.deletions.EXPECT().Update(testCtx, testDeletion).Return(nil),
+		w.media.EXPECT().Delete([]string{testMediaID, "9823"}).Return(nil),
+		w.deletions.EXPECT().Update(testCtx, testDeletion).Return(nil),
+	)
+
+	n, err := w.worker.Drain(testCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, model.DeletionCompleted, testDeletion.Status())
+}
+
+func TestWorker_Drain_WhereStepFails_RecordsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testDeletion := newTestDeletion()
+
+	w := newTestWorker(ctrl)
+	w.deletions.EXPECT().Claim(testCtx, gomock.Any(), gomock.Any(), DefaultBatchSize).
+		Return([]*model.AccountDeletion{testDeletion}, nil)
+	w.auth.EXPECT().RevokeUserTokens("2389").Return(nil)
+	w.notifications.EXPECT().DeleteUser("2389").Return(errors.New("an error occured"))
+	w.deletions.EXPECT().Update(testCtx, testDeletion).Return(nil)
+
+	n, err := w.worker.Drain(testCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, model.DeletionInProgress, testDeletion.Status())
+	assert.Equal(t, "delete notifications: an error occured", *testDeletion.LastError())
+	assert.True(t, testDeletion.Dao().NextAttempt.After(time.Now().UTC()))
+}
+
+func TestWorker_Drain_WhereClaimFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	w := newTestWorker(ctrl)
+	w.deletions.EXPECT().Claim(testCtx, gomock.Any(), gomock.Any(), DefaultBatchSize).Return(nil, testError)
+
+	n, err := w.worker.Drain(testCtx)
+	assert.Equal(t, testError, err)
+	assert.Equal(t, 0, n)
+}
@@ -0,0 +1,11 @@
+package dto
+
+// Export contains a user's profile data, for their personal data export.
+type Export struct {
+	ID        string   `json:"id"`
+	Username  string   `json:"username"`
+	Bio       *string  `json:"bio"`
+	MediaID   *string  `json:"mediaId"`
+	Followers []string `json:"followers"`
+	Following []string `json:"following"`
+}
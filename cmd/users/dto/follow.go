@@ -0,0 +1,20 @@
+package dto
+
+// Follow is an entry in a list of a user's followers, or the users they follow.
+type Follow struct {
+	ID       string  `json:"id"`
+	Username string  `json:"username"`
+	MediaID  *string `json:"mediaId"`
+
+	// IsFollowing determines if the user requesting the list follows this user.
+	IsFollowing bool `json:"isFollowing"`
+}
+
+// FollowList is a page of a user's followers, or the users they follow.
+type FollowList struct {
+	Items []*Follow `json:"items"`
+
+	// NextCursor is used to read the next page of the list,
+	// and is nil if there are no more items.
+	NextCursor *string `json:"nextCursor"`
+}
@@ -2,12 +2,21 @@ package dto
 
 // Profile contains the data returns from getting a user's profile.
 type Profile struct {
-	UserID        string  `json:"userId"`
-	Username      string  `json:"username"`
-	MediaID       *string `json:"mediaId"`
-	Bio           *string `json:"bio"`
-	FollowerCount int     `json:"followerCount"`
-	IsFollowing   bool    `json:"isFollowing"`
-	IsOwner       bool    `json:"isOwner"`
-	PostCount     int     `json:"postCount"`
+	UserID         string  `json:"userId"`
+	Username       string  `json:"username"`
+	MediaID        *string `json:"mediaId"`
+	Bio            *string `json:"bio"`
+	FollowerCount  int     `json:"followerCount"`
+	IsFollowing    bool    `json:"isFollowing"`
+	IsOwner        bool    `json:"isOwner"`
+	PostCount      int     `json:"postCount"`
+	FollowingCount int     `json:"followingCount"`
+	IsPrivate      bool    `json:"isPrivate"`
+
+	// IsRequested indicates whether the requesting user is
+	// waiting for the user to approve their follow request.
+	IsRequested bool `json:"isRequested"`
+
+	// IsMuted indicates whether the requesting user has muted the user.
+	IsMuted bool `json:"isMuted"`
 }
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/reecerussell/open-social/cmd/users/model"
+)
+
+// AccountDeletionResponse represents the status of a user's account deletion.
+type AccountDeletionResponse struct {
+	Status    string    `json:"status"`
+	Requested time.Time `json:"requested"`
+	Scheduled time.Time `json:"scheduled"`
+	Attempts  int       `json:"attempts"`
+	LastError *string   `json:"lastError"`
+}
+
+func newAccountDeletionResponse(d *model.AccountDeletion) *AccountDeletionResponse {
+	return &AccountDeletionResponse{
+		Status:    d.Status(),
+		Requested: d.Requested(),
+		Scheduled: d.Scheduled(),
+		Attempts:  d.Attempts(),
+		LastError: d.LastError(),
+	}
+}
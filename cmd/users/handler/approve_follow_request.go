@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/eventing"
+)
+
+// ApproveFollowRequestHandler is a http.Handler used to approve a request to
+// follow a private user, replacing the request with a follow record.
+type ApproveFollowRequestHandler struct {
+	core.Handler
+	repo      repository.UserRepository
+	followers repository.FollowerRepository
+	requests  repository.FollowRequestRepository
+	uow       database.UnitOfWork
+	events    eventing.Publisher
+}
+
+// NewApproveFollowRequestHandler returns a new instance of ApproveFollowRequestHandler.
+func NewApproveFollowRequestHandler(repo repository.UserRepository, followers repository.FollowerRepository, requests repository.FollowRequestRepository, uow database.UnitOfWork, events eventing.Publisher) *ApproveFollowRequestHandler {
+	return &ApproveFollowRequestHandler{
+		repo:      repo,
+		followers: followers,
+		requests:  requests,
+		uow:       uow,
+		events:    events,
+	}
+}
+
+func (h *ApproveFollowRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceId"]
+	followerReferenceID := params["followerReferenceId"]
+
+	ctx := r.Context()
+	userID, err := h.repo.GetIDByReference(ctx, userReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	err = h.uow.Transaction(ctx, func(ctx context.Context) error {
+		err := h.requests.Delete(ctx, *userID, followerReferenceID)
+		if err != nil {
+			return err
+		}
+
+		err = h.followers.Create(ctx, *userID, followerReferenceID)
+		if err != nil {
+			return err
+		}
+
+		event := eventing.NewEvent(eventing.FollowRequestApproved, &eventing.FollowRequestApprovedPayload{
+			UserReferenceID:     userReferenceID,
+			FollowerReferenceID: followerReferenceID,
+		})
+		return h.events.Publish(ctx, userReferenceID, event)
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrFollowRequestNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	h.Respond(w, nil)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	mock "github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/eventing"
+	"github.com/reecerussell/open-social/eventing/memory"
+)
+
+func followRequestRequest(userReferenceID, followerReferenceID string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/%s", userReferenceID, followerReferenceID), nil)
+
+	return mux.SetURLVars(req, map[string]string{
+		"userReferenceId":     userReferenceID,
+		"followerReferenceId": followerReferenceID,
+	})
+}
+
+func TestApproveFollowRequest_GivenPendingRequest_CreatesFollow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "32047023"
+	const testFollowerReferenceID = "01379nldsd"
+	testUserID := 1203
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), testUserReferenceID).Return(&testUserID, nil)
+
+	mockRequests := mock.NewMockFollowRequestRepository(ctrl)
+	mockFollowers := mock.NewMockFollowerRepository(ctrl)
+	gomock.InOrder(
+		mockRequests.EXPECT().Delete(gomock.Any(), testUserID, testFollowerReferenceID).Return(nil),
+		mockFollowers.EXPECT().Create(gomock.Any(), testUserID, testFollowerReferenceID).Return(nil),
+	)
+
+	events := memory.NewPublisher()
+	handler := NewApproveFollowRequestHandler(mockUsers, mockFollowers, mockRequests, newClaimsMockUow(ctrl), events)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, followRequestRequest(testUserReferenceID, testFollowerReferenceID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	messages := events.Messages()
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, testUserReferenceID, messages[0].Key)
+
+	var event struct {
+		Type    string                                `json:"type"`
+		Payload eventing.FollowRequestApprovedPayload `json:"payload"`
+	}
+	_ = json.Unmarshal(messages[0].Value, &event)
+	assert.Equal(t, eventing.FollowRequestApproved, event.Type)
+	assert.Equal(t, testUserReferenceID, event.Payload.UserReferenceID)
+	assert.Equal(t, testFollowerReferenceID, event.Payload.FollowerReferenceID)
+}
+
+func TestApproveFollowRequest_NoPendingRequest_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "32047023"
+	const testFollowerReferenceID = "01379nldsd"
+	testUserID := 1203
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), testUserReferenceID).Return(&testUserID, nil)
+
+	mockRequests := mock.NewMockFollowRequestRepository(ctrl)
+	mockRequests.EXPECT().Delete(gomock.Any(), testUserID, testFollowerReferenceID).Return(repository.ErrFollowRequestNotFound)
+
+	events := memory.NewPublisher()
+	handler := NewApproveFollowRequestHandler(mockUsers, nil, mockRequests, newClaimsMockUow(ctrl), events)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, followRequestRequest(testUserReferenceID, testFollowerReferenceID))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%s\"}\n", repository.ErrFollowRequestNotFound.Error()), rr.Body.String())
+	assert.Equal(t, 0, len(events.Messages()))
+}
+
+func TestApproveFollowRequest_UserDoesNotExist_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), "32047023").Return(nil, repository.ErrUserNotFound)
+
+	handler := NewApproveFollowRequestHandler(mockUsers, nil, nil, nil, nil)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, followRequestRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
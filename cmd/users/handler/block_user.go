@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+)
+
+// BlockUserHandler is a http.Handler used to handle requests to block a user. Blocking
+// a user removes any follows between the users, and stops them from following each other.
+type BlockUserHandler struct {
+	core.Handler
+	repo   repository.UserRepository
+	blocks repository.BlockRepository
+	uow    database.UnitOfWork
+}
+
+// NewBlockUserHandler returns a new instance of BlockUserHandler.
+func NewBlockUserHandler(repo repository.UserRepository, blocks repository.BlockRepository, uow database.UnitOfWork) *BlockUserHandler {
+	return &BlockUserHandler{
+		repo:   repo,
+		blocks: blocks,
+		uow:    uow,
+	}
+}
+
+func (h *BlockUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceId"]
+	blockerReferenceID := params["blockerReferenceId"]
+
+	if userReferenceID == blockerReferenceID {
+		h.RespondError(w, errors.New("users cannot block themselves"), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := h.repo.GetUserByReference(ctx, userReferenceID, blockerReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	err = user.CanBlock()
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	err = h.uow.Transaction(ctx, func(ctx context.Context) error {
+		return h.blocks.Create(ctx, user.ID(), blockerReferenceID)
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	h.Respond(w, nil)
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	mock "github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+func blockRequest(userReferenceID, blockerReferenceID string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/block/%s/%s", userReferenceID, blockerReferenceID), nil)
+
+	return mux.SetURLVars(req, map[string]string{
+		"userReferenceId":    userReferenceID,
+		"blockerReferenceId": blockerReferenceID,
+	})
+}
+
+func TestBlockUser_GivenValidData_CreatesBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "32047023"
+	const testUserID = 1203
+	const testBlockerReferenceID = "01379nldsd"
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetUserByReference(gomock.Any(), testUserReferenceID, testBlockerReferenceID).
+		Return(model.NewUserFromDao(&dao.User{ID: testUserID, IsFollowing: true}), nil)
+
+	mockBlocks := mock.NewMockBlockRepository(ctrl)
+	mockBlocks.EXPECT().Create(gomock.Any(), testUserID, testBlockerReferenceID).Return(nil)
+
+	rr := httptest.NewRecorder()
+	NewBlockUserHandler(mockUsers, mockBlocks, newClaimsMockUow(ctrl)).ServeHTTP(rr, blockRequest(testUserReferenceID, testBlockerReferenceID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestBlockUser_BlockingThemselves_ReturnsBadRequest(t *testing.T) {
+	rr := httptest.NewRecorder()
+	NewBlockUserHandler(nil, nil, nil).ServeHTTP(rr, blockRequest("32047023", "32047023"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "{\"message\":\"users cannot block themselves\"}\n", rr.Body.String())
+}
+
+func TestBlockUser_UserDoesNotExist_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetUserByReference(gomock.Any(), "32047023", "01379nldsd").
+		Return(nil, repository.ErrUserNotFound)
+
+	rr := httptest.NewRecorder()
+	NewBlockUserHandler(mockUsers, nil, nil).ServeHTTP(rr, blockRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%s\"}\n", repository.ErrUserNotFound.Error()), rr.Body.String())
+}
+
+func TestBlockUser_AlreadyBlocked_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetUserByReference(gomock.Any(), "32047023", "01379nldsd").
+		Return(model.NewUserFromDao(&dao.User{ID: 1203, IsBlocking: true}), nil)
+
+	rr := httptest.NewRecorder()
+	NewBlockUserHandler(mockUsers, nil, nil).ServeHTTP(rr, blockRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "{\"message\":\"user has already blocked this user\"}\n", rr.Body.String())
+}
+
+func TestBlockUser_CreateFails_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetUserByReference(gomock.Any(), "32047023", "01379nldsd").
+		Return(model.NewUserFromDao(&dao.User{ID: 1203}), nil)
+
+	mockBlocks := mock.NewMockBlockRepository(ctrl)
+	mockBlocks.EXPECT().Create(gomock.Any(), 1203, "01379nldsd").Return(testError)
+
+	rr := httptest.NewRecorder()
+	NewBlockUserHandler(mockUsers, mockBlocks, newClaimsMockUow(ctrl)).ServeHTTP(rr, blockRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%s\"}\n", testError.Error()), rr.Body.String())
+}
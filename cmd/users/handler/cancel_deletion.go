@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+)
+
+// CancelDeletionHandler is a http.Handler used to cancel a user's account
+// deletion, during the grace period, before the deletion has started.
+type CancelDeletionHandler struct {
+	core.Handler
+
+	deletions repository.AccountDeletionRepository
+	uow       database.UnitOfWork
+}
+
+// NewCancelDeletionHandler returns a new instance of CancelDeletionHandler.
+func NewCancelDeletionHandler(deletions repository.AccountDeletionRepository, uow database.UnitOfWork) *CancelDeletionHandler {
+	return &CancelDeletionHandler{
+		deletions: deletions,
+		uow:       uow,
+	}
+}
+
+// ServeHTTP handles requests to cancel a user's account deletion.
+func (h *CancelDeletionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	var deletion *model.AccountDeletion
+	err := h.uow.Transaction(r.Context(), func(ctx context.Context) error {
+		var err error
+		deletion, err = h.deletions.Get(ctx, userReferenceID)
+		if err != nil {
+			return err
+		}
+
+		err = deletion.Cancel()
+		if err != nil {
+			return err
+		}
+
+		return h.deletions.Update(ctx, deletion)
+	})
+	if err != nil {
+		switch err {
+		case repository.ErrDeletionNotFound:
+			h.RespondError(w, err, http.StatusNotFound)
+		case model.ErrDeletionStarted, model.ErrDeletionCancelled:
+			h.RespondError(w, err, http.StatusConflict)
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	h.Respond(w, newAccountDeletionResponse(deletion))
+}
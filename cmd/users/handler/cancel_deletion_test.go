@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	repo "github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+func cancelDeletionRequest(referenceID string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "/deletion/cancel/"+referenceID, nil)
+	return mux.SetURLVars(req, map[string]string{"userReferenceID": referenceID})
+}
+
+func TestCancelDeletionHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testDeletion, _ := model.AccountDeletionFromDao(&dao.AccountDeletion{
+		UserReferenceID: testReferenceID,
+		Scheduled:       time.Now().UTC().Add(time.Hour),
+	})
+
+	mockDeletions := repository.NewMockAccountDeletionRepository(ctrl)
+	mockDeletions.EXPECT().Get(gomock.Any(), testReferenceID).Return(testDeletion, nil)
+	mockDeletions.EXPECT().Update(gomock.Any(), testDeletion).Return(nil)
+
+	handler := NewCancelDeletionHandler(mockDeletions, newClaimsMockUow(ctrl))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, cancelDeletionRequest(testReferenceID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var data AccountDeletionResponse
+	json.NewDecoder(rr.Body).Decode(&data)
+	assert.Equal(t, model.DeletionCancelled, data.Status)
+}
+
+func TestCancelDeletionHandler_WhereStarted_ReturnsConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testDeletion, _ := model.AccountDeletionFromDao(&dao.AccountDeletion{
+		UserReferenceID: testReferenceID,
+		Scheduled:       time.Now().UTC().Add(-time.Hour),
+		Attempts:        1,
+	})
+
+	mockDeletions := repository.NewMockAccountDeletionRepository(ctrl)
+	mockDeletions.EXPECT().Get(gomock.Any(), testReferenceID).Return(testDeletion, nil)
+
+	handler := NewCancelDeletionHandler(mockDeletions, newClaimsMockUow(ctrl))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, cancelDeletionRequest(testReferenceID))
+
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrDeletionStarted), rr.Body.String())
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestCancelDeletionHandler_WhereNotRequested_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+
+	mockDeletions := repository.NewMockAccountDeletionRepository(ctrl)
+	mockDeletions.EXPECT().Get(gomock.Any(), testReferenceID).Return(nil, repo.ErrDeletionNotFound)
+
+	handler := NewCancelDeletionHandler(mockDeletions, newClaimsMockUow(ctrl))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, cancelDeletionRequest(testReferenceID))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	hashpkg "github.com/reecerussell/adaptive-password-hasher"
+
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/password"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+)
+
+// ChangePasswordHandler is a http.Handler used to change a user's password,
+// given their current password. Failed attempts are tracked and locked out
+// in the same way as logging in.
+type ChangePasswordHandler struct {
+	loginThrottler
+
+	val    password.Validator
+	hasher hashpkg.Hasher
+	repo   repository.UserRepository
+}
+
+// NewChangePasswordHandler returns a new instance of ChangePasswordHandler.
+func NewChangePasswordHandler(val password.Validator, hasher hashpkg.Hasher, repo repository.UserRepository, throttles repository.LoginThrottleRepository, uow database.UnitOfWork, userPolicy, ipPolicy model.LockoutPolicy) *ChangePasswordHandler {
+	return &ChangePasswordHandler{
+		loginThrottler: loginThrottler{
+			throttles:  throttles,
+			uow:        uow,
+			userPolicy: userPolicy,
+			ipPolicy:   ipPolicy,
+		},
+		val:    val,
+		hasher: hasher,
+		repo:   repo,
+	}
+}
+
+// ChangePasswordRequest represents the request body.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+	IPAddress       string `json:"ipAddress"`
+}
+
+// ServeHTTP handles requests to change a user's password.
+func (h *ChangePasswordHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	var data ChangePasswordRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	ctx := r.Context()
+	user, err := h.repo.GetUserByReference(ctx, userReferenceID, userReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	if !h.verifyPassword(w, r, user, data.CurrentPassword, data.IPAddress, h.hasher) {
+		return
+	}
+
+	err = user.SetPassword(data.NewPassword, h.val, h.hasher)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	err = h.repo.Update(ctx, user)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, nil)
+}
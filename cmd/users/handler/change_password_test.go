@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	hashermock "github.com/reecerussell/adaptive-password-hasher/mock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/mock"
+	"github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	repo "github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+// newClearThrottles returns a LoginThrottleRepository in which the
+// test IP address and the given user aren't locked.
+func newClearThrottles(ctrl *gomock.Controller, referenceID string) *repository.MockLoginThrottleRepository {
+	ipKey := model.IPThrottleKey(testClientIP)
+	userKey := model.UserThrottleKey(referenceID)
+
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), ipKey).Return(model.NewLoginThrottle(ipKey), nil).AnyTimes()
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(model.NewLoginThrottle(userKey), nil).AnyTimes()
+
+	return mockThrottles
+}
+
+const testClientIP = "10.0.0.1"
+
+func changePasswordRequest(referenceID, current, pwd string) *http.Request {
+	body := fmt.Sprintf(`{"currentPassword": "%s", "newPassword": "%s", "ipAddress": "%s"}`, current, pwd, testClientIP)
+	req, _ := http.NewRequest(http.MethodPost, "/password/change/"+referenceID, strings.NewReader(body))
+
+	return mux.SetURLVars(req, map[string]string{"userReferenceID": referenceID})
+}
+
+func TestChangePasswordHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+
+	mockValidator := mock.NewMockValidator(ctrl)
+	mockValidator.EXPECT().Validate("NewPassword_123", gomock.Any()).Return(nil)
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify([]byte("Password_123"), gomock.Any()).Return(true)
+	mockHasher.EXPECT().Hash([]byte("NewPassword_123")).Return([]byte("new-hash"))
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), testUser).Return(nil)
+
+	rr := httptest.NewRecorder()
+	NewChangePasswordHandler(mockValidator, mockHasher, mockRepo, newClearThrottles(ctrl, testReferenceID), nil, testUserPolicy, testIPPolicy).
+		ServeHTTP(rr, changePasswordRequest(testReferenceID, "Password_123", "NewPassword_123"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.NotEqual(t, "t6832ihlw", testUser.Dao().PasswordHash)
+}
+
+func TestChangePasswordHandler_InvalidCurrentPassword_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify([]byte("wrong"), gomock.Any()).Return(false)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).
+		Return(getMockUser(testReferenceID, "testing"), nil)
+
+	mockThrottles := newClearThrottles(ctrl, testReferenceID)
+	mockThrottles.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	rr := httptest.NewRecorder()
+	NewChangePasswordHandler(nil, mockHasher, mockRepo, mockThrottles, newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy).
+		ServeHTTP(rr, changePasswordRequest(testReferenceID, "wrong", "NewPassword_123"))
+
+	exp := fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrInvalidPassword)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestChangePasswordHandler_InvalidNewPassword_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testError := errors.New("password is too short")
+
+	mockValidator := mock.NewMockValidator(ctrl)
+	mockValidator.EXPECT().Validate("short", gomock.Any()).Return(testError)
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).
+		Return(getMockUser(testReferenceID, "testing"), nil)
+
+	rr := httptest.NewRecorder()
+	NewChangePasswordHandler(mockValidator, mockHasher, mockRepo, newClearThrottles(ctrl, testReferenceID), nil, testUserPolicy, testIPPolicy).
+		ServeHTTP(rr, changePasswordRequest(testReferenceID, "Password_123", "short"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestChangePasswordHandler_UserNotFound_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, repo.ErrUserNotFound)
+
+	rr := httptest.NewRecorder()
+	NewChangePasswordHandler(nil, nil, mockRepo, nil, nil, testUserPolicy, testIPPolicy).
+		ServeHTTP(rr, changePasswordRequest("23470324", "Password_123", "NewPassword_123"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestChangePasswordHandler_UserLocked_ReturnsLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	ipKey := model.IPThrottleKey(testClientIP)
+	userKey := model.UserThrottleKey(testReferenceID)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).
+		Return(getMockUser(testReferenceID, "testing"), nil)
+
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), ipKey).Return(model.NewLoginThrottle(ipKey), nil)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(lockedThrottle(userKey), nil)
+
+	rr := httptest.NewRecorder()
+	NewChangePasswordHandler(nil, nil, mockRepo, mockThrottles, nil, testUserPolicy, testIPPolicy).
+		ServeHTTP(rr, changePasswordRequest(testReferenceID, "Password_123", "NewPassword_123"))
+
+	exp := fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrAccountLocked)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusLocked, rr.Code)
+	assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+}
+
+func TestChangePasswordHandler_IPLocked_ReturnsTooManyRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	ipKey := model.IPThrottleKey(testClientIP)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).
+		Return(getMockUser(testReferenceID, "testing"), nil)
+
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), ipKey).Return(lockedThrottle(ipKey), nil)
+
+	rr := httptest.NewRecorder()
+	NewChangePasswordHandler(nil, nil, mockRepo, mockThrottles, nil, testUserPolicy, testIPPolicy).
+		ServeHTTP(rr, changePasswordRequest(testReferenceID, "Password_123", "NewPassword_123"))
+
+	exp := fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrTooManyAttempts)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+)
+
+// ConfirmTwoFactorHandler is a http.Handler used to enable a user's two-factor
+// authentication, given the first code from their authenticator. A new set of
+// recovery codes is generated, which are only ever returned in the response.
+type ConfirmTwoFactorHandler struct {
+	core.Handler
+
+	twoFactors        repository.TwoFactorRepository
+	uow               database.UnitOfWork
+	recoveryCodeCount int
+}
+
+// NewConfirmTwoFactorHandler returns a new instance of ConfirmTwoFactorHandler.
+func NewConfirmTwoFactorHandler(twoFactors repository.TwoFactorRepository, uow database.UnitOfWork, recoveryCodeCount int) *ConfirmTwoFactorHandler {
+	return &ConfirmTwoFactorHandler{
+		twoFactors:        twoFactors,
+		uow:               uow,
+		recoveryCodeCount: recoveryCodeCount,
+	}
+}
+
+// ConfirmTwoFactorRequest represents the request body.
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code"`
+}
+
+// ConfirmTwoFactorResponse represents the response body.
+type ConfirmTwoFactorResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// ServeHTTP handles requests to confirm a user's two-factor authentication.
+func (h *ConfirmTwoFactorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	var data ConfirmTwoFactorRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	var resp ConfirmTwoFactorResponse
+
+	ctx := r.Context()
+	err := h.uow.Transaction(ctx, func(ctx context.Context) error {
+		twoFactor, err := h.twoFactors.Get(ctx, userReferenceID)
+		if err != nil {
+			return err
+		}
+
+		err = twoFactor.Confirm(data.Code, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		err = h.twoFactors.Save(ctx, twoFactor)
+		if err != nil {
+			return err
+		}
+
+		codes := make([]*model.RecoveryCode, h.recoveryCodeCount)
+		resp.RecoveryCodes = make([]string, h.recoveryCodeCount)
+		for i := range codes {
+			codes[i], err = model.NewRecoveryCode(twoFactor)
+			if err != nil {
+				return err
+			}
+
+			resp.RecoveryCodes[i] = codes[i].Code()
+		}
+
+		return h.twoFactors.SetRecoveryCodes(ctx, twoFactor, codes)
+	})
+	if err != nil {
+		switch err {
+		case repository.ErrTwoFactorNotFound, model.ErrInvalidCode:
+			h.RespondError(w, err, http.StatusBadRequest)
+		case model.ErrTwoFactorEnabled:
+			h.RespondError(w, err, http.StatusConflict)
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	h.Respond(w, resp)
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	repo "github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/cmd/users/totp"
+)
+
+func confirmTwoFactorRequest(referenceID, code string) *http.Request {
+	body := fmt.Sprintf(`{"code": "%s"}`, code)
+	req, _ := http.NewRequest(http.MethodPost, "/2fa/confirm/"+referenceID, strings.NewReader(body))
+	return mux.SetURLVars(req, map[string]string{"userReferenceID": referenceID})
+}
+
+func TestConfirmTwoFactorHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	secret, _ := totp.GenerateSecret()
+	code, _ := totp.Code(secret, totp.Step(time.Now()))
+	twoFactor := model.TwoFactorFromDao(&dao.TwoFactor{ID: 3, UserID: 1, UserReferenceID: testReferenceID, Secret: secret})
+
+	var codes []*model.RecoveryCode
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), testReferenceID).Return(twoFactor, nil)
+	mockTwoFactors.EXPECT().Save(gomock.Any(), twoFactor).Return(nil)
+	mockTwoFactors.EXPECT().SetRecoveryCodes(gomock.Any(), twoFactor, gomock.Any()).DoAndReturn(func(_ interface{}, _ *model.TwoFactor, c []*model.RecoveryCode) error {
+		codes = c
+		return nil
+	})
+
+	handler := NewConfirmTwoFactorHandler(mockTwoFactors, newClaimsMockUow(ctrl), 10)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, confirmTwoFactorRequest(testReferenceID, code))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, twoFactor.Enabled())
+
+	var resp ConfirmTwoFactorResponse
+	_ = json.NewDecoder(rr.Body).Decode(&resp)
+	assert.Len(t, resp.RecoveryCodes, 10)
+	assert.Len(t, codes, 10)
+
+	// Only hashes of the codes are persisted.
+	for i, c := range codes {
+		assert.Equal(t, model.HashRecoveryCode(resp.RecoveryCodes[i]), c.Dao().CodeHash)
+	}
+}
+
+func TestConfirmTwoFactorHandler_GivenInvalidCode_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	secret, _ := totp.GenerateSecret()
+	twoFactor := model.TwoFactorFromDao(&dao.TwoFactor{UserReferenceID: testReferenceID, Secret: secret})
+
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), testReferenceID).Return(twoFactor, nil)
+
+	handler := NewConfirmTwoFactorHandler(mockTwoFactors, newClaimsMockUow(ctrl), 10)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, confirmTwoFactorRequest(testReferenceID, "12345"))
+
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrInvalidCode), rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.False(t, twoFactor.Enabled())
+}
+
+func TestConfirmTwoFactorHandler_NotEnrolled_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), testReferenceID).Return(nil, repo.ErrTwoFactorNotFound)
+
+	handler := NewConfirmTwoFactorHandler(mockTwoFactors, newClaimsMockUow(ctrl), 10)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, confirmTwoFactorRequest(testReferenceID, "123456"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestConfirmTwoFactorHandler_AlreadyEnabled_ReturnsConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), testReferenceID).Return(model.TwoFactorFromDao(&dao.TwoFactor{Enabled: true}), nil)
+
+	handler := NewConfirmTwoFactorHandler(mockTwoFactors, newClaimsMockUow(ctrl), 10)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, confirmTwoFactorRequest(testReferenceID, "123456"))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
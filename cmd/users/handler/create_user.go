@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -11,6 +12,8 @@ import (
 	"github.com/reecerussell/open-social/cmd/users/model"
 	"github.com/reecerussell/open-social/cmd/users/password"
 	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/eventing"
 )
 
 // CreateUserHandler is a http.Handler which handles requests to create users.
@@ -20,15 +23,19 @@ type CreateUserHandler struct {
 	val    password.Validator
 	hasher hashpkg.Hasher
 	repo   repository.UserRepository
+	uow    database.UnitOfWork
+	events eventing.Publisher
 }
 
 // NewCreateUserHandler returns a new instance of CreateUserHandler,
 // with the given dependencies.
-func NewCreateUserHandler(val password.Validator, hasher hashpkg.Hasher, repo repository.UserRepository) *CreateUserHandler {
+func NewCreateUserHandler(val password.Validator, hasher hashpkg.Hasher, repo repository.UserRepository, uow database.UnitOfWork, events eventing.Publisher) *CreateUserHandler {
 	return &CreateUserHandler{
 		val:    val,
 		hasher: hasher,
 		repo:   repo,
+		uow:    uow,
+		events: events,
 	}
 }
 
@@ -68,7 +75,18 @@ func (h *CreateUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.repo.Create(ctx, user)
+	err = h.uow.Transaction(ctx, func(ctx context.Context) error {
+		err := h.repo.Create(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		event := eventing.NewEvent(eventing.UserCreated, &eventing.UserCreatedPayload{
+			UserReferenceID: user.ReferenceID(),
+			Username:        user.Username(),
+		})
+		return h.events.Publish(ctx, user.ReferenceID(), event)
+	})
 	if err != nil {
 		h.RespondError(w, err, http.StatusInternalServerError)
 		return
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,6 +17,9 @@ import (
 	"github.com/reecerussell/open-social/cmd/users/mock"
 	"github.com/reecerussell/open-social/cmd/users/mock/repository"
 	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/eventing"
+	"github.com/reecerussell/open-social/eventing/memory"
+	dbMock "github.com/reecerussell/open-social/mock/database"
 )
 
 func TestCreateUserHandler(t *testing.T) {
@@ -30,7 +34,7 @@ func TestCreateUserHandler(t *testing.T) {
 	)
 
 	mockValidator := mock.NewMockValidator(ctrl)
-	mockValidator.EXPECT().Validate(testPassword).Return(nil)
+	mockValidator.EXPECT().Validate(testPassword, gomock.Any()).Return(nil)
 
 	mockHasher := hashermock.NewMockHasher(ctrl)
 	mockHasher.EXPECT().Hash([]byte(testPassword)).Return([]byte(testPassword))
@@ -45,7 +49,14 @@ func TestCreateUserHandler(t *testing.T) {
 			return nil
 		})
 
-	handler := NewCreateUserHandler(mockValidator, mockHasher, mockRepo)
+	events := memory.NewPublisher()
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewCreateUserHandler(mockValidator, mockHasher, mockRepo, mockUow, events)
 
 	body := fmt.Sprintf(`{"username": "%s", "password": "%s"}`, testUsername, testPassword)
 	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
@@ -60,6 +71,21 @@ func TestCreateUserHandler(t *testing.T) {
 	assert.Equal(t, exp, string(data))
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.Equal(t, "application/json", rr.HeaderMap.Get("Content-Type"))
+
+	messages := events.Messages()
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, testReferenceID, messages[0].Key)
+
+	var event struct {
+		Type    string                      `json:"type"`
+		Version int                         `json:"version"`
+		Payload eventing.UserCreatedPayload `json:"payload"`
+	}
+	_ = json.Unmarshal(messages[0].Value, &event)
+	assert.Equal(t, eventing.UserCreated, event.Type)
+	assert.Equal(t, eventing.EventVersion, event.Version)
+	assert.Equal(t, testReferenceID, event.Payload.UserReferenceID)
+	assert.Equal(t, testUsername, event.Payload.Username)
 }
 
 func TestCreateUserHandler_GivenInvalidUserData_ReturnsBadRequest(t *testing.T) {
@@ -73,9 +99,9 @@ func TestCreateUserHandler_GivenInvalidUserData_ReturnsBadRequest(t *testing.T)
 	)
 
 	mockValidator := mock.NewMockValidator(ctrl)
-	mockValidator.EXPECT().Validate(testPassword).Return(errors.New(testErrorMessage))
+	mockValidator.EXPECT().Validate(testPassword, gomock.Any()).Return(errors.New(testErrorMessage))
 
-	handler := NewCreateUserHandler(mockValidator, nil, nil)
+	handler := NewCreateUserHandler(mockValidator, nil, nil, nil, nil)
 
 	body := fmt.Sprintf(`{"username": "%s", "password": "%s"}`, testUsername, testPassword)
 	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
@@ -105,7 +131,7 @@ func TestCreateUserHandler_DoesUsernameExistError_ReturnsInternalServerError(t *
 	)
 
 	mockValidator := mock.NewMockValidator(ctrl)
-	mockValidator.EXPECT().Validate(testPassword).Return(nil)
+	mockValidator.EXPECT().Validate(testPassword, gomock.Any()).Return(nil)
 
 	mockHasher := hashermock.NewMockHasher(ctrl)
 	mockHasher.EXPECT().Hash([]byte(testPassword)).Return([]byte(testPassword))
@@ -113,7 +139,7 @@ func TestCreateUserHandler_DoesUsernameExistError_ReturnsInternalServerError(t *
 	mockRepo := repository.NewMockUserRepository(ctrl)
 	mockRepo.EXPECT().DoesUsernameExist(gomock.Any(), testUsername, nil).Return(false, errors.New(testErrorMessage))
 
-	handler := NewCreateUserHandler(mockValidator, mockHasher, mockRepo)
+	handler := NewCreateUserHandler(mockValidator, mockHasher, mockRepo, nil, nil)
 
 	body := fmt.Sprintf(`{"username": "%s", "password": "%s"}`, testUsername, testPassword)
 	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
@@ -140,7 +166,7 @@ func TestCreateUserHandler_UsernameAlreadyExists_ReturnsBadRequest(t *testing.T)
 	)
 
 	mockValidator := mock.NewMockValidator(ctrl)
-	mockValidator.EXPECT().Validate(testPassword).Return(nil)
+	mockValidator.EXPECT().Validate(testPassword, gomock.Any()).Return(nil)
 
 	mockHasher := hashermock.NewMockHasher(ctrl)
 	mockHasher.EXPECT().Hash([]byte(testPassword)).Return([]byte(testPassword))
@@ -148,7 +174,7 @@ func TestCreateUserHandler_UsernameAlreadyExists_ReturnsBadRequest(t *testing.T)
 	mockRepo := repository.NewMockUserRepository(ctrl)
 	mockRepo.EXPECT().DoesUsernameExist(gomock.Any(), testUsername, nil).Return(true, nil)
 
-	handler := NewCreateUserHandler(mockValidator, mockHasher, mockRepo)
+	handler := NewCreateUserHandler(mockValidator, mockHasher, mockRepo, nil, nil)
 
 	body := fmt.Sprintf(`{"username": "%s", "password": "%s"}`, testUsername, testPassword)
 	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
@@ -178,7 +204,7 @@ func TestCreateUserHandler_CreateError_ReturnsInternalServerError(t *testing.T)
 	)
 
 	mockValidator := mock.NewMockValidator(ctrl)
-	mockValidator.EXPECT().Validate(testPassword).Return(nil)
+	mockValidator.EXPECT().Validate(testPassword, gomock.Any()).Return(nil)
 
 	mockHasher := hashermock.NewMockHasher(ctrl)
 	mockHasher.EXPECT().Hash([]byte(testPassword)).Return([]byte(testPassword))
@@ -187,7 +213,13 @@ func TestCreateUserHandler_CreateError_ReturnsInternalServerError(t *testing.T)
 	mockRepo.EXPECT().DoesUsernameExist(gomock.Any(), testUsername, nil).Return(false, nil)
 	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New(testErrorMessage))
 
-	handler := NewCreateUserHandler(mockValidator, mockHasher, mockRepo)
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewCreateUserHandler(mockValidator, mockHasher, mockRepo, mockUow, nil)
 
 	body := fmt.Sprintf(`{"username": "%s", "password": "%s"}`, testUsername, testPassword)
 	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
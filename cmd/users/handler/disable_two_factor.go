@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	hashpkg "github.com/reecerussell/adaptive-password-hasher"
+
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+)
+
+// DisableTwoFactorHandler is a http.Handler used to disable a user's two-factor
+// authentication, given their password and a code from their authenticator,
+// or one of their recovery codes. Failed password attempts are tracked and
+// locked out in the same way as logging in.
+type DisableTwoFactorHandler struct {
+	loginThrottler
+
+	hasher     hashpkg.Hasher
+	repo       repository.UserRepository
+	twoFactors repository.TwoFactorRepository
+}
+
+// NewDisableTwoFactorHandler returns a new instance of DisableTwoFactorHandler.
+func NewDisableTwoFactorHandler(hasher hashpkg.Hasher, repo repository.UserRepository, twoFactors repository.TwoFactorRepository, throttles repository.LoginThrottleRepository, uow database.UnitOfWork, userPolicy, ipPolicy model.LockoutPolicy) *DisableTwoFactorHandler {
+	return &DisableTwoFactorHandler{
+		loginThrottler: loginThrottler{
+			throttles:  throttles,
+			uow:        uow,
+			userPolicy: userPolicy,
+			ipPolicy:   ipPolicy,
+		},
+		hasher:     hasher,
+		repo:       repo,
+		twoFactors: twoFactors,
+	}
+}
+
+// DisableTwoFactorRequest represents the request body.
+type DisableTwoFactorRequest struct {
+	Password  string `json:"password"`
+	Code      string `json:"code"`
+	IPAddress string `json:"ipAddress"`
+}
+
+// ServeHTTP handles requests to disable a user's two-factor authentication.
+func (h *DisableTwoFactorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	var data DisableTwoFactorRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	ctx := r.Context()
+	user, err := h.repo.GetUserByReference(ctx, userReferenceID, userReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	if !h.verifyPassword(w, r, user, data.Password, data.IPAddress, h.hasher) {
+		return
+	}
+
+	err = h.uow.Transaction(ctx, func(ctx context.Context) error {
+		err := verifySecondFactor(ctx, h.twoFactors, userReferenceID, data.Code, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		return h.twoFactors.Delete(ctx, userReferenceID)
+	})
+	if err != nil {
+		switch err {
+		case repository.ErrTwoFactorNotFound, model.ErrTwoFactorNotEnabled, model.ErrInvalidCode, model.ErrRecoveryCodeUsed:
+			h.RespondError(w, err, http.StatusBadRequest)
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	h.Respond(w, nil)
+}
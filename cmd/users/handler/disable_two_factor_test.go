@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	hashermock "github.com/reecerussell/adaptive-password-hasher/mock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+)
+
+func disableTwoFactorRequest(referenceID, password, code string) *http.Request {
+	body := fmt.Sprintf(`{"password": "%s", "code": "%s", "ipAddress": "%s"}`, password, code, testClientIP)
+	req, _ := http.NewRequest(http.MethodPost, "/2fa/disable/"+referenceID, strings.NewReader(body))
+	return mux.SetURLVars(req, map[string]string{"userReferenceID": referenceID})
+}
+
+func TestDisableTwoFactorHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+	twoFactor, code := testTwoFactor(t, testReferenceID)
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify([]byte("Password_123"), gomock.Any()).Return(true)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), testReferenceID).Return(twoFactor, nil)
+	mockTwoFactors.EXPECT().Save(gomock.Any(), twoFactor).Return(nil)
+	mockTwoFactors.EXPECT().Delete(gomock.Any(), testReferenceID).Return(nil)
+
+	handler := NewDisableTwoFactorHandler(mockHasher, mockRepo, mockTwoFactors, newClearThrottles(ctrl, testReferenceID), newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, disableTwoFactorRequest(testReferenceID, "Password_123", code))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestDisableTwoFactorHandler_GivenInvalidPassword_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+
+	mockThrottles := newClearThrottles(ctrl, testReferenceID)
+	mockThrottles.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	handler := NewDisableTwoFactorHandler(mockHasher, mockRepo, nil, mockThrottles, newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, disableTwoFactorRequest(testReferenceID, "wrong", "123456"))
+
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrInvalidPassword), rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestDisableTwoFactorHandler_GivenInvalidCode_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+	twoFactor, code := testTwoFactor(t, testReferenceID)
+	wrongCode := "000000"
+	if code == wrongCode {
+		wrongCode = "111111"
+	}
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), testReferenceID).Return(twoFactor, nil)
+
+	handler := NewDisableTwoFactorHandler(mockHasher, mockRepo, mockTwoFactors, newClearThrottles(ctrl, testReferenceID), newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, disableTwoFactorRequest(testReferenceID, "Password_123", wrongCode))
+
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrInvalidCode), rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestDisableTwoFactorHandler_UserLocked_ReturnsLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	ipKey := model.IPThrottleKey(testClientIP)
+	userKey := model.UserThrottleKey(testReferenceID)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).
+		Return(getMockUser(testReferenceID, "testing"), nil)
+
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), ipKey).Return(model.NewLoginThrottle(ipKey), nil)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(lockedThrottle(userKey), nil)
+
+	handler := NewDisableTwoFactorHandler(nil, mockRepo, nil, mockThrottles, nil, testUserPolicy, testIPPolicy)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, disableTwoFactorRequest(testReferenceID, "Password_123", "123456"))
+
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrAccountLocked), rr.Body.String())
+	assert.Equal(t, http.StatusLocked, rr.Code)
+}
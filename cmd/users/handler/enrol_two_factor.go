@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+// EnrolTwoFactorHandler is a http.Handler used to start enrolling a user in
+// two-factor authentication, generating a new secret for their authenticator.
+// It isn't enabled until confirmed by the ConfirmTwoFactorHandler.
+type EnrolTwoFactorHandler struct {
+	core.Handler
+
+	repo       repository.UserRepository
+	twoFactors repository.TwoFactorRepository
+	issuer     string
+}
+
+// NewEnrolTwoFactorHandler returns a new instance of EnrolTwoFactorHandler. The
+// issuer is the name authenticator apps show the user's account under.
+func NewEnrolTwoFactorHandler(repo repository.UserRepository, twoFactors repository.TwoFactorRepository, issuer string) *EnrolTwoFactorHandler {
+	return &EnrolTwoFactorHandler{
+		repo:       repo,
+		twoFactors: twoFactors,
+		issuer:     issuer,
+	}
+}
+
+// EnrolTwoFactorResponse represents the response body.
+type EnrolTwoFactorResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// ServeHTTP handles requests to enrol a user in two-factor authentication.
+func (h *EnrolTwoFactorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	ctx := r.Context()
+	user, err := h.repo.GetUserByReference(ctx, userReferenceID, userReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	existing, err := h.twoFactors.Get(ctx, userReferenceID)
+	if err != nil && err != repository.ErrTwoFactorNotFound {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if existing != nil && existing.Enabled() {
+		h.RespondError(w, model.ErrTwoFactorEnabled, http.StatusConflict)
+		return
+	}
+
+	twoFactor, err := model.NewTwoFactor(user)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	err = h.twoFactors.Save(ctx, twoFactor)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := EnrolTwoFactorResponse{
+		Secret: twoFactor.Secret(),
+		URI:    twoFactor.URI(h.issuer, user.Username()),
+	}
+
+	h.Respond(w, resp)
+}
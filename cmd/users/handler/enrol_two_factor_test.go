@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	repo "github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+func enrolTwoFactorRequest(referenceID string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "/2fa/enrol/"+referenceID, nil)
+	return mux.SetURLVars(req, map[string]string{"userReferenceID": referenceID})
+}
+
+func TestEnrolTwoFactorHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+
+	var saved *model.TwoFactor
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), testReferenceID).Return(nil, repo.ErrTwoFactorNotFound)
+	mockTwoFactors.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, t *model.TwoFactor) error {
+		saved = t
+		return nil
+	})
+
+	handler := NewEnrolTwoFactorHandler(mockRepo, mockTwoFactors, "Open Social")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, enrolTwoFactorRequest(testReferenceID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.False(t, saved.Enabled())
+
+	var resp EnrolTwoFactorResponse
+	_ = json.NewDecoder(rr.Body).Decode(&resp)
+	assert.Equal(t, saved.Secret(), resp.Secret)
+	assert.True(t, strings.HasPrefix(resp.URI, "otpauth://totp/Open%20Social:testing?"))
+	assert.Contains(t, resp.URI, "secret="+saved.Secret())
+}
+
+func TestEnrolTwoFactorHandler_AlreadyEnabled_ReturnsConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), testReferenceID).Return(model.TwoFactorFromDao(&dao.TwoFactor{Enabled: true}), nil)
+
+	handler := NewEnrolTwoFactorHandler(mockRepo, mockTwoFactors, "Open Social")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, enrolTwoFactorRequest(testReferenceID))
+
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrTwoFactorEnabled), rr.Body.String())
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestEnrolTwoFactorHandler_UserNotFound_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(nil, repo.ErrUserNotFound)
+
+	handler := NewEnrolTwoFactorHandler(mockRepo, nil, "Open Social")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, enrolTwoFactorRequest(testReferenceID))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -1,29 +1,46 @@
 package handler
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
 	core "github.com/reecerussell/open-social"
 	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/eventing"
 )
 
 // FollowUserHandler is a http.Handler used to handle requests to create a follow record.
+// Following a private user creates a follow request, which the user must approve.
 type FollowUserHandler struct {
 	core.Handler
 	repo      repository.UserRepository
 	followers repository.FollowerRepository
+	requests  repository.FollowRequestRepository
+	uow       database.UnitOfWork
+	events    eventing.Publisher
 }
 
 // NewFollowUserHandler returns a new instance of FollowUserHandler.
-func NewFollowUserHandler(repo repository.UserRepository, followers repository.FollowerRepository) *FollowUserHandler {
+func NewFollowUserHandler(repo repository.UserRepository, followers repository.FollowerRepository, requests repository.FollowRequestRepository, uow database.UnitOfWork, events eventing.Publisher) *FollowUserHandler {
 	return &FollowUserHandler{
 		repo:      repo,
 		followers: followers,
+		requests:  requests,
+		uow:       uow,
+		events:    events,
 	}
 }
 
+// FollowUserResponse represents the response body. IsRequested is true if
+// the user is private, and the follow is waiting for their approval.
+type FollowUserResponse struct {
+	IsFollowing bool `json:"isFollowing"`
+	IsRequested bool `json:"isRequested"`
+}
+
 func (h *FollowUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	userReferenceID := params["userReferenceId"]
@@ -47,7 +64,38 @@ func (h *FollowUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.followers.Create(ctx, user.ID(), followerReferenceID)
+	err = h.uow.Transaction(ctx, func(ctx context.Context) error {
+		if user.IsPrivate() {
+			err := h.requests.Create(ctx, user.ID(), followerReferenceID)
+			if err != nil {
+				return err
+			}
+
+			event := eventing.NewEvent(eventing.FollowRequested, &eventing.FollowRequestedPayload{
+				UserReferenceID:     userReferenceID,
+				FollowerReferenceID: followerReferenceID,
+			})
+			return h.events.Publish(ctx, userReferenceID, event)
+		}
+
+		if user.HasRequested() {
+			err := h.requests.Delete(ctx, user.ID(), followerReferenceID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := h.followers.Create(ctx, user.ID(), followerReferenceID)
+		if err != nil {
+			return err
+		}
+
+		event := eventing.NewEvent(eventing.UserFollowed, &eventing.UserFollowedPayload{
+			UserReferenceID:     userReferenceID,
+			FollowerReferenceID: followerReferenceID,
+		})
+		return h.events.Publish(ctx, userReferenceID, event)
+	})
 	if err != nil {
 		status := http.StatusInternalServerError
 		if err == repository.ErrFollowerNotFound {
@@ -58,5 +106,8 @@ func (h *FollowUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.Respond(w, nil)
+	h.Respond(w, FollowUserResponse{
+		IsFollowing: !user.IsPrivate(),
+		IsRequested: user.IsPrivate(),
+	})
 }
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -15,6 +16,9 @@ import (
 	mock "github.com/reecerussell/open-social/cmd/users/mock/repository"
 	"github.com/reecerussell/open-social/cmd/users/model"
 	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/eventing"
+	"github.com/reecerussell/open-social/eventing/memory"
+	dbMock "github.com/reecerussell/open-social/mock/database"
 )
 
 func TestFollowUser_GivenValidData_ReturnsOK(t *testing.T) {
@@ -33,7 +37,14 @@ func TestFollowUser_GivenValidData_ReturnsOK(t *testing.T) {
 	mockFollowers.EXPECT().Create(gomock.Any(), testUserID, testFollowerReferenceID).
 		Return(nil)
 
-	handler := NewFollowUserHandler(mockUsers, mockFollowers)
+	events := memory.NewPublisher()
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewFollowUserHandler(mockUsers, mockFollowers, nil, mockUow, events)
 	router := mux.NewRouter()
 	router.Handle("/{userReferenceId}/{followerReferenceId}", handler)
 
@@ -42,6 +53,22 @@ func TestFollowUser_GivenValidData_ReturnsOK(t *testing.T) {
 	router.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "{\"isFollowing\":true,\"isRequested\":false}\n", rr.Body.String())
+
+	messages := events.Messages()
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, testUserReferenceID, messages[0].Key)
+
+	var event struct {
+		Type    string                       `json:"type"`
+		Version int                          `json:"version"`
+		Payload eventing.UserFollowedPayload `json:"payload"`
+	}
+	_ = json.Unmarshal(messages[0].Value, &event)
+	assert.Equal(t, eventing.UserFollowed, event.Type)
+	assert.Equal(t, eventing.EventVersion, event.Version)
+	assert.Equal(t, testUserReferenceID, event.Payload.UserReferenceID)
+	assert.Equal(t, testFollowerReferenceID, event.Payload.FollowerReferenceID)
 }
 
 func TestFollowUser_UserDoesNotExist_ReturnsNotFound(t *testing.T) {
@@ -56,7 +83,7 @@ func TestFollowUser_UserDoesNotExist_ReturnsNotFound(t *testing.T) {
 	mockUsers.EXPECT().GetUserByReference(gomock.Any(), testUserReferenceID, testFollowerReferenceID).
 		Return(nil, repository.ErrUserNotFound)
 
-	handler := NewFollowUserHandler(mockUsers, nil)
+	handler := NewFollowUserHandler(mockUsers, nil, nil, nil, nil)
 	router := mux.NewRouter()
 	router.Handle("/{userReferenceId}/{followerReferenceId}", handler)
 
@@ -84,7 +111,7 @@ func TestFollowUser_UserAlreadyFollowing_ReturnsBadRequest(t *testing.T) {
 	mockUsers.EXPECT().GetUserByReference(gomock.Any(), testUserReferenceID, testFollowerReferenceID).
 		Return(model.NewUserFromDao(&dao.User{ID: testUserID, IsFollowing: true}), nil)
 
-	handler := NewFollowUserHandler(mockUsers, nil)
+	handler := NewFollowUserHandler(mockUsers, nil, nil, nil, nil)
 	router := mux.NewRouter()
 	router.Handle("/{userReferenceId}/{followerReferenceId}", handler)
 
@@ -112,7 +139,13 @@ func TestFollowUser_FollowerNotFound_ReturnsNotFound(t *testing.T) {
 	mockFollowers.EXPECT().Create(gomock.Any(), testUserID, testFollowerReferenceID).
 		Return(repository.ErrFollowerNotFound)
 
-	handler := NewFollowUserHandler(mockUsers, mockFollowers)
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewFollowUserHandler(mockUsers, mockFollowers, nil, mockUow, nil)
 	router := mux.NewRouter()
 	router.Handle("/{userReferenceId}/{followerReferenceId}", handler)
 
@@ -127,3 +160,118 @@ func TestFollowUser_FollowerNotFound_ReturnsNotFound(t *testing.T) {
 	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
 	assert.Equal(t, repository.ErrFollowerNotFound.Error(), data["message"])
 }
+
+func TestFollowUser_UserIsPrivate_CreatesRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "32047023"
+	const testUserID = 1203
+	const testFollowerReferenceID = "01379nldsd"
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetUserByReference(gomock.Any(), testUserReferenceID, testFollowerReferenceID).
+		Return(model.NewUserFromDao(&dao.User{ID: testUserID, IsPrivate: true}), nil)
+
+	mockRequests := mock.NewMockFollowRequestRepository(ctrl)
+	mockRequests.EXPECT().Create(gomock.Any(), testUserID, testFollowerReferenceID).Return(nil)
+
+	events := memory.NewPublisher()
+	handler := NewFollowUserHandler(mockUsers, nil, mockRequests, newClaimsMockUow(ctrl), events)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceId}/{followerReferenceId}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/%s", testUserReferenceID, testFollowerReferenceID), nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "{\"isFollowing\":false,\"isRequested\":true}\n", rr.Body.String())
+
+	messages := events.Messages()
+	assert.Equal(t, 1, len(messages))
+
+	var event struct {
+		Type    string                          `json:"type"`
+		Payload eventing.FollowRequestedPayload `json:"payload"`
+	}
+	_ = json.Unmarshal(messages[0].Value, &event)
+	assert.Equal(t, eventing.FollowRequested, event.Type)
+	assert.Equal(t, testUserReferenceID, event.Payload.UserReferenceID)
+	assert.Equal(t, testFollowerReferenceID, event.Payload.FollowerReferenceID)
+}
+
+func TestFollowUser_UserIsPrivateAlreadyRequested_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "32047023"
+	const testFollowerReferenceID = "01379nldsd"
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetUserByReference(gomock.Any(), testUserReferenceID, testFollowerReferenceID).
+		Return(model.NewUserFromDao(&dao.User{ID: 1203, IsPrivate: true, HasRequested: true}), nil)
+
+	handler := NewFollowUserHandler(mockUsers, nil, nil, nil, nil)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceId}/{followerReferenceId}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/%s", testUserReferenceID, testFollowerReferenceID), nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestFollowUser_UserIsBlocked_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "32047023"
+	const testFollowerReferenceID = "01379nldsd"
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetUserByReference(gomock.Any(), testUserReferenceID, testFollowerReferenceID).
+		Return(model.NewUserFromDao(&dao.User{ID: 1203, IsBlockedBy: true}), nil)
+
+	handler := NewFollowUserHandler(mockUsers, nil, nil, nil, nil)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceId}/{followerReferenceId}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/%s", testUserReferenceID, testFollowerReferenceID), nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "{\"message\":\"user cannot follow this user\"}\n", rr.Body.String())
+}
+
+func TestFollowUser_UserMadePublicWithPendingRequest_ReplacesRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "32047023"
+	const testUserID = 1203
+	const testFollowerReferenceID = "01379nldsd"
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetUserByReference(gomock.Any(), testUserReferenceID, testFollowerReferenceID).
+		Return(model.NewUserFromDao(&dao.User{ID: testUserID, HasRequested: true}), nil)
+
+	mockRequests := mock.NewMockFollowRequestRepository(ctrl)
+	mockFollowers := mock.NewMockFollowerRepository(ctrl)
+	gomock.InOrder(
+		mockRequests.EXPECT().Delete(gomock.Any(), testUserID, testFollowerReferenceID).Return(nil),
+		mockFollowers.EXPECT().Create(gomock.Any(), testUserID, testFollowerReferenceID).Return(nil),
+	)
+
+	handler := NewFollowUserHandler(mockUsers, mockFollowers, mockRequests, newClaimsMockUow(ctrl), memory.NewPublisher())
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceId}/{followerReferenceId}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/%s", testUserReferenceID, testFollowerReferenceID), nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/pagination"
+	"github.com/reecerussell/open-social/cmd/users/provider"
+)
+
+// GetBlockedUsersHandler is a http.Handler used to list the users blocked by a user.
+type GetBlockedUsersHandler struct {
+	core.Handler
+	provider provider.UserProvider
+}
+
+// NewGetBlockedUsersHandler returns a new instance of GetBlockedUsersHandler.
+func NewGetBlockedUsersHandler(provider provider.UserProvider) *GetBlockedUsersHandler {
+	return &GetBlockedUsersHandler{
+		provider: provider,
+	}
+}
+
+func (h *GetBlockedUsersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	page, err := pagination.PageFromRequest(r)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	list, err := h.provider.GetBlockedUsers(ctx, userReferenceID, page)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, list)
+}
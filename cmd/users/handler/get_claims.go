@@ -2,41 +2,70 @@ package handler
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
+	"time"
 
-	hashpkg "github.com/reecerussell/adaptive-password-hasher"
-
-	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/password"
 	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
 )
 
 // GetClaimsHandler is a http.Handler used to get a user's claim values,
-// given a username and password.
+// given a username and password. Failed attempts are tracked for both the
+// user and the IP address of the request, each of which is temporarily
+// locked out once it has failed too many times. Passwords hashed with
+// outdated options are rehashed once they've been verified.
+//
+// Users with two-factor authentication get a login challenge instead of their
+// claims, which is completed with a code by the VerifySecondFactorHandler.
 type GetClaimsHandler struct {
-	core.Handler
+	loginThrottler
 
-	hasher hashpkg.Hasher
-	repo   repository.UserRepository
+	hasher            password.Hasher
+	repo              repository.UserRepository
+	twoFactors        repository.TwoFactorRepository
+	challenges        repository.LoginChallengeRepository
+	challengeLifetime time.Duration
 }
 
 // NewGetClaimsHandler returns a new instance of GetClaimsHandler,
 // with the given dependcies.
-func NewGetClaimsHandler(hasher hashpkg.Hasher, repo repository.UserRepository) *GetClaimsHandler {
+func NewGetClaimsHandler(hasher password.Hasher, repo repository.UserRepository, throttles repository.LoginThrottleRepository, twoFactors repository.TwoFactorRepository, challenges repository.LoginChallengeRepository, uow database.UnitOfWork, userPolicy, ipPolicy model.LockoutPolicy, challengeLifetime time.Duration) *GetClaimsHandler {
 	return &GetClaimsHandler{
-		hasher: hasher,
-		repo:   repo,
+		loginThrottler: loginThrottler{
+			throttles:  throttles,
+			uow:        uow,
+			userPolicy: userPolicy,
+			ipPolicy:   ipPolicy,
+		},
+		hasher:            hasher,
+		repo:              repo,
+		twoFactors:        twoFactors,
+		challenges:        challenges,
+		challengeLifetime: challengeLifetime,
 	}
 }
 
 // GetClaimsRequest represents the request body.
 type GetClaimsRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	IPAddress string `json:"ipAddress"`
 }
 
-// GetClaimsResponse represents the response body.
+// GetClaimsResponse represents the response body. Either the claims, or
+// a challenge requiring a second factor, are set.
 type GetClaimsResponse struct {
-	Claims map[string]interface{} `json:"claims"`
+	Claims    map[string]interface{}  `json:"claims,omitempty"`
+	Challenge *LoginChallengeResponse `json:"challenge,omitempty"`
+}
+
+// LoginChallengeResponse contains a login challenge's token, and its expiry date.
+type LoginChallengeResponse struct {
+	Token   string `json:"token"`
+	Expires int64  `json:"expires"`
 }
 
 // ServeHTTP handles HTTP requests to get a user's claims.
@@ -46,10 +75,23 @@ func (h *GetClaimsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
 	ctx := r.Context()
+	now := time.Now().UTC()
+
+	until, locked, err := h.ipLockedUntil(ctx, now, data.IPAddress)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if locked {
+		h.respondLocked(w, model.ErrTooManyAttempts, http.StatusTooManyRequests, until.Sub(now))
+		return
+	}
+
 	user, err := h.repo.GetUserByUsername(ctx, data.Username)
 	if err != nil {
 		if err == repository.ErrUserNotFound {
-			h.RespondError(w, err, http.StatusBadRequest)
+			h.respondFailure(w, err, h.recordFailure(ctx, now, "", data.IPAddress))
 			return
 		}
 
@@ -57,20 +99,91 @@ func (h *GetClaimsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userKey := model.UserThrottleKey(user.ReferenceID())
+	throttle, err := h.throttles.Get(ctx, userKey)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if until, locked := throttle.LockedUntil(now); locked {
+		h.respondLocked(w, model.ErrAccountLocked, http.StatusLocked, until.Sub(now))
+		return
+	}
+
 	err = user.VerifyPassword(data.Password, h.hasher)
 	if err != nil {
-		h.RespondError(w, err, http.StatusBadRequest)
+		h.respondFailure(w, err, h.recordFailure(ctx, now, userKey, data.IPAddress))
 		return
 	}
 
-	claims := map[string]interface{}{
-		"username": user.Username(),
-		"uid":      user.ReferenceID(),
+	if user.NeedsRehash(h.hasher) {
+		user.Rehash(data.Password, h.hasher)
+
+		// The login shouldn't fail because of this, as it can be tried again next time.
+		err = h.repo.Update(ctx, user)
+		if err != nil {
+			log.Printf("WARN: failed to rehash password for %s: %v\n", user.ReferenceID(), err)
+		}
+	}
+
+	twoFactor, err := h.twoFactors.Get(ctx, user.ReferenceID())
+	if err != nil && err != repository.ErrTwoFactorNotFound {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	// The user's backoff isn't reset until the second factor has been verified,
+	// so codes can't be guessed by repeatedly logging in with a stolen password.
+	if twoFactor != nil && twoFactor.Enabled() {
+		h.respondChallenge(w, r, user)
+		return
+	}
+
+	// A successful login resets the user's backoff, but not the IP address's,
+	// otherwise an attacker could reset it by logging into their own account.
+	if !throttle.IsClear() {
+		err = h.throttles.Delete(ctx, userKey)
+		if err != nil {
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	resp := GetClaimsResponse{
+		Claims: userClaims(user),
+	}
+
+	h.Respond(w, resp)
+}
+
+func (h *GetClaimsHandler) respondChallenge(w http.ResponseWriter, r *http.Request, user *model.User) {
+	challenge, err := model.NewLoginChallenge(user, h.challengeLifetime)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	err = h.challenges.Create(r.Context(), challenge)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
 	}
 
 	resp := GetClaimsResponse{
-		Claims: claims,
+		Challenge: &LoginChallengeResponse{
+			Token:   challenge.Token(),
+			Expires: challenge.Expires().Unix(),
+		},
 	}
 
 	h.Respond(w, resp)
 }
+
+// userClaims returns the claims to be issued in a user's access token.
+func userClaims(user *model.User) map[string]interface{} {
+	return map[string]interface{}{
+		"username": user.Username(),
+		"uid":      user.ReferenceID(),
+	}
+}
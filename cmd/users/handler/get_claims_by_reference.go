@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+// GetClaimsByReferenceHandler is a http.Handler used to get a user's claim values,
+// given their reference id. This is used to reissue access tokens, once the user
+// has already been authenticated, such as when a refresh token is used.
+type GetClaimsByReferenceHandler struct {
+	core.Handler
+	repo repository.UserRepository
+}
+
+// NewGetClaimsByReferenceHandler returns a new instance of GetClaimsByReferenceHandler.
+func NewGetClaimsByReferenceHandler(repo repository.UserRepository) *GetClaimsByReferenceHandler {
+	return &GetClaimsByReferenceHandler{
+		repo: repo,
+	}
+}
+
+// ServeHTTP handles HTTP requests to get a user's claims.
+func (h *GetClaimsByReferenceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	ctx := r.Context()
+	user, err := h.repo.GetUserByReference(ctx, userReferenceID, userReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	resp := GetClaimsResponse{
+		Claims: userClaims(user),
+	}
+
+	h.Respond(w, resp)
+}
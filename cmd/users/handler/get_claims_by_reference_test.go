@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/mock/repository"
+	repo "github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+func TestGetClaimsByReferenceHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUsername = "testing"
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, testUsername)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+
+	handler := NewGetClaimsByReferenceHandler(mockRepo)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler)
+
+	req, _ := http.NewRequest(http.MethodGet, "/"+testReferenceID, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	exp := fmt.Sprintf("{\"claims\":{\"uid\":\"%s\",\"username\":\"%s\"}}\n", testReferenceID, testUsername)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestGetClaimsByReferenceHandler_UserNotFound_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(nil, repo.ErrUserNotFound)
+
+	handler := NewGetClaimsByReferenceHandler(mockRepo)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler)
+
+	req, _ := http.NewRequest(http.MethodGet, "/"+testReferenceID, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "{\"message\":\"user not found\"}\n", rr.Body.String())
+}
+
+func TestGetClaimsByReferenceHandler_RepoReturnsError_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testError := errors.New("an error occured")
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(nil, testError)
+
+	handler := NewGetClaimsByReferenceHandler(mockRepo)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler)
+
+	req, _ := http.NewRequest(http.MethodGet, "/"+testReferenceID, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "{\"message\":\"an error occured\"}\n", rr.Body.String())
+}
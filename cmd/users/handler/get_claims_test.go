@@ -1,23 +1,60 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
-	hashermock "github.com/reecerussell/adaptive-password-hasher/mock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/mock"
 	"github.com/reecerussell/open-social/cmd/users/mock/repository"
 	"github.com/reecerussell/open-social/cmd/users/model"
 	repo "github.com/reecerussell/open-social/cmd/users/repository"
+	dbMock "github.com/reecerussell/open-social/mock/database"
 )
 
+var (
+	testUserPolicy = model.LockoutPolicy{MaxAttempts: 3, Window: time.Minute, Lockout: time.Minute, MaxLockout: time.Hour}
+	testIPPolicy   = model.LockoutPolicy{MaxAttempts: 10, Window: time.Minute, Lockout: time.Minute, MaxLockout: time.Hour}
+)
+
+const testChallengeLifetime = 5 * time.Minute
+
+// newNoTwoFactorRepo returns a TwoFactorRepository for a user without two-factor authentication.
+func newNoTwoFactorRepo(ctrl *gomock.Controller) *repository.MockTwoFactorRepository {
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, repo.ErrTwoFactorNotFound)
+
+	return mockTwoFactors
+}
+
+func newClaimsMockUow(ctrl *gomock.Controller) *dbMock.MockUnitOfWork {
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	return mockUow
+}
+
+func lockedThrottle(key string) *model.LoginThrottle {
+	throttle := model.NewLoginThrottle(key)
+	for i := 0; i < testUserPolicy.MaxAttempts; i++ {
+		throttle.RecordFailure(time.Now().UTC(), testUserPolicy)
+	}
+
+	return throttle
+}
+
 func getMockUser(referenceID, username string) *model.User {
 	return model.NewUserFromDao(&dao.User{
 		ID:           1,
@@ -36,13 +73,18 @@ func TestGetClaimsHandler(t *testing.T) {
 	const testPassword = "Password_123"
 	testUser := getMockUser(testReferenceID, testUsername)
 
-	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher := mock.NewMockHasher(ctrl)
 	mockHasher.EXPECT().Verify([]byte(testPassword), gomock.Any()).Return(true)
+	mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
 
 	mockRepo := repository.NewMockUserRepository(ctrl)
 	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), testUsername).Return(testUser, nil)
 
-	handler := NewGetClaimsHandler(mockHasher, mockRepo)
+	userKey := model.UserThrottleKey(testReferenceID)
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(model.NewLoginThrottle(userKey), nil)
+
+	handler := NewGetClaimsHandler(mockHasher, mockRepo, mockThrottles, newNoTwoFactorRepo(ctrl), nil, nil, testUserPolicy, testIPPolicy, testChallengeLifetime)
 
 	body := fmt.Sprintf(`{"username": "%s", "password": "%s"}`, testUsername, testPassword)
 	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
@@ -69,7 +111,7 @@ func TestGetClaimsHandler_UserDoesNotExist_ReturnsBadRequest(t *testing.T) {
 	mockRepo := repository.NewMockUserRepository(ctrl)
 	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), testUsername).Return(nil, repo.ErrUserNotFound)
 
-	handler := NewGetClaimsHandler(nil, mockRepo)
+	handler := NewGetClaimsHandler(nil, mockRepo, nil, nil, nil, newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy, testChallengeLifetime)
 
 	body := fmt.Sprintf(`{"username": "%s", "password": "%s"}`, testUsername, testPassword)
 	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
@@ -97,7 +139,7 @@ func TestGetClaimsHandler_FailedToGetUser_ReturnsInternalServerError(t *testing.
 	mockRepo := repository.NewMockUserRepository(ctrl)
 	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), testUsername).Return(nil, errors.New(errorMessage))
 
-	handler := NewGetClaimsHandler(nil, mockRepo)
+	handler := NewGetClaimsHandler(nil, mockRepo, nil, nil, nil, nil, testUserPolicy, testIPPolicy, testChallengeLifetime)
 
 	body := fmt.Sprintf(`{"username": "%s", "password": "%s"}`, testUsername, testPassword)
 	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
@@ -123,13 +165,18 @@ func TestGetClaimsHandler_GivenInvalidPassword_ReturnsBadRequest(t *testing.T) {
 	const testPassword = "Password_123"
 	testUser := getMockUser(testReferenceID, testUsername)
 
-	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher := mock.NewMockHasher(ctrl)
 	mockHasher.EXPECT().Verify([]byte(testPassword), gomock.Any()).Return(false)
 
 	mockRepo := repository.NewMockUserRepository(ctrl)
 	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), testUsername).Return(testUser, nil)
 
-	handler := NewGetClaimsHandler(mockHasher, mockRepo)
+	userKey := model.UserThrottleKey(testReferenceID)
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(model.NewLoginThrottle(userKey), nil).Times(2)
+	mockThrottles.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	handler := NewGetClaimsHandler(mockHasher, mockRepo, mockThrottles, nil, nil, newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy, testChallengeLifetime)
 
 	body := fmt.Sprintf(`{"username": "%s", "password": "%s"}`, testUsername, testPassword)
 	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
@@ -145,3 +192,320 @@ func TestGetClaimsHandler_GivenInvalidPassword_ReturnsBadRequest(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 	assert.Equal(t, "application/json", rr.HeaderMap.Get("Content-Type"))
 }
+
+func TestGetClaimsHandler_UserLocked_ReturnsLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUsername = "testing"
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, testUsername)
+	userKey := model.UserThrottleKey(testReferenceID)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), testUsername).Return(testUser, nil)
+
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(lockedThrottle(userKey), nil)
+
+	// The password must not be verified while the account is locked.
+	handler := NewGetClaimsHandler(nil, mockRepo, mockThrottles, nil, nil, nil, testUserPolicy, testIPPolicy, testChallengeLifetime)
+
+	body := fmt.Sprintf(`{"username": "%s", "password": "Password_123"}`, testUsername)
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	exp := fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrAccountLocked)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusLocked, rr.Code)
+	assert.Equal(t, "60", rr.Header().Get("Retry-After"))
+}
+
+func TestGetClaimsHandler_IPLocked_ReturnsTooManyRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testIP = "10.0.0.1"
+	ipKey := model.IPThrottleKey(testIP)
+
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), ipKey).Return(lockedThrottle(ipKey), nil)
+
+	handler := NewGetClaimsHandler(nil, nil, mockThrottles, nil, nil, nil, testUserPolicy, testIPPolicy, testChallengeLifetime)
+
+	body := fmt.Sprintf(`{"username": "testing", "password": "Password_123", "ipAddress": "%s"}`, testIP)
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	exp := fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrTooManyAttempts)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+}
+
+func TestGetClaimsHandler_InvalidPassword_RecordsUserAndIPFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUsername = "testing"
+	const testReferenceID = "23470324"
+	const testIP = "10.0.0.1"
+	testUser := getMockUser(testReferenceID, testUsername)
+	userKey := model.UserThrottleKey(testReferenceID)
+	ipKey := model.IPThrottleKey(testIP)
+
+	mockHasher := mock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), testUsername).Return(testUser, nil)
+
+	saved := make(map[string]*model.LoginThrottle)
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), ipKey).Return(model.NewLoginThrottle(ipKey), nil).Times(2)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(model.NewLoginThrottle(userKey), nil).Times(2)
+	mockThrottles.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, throttle *model.LoginThrottle) error {
+			saved[throttle.Key()] = throttle
+			return nil
+		}).Times(2)
+
+	handler := NewGetClaimsHandler(mockHasher, mockRepo, mockThrottles, nil, nil, newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy, testChallengeLifetime)
+
+	body := fmt.Sprintf(`{"username": "%s", "password": "wrong", "ipAddress": "%s"}`, testUsername, testIP)
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, 1, saved[userKey].Dao().Failures)
+	assert.Equal(t, 1, saved[ipKey].Dao().Failures)
+}
+
+func TestGetClaimsHandler_FailedToRecordFailure_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUsername = "testing"
+	const testReferenceID = "23470324"
+	const errorMessage = "an error occured"
+	testUser := getMockUser(testReferenceID, testUsername)
+	userKey := model.UserThrottleKey(testReferenceID)
+
+	mockHasher := mock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), testUsername).Return(testUser, nil)
+
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(model.NewLoginThrottle(userKey), nil).Times(2)
+	mockThrottles.EXPECT().Save(gomock.Any(), gomock.Any()).Return(errors.New(errorMessage))
+
+	handler := NewGetClaimsHandler(mockHasher, mockRepo, mockThrottles, nil, nil, newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy, testChallengeLifetime)
+
+	body := fmt.Sprintf(`{"username": "%s", "password": "wrong"}`, testUsername)
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	exp := fmt.Sprintf("{\"message\":\"%v\"}\n", errorMessage)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestGetClaimsHandler_SuccessAfterFailures_ResetsUserThrottle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUsername = "testing"
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, testUsername)
+	userKey := model.UserThrottleKey(testReferenceID)
+
+	throttle := model.NewLoginThrottle(userKey)
+	throttle.RecordFailure(time.Now().UTC(), testUserPolicy)
+
+	mockHasher := mock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true)
+	mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), testUsername).Return(testUser, nil)
+
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(throttle, nil)
+	mockThrottles.EXPECT().Delete(gomock.Any(), userKey).Return(nil)
+
+	handler := NewGetClaimsHandler(mockHasher, mockRepo, mockThrottles, newNoTwoFactorRepo(ctrl), nil, nil, testUserPolicy, testIPPolicy, testChallengeLifetime)
+
+	body := fmt.Sprintf(`{"username": "%s", "password": "Password_123"}`, testUsername)
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestGetClaimsHandler_OutdatedHash_RehashesPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUsername = "testing"
+	const testReferenceID = "23470324"
+	const testPassword = "Password_123"
+	testUser := getMockUser(testReferenceID, testUsername)
+
+	mockHasher := mock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify([]byte(testPassword), gomock.Any()).Return(true)
+	mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(true)
+	mockHasher.EXPECT().Hash([]byte(testPassword)).Return([]byte("new hash"))
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), testUsername).Return(testUser, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), testUser).DoAndReturn(func(ctx context.Context, u *model.User) error {
+		assert.Equal(t, "bmV3IGhhc2g=", u.Dao().PasswordHash)
+		return nil
+	})
+
+	userKey := model.UserThrottleKey(testReferenceID)
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(model.NewLoginThrottle(userKey), nil)
+
+	handler := NewGetClaimsHandler(mockHasher, mockRepo, mockThrottles, newNoTwoFactorRepo(ctrl), nil, nil, testUserPolicy, testIPPolicy, testChallengeLifetime)
+
+	body := fmt.Sprintf(`{"username": "%s", "password": "%s"}`, testUsername, testPassword)
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestGetClaimsHandler_FailedToRehash_StillReturnsClaims(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUsername = "testing"
+	const testReferenceID = "23470324"
+	const testPassword = "Password_123"
+	testUser := getMockUser(testReferenceID, testUsername)
+
+	mockHasher := mock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify([]byte(testPassword), gomock.Any()).Return(true)
+	mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(true)
+	mockHasher.EXPECT().Hash([]byte(testPassword)).Return([]byte("new hash"))
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), testUsername).Return(testUser, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), testUser).Return(errors.New("an error occured"))
+
+	userKey := model.UserThrottleKey(testReferenceID)
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(model.NewLoginThrottle(userKey), nil)
+
+	handler := NewGetClaimsHandler(mockHasher, mockRepo, mockThrottles, newNoTwoFactorRepo(ctrl), nil, nil, testUserPolicy, testIPPolicy, testChallengeLifetime)
+
+	body := fmt.Sprintf(`{"username": "%s", "password": "%s"}`, testUsername, testPassword)
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	exp := fmt.Sprintf("{\"claims\":{\"uid\":\"%s\",\"username\":\"%s\"}}\n", testReferenceID, testUsername)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestGetClaimsHandler_TwoFactorEnabled_ReturnsChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUsername = "testing"
+	const testReferenceID = "23470324"
+	const testPassword = "Password_123"
+	testUser := getMockUser(testReferenceID, testUsername)
+
+	mockHasher := mock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify([]byte(testPassword), gomock.Any()).Return(true)
+	mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), testUsername).Return(testUser, nil)
+
+	// The user's throttle isn't reset until the second factor is verified.
+	userKey := model.UserThrottleKey(testReferenceID)
+	throttle := model.NewLoginThrottle(userKey)
+	throttle.RecordFailure(time.Now().UTC(), testUserPolicy)
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(throttle, nil)
+
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), testReferenceID).Return(model.TwoFactorFromDao(&dao.TwoFactor{Enabled: true}), nil)
+
+	var challenge *model.LoginChallenge
+	mockChallenges := repository.NewMockLoginChallengeRepository(ctrl)
+	mockChallenges.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, c *model.LoginChallenge) error {
+		challenge = c
+		return nil
+	})
+
+	handler := NewGetClaimsHandler(mockHasher, mockRepo, mockThrottles, mockTwoFactors, mockChallenges, nil, testUserPolicy, testIPPolicy, testChallengeLifetime)
+
+	body := fmt.Sprintf(`{"username": "%s", "password": "%s"}`, testUsername, testPassword)
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, testReferenceID, challenge.UserReferenceID())
+	assert.WithinDuration(t, time.Now().Add(testChallengeLifetime), challenge.Expires(), time.Second)
+
+	exp := fmt.Sprintf("{\"challenge\":{\"token\":\"%s\",\"expires\":%d}}\n", challenge.Token(), challenge.Expires().Unix())
+	assert.Equal(t, exp, rr.Body.String())
+}
+
+func TestGetClaimsHandler_TwoFactorNotConfirmed_ReturnsClaims(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUsername = "testing"
+	const testReferenceID = "23470324"
+	const testPassword = "Password_123"
+	testUser := getMockUser(testReferenceID, testUsername)
+
+	mockHasher := mock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify([]byte(testPassword), gomock.Any()).Return(true)
+	mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), testUsername).Return(testUser, nil)
+
+	userKey := model.UserThrottleKey(testReferenceID)
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(model.NewLoginThrottle(userKey), nil)
+
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), testReferenceID).Return(model.TwoFactorFromDao(&dao.TwoFactor{Enabled: false}), nil)
+
+	handler := NewGetClaimsHandler(mockHasher, mockRepo, mockThrottles, mockTwoFactors, nil, nil, testUserPolicy, testIPPolicy, testChallengeLifetime)
+
+	body := fmt.Sprintf(`{"username": "%s", "password": "%s"}`, testUsername, testPassword)
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	exp := fmt.Sprintf("{\"claims\":{\"uid\":\"%s\",\"username\":\"%s\"}}\n", testReferenceID, testUsername)
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
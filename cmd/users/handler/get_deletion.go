@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+// GetDeletionHandler is a http.Handler used to get the status of a user's account deletion.
+type GetDeletionHandler struct {
+	core.Handler
+	deletions repository.AccountDeletionRepository
+}
+
+// NewGetDeletionHandler returns a new instance of GetDeletionHandler.
+func NewGetDeletionHandler(deletions repository.AccountDeletionRepository) *GetDeletionHandler {
+	return &GetDeletionHandler{
+		deletions: deletions,
+	}
+}
+
+func (h *GetDeletionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	deletion, err := h.deletions.Get(r.Context(), userReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrDeletionNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	h.Respond(w, newAccountDeletionResponse(deletion))
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	repo "github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+func TestGetDeletionHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testError := "delete posts: an error occured"
+	testDeletion, _ := model.AccountDeletionFromDao(&dao.AccountDeletion{
+		UserReferenceID: testReferenceID,
+		Scheduled:       time.Now().UTC().Add(-time.Hour),
+		Attempts:        2,
+		LastError:       &testError,
+	})
+
+	mockDeletions := repository.NewMockAccountDeletionRepository(ctrl)
+	mockDeletions.EXPECT().Get(gomock.Any(), testReferenceID).Return(testDeletion, nil)
+
+	handler := NewGetDeletionHandler(mockDeletions)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/"+testReferenceID, nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var data AccountDeletionResponse
+	json.NewDecoder(rr.Body).Decode(&data)
+	assert.Equal(t, model.DeletionInProgress, data.Status)
+	assert.Equal(t, 2, data.Attempts)
+	assert.Equal(t, testError, *data.LastError)
+}
+
+func TestGetDeletionHandler_WhereNotRequested_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+
+	mockDeletions := repository.NewMockAccountDeletionRepository(ctrl)
+	mockDeletions.EXPECT().Get(gomock.Any(), testReferenceID).Return(nil, repo.ErrDeletionNotFound)
+
+	handler := NewGetDeletionHandler(mockDeletions)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/"+testReferenceID, nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
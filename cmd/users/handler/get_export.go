@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/provider"
+)
+
+// GetExportHandler is a http.Handler used to get a user's profile
+// data, for their personal data export.
+type GetExportHandler struct {
+	core.Handler
+	provider provider.UserProvider
+}
+
+// NewGetExportHandler returns a new instance of GetExportHandler.
+func NewGetExportHandler(provider provider.UserProvider) *GetExportHandler {
+	return &GetExportHandler{
+		provider: provider,
+	}
+}
+
+func (h *GetExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	export, err := h.provider.GetExport(r.Context(), userReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == provider.ErrProfileNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	h.Respond(w, export)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dto"
+	mock "github.com/reecerussell/open-social/cmd/users/mock/provider"
+	"github.com/reecerussell/open-social/cmd/users/provider"
+)
+
+func TestGetExportHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "3274032"
+
+	mockProvider := mock.NewMockUserProvider(ctrl)
+	mockProvider.EXPECT().GetExport(gomock.Any(), testUserReferenceID).
+		Return(&dto.Export{
+			ID:        testUserReferenceID,
+			Username:  "test",
+			Followers: []string{"alice"},
+			Following: []string{},
+		}, nil)
+
+	handler := NewGetExportHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/"+testUserReferenceID, nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var data dto.Export
+	json.NewDecoder(rr.Body).Decode(&data)
+	assert.Equal(t, "test", data.Username)
+	assert.Equal(t, []string{"alice"}, data.Followers)
+	assert.Equal(t, []string{}, data.Following)
+}
+
+func TestGetExportHandler_WhereUserDoesNotExist_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "3274032"
+
+	mockProvider := mock.NewMockUserProvider(ctrl)
+	mockProvider.EXPECT().GetExport(gomock.Any(), testUserReferenceID).Return(nil, provider.ErrProfileNotFound)
+
+	handler := NewGetExportHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/"+testUserReferenceID, nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
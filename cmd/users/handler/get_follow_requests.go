@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/pagination"
+	"github.com/reecerussell/open-social/cmd/users/provider"
+)
+
+// GetFollowRequestsHandler is a http.Handler used to list the users
+// waiting for a private user to approve their follow requests.
+type GetFollowRequestsHandler struct {
+	core.Handler
+	provider provider.UserProvider
+}
+
+// NewGetFollowRequestsHandler returns a new instance of GetFollowRequestsHandler.
+func NewGetFollowRequestsHandler(provider provider.UserProvider) *GetFollowRequestsHandler {
+	return &GetFollowRequestsHandler{
+		provider: provider,
+	}
+}
+
+func (h *GetFollowRequestsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	page, err := pagination.PageFromRequest(r)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	list, err := h.provider.GetFollowRequests(ctx, userReferenceID, page)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, list)
+}
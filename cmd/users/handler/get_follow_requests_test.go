@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dto"
+	mock "github.com/reecerussell/open-social/cmd/users/mock/provider"
+	"github.com/reecerussell/open-social/cmd/users/pagination"
+)
+
+func getFollowRequestsRequest(userReferenceID, query string) *http.Request {
+	req, _ := http.NewRequest(http.MethodGet, "/follow-requests/"+userReferenceID+query, nil)
+
+	return mux.SetURLVars(req, map[string]string{"userReferenceID": userReferenceID})
+}
+
+func TestGetFollowRequestsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "3274032"
+
+	mockProvider := mock.NewMockUserProvider(ctrl)
+	mockProvider.EXPECT().GetFollowRequests(gomock.Any(), testUserReferenceID, &pagination.Page{Limit: 5}).
+		Return(&dto.FollowList{
+			Items: []*dto.Follow{
+				{ID: "3423", Username: "alice"},
+			},
+		}, nil)
+
+	rr := httptest.NewRecorder()
+	NewGetFollowRequestsHandler(mockProvider).ServeHTTP(rr, getFollowRequestsRequest(testUserReferenceID, "?limit=5"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var data dto.FollowList
+	err := json.NewDecoder(rr.Body).Decode(&data)
+	assert.NoError(t, err)
+	assert.Len(t, data.Items, 1)
+	assert.Equal(t, "alice", data.Items[0].Username)
+	assert.Nil(t, data.NextCursor)
+}
+
+func TestGetFollowRequestsHandler_InvalidLimit_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rr := httptest.NewRecorder()
+	NewGetFollowRequestsHandler(mock.NewMockUserProvider(ctrl)).ServeHTTP(rr, getFollowRequestsRequest("3274032", "?limit=0"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestGetFollowRequestsHandler_ProviderFails_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProvider := mock.NewMockUserProvider(ctrl)
+	mockProvider.EXPECT().GetFollowRequests(gomock.Any(), "3274032", gomock.Any()).Return(nil, errors.New("an error occured"))
+
+	rr := httptest.NewRecorder()
+	NewGetFollowRequestsHandler(mockProvider).ServeHTTP(rr, getFollowRequestsRequest("3274032", ""))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/pagination"
+	"github.com/reecerussell/open-social/cmd/users/provider"
+)
+
+// GetFollowersHandler is a http.Handler used to list the followers of a user.
+type GetFollowersHandler struct {
+	core.Handler
+	provider provider.UserProvider
+}
+
+// NewGetFollowersHandler returns a new instance of GetFollowersHandler.
+func NewGetFollowersHandler(provider provider.UserProvider) *GetFollowersHandler {
+	return &GetFollowersHandler{
+		provider: provider,
+	}
+}
+
+func (h *GetFollowersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	username := params["username"]
+	userReferenceID := params["userReferenceID"]
+
+	page, err := pagination.PageFromRequest(r)
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	list, err := h.provider.GetFollowers(ctx, username, userReferenceID, page)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err {
+		case provider.ErrProfileNotFound:
+			status = http.StatusNotFound
+		case provider.ErrProfilePrivate:
+			status = http.StatusForbidden
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	h.Respond(w, list)
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dto"
+	mock "github.com/reecerussell/open-social/cmd/users/mock/provider"
+	"github.com/reecerussell/open-social/cmd/users/pagination"
+	"github.com/reecerussell/open-social/cmd/users/provider"
+)
+
+func TestGetFollowingHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUsername := "test"
+	testUserReferenceID := "3274032"
+	testCursor := (&pagination.Cursor{Username: "alice"}).String()
+	testNextCursor := (&pagination.Cursor{Username: "bob"}).String()
+	testPage := &pagination.Page{
+		Cursor: &pagination.Cursor{Username: "alice"},
+		Limit:  1,
+	}
+
+	mockProvider := mock.NewMockUserProvider(ctrl)
+	mockProvider.EXPECT().GetFollowing(gomock.Any(), testUsername, testUserReferenceID, testPage).
+		Return(&dto.FollowList{
+			Items: []*dto.Follow{
+				{ID: "3423", Username: "bob", IsFollowing: true},
+			},
+			NextCursor: &testNextCursor,
+		}, nil)
+
+	handler := NewGetFollowingHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{username}/{userReferenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/%s?cursor=%s&limit=1", testUsername, testUserReferenceID, testCursor), nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var data dto.FollowList
+	err := json.NewDecoder(rr.Body).Decode(&data)
+	assert.NoError(t, err)
+	assert.Len(t, data.Items, 1)
+	assert.Equal(t, "bob", data.Items[0].Username)
+	assert.True(t, data.Items[0].IsFollowing)
+	assert.Equal(t, testNextCursor, *data.NextCursor)
+}
+
+func TestGetFollowingHandler_InvalidCursor_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProvider := mock.NewMockUserProvider(ctrl)
+
+	handler := NewGetFollowingHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{username}/{userReferenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/test/3274032?cursor=%25%25", nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%s\"}\n", pagination.ErrInvalidCursor.Error()), rr.Body.String())
+}
+
+func TestGetFollowingHandler_UserDoesNotExist_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProvider := mock.NewMockUserProvider(ctrl)
+	mockProvider.EXPECT().GetFollowing(gomock.Any(), "test", "3274032", gomock.Any()).Return(nil, provider.ErrProfileNotFound)
+
+	handler := NewGetFollowingHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{username}/{userReferenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/test/3274032", nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%s\"}\n", provider.ErrProfileNotFound.Error()), rr.Body.String())
+}
+
+func TestGetFollowingHandler_ProviderFails_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockProvider := mock.NewMockUserProvider(ctrl)
+	mockProvider.EXPECT().GetFollowing(gomock.Any(), "test", "3274032", gomock.Any()).Return(nil, testError)
+
+	handler := NewGetFollowingHandler(mockProvider)
+	router := mux.NewRouter()
+	router.Handle("/{username}/{userReferenceID}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/test/3274032", nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%s\"}\n", testError.Error()), rr.Body.String())
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	hashpkg "github.com/reecerussell/adaptive-password-hasher"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+)
+
+// loginThrottler provides helpers to handlers which log users in, tracking
+// failed attempts for both the user and the IP address of the request.
+type loginThrottler struct {
+	core.Handler
+
+	throttles  repository.LoginThrottleRepository
+	uow        database.UnitOfWork
+	userPolicy model.LockoutPolicy
+	ipPolicy   model.LockoutPolicy
+}
+
+// ipLockedUntil returns the time the IP address is locked until, if it's locked.
+func (h *loginThrottler) ipLockedUntil(ctx context.Context, now time.Time, ipAddress string) (time.Time, bool, error) {
+	if ipAddress == "" {
+		return time.Time{}, false, nil
+	}
+
+	throttle, err := h.throttles.Get(ctx, model.IPThrottleKey(ipAddress))
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	until, locked := throttle.LockedUntil(now)
+
+	return until, locked, nil
+}
+
+// recordFailure records a failed attempt against the user's and IP address's
+// throttles, either of which may be empty.
+func (h *loginThrottler) recordFailure(ctx context.Context, now time.Time, userKey, ipAddress string) error {
+	return h.uow.Transaction(ctx, func(ctx context.Context) error {
+		if userKey != "" {
+			err := h.recordThrottleFailure(ctx, now, userKey, h.userPolicy)
+			if err != nil {
+				return err
+			}
+		}
+
+		if ipAddress != "" {
+			return h.recordThrottleFailure(ctx, now, model.IPThrottleKey(ipAddress), h.ipPolicy)
+		}
+
+		return nil
+	})
+}
+
+func (h *loginThrottler) recordThrottleFailure(ctx context.Context, now time.Time, key string, policy model.LockoutPolicy) error {
+	throttle, err := h.throttles.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if throttle.RecordFailure(now, policy) {
+		log.Printf("WARN: %s locked out after repeated failed login attempts\n", key)
+	}
+
+	return h.throttles.Save(ctx, throttle)
+}
+
+// verifyPassword verifies an authenticated user's password, for actions which
+// require it to be re-entered, applying the same lockouts as logging in. False
+// is returned if the password couldn't be verified, once a response is written.
+func (h *loginThrottler) verifyPassword(w http.ResponseWriter, r *http.Request, user *model.User, pwd, ipAddress string, hasher hashpkg.Hasher) bool {
+	ctx := r.Context()
+	now := time.Now().UTC()
+
+	until, locked, err := h.ipLockedUntil(ctx, now, ipAddress)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return false
+	}
+
+	if locked {
+		h.respondLocked(w, model.ErrTooManyAttempts, http.StatusTooManyRequests, until.Sub(now))
+		return false
+	}
+
+	userKey := model.UserThrottleKey(user.ReferenceID())
+	throttle, err := h.throttles.Get(ctx, userKey)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return false
+	}
+
+	if until, locked := throttle.LockedUntil(now); locked {
+		h.respondLocked(w, model.ErrAccountLocked, http.StatusLocked, until.Sub(now))
+		return false
+	}
+
+	err = user.VerifyPassword(pwd, hasher)
+	if err != nil {
+		h.respondFailure(w, err, h.recordFailure(ctx, now, userKey, ipAddress))
+		return false
+	}
+
+	return true
+}
+
+// respondFailure responds to a failed login attempt with err, unless the
+// attempt couldn't be recorded, in which case recordErr is returned.
+func (h *loginThrottler) respondFailure(w http.ResponseWriter, err, recordErr error) {
+	if recordErr != nil {
+		h.RespondError(w, recordErr, http.StatusInternalServerError)
+		return
+	}
+
+	h.RespondError(w, err, http.StatusBadRequest)
+}
+
+func (h *loginThrottler) respondLocked(w http.ResponseWriter, err error, status int, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	h.RespondError(w, err, status)
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+// MuteUserHandler is a http.Handler used to handle requests to mute a user. Muting
+// a user hides their posts from the muter's feed, without unfollowing them.
+type MuteUserHandler struct {
+	core.Handler
+	repo  repository.UserRepository
+	mutes repository.MuteRepository
+}
+
+// NewMuteUserHandler returns a new instance of MuteUserHandler.
+func NewMuteUserHandler(repo repository.UserRepository, mutes repository.MuteRepository) *MuteUserHandler {
+	return &MuteUserHandler{
+		repo:  repo,
+		mutes: mutes,
+	}
+}
+
+func (h *MuteUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceId"]
+	muterReferenceID := params["muterReferenceId"]
+
+	if userReferenceID == muterReferenceID {
+		h.RespondError(w, errors.New("users cannot mute themselves"), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := h.repo.GetUserByReference(ctx, userReferenceID, muterReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	err = user.CanMute()
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	err = h.mutes.Create(ctx, user.ID(), muterReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	h.Respond(w, nil)
+}
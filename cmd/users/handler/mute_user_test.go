@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	mock "github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+func muteRequest(userReferenceID, muterReferenceID string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/mute/%s/%s", userReferenceID, muterReferenceID), nil)
+
+	return mux.SetURLVars(req, map[string]string{
+		"userReferenceId":  userReferenceID,
+		"muterReferenceId": muterReferenceID,
+	})
+}
+
+func TestMuteUser_GivenValidData_CreatesMute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "32047023"
+	const testUserID = 1203
+	const testMuterReferenceID = "01379nldsd"
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetUserByReference(gomock.Any(), testUserReferenceID, testMuterReferenceID).
+		Return(model.NewUserFromDao(&dao.User{ID: testUserID, IsFollowing: true}), nil)
+
+	mockMutes := mock.NewMockMuteRepository(ctrl)
+	mockMutes.EXPECT().Create(gomock.Any(), testUserID, testMuterReferenceID).Return(nil)
+
+	rr := httptest.NewRecorder()
+	NewMuteUserHandler(mockUsers, mockMutes).ServeHTTP(rr, muteRequest(testUserReferenceID, testMuterReferenceID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestMuteUser_MutingThemselves_ReturnsBadRequest(t *testing.T) {
+	rr := httptest.NewRecorder()
+	NewMuteUserHandler(nil, nil).ServeHTTP(rr, muteRequest("32047023", "32047023"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "{\"message\":\"users cannot mute themselves\"}\n", rr.Body.String())
+}
+
+func TestMuteUser_UserDoesNotExist_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetUserByReference(gomock.Any(), "32047023", "01379nldsd").
+		Return(nil, repository.ErrUserNotFound)
+
+	rr := httptest.NewRecorder()
+	NewMuteUserHandler(mockUsers, nil).ServeHTTP(rr, muteRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%s\"}\n", repository.ErrUserNotFound.Error()), rr.Body.String())
+}
+
+func TestMuteUser_AlreadyMuted_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetUserByReference(gomock.Any(), "32047023", "01379nldsd").
+		Return(model.NewUserFromDao(&dao.User{ID: 1203, IsMuting: true}), nil)
+
+	rr := httptest.NewRecorder()
+	NewMuteUserHandler(mockUsers, nil).ServeHTTP(rr, muteRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "{\"message\":\"user has already muted this user\"}\n", rr.Body.String())
+}
+
+func TestMuteUser_CreateFails_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetUserByReference(gomock.Any(), "32047023", "01379nldsd").
+		Return(model.NewUserFromDao(&dao.User{ID: 1203}), nil)
+
+	mockMutes := mock.NewMockMuteRepository(ctrl)
+	mockMutes.EXPECT().Create(gomock.Any(), 1203, "01379nldsd").Return(testError)
+
+	rr := httptest.NewRecorder()
+	NewMuteUserHandler(mockUsers, mockMutes).ServeHTTP(rr, muteRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%s\"}\n", testError.Error()), rr.Body.String())
+}
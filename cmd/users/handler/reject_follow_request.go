@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+// RejectFollowRequestHandler is a http.Handler used to reject a request to follow a private user.
+type RejectFollowRequestHandler struct {
+	core.Handler
+	repo     repository.UserRepository
+	requests repository.FollowRequestRepository
+}
+
+// NewRejectFollowRequestHandler returns a new instance of RejectFollowRequestHandler.
+func NewRejectFollowRequestHandler(repo repository.UserRepository, requests repository.FollowRequestRepository) *RejectFollowRequestHandler {
+	return &RejectFollowRequestHandler{
+		repo:     repo,
+		requests: requests,
+	}
+}
+
+func (h *RejectFollowRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceId"]
+	followerReferenceID := params["followerReferenceId"]
+
+	ctx := r.Context()
+	userID, err := h.repo.GetIDByReference(ctx, userReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	err = h.requests.Delete(ctx, *userID, followerReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrFollowRequestNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	h.Respond(w, nil)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock "github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+func TestRejectFollowRequest_GivenPendingRequest_DeletesRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "32047023"
+	const testFollowerReferenceID = "01379nldsd"
+	testUserID := 1203
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), testUserReferenceID).Return(&testUserID, nil)
+
+	mockRequests := mock.NewMockFollowRequestRepository(ctrl)
+	mockRequests.EXPECT().Delete(gomock.Any(), testUserID, testFollowerReferenceID).Return(nil)
+
+	rr := httptest.NewRecorder()
+	NewRejectFollowRequestHandler(mockUsers, mockRequests).ServeHTTP(rr, followRequestRequest(testUserReferenceID, testFollowerReferenceID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestRejectFollowRequest_NoPendingRequest_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "32047023"
+	const testFollowerReferenceID = "01379nldsd"
+	testUserID := 1203
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), testUserReferenceID).Return(&testUserID, nil)
+
+	mockRequests := mock.NewMockFollowRequestRepository(ctrl)
+	mockRequests.EXPECT().Delete(gomock.Any(), testUserID, testFollowerReferenceID).Return(repository.ErrFollowRequestNotFound)
+
+	rr := httptest.NewRecorder()
+	NewRejectFollowRequestHandler(mockUsers, mockRequests).ServeHTTP(rr, followRequestRequest(testUserReferenceID, testFollowerReferenceID))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%s\"}\n", repository.ErrFollowRequestNotFound.Error()), rr.Body.String())
+}
+
+func TestRejectFollowRequest_DeleteFails_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserID := 1203
+	testError := errors.New("an error occured")
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), "32047023").Return(&testUserID, nil)
+
+	mockRequests := mock.NewMockFollowRequestRepository(ctrl)
+	mockRequests.EXPECT().Delete(gomock.Any(), testUserID, "01379nldsd").Return(testError)
+
+	rr := httptest.NewRecorder()
+	NewRejectFollowRequestHandler(mockUsers, mockRequests).ServeHTTP(rr, followRequestRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
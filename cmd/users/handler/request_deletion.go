@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	hashpkg "github.com/reecerussell/adaptive-password-hasher"
+
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+)
+
+// RequestDeletionHandler is a http.Handler used to schedule a user's account to be
+// deleted, after a grace period, given their password. If the user's account is
+// already scheduled to be deleted, the existing deletion is returned. Failed
+// password attempts are tracked and locked out in the same way as logging in.
+type RequestDeletionHandler struct {
+	loginThrottler
+
+	hasher    hashpkg.Hasher
+	repo      repository.UserRepository
+	deletions repository.AccountDeletionRepository
+	grace     time.Duration
+}
+
+// NewRequestDeletionHandler returns a new instance of RequestDeletionHandler.
+func NewRequestDeletionHandler(hasher hashpkg.Hasher, repo repository.UserRepository, deletions repository.AccountDeletionRepository,
+	throttles repository.LoginThrottleRepository, uow database.UnitOfWork, userPolicy, ipPolicy model.LockoutPolicy, grace time.Duration) *RequestDeletionHandler {
+	return &RequestDeletionHandler{
+		loginThrottler: loginThrottler{
+			throttles:  throttles,
+			uow:        uow,
+			userPolicy: userPolicy,
+			ipPolicy:   ipPolicy,
+		},
+		hasher:    hasher,
+		repo:      repo,
+		deletions: deletions,
+		grace:     grace,
+	}
+}
+
+// RequestDeletionRequest represents the request body.
+type RequestDeletionRequest struct {
+	Password  string `json:"password"`
+	IPAddress string `json:"ipAddress"`
+}
+
+// ServeHTTP handles requests to delete a user's account.
+func (h *RequestDeletionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	var data RequestDeletionRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	ctx := r.Context()
+	user, err := h.repo.GetUserByReference(ctx, userReferenceID, userReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	if !h.verifyPassword(w, r, user, data.Password, data.IPAddress, h.hasher) {
+		return
+	}
+
+	var deletion *model.AccountDeletion
+	err = h.uow.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		deletion, err = h.deletions.Get(ctx, userReferenceID)
+		if err == nil && deletion.IsActive() {
+			return nil
+		}
+		if err != nil && err != repository.ErrDeletionNotFound {
+			return err
+		}
+
+		deletion = model.NewAccountDeletion(user, h.grace)
+		return h.deletions.Save(ctx, deletion)
+	})
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, newAccountDeletionResponse(deletion))
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	hashermock "github.com/reecerussell/adaptive-password-hasher/mock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	repo "github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+func requestDeletionRequest(referenceID, password string) *http.Request {
+	body := fmt.Sprintf(`{"password": "%s", "ipAddress": "%s"}`, password, testClientIP)
+	req, _ := http.NewRequest(http.MethodPost, "/deletion/request/"+referenceID, strings.NewReader(body))
+	return mux.SetURLVars(req, map[string]string{"userReferenceID": referenceID})
+}
+
+func TestRequestDeletionHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify([]byte("Password_123"), gomock.Any()).Return(true)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+
+	mockDeletions := repository.NewMockAccountDeletionRepository(ctrl)
+	mockDeletions.EXPECT().Get(gomock.Any(), testReferenceID).Return(nil, repo.ErrDeletionNotFound)
+	mockDeletions.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	handler := NewRequestDeletionHandler(mockHasher, mockRepo, mockDeletions, newClearThrottles(ctrl, testReferenceID), newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy, 24*time.Hour)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestDeletionRequest(testReferenceID, "Password_123"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var data AccountDeletionResponse
+	json.NewDecoder(rr.Body).Decode(&data)
+	assert.Equal(t, model.DeletionScheduled, data.Status)
+	assert.Equal(t, 24*time.Hour, data.Scheduled.Sub(data.Requested))
+}
+
+func TestRequestDeletionHandler_WhereAlreadyRequested_ReturnsExistingDeletion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+	testScheduled := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	testDeletion, _ := model.AccountDeletionFromDao(&dao.AccountDeletion{
+		UserReferenceID: testReferenceID,
+		Scheduled:       testScheduled,
+	})
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+
+	mockDeletions := repository.NewMockAccountDeletionRepository(ctrl)
+	mockDeletions.EXPECT().Get(gomock.Any(), testReferenceID).Return(testDeletion, nil)
+
+	handler := NewRequestDeletionHandler(mockHasher, mockRepo, mockDeletions, newClearThrottles(ctrl, testReferenceID), newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy, 24*time.Hour)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestDeletionRequest(testReferenceID, "Password_123"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var data AccountDeletionResponse
+	json.NewDecoder(rr.Body).Decode(&data)
+	assert.Equal(t, testScheduled, data.Scheduled)
+}
+
+func TestRequestDeletionHandler_GivenInvalidPassword_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+
+	mockThrottles := newClearThrottles(ctrl, testReferenceID)
+	mockThrottles.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	handler := NewRequestDeletionHandler(mockHasher, mockRepo, nil, mockThrottles, newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy, time.Hour)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestDeletionRequest(testReferenceID, "wrong"))
+
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrInvalidPassword), rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestRequestDeletionHandler_IPLocked_ReturnsTooManyRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	ipKey := model.IPThrottleKey(testClientIP)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).
+		Return(getMockUser(testReferenceID, "testing"), nil)
+
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), ipKey).Return(lockedThrottle(ipKey), nil)
+
+	handler := NewRequestDeletionHandler(nil, mockRepo, nil, mockThrottles, nil, testUserPolicy, testIPPolicy, time.Hour)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestDeletionRequest(testReferenceID, "Password_123"))
+
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrTooManyAttempts), rr.Body.String())
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+}
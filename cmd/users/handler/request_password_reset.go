@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/notifier"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+// RequestPasswordResetHandler is a http.Handler used to send a user a link to reset their password.
+type RequestPasswordResetHandler struct {
+	core.Handler
+
+	repo     repository.UserRepository
+	tokens   repository.PasswordResetTokenRepository
+	notifier notifier.Notifier
+	lifetime time.Duration
+	resetURL string
+}
+
+// NewRequestPasswordResetHandler returns a new instance of RequestPasswordResetHandler. Reset
+// tokens expire after lifetime, and are sent to the user as the token query parameter of resetURL.
+func NewRequestPasswordResetHandler(repo repository.UserRepository, tokens repository.PasswordResetTokenRepository, notifier notifier.Notifier, lifetime time.Duration, resetURL string) *RequestPasswordResetHandler {
+	return &RequestPasswordResetHandler{
+		repo:     repo,
+		tokens:   tokens,
+		notifier: notifier,
+		lifetime: lifetime,
+		resetURL: resetURL,
+	}
+}
+
+// RequestPasswordResetRequest represents the request body.
+type RequestPasswordResetRequest struct {
+	Username string `json:"username"`
+}
+
+// ServeHTTP handles requests for a password reset. The response is the same
+// whether or not the user exists, so it can't be used to find usernames.
+func (h *RequestPasswordResetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var data RequestPasswordResetRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	ctx := r.Context()
+	user, err := h.repo.GetUserByUsername(ctx, data.Username)
+	if err != nil {
+		if err == repository.ErrUserNotFound {
+			h.Respond(w, nil)
+			return
+		}
+
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	token, err := model.NewPasswordResetToken(user, h.lifetime)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	err = h.tokens.Create(ctx, token)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	err = h.notifier.Notify(ctx, &notifier.Message{
+		To:      user.Username(),
+		Subject: "Reset your password",
+		Body:    "Use the following link to reset your password, before it expires at " + token.Expires().Format(time.RFC1123) + ":\n" + h.link(token.Token()),
+		Sent:    time.Now().UTC(),
+	})
+	if err != nil {
+		// Responding with an error would reveal that the user exists, so it's only
+		// logged; the user can request another reset if it doesn't arrive.
+		log.Printf("Error: failed to send password reset to %s: %v\n", user.Username(), err)
+	}
+
+	h.Respond(w, nil)
+}
+
+func (h *RequestPasswordResetHandler) link(token string) string {
+	u, err := url.Parse(h.resetURL)
+	if err != nil {
+		return h.resetURL + "?token=" + url.QueryEscape(token)
+	}
+
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
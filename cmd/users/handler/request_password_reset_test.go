@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	notifiermock "github.com/reecerussell/open-social/cmd/users/mock/notifier"
+	"github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/notifier"
+	repo "github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+func requestPasswordResetRequest() *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "/password/reset", strings.NewReader(`{"username": "testing"}`))
+
+	return req
+}
+
+func TestRequestPasswordResetHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUser := getMockUser("23470324", "testing")
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "testing").Return(testUser, nil)
+
+	var created *model.PasswordResetToken
+	mockTokens := repository.NewMockPasswordResetTokenRepository(ctrl)
+	mockTokens.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, token *model.PasswordResetToken) error {
+			created = token
+			return nil
+		})
+
+	mockNotifier := notifiermock.NewMockNotifier(ctrl)
+	mockNotifier.EXPECT().Notify(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, msg *notifier.Message) error {
+			assert.Equal(t, "testing", msg.To)
+			assert.Contains(t, msg.Body, "http://localhost:3000/reset-password?token="+created.Token())
+			return nil
+		})
+
+	handler := NewRequestPasswordResetHandler(mockRepo, mockTokens, mockNotifier, time.Hour, "http://localhost:3000/reset-password")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestPasswordResetRequest())
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 1, created.Dao().UserID)
+	assert.Equal(t, model.HashPasswordResetToken(created.Token()), created.Dao().TokenHash)
+}
+
+func TestRequestPasswordResetHandler_UserNotFound_ReturnsOK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "testing").Return(nil, repo.ErrUserNotFound)
+
+	handler := NewRequestPasswordResetHandler(mockRepo, nil, nil, time.Hour, "http://localhost:3000/reset-password")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestPasswordResetRequest())
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestRequestPasswordResetHandler_NotifyFails_ReturnsOK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "testing").Return(getMockUser("23470324", "testing"), nil)
+
+	mockTokens := repository.NewMockPasswordResetTokenRepository(ctrl)
+	mockTokens.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	mockNotifier := notifiermock.NewMockNotifier(ctrl)
+	mockNotifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(errors.New("an error occured"))
+
+	handler := NewRequestPasswordResetHandler(mockRepo, mockTokens, mockNotifier, time.Hour, "http://localhost:3000/reset-password")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestPasswordResetRequest())
+
+	// The response must match that of an unknown user.
+	notFound := httptest.NewRecorder()
+	mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "testing").Return(nil, repo.ErrUserNotFound)
+	handler.ServeHTTP(notFound, requestPasswordResetRequest())
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, notFound.Body.String(), rr.Body.String())
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	hashpkg "github.com/reecerussell/adaptive-password-hasher"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/client/auth"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/password"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+)
+
+// ResetPasswordHandler is a http.Handler used to set a user's password, using a password reset token.
+// All of the user's sessions are revoked before the reset is committed, so the reset fails, and the
+// token can be used again, if they can't be.
+type ResetPasswordHandler struct {
+	core.Handler
+
+	val    password.Validator
+	hasher hashpkg.Hasher
+	repo   repository.UserRepository
+	tokens repository.PasswordResetTokenRepository
+	auth   auth.Client
+	uow    database.UnitOfWork
+}
+
+// NewResetPasswordHandler returns a new instance of ResetPasswordHandler.
+func NewResetPasswordHandler(val password.Validator, hasher hashpkg.Hasher, repo repository.UserRepository, tokens repository.PasswordResetTokenRepository, auth auth.Client, uow database.UnitOfWork) *ResetPasswordHandler {
+	return &ResetPasswordHandler{
+		val:    val,
+		hasher: hasher,
+		repo:   repo,
+		tokens: tokens,
+		auth:   auth,
+		uow:    uow,
+	}
+}
+
+// ResetPasswordRequest represents the request body.
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// ResetPasswordResponse represents the response body.
+type ResetPasswordResponse struct {
+	UserReferenceID string `json:"userReferenceId"`
+}
+
+// ServeHTTP handles requests to reset a user's password.
+func (h *ResetPasswordHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var data ResetPasswordRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	// Validate the password up front, so the token isn't looked up for an invalid password.
+	// It's validated against the username once the user is known, when it's set, rolling
+	// back the use of the token if it's invalid.
+	err := h.val.Validate(data.Password, "")
+	if err != nil {
+		h.RespondError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	var resp ResetPasswordResponse
+
+	ctx := r.Context()
+	err = h.uow.Transaction(ctx, func(ctx context.Context) error {
+		token, err := h.tokens.Get(ctx, data.Token)
+		if err != nil {
+			return err
+		}
+
+		err = token.Use()
+		if err != nil {
+			return err
+		}
+
+		user, err := h.repo.GetUserByReference(ctx, token.UserReferenceID(), token.UserReferenceID())
+		if err != nil {
+			return err
+		}
+
+		err = user.SetPassword(data.Password, h.val, h.hasher)
+		if err != nil {
+			return &invalidPasswordError{err: err}
+		}
+
+		err = h.repo.Update(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		err = h.tokens.Update(ctx, token)
+		if err != nil {
+			return err
+		}
+
+		// Any other outstanding tokens can no longer be used.
+		err = h.tokens.RevokeUser(ctx, user.ReferenceID())
+		if err != nil {
+			return err
+		}
+
+		// Revoked last, as it can't be rolled back. Revoking the sessions
+		// again, if the reset is retried, does no harm.
+		err = h.auth.RevokeUserTokens(user.ReferenceID())
+		if err != nil {
+			return fmt.Errorf("revoke sessions: %v", err)
+		}
+
+		resp.UserReferenceID = user.ReferenceID()
+
+		return nil
+	})
+	if err != nil {
+		if e, ok := err.(*invalidPasswordError); ok {
+			h.RespondError(w, e.err, http.StatusBadRequest)
+			return
+		}
+
+		switch err {
+		case repository.ErrResetTokenNotFound, model.ErrResetTokenExpired, model.ErrResetTokenUsed:
+			h.RespondError(w, err, http.StatusBadRequest)
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	h.Respond(w, resp)
+}
+
+// invalidPasswordError is returned from the transaction when the password is
+// rejected by the validator, such as for containing the user's username.
+type invalidPasswordError struct {
+	err error
+}
+
+func (e *invalidPasswordError) Error() string {
+	return e.err.Error()
+}
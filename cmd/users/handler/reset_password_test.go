@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	hashermock "github.com/reecerussell/adaptive-password-hasher/mock"
+	"github.com/stretchr/testify/assert"
+
+	authMock "github.com/reecerussell/open-social/client/mock/auth"
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/mock"
+	"github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	repo "github.com/reecerussell/open-social/cmd/users/repository"
+	dbMock "github.com/reecerussell/open-social/mock/database"
+)
+
+const testResetToken = "xK2nP0qv8Tm"
+
+func resetPasswordRequest(pwd string) *http.Request {
+	body := fmt.Sprintf(`{"token": "%s", "password": "%s"}`, testResetToken, pwd)
+	req, _ := http.NewRequest(http.MethodPost, "/password/reset/confirm", strings.NewReader(body))
+
+	return req
+}
+
+func testPasswordResetToken(referenceID string, expires time.Time, used *time.Time) *model.PasswordResetToken {
+	return model.PasswordResetTokenFromDao(&dao.PasswordResetToken{
+		ID:              4,
+		UserID:          1,
+		UserReferenceID: referenceID,
+		Expires:         expires,
+		Used:            used,
+	})
+}
+
+func TestResetPasswordHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+	token := testPasswordResetToken(testReferenceID, time.Now().UTC().Add(time.Hour), nil)
+
+	mockValidator := mock.NewMockValidator(ctrl)
+	mockValidator.EXPECT().Validate("NewPassword_123", gomock.Any()).Return(nil).Times(2)
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Hash([]byte("NewPassword_123")).Return([]byte("new-hash"))
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), testUser).Return(nil)
+
+	mockTokens := repository.NewMockPasswordResetTokenRepository(ctrl)
+	mockTokens.EXPECT().Get(gomock.Any(), testResetToken).Return(token, nil)
+	mockTokens.EXPECT().Update(gomock.Any(), token).Return(nil)
+	mockTokens.EXPECT().RevokeUser(gomock.Any(), testReferenceID).Return(nil)
+
+	mockAuth := authMock.NewMockClient(ctrl)
+	mockAuth.EXPECT().RevokeUserTokens(testReferenceID).Return(nil)
+
+	handler := NewResetPasswordHandler(mockValidator, mockHasher, mockRepo, mockTokens, mockAuth, newClaimsMockUow(ctrl))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, resetPasswordRequest("NewPassword_123"))
+
+	assert.Equal(t, fmt.Sprintf("{\"userReferenceId\":\"%s\"}\n", testReferenceID), rr.Body.String())
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.NotNil(t, token.Dao().Used)
+}
+
+func TestResetPasswordHandler_InvalidToken_ReturnsBadRequest(t *testing.T) {
+	used := time.Now().UTC()
+
+	tests := []struct {
+		name  string
+		token *model.PasswordResetToken
+		err   error
+	}{
+		{"NotFound", nil, repo.ErrResetTokenNotFound},
+		{"Expired", testPasswordResetToken("23470324", time.Now().UTC().Add(-time.Minute), nil), model.ErrResetTokenExpired},
+		{"Used", testPasswordResetToken("23470324", time.Now().UTC().Add(time.Hour), &used), model.ErrResetTokenUsed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockValidator := mock.NewMockValidator(ctrl)
+			mockValidator.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+
+			mockTokens := repository.NewMockPasswordResetTokenRepository(ctrl)
+			if test.token == nil {
+				mockTokens.EXPECT().Get(gomock.Any(), testResetToken).Return(nil, test.err)
+			} else {
+				mockTokens.EXPECT().Get(gomock.Any(), testResetToken).Return(test.token, nil)
+			}
+
+			handler := NewResetPasswordHandler(mockValidator, nil, nil, mockTokens, nil, newClaimsMockUow(ctrl))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, resetPasswordRequest("NewPassword_123"))
+
+			assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", test.err), rr.Body.String())
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
+
+func TestResetPasswordHandler_InvalidPassword_DoesNotUseToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockValidator := mock.NewMockValidator(ctrl)
+	mockValidator.EXPECT().Validate("short", gomock.Any()).Return(fmt.Errorf("password is too short"))
+
+	handler := NewResetPasswordHandler(mockValidator, nil, nil, nil, nil, nil)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, resetPasswordRequest("short"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestResetPasswordHandler_PasswordContainsUsername_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	const testPassword = "testing_Password_123"
+	testUser := getMockUser(testReferenceID, "testing")
+	token := testPasswordResetToken(testReferenceID, time.Now().UTC().Add(time.Hour), nil)
+	testErr := fmt.Errorf("password cannot contain your username")
+
+	mockValidator := mock.NewMockValidator(ctrl)
+	gomock.InOrder(
+		mockValidator.EXPECT().Validate(testPassword, "").Return(nil),
+		mockValidator.EXPECT().Validate(testPassword, "testing").Return(testErr),
+	)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+
+	mockTokens := repository.NewMockPasswordResetTokenRepository(ctrl)
+	mockTokens.EXPECT().Get(gomock.Any(), testResetToken).Return(token, nil)
+
+	handler := NewResetPasswordHandler(mockValidator, nil, mockRepo, mockTokens, nil, newClaimsMockUow(ctrl))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, resetPasswordRequest(testPassword))
+
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%s\"}\n", testErr), rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestResetPasswordHandler_RevokeSessionsFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+	token := testPasswordResetToken(testReferenceID, time.Now().UTC().Add(time.Hour), nil)
+	testErr := errors.New("an error occured")
+
+	mockValidator := mock.NewMockValidator(ctrl)
+	mockValidator.EXPECT().Validate("NewPassword_123", gomock.Any()).Return(nil).Times(2)
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Hash([]byte("NewPassword_123")).Return([]byte("new-hash"))
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), testUser).Return(nil)
+
+	mockTokens := repository.NewMockPasswordResetTokenRepository(ctrl)
+	mockTokens.EXPECT().Get(gomock.Any(), testResetToken).Return(token, nil)
+	mockTokens.EXPECT().Update(gomock.Any(), token).Return(nil)
+	mockTokens.EXPECT().RevokeUser(gomock.Any(), testReferenceID).Return(nil)
+
+	mockAuth := authMock.NewMockClient(ctrl)
+	mockAuth.EXPECT().RevokeUserTokens(testReferenceID).Return(testErr)
+
+	// The transaction is rolled back, so the token can be used again.
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewResetPasswordHandler(mockValidator, mockHasher, mockRepo, mockTokens, mockAuth, mockUow)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, resetPasswordRequest("NewPassword_123"))
+
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"revoke sessions: %v\"}\n", testErr), rr.Body.String())
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
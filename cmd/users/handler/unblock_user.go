@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+// UnblockUserHandler is a http.Handler used to handle requests to unblock a user.
+type UnblockUserHandler struct {
+	core.Handler
+	repo   repository.UserRepository
+	blocks repository.BlockRepository
+}
+
+// NewUnblockUserHandler returns a new instance of UnblockUserHandler.
+func NewUnblockUserHandler(repo repository.UserRepository, blocks repository.BlockRepository) *UnblockUserHandler {
+	return &UnblockUserHandler{
+		repo:   repo,
+		blocks: blocks,
+	}
+}
+
+func (h *UnblockUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceId"]
+	blockerReferenceID := params["blockerReferenceId"]
+
+	ctx := r.Context()
+	userID, err := h.repo.GetIDByReference(ctx, userReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	err = h.blocks.Delete(ctx, *userID, blockerReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrBlockNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	h.Respond(w, nil)
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock "github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+func TestUnblockUser_GivenBlockedUser_DeletesBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "32047023"
+	const testBlockerReferenceID = "01379nldsd"
+	testUserID := 1203
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), testUserReferenceID).Return(&testUserID, nil)
+
+	mockBlocks := mock.NewMockBlockRepository(ctrl)
+	mockBlocks.EXPECT().Delete(gomock.Any(), testUserID, testBlockerReferenceID).Return(nil)
+
+	rr := httptest.NewRecorder()
+	NewUnblockUserHandler(mockUsers, mockBlocks).ServeHTTP(rr, blockRequest(testUserReferenceID, testBlockerReferenceID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestUnblockUser_UserDoesNotExist_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), "32047023").Return(nil, repository.ErrUserNotFound)
+
+	rr := httptest.NewRecorder()
+	NewUnblockUserHandler(mockUsers, nil).ServeHTTP(rr, blockRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestUnblockUser_NotBlocked_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserID := 1203
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), "32047023").Return(&testUserID, nil)
+
+	mockBlocks := mock.NewMockBlockRepository(ctrl)
+	mockBlocks.EXPECT().Delete(gomock.Any(), testUserID, "01379nldsd").Return(repository.ErrBlockNotFound)
+
+	rr := httptest.NewRecorder()
+	NewUnblockUserHandler(mockUsers, mockBlocks).ServeHTTP(rr, blockRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%s\"}\n", repository.ErrBlockNotFound.Error()), rr.Body.String())
+}
+
+func TestUnblockUser_DeleteFails_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserID := 1203
+	testError := errors.New("an error occured")
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), "32047023").Return(&testUserID, nil)
+
+	mockBlocks := mock.NewMockBlockRepository(ctrl)
+	mockBlocks.EXPECT().Delete(gomock.Any(), testUserID, "01379nldsd").Return(testError)
+
+	rr := httptest.NewRecorder()
+	NewUnblockUserHandler(mockUsers, mockBlocks).ServeHTTP(rr, blockRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
@@ -1,26 +1,36 @@
 package handler
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
 	core "github.com/reecerussell/open-social"
 	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/eventing"
 )
 
 // UnfollowUserHandler is a http.Handler used to handle requests to delete a follow record.
+// If the follow is still waiting for approval, the follow request is withdrawn instead.
 type UnfollowUserHandler struct {
 	core.Handler
 	repo      repository.UserRepository
 	followers repository.FollowerRepository
+	requests  repository.FollowRequestRepository
+	uow       database.UnitOfWork
+	events    eventing.Publisher
 }
 
 // NewUnfollowUserHandler returns a new instance of UnfollowUserHandler.
-func NewUnfollowUserHandler(repo repository.UserRepository, followers repository.FollowerRepository) *UnfollowUserHandler {
+func NewUnfollowUserHandler(repo repository.UserRepository, followers repository.FollowerRepository, requests repository.FollowRequestRepository, uow database.UnitOfWork, events eventing.Publisher) *UnfollowUserHandler {
 	return &UnfollowUserHandler{
 		repo:      repo,
 		followers: followers,
+		requests:  requests,
+		uow:       uow,
+		events:    events,
 	}
 }
 
@@ -47,7 +57,34 @@ func (h *UnfollowUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.followers.Delete(ctx, user.ID(), followerReferenceID)
+	if !user.IsFollowing() {
+		err = h.requests.Delete(ctx, user.ID(), followerReferenceID)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if err == repository.ErrFollowRequestNotFound {
+				status = http.StatusNotFound
+			}
+
+			h.RespondError(w, err, status)
+			return
+		}
+
+		h.Respond(w, nil)
+		return
+	}
+
+	err = h.uow.Transaction(ctx, func(ctx context.Context) error {
+		err := h.followers.Delete(ctx, user.ID(), followerReferenceID)
+		if err != nil {
+			return err
+		}
+
+		event := eventing.NewEvent(eventing.UserUnfollowed, &eventing.UserUnfollowedPayload{
+			UserReferenceID:     userReferenceID,
+			FollowerReferenceID: followerReferenceID,
+		})
+		return h.events.Publish(ctx, userReferenceID, event)
+	})
 	if err != nil {
 		status := http.StatusInternalServerError
 		if err == repository.ErrFollowerNotFound {
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -15,6 +16,9 @@ import (
 	mock "github.com/reecerussell/open-social/cmd/users/mock/repository"
 	"github.com/reecerussell/open-social/cmd/users/model"
 	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/eventing"
+	"github.com/reecerussell/open-social/eventing/memory"
+	dbMock "github.com/reecerussell/open-social/mock/database"
 )
 
 func TestUnfollowUser_GivenValidData_ReturnsOK(t *testing.T) {
@@ -33,7 +37,14 @@ func TestUnfollowUser_GivenValidData_ReturnsOK(t *testing.T) {
 	mockFollowers.EXPECT().Delete(gomock.Any(), testUserID, testFollowerReferenceID).
 		Return(nil)
 
-	handler := NewUnfollowUserHandler(mockUsers, mockFollowers)
+	events := memory.NewPublisher()
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewUnfollowUserHandler(mockUsers, mockFollowers, nil, mockUow, events)
 	router := mux.NewRouter()
 	router.Handle("/{userReferenceId}/{followerReferenceId}", handler)
 
@@ -42,6 +53,21 @@ func TestUnfollowUser_GivenValidData_ReturnsOK(t *testing.T) {
 	router.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Code)
+
+	messages := events.Messages()
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, testUserReferenceID, messages[0].Key)
+
+	var event struct {
+		Type    string                         `json:"type"`
+		Version int                            `json:"version"`
+		Payload eventing.UserUnfollowedPayload `json:"payload"`
+	}
+	_ = json.Unmarshal(messages[0].Value, &event)
+	assert.Equal(t, eventing.UserUnfollowed, event.Type)
+	assert.Equal(t, eventing.EventVersion, event.Version)
+	assert.Equal(t, testUserReferenceID, event.Payload.UserReferenceID)
+	assert.Equal(t, testFollowerReferenceID, event.Payload.FollowerReferenceID)
 }
 
 func TestUnfollowUser_UserDoesNotExist_ReturnsNotFound(t *testing.T) {
@@ -56,7 +82,7 @@ func TestUnfollowUser_UserDoesNotExist_ReturnsNotFound(t *testing.T) {
 	mockUsers.EXPECT().GetUserByReference(gomock.Any(), testUserReferenceID, testFollowerReferenceID).
 		Return(nil, repository.ErrUserNotFound)
 
-	handler := NewUnfollowUserHandler(mockUsers, nil)
+	handler := NewUnfollowUserHandler(mockUsers, nil, nil, nil, nil)
 	router := mux.NewRouter()
 	router.Handle("/{userReferenceId}/{followerReferenceId}", handler)
 
@@ -84,7 +110,7 @@ func TestUnfollowUser_UserIsNotFollowing_ReturnsBadRequest(t *testing.T) {
 	mockUsers.EXPECT().GetUserByReference(gomock.Any(), testUserReferenceID, testFollowerReferenceID).
 		Return(model.NewUserFromDao(&dao.User{ID: testUserID, IsFollowing: false}), nil)
 
-	handler := NewUnfollowUserHandler(mockUsers, nil)
+	handler := NewUnfollowUserHandler(mockUsers, nil, nil, nil, nil)
 	router := mux.NewRouter()
 	router.Handle("/{userReferenceId}/{followerReferenceId}", handler)
 
@@ -112,7 +138,13 @@ func TestUnfollowUser_FollowerNotFound_ReturnsNotFound(t *testing.T) {
 	mockFollowers.EXPECT().Delete(gomock.Any(), testUserID, testFollowerReferenceID).
 		Return(repository.ErrFollowerNotFound)
 
-	handler := NewUnfollowUserHandler(mockUsers, mockFollowers)
+	mockUow := dbMock.NewMockUnitOfWork(ctrl)
+	mockUow.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	handler := NewUnfollowUserHandler(mockUsers, mockFollowers, nil, mockUow, nil)
 	router := mux.NewRouter()
 	router.Handle("/{userReferenceId}/{followerReferenceId}", handler)
 
@@ -127,3 +159,31 @@ func TestUnfollowUser_FollowerNotFound_ReturnsNotFound(t *testing.T) {
 	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
 	assert.Equal(t, repository.ErrFollowerNotFound.Error(), data["message"])
 }
+
+func TestUnfollowUser_PendingRequest_WithdrawsRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "32047023"
+	const testUserID = 1203
+	const testFollowerReferenceID = "01379nldsd"
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetUserByReference(gomock.Any(), testUserReferenceID, testFollowerReferenceID).
+		Return(model.NewUserFromDao(&dao.User{ID: testUserID, IsPrivate: true, HasRequested: true}), nil)
+
+	mockRequests := mock.NewMockFollowRequestRepository(ctrl)
+	mockRequests.EXPECT().Delete(gomock.Any(), testUserID, testFollowerReferenceID).Return(nil)
+
+	events := memory.NewPublisher()
+	handler := NewUnfollowUserHandler(mockUsers, nil, mockRequests, nil, events)
+	router := mux.NewRouter()
+	router.Handle("/{userReferenceId}/{followerReferenceId}", handler)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/%s", testUserReferenceID, testFollowerReferenceID), nil)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 0, len(events.Messages()))
+}
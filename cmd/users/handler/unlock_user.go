@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+// UnlockUserHandler is a http.Handler used by administrators to unlock a user's
+// account, clearing their failed login attempts and resetting their backoff.
+type UnlockUserHandler struct {
+	core.Handler
+	throttles repository.LoginThrottleRepository
+}
+
+// NewUnlockUserHandler returns a new instance of UnlockUserHandler.
+func NewUnlockUserHandler(throttles repository.LoginThrottleRepository) *UnlockUserHandler {
+	return &UnlockUserHandler{throttles: throttles}
+}
+
+func (h *UnlockUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	err := h.throttles.Delete(r.Context(), model.UserThrottleKey(userReferenceID))
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.Respond(w, nil)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+)
+
+func TestUnlockUserHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Delete(gomock.Any(), model.UserThrottleKey(testReferenceID)).Return(nil)
+
+	req, _ := http.NewRequest(http.MethodPost, "/unlock/"+testReferenceID, nil)
+	req = mux.SetURLVars(req, map[string]string{"userReferenceID": testReferenceID})
+
+	rr := httptest.NewRecorder()
+	NewUnlockUserHandler(mockThrottles).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestUnlockUserHandler_DeleteFails_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(errors.New("an error occured"))
+
+	req, _ := http.NewRequest(http.MethodPost, "/unlock/23470324", nil)
+	req = mux.SetURLVars(req, map[string]string{"userReferenceID": "23470324"})
+
+	rr := httptest.NewRecorder()
+	NewUnlockUserHandler(mockThrottles).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
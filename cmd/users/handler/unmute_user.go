@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+// UnmuteUserHandler is a http.Handler used to handle requests to unmute a user.
+type UnmuteUserHandler struct {
+	core.Handler
+	repo  repository.UserRepository
+	mutes repository.MuteRepository
+}
+
+// NewUnmuteUserHandler returns a new instance of UnmuteUserHandler.
+func NewUnmuteUserHandler(repo repository.UserRepository, mutes repository.MuteRepository) *UnmuteUserHandler {
+	return &UnmuteUserHandler{
+		repo:  repo,
+		mutes: mutes,
+	}
+}
+
+func (h *UnmuteUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceId"]
+	muterReferenceID := params["muterReferenceId"]
+
+	ctx := r.Context()
+	userID, err := h.repo.GetIDByReference(ctx, userReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	err = h.mutes.Delete(ctx, *userID, muterReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrMuteNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	h.Respond(w, nil)
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock "github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+func TestUnmuteUser_GivenMutedUser_DeletesMute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserReferenceID = "32047023"
+	const testMuterReferenceID = "01379nldsd"
+	testUserID := 1203
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), testUserReferenceID).Return(&testUserID, nil)
+
+	mockMutes := mock.NewMockMuteRepository(ctrl)
+	mockMutes.EXPECT().Delete(gomock.Any(), testUserID, testMuterReferenceID).Return(nil)
+
+	rr := httptest.NewRecorder()
+	NewUnmuteUserHandler(mockUsers, mockMutes).ServeHTTP(rr, muteRequest(testUserReferenceID, testMuterReferenceID))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestUnmuteUser_UserDoesNotExist_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), "32047023").Return(nil, repository.ErrUserNotFound)
+
+	rr := httptest.NewRecorder()
+	NewUnmuteUserHandler(mockUsers, nil).ServeHTTP(rr, muteRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestUnmuteUser_NotMuted_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserID := 1203
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), "32047023").Return(&testUserID, nil)
+
+	mockMutes := mock.NewMockMuteRepository(ctrl)
+	mockMutes.EXPECT().Delete(gomock.Any(), testUserID, "01379nldsd").Return(repository.ErrMuteNotFound)
+
+	rr := httptest.NewRecorder()
+	NewUnmuteUserHandler(mockUsers, mockMutes).ServeHTTP(rr, muteRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%s\"}\n", repository.ErrMuteNotFound.Error()), rr.Body.String())
+}
+
+func TestUnmuteUser_DeleteFails_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserID := 1203
+	testError := errors.New("an error occured")
+
+	mockUsers := mock.NewMockUserRepository(ctrl)
+	mockUsers.EXPECT().GetIDByReference(gomock.Any(), "32047023").Return(&testUserID, nil)
+
+	mockMutes := mock.NewMockMuteRepository(ctrl)
+	mockMutes.EXPECT().Delete(gomock.Any(), testUserID, "01379nldsd").Return(testError)
+
+	rr := httptest.NewRecorder()
+	NewUnmuteUserHandler(mockUsers, mockMutes).ServeHTTP(rr, muteRequest("32047023", "01379nldsd"))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
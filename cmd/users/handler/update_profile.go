@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+// UpdateProfileHandler is a http.Handler used to update a user's
+// profile, including their username, bio and avatar.
+type UpdateProfileHandler struct {
+	core.Handler
+	repo repository.UserRepository
+}
+
+// NewUpdateProfileHandler returns a new instance of UpdateProfileHandler.
+func NewUpdateProfileHandler(repo repository.UserRepository) *UpdateProfileHandler {
+	return &UpdateProfileHandler{
+		repo: repo,
+	}
+}
+
+// UpdateProfileRequest represents the request body. Fields which
+// are nil are left unchanged, and an empty bio clears it.
+type UpdateProfileRequest struct {
+	Username  *string `json:"username"`
+	Bio       *string `json:"bio"`
+	MediaID   *int    `json:"mediaId"`
+	IsPrivate *bool   `json:"isPrivate"`
+}
+
+// UpdateProfileResponse represents the response body. As the username is one
+// of the user's claims, UsernameChanged indicates their access token is stale.
+type UpdateProfileResponse struct {
+	Username        string  `json:"username"`
+	Bio             *string `json:"bio"`
+	UsernameChanged bool    `json:"usernameChanged"`
+	IsPrivate       bool    `json:"isPrivate"`
+}
+
+// ServeHTTP handles requests to update a user's profile.
+func (h *UpdateProfileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userReferenceID := params["userReferenceID"]
+
+	var data UpdateProfileRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	ctx := r.Context()
+	user, err := h.repo.GetUserByReference(ctx, userReferenceID, userReferenceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == repository.ErrUserNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.RespondError(w, err, status)
+		return
+	}
+
+	currentUsername := user.Username()
+
+	if data.Username != nil {
+		err = user.UpdateUsername(*data.Username)
+		if err != nil {
+			h.RespondError(w, err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	usernameChanged := user.Username() != currentUsername
+	if usernameChanged {
+		exists, err := h.repo.DoesUsernameExist(ctx, user.Username(), &userReferenceID)
+		if err != nil {
+			h.RespondError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		if exists {
+			h.RespondError(w, fmt.Errorf("the username '%s' is taken", user.Username()), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if data.Bio != nil {
+		err = user.UpdateBio(*data.Bio)
+		if err != nil {
+			h.RespondError(w, err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	if data.MediaID != nil {
+		user.SetMedia(*data.MediaID)
+	}
+
+	if data.IsPrivate != nil {
+		user.SetPrivate(*data.IsPrivate)
+	}
+
+	err = h.repo.Update(ctx, user)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := UpdateProfileResponse{
+		Username:        user.Username(),
+		Bio:             user.Bio(),
+		UsernameChanged: usernameChanged,
+		IsPrivate:       user.IsPrivate(),
+	}
+
+	h.Respond(w, resp)
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/mock/repository"
+	repo "github.com/reecerussell/open-social/cmd/users/repository"
+)
+
+func updateProfileRequest(referenceID, body string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "/profile/update/"+referenceID, strings.NewReader(body))
+
+	return mux.SetURLVars(req, map[string]string{"userReferenceID": referenceID})
+}
+
+func TestUpdateProfileHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+	mockRepo.EXPECT().DoesUsernameExist(gomock.Any(), "new-name", gomock.Any()).
+		DoAndReturn(func(_ interface{}, _ string, excludeRefID *string) (bool, error) {
+			assert.Equal(t, testReferenceID, *excludeRefID)
+			return false, nil
+		})
+	mockRepo.EXPECT().Update(gomock.Any(), testUser).Return(nil)
+
+	body := `{"username": "New-Name", "bio": " Hello, world! ", "mediaId": 12}`
+	rr := httptest.NewRecorder()
+	NewUpdateProfileHandler(mockRepo).ServeHTTP(rr, updateProfileRequest(testReferenceID, body))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "{\"username\":\"new-name\",\"bio\":\"Hello, world!\",\"usernameChanged\":true,\"isPrivate\":false}\n", rr.Body.String())
+	assert.Equal(t, 12, *testUser.MediaID())
+}
+
+func TestUpdateProfileHandler_OnlyBio_KeepsUsername(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), testUser).Return(nil)
+
+	rr := httptest.NewRecorder()
+	NewUpdateProfileHandler(mockRepo).ServeHTTP(rr, updateProfileRequest(testReferenceID, `{"bio": "Hi"}`))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp UpdateProfileResponse
+	_ = json.NewDecoder(rr.Body).Decode(&resp)
+	assert.Equal(t, "testing", resp.Username)
+	assert.False(t, resp.UsernameChanged)
+	assert.Nil(t, testUser.MediaID())
+}
+
+func TestUpdateProfileHandler_IsPrivate_SetsPrivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), testUser).Return(nil)
+
+	rr := httptest.NewRecorder()
+	NewUpdateProfileHandler(mockRepo).ServeHTTP(rr, updateProfileRequest(testReferenceID, `{"isPrivate": true}`))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp UpdateProfileResponse
+	_ = json.NewDecoder(rr.Body).Decode(&resp)
+	assert.True(t, resp.IsPrivate)
+	assert.True(t, testUser.IsPrivate())
+}
+
+func TestUpdateProfileHandler_UsernameTaken_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).
+		Return(getMockUser(testReferenceID, "testing"), nil)
+	mockRepo.EXPECT().DoesUsernameExist(gomock.Any(), "taken", gomock.Any()).Return(true, nil)
+
+	rr := httptest.NewRecorder()
+	NewUpdateProfileHandler(mockRepo).ServeHTTP(rr, updateProfileRequest(testReferenceID, `{"username": "taken"}`))
+
+	assert.Equal(t, "{\"message\":\"the username 'taken' is taken\"}\n", rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestUpdateProfileHandler_BioTooLong_ReturnsBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).
+		Return(getMockUser(testReferenceID, "testing"), nil)
+
+	body := `{"bio": "` + strings.Repeat("a", 256) + `"}`
+	rr := httptest.NewRecorder()
+	NewUpdateProfileHandler(mockRepo).ServeHTTP(rr, updateProfileRequest(testReferenceID, body))
+
+	assert.Equal(t, "{\"message\":\"bio cannot be greater than 255 characters long\"}\n", rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestUpdateProfileHandler_UserNotFound_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(nil, repo.ErrUserNotFound)
+
+	rr := httptest.NewRecorder()
+	NewUpdateProfileHandler(mockRepo).ServeHTTP(rr, updateProfileRequest(testReferenceID, `{}`))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
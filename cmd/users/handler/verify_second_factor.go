@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/database"
+)
+
+// VerifySecondFactorHandler is a http.Handler used to complete a login challenge,
+// given a code from the user's authenticator, or one of their recovery codes, in
+// exchange for their claims. Failed attempts count towards the user's and IP
+// address's lockouts, as well as the challenge's own limit.
+type VerifySecondFactorHandler struct {
+	loginThrottler
+
+	repo       repository.UserRepository
+	twoFactors repository.TwoFactorRepository
+	challenges repository.LoginChallengeRepository
+}
+
+// NewVerifySecondFactorHandler returns a new instance of VerifySecondFactorHandler.
+func NewVerifySecondFactorHandler(repo repository.UserRepository, throttles repository.LoginThrottleRepository, twoFactors repository.TwoFactorRepository, challenges repository.LoginChallengeRepository, uow database.UnitOfWork, userPolicy, ipPolicy model.LockoutPolicy) *VerifySecondFactorHandler {
+	return &VerifySecondFactorHandler{
+		loginThrottler: loginThrottler{
+			throttles:  throttles,
+			uow:        uow,
+			userPolicy: userPolicy,
+			ipPolicy:   ipPolicy,
+		},
+		repo:       repo,
+		twoFactors: twoFactors,
+		challenges: challenges,
+	}
+}
+
+// VerifySecondFactorRequest represents the request body.
+type VerifySecondFactorRequest struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"`
+	IPAddress      string `json:"ipAddress"`
+}
+
+// ServeHTTP handles requests to complete a login challenge.
+func (h *VerifySecondFactorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var data VerifySecondFactorRequest
+	_ = json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+
+	ctx := r.Context()
+	now := time.Now().UTC()
+
+	until, locked, err := h.ipLockedUntil(ctx, now, data.IPAddress)
+	if err != nil {
+		h.RespondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if locked {
+		h.respondLocked(w, model.ErrTooManyAttempts, http.StatusTooManyRequests, until.Sub(now))
+		return
+	}
+
+	var (
+		user    *model.User
+		userKey string
+		codeErr error
+	)
+
+	// A failed attempt is recorded against the challenge, so the transaction
+	// is committed, with the failure reported once it has.
+	err = h.uow.Transaction(ctx, func(ctx context.Context) error {
+		challenge, err := h.challenges.Get(ctx, data.ChallengeToken)
+		if err != nil {
+			return err
+		}
+
+		err = challenge.Check(now)
+		if err != nil {
+			return err
+		}
+
+		userKey = model.UserThrottleKey(challenge.UserReferenceID())
+		throttle, err := h.throttles.Get(ctx, userKey)
+		if err != nil {
+			return err
+		}
+
+		if lockedUntil, locked := throttle.LockedUntil(now); locked {
+			until = lockedUntil
+			return model.ErrAccountLocked
+		}
+
+		codeErr = verifySecondFactor(ctx, h.twoFactors, challenge.UserReferenceID(), data.Code, now)
+		switch codeErr {
+		case nil:
+		case model.ErrInvalidCode, model.ErrRecoveryCodeUsed, model.ErrTwoFactorNotEnabled, repository.ErrTwoFactorNotFound:
+			challenge.RecordFailure()
+			return h.challenges.Update(ctx, challenge)
+		default:
+			return codeErr
+		}
+
+		challenge.Complete(now)
+		err = h.challenges.Update(ctx, challenge)
+		if err != nil {
+			return err
+		}
+
+		if !throttle.IsClear() {
+			err = h.throttles.Delete(ctx, userKey)
+			if err != nil {
+				return err
+			}
+		}
+
+		user, err = h.repo.GetUserByReference(ctx, challenge.UserReferenceID(), challenge.UserReferenceID())
+
+		return err
+	})
+	if err != nil {
+		switch err {
+		case repository.ErrChallengeNotFound, model.ErrChallengeExpired:
+			h.RespondError(w, err, http.StatusBadRequest)
+		case model.ErrAccountLocked:
+			h.respondLocked(w, err, http.StatusLocked, until.Sub(now))
+		default:
+			h.RespondError(w, err, http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	if codeErr != nil {
+		h.respondFailure(w, codeErr, h.recordFailure(ctx, now, userKey, data.IPAddress))
+		return
+	}
+
+	resp := GetClaimsResponse{
+		Claims: userClaims(user),
+	}
+
+	h.Respond(w, resp)
+}
+
+// verifySecondFactor verifies the code as either a code from the user's authenticator,
+// or one of their recovery codes, which is then used up. This should be called within
+// a transaction, so the same code can't be used concurrently.
+func verifySecondFactor(ctx context.Context, twoFactors repository.TwoFactorRepository, userReferenceID, code string, now time.Time) error {
+	twoFactor, err := twoFactors.Get(ctx, userReferenceID)
+	if err != nil {
+		return err
+	}
+
+	if !twoFactor.Enabled() {
+		return model.ErrTwoFactorNotEnabled
+	}
+
+	if !model.IsRecoveryCode(code) {
+		err = twoFactor.VerifyCode(code, now)
+		if err != nil {
+			return err
+		}
+
+		return twoFactors.Save(ctx, twoFactor)
+	}
+
+	recoveryCode, err := twoFactors.GetRecoveryCode(ctx, userReferenceID, code)
+	if err != nil {
+		if err == repository.ErrRecoveryCodeNotFound {
+			return model.ErrInvalidCode
+		}
+
+		return err
+	}
+
+	err = recoveryCode.Use()
+	if err != nil {
+		return err
+	}
+
+	return twoFactors.UpdateRecoveryCode(ctx, recoveryCode)
+}
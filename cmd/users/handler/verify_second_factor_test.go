@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/mock/repository"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	repo "github.com/reecerussell/open-social/cmd/users/repository"
+	"github.com/reecerussell/open-social/cmd/users/totp"
+)
+
+const testChallengeToken = "q8Tm2nP0xKv"
+
+func verifySecondFactorRequest(code, ipAddress string) *http.Request {
+	body := fmt.Sprintf(`{"challengeToken": "%s", "code": "%s", "ipAddress": "%s"}`, testChallengeToken, code, ipAddress)
+	req, _ := http.NewRequest(http.MethodPost, "/claims/2fa", strings.NewReader(body))
+
+	return req
+}
+
+func testLoginChallenge(referenceID string, expires time.Time, attempts int) *model.LoginChallenge {
+	return model.LoginChallengeFromDao(&dao.LoginChallenge{
+		ID:              7,
+		UserID:          1,
+		UserReferenceID: referenceID,
+		Expires:         expires,
+		Attempts:        attempts,
+	})
+}
+
+func testTwoFactor(t *testing.T, referenceID string) (*model.TwoFactor, string) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, _ := totp.Code(secret, totp.Step(time.Now()))
+	twoFactor := model.TwoFactorFromDao(&dao.TwoFactor{
+		ID:              3,
+		UserID:          1,
+		UserReferenceID: referenceID,
+		Secret:          secret,
+		Enabled:         true,
+	})
+
+	return twoFactor, code
+}
+
+func TestVerifySecondFactorHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	testUser := getMockUser(testReferenceID, "testing")
+	challenge := testLoginChallenge(testReferenceID, time.Now().UTC().Add(time.Minute), 0)
+	twoFactor, code := testTwoFactor(t, testReferenceID)
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+
+	userKey := model.UserThrottleKey(testReferenceID)
+	throttle := model.NewLoginThrottle(userKey)
+	throttle.RecordFailure(time.Now().UTC(), testUserPolicy)
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(throttle, nil)
+	mockThrottles.EXPECT().Delete(gomock.Any(), userKey).Return(nil)
+
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), testReferenceID).Return(twoFactor, nil)
+	mockTwoFactors.EXPECT().Save(gomock.Any(), twoFactor).Return(nil)
+
+	mockChallenges := repository.NewMockLoginChallengeRepository(ctrl)
+	mockChallenges.EXPECT().Get(gomock.Any(), testChallengeToken).Return(challenge, nil)
+	mockChallenges.EXPECT().Update(gomock.Any(), challenge).Return(nil)
+
+	handler := NewVerifySecondFactorHandler(mockRepo, mockThrottles, mockTwoFactors, mockChallenges, newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, verifySecondFactorRequest(code, ""))
+
+	exp := fmt.Sprintf("{\"claims\":{\"uid\":\"%s\",\"username\":\"%s\"}}\n", testReferenceID, "testing")
+	assert.Equal(t, exp, rr.Body.String())
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Neither the challenge, nor the code, can be used again.
+	assert.Equal(t, model.ErrChallengeExpired, challenge.Check(time.Now().UTC()))
+	assert.Equal(t, model.ErrInvalidCode, twoFactor.VerifyCode(code, time.Now().UTC()))
+}
+
+func TestVerifySecondFactorHandler_GivenRecoveryCode_ReturnsClaims(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	const testRecoveryCode = "abcdefgh-ijklmnop"
+	testUser := getMockUser(testReferenceID, "testing")
+	challenge := testLoginChallenge(testReferenceID, time.Now().UTC().Add(time.Minute), 0)
+	twoFactor, _ := testTwoFactor(t, testReferenceID)
+	recoveryCode := model.RecoveryCodeFromDao(&dao.RecoveryCode{ID: 2, UserID: 1})
+
+	mockRepo := repository.NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().GetUserByReference(gomock.Any(), testReferenceID, testReferenceID).Return(testUser, nil)
+
+	userKey := model.UserThrottleKey(testReferenceID)
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(model.NewLoginThrottle(userKey), nil)
+
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), testReferenceID).Return(twoFactor, nil)
+	mockTwoFactors.EXPECT().GetRecoveryCode(gomock.Any(), testReferenceID, testRecoveryCode).Return(recoveryCode, nil)
+	mockTwoFactors.EXPECT().UpdateRecoveryCode(gomock.Any(), recoveryCode).Return(nil)
+
+	mockChallenges := repository.NewMockLoginChallengeRepository(ctrl)
+	mockChallenges.EXPECT().Get(gomock.Any(), testChallengeToken).Return(challenge, nil)
+	mockChallenges.EXPECT().Update(gomock.Any(), challenge).Return(nil)
+
+	handler := NewVerifySecondFactorHandler(mockRepo, mockThrottles, mockTwoFactors, mockChallenges, newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, verifySecondFactorRequest(testRecoveryCode, ""))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, model.ErrRecoveryCodeUsed, recoveryCode.Use())
+}
+
+func TestVerifySecondFactorHandler_GivenInvalidCode_RecordsFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	const testIPAddress = "10.0.0.1"
+	challenge := testLoginChallenge(testReferenceID, time.Now().UTC().Add(time.Minute), model.MaxChallengeAttempts-1)
+	twoFactor, code := testTwoFactor(t, testReferenceID)
+	wrongCode := "000000"
+	if code == wrongCode {
+		wrongCode = "111111"
+	}
+
+	userKey := model.UserThrottleKey(testReferenceID)
+	ipKey := model.IPThrottleKey(testIPAddress)
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), ipKey).Return(model.NewLoginThrottle(ipKey), nil).Times(2)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(model.NewLoginThrottle(userKey), nil).Times(2)
+	mockThrottles.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, throttle *model.LoginThrottle) error {
+		assert.Equal(t, 1, throttle.Dao().Failures)
+		return nil
+	}).Times(2)
+
+	mockTwoFactors := repository.NewMockTwoFactorRepository(ctrl)
+	mockTwoFactors.EXPECT().Get(gomock.Any(), testReferenceID).Return(twoFactor, nil)
+
+	mockChallenges := repository.NewMockLoginChallengeRepository(ctrl)
+	mockChallenges.EXPECT().Get(gomock.Any(), testChallengeToken).Return(challenge, nil)
+	mockChallenges.EXPECT().Update(gomock.Any(), challenge).Return(nil)
+
+	handler := NewVerifySecondFactorHandler(nil, mockThrottles, mockTwoFactors, mockChallenges, newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, verifySecondFactorRequest(wrongCode, testIPAddress))
+
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", model.ErrInvalidCode), rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// That was the challenge's last attempt.
+	assert.Equal(t, model.ErrChallengeExpired, challenge.Check(time.Now().UTC()))
+}
+
+func TestVerifySecondFactorHandler_GivenInvalidChallenge_ReturnsBadRequest(t *testing.T) {
+	const testReferenceID = "23470324"
+	now := time.Now().UTC()
+
+	tests := map[string]struct {
+		challenge *model.LoginChallenge
+		err       error
+		exp       error
+	}{
+		"Not Found":        {err: repo.ErrChallengeNotFound, exp: repo.ErrChallengeNotFound},
+		"Expired":          {challenge: testLoginChallenge(testReferenceID, now.Add(-time.Second), 0), exp: model.ErrChallengeExpired},
+		"Too Many Attemps": {challenge: testLoginChallenge(testReferenceID, now.Add(time.Minute), model.MaxChallengeAttempts), exp: model.ErrChallengeExpired},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockChallenges := repository.NewMockLoginChallengeRepository(ctrl)
+			mockChallenges.EXPECT().Get(gomock.Any(), testChallengeToken).Return(test.challenge, test.err)
+
+			handler := NewVerifySecondFactorHandler(nil, nil, nil, mockChallenges, newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, verifySecondFactorRequest("123456", ""))
+
+			assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", test.exp), rr.Body.String())
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
+
+func TestVerifySecondFactorHandler_UserLocked_ReturnsLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testReferenceID = "23470324"
+	challenge := testLoginChallenge(testReferenceID, time.Now().UTC().Add(time.Minute), 0)
+
+	userKey := model.UserThrottleKey(testReferenceID)
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), userKey).Return(lockedThrottle(userKey), nil)
+
+	mockChallenges := repository.NewMockLoginChallengeRepository(ctrl)
+	mockChallenges.EXPECT().Get(gomock.Any(), testChallengeToken).Return(challenge, nil)
+
+	handler := NewVerifySecondFactorHandler(nil, mockThrottles, nil, mockChallenges, newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, verifySecondFactorRequest("123456", ""))
+
+	assert.Equal(t, http.StatusLocked, rr.Code)
+	assert.Equal(t, "60", rr.Header().Get("Retry-After"))
+}
+
+func TestVerifySecondFactorHandler_IPLocked_ReturnsTooManyRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testIPAddress = "10.0.0.1"
+	ipKey := model.IPThrottleKey(testIPAddress)
+	mockThrottles := repository.NewMockLoginThrottleRepository(ctrl)
+	mockThrottles.EXPECT().Get(gomock.Any(), ipKey).Return(lockedThrottle(ipKey), nil)
+
+	handler := NewVerifySecondFactorHandler(nil, mockThrottles, nil, nil, nil, testUserPolicy, testIPPolicy)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, verifySecondFactorRequest("123456", testIPAddress))
+
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+}
+
+func TestVerifySecondFactorHandler_FailedToGetChallenge_ReturnsInternalServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testErr := errors.New("an error occured")
+	mockChallenges := repository.NewMockLoginChallengeRepository(ctrl)
+	mockChallenges.EXPECT().Get(gomock.Any(), testChallengeToken).Return(nil, testErr)
+
+	handler := NewVerifySecondFactorHandler(nil, nil, nil, mockChallenges, newClaimsMockUow(ctrl), testUserPolicy, testIPPolicy)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, verifySecondFactorRequest("123456", ""))
+
+	assert.Equal(t, fmt.Sprintf("{\"message\":\"%v\"}\n", testErr), rr.Body.String())
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
@@ -1,25 +1,43 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"time"
 
 	hashpkg "github.com/reecerussell/adaptive-password-hasher"
 
 	core "github.com/reecerussell/open-social"
+	"github.com/reecerussell/open-social/client/auth"
+	"github.com/reecerussell/open-social/client/media"
+	"github.com/reecerussell/open-social/client/notifications"
+	"github.com/reecerussell/open-social/client/posts"
+	"github.com/reecerussell/open-social/cmd/users/deletion"
 	"github.com/reecerussell/open-social/cmd/users/handler"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/cmd/users/notifier"
 	"github.com/reecerussell/open-social/cmd/users/password"
 	"github.com/reecerussell/open-social/cmd/users/provider"
 	"github.com/reecerussell/open-social/cmd/users/repository"
 	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/eventing"
+	"github.com/reecerussell/open-social/eventing/kafka"
+	"github.com/reecerussell/open-social/eventing/outbox"
 	"github.com/reecerussell/open-social/util"
 )
 
 const (
 	connectionStringVar = "CONNECTION_STRING"
 	configFileVar       = "CONFIG_FILE"
+	kafkaHostVar        = "KAFKA_HOST"
+	kafkaTopicVar       = "KAFKA_TOPIC"
+	authAPIVar          = "AUTH_API_URL"
+	postsAPIVar         = "POSTS_API_URL"
+	mediaAPIVar         = "MEDIA_API_URL"
+	notificationsAPIVar = "NOTIFICATIONS_API_URL"
 )
 
 func main() {
@@ -29,11 +47,35 @@ func main() {
 
 	createUser := ctn.GetService("CreateUserHandler").(*handler.CreateUserHandler)
 	getClaims := ctn.GetService("GetClaimsHandler").(*handler.GetClaimsHandler)
+	getClaimsByReference := ctn.GetService("GetClaimsByReferenceHandler").(*handler.GetClaimsByReferenceHandler)
 	getIDByReference := ctn.GetService("GetIDByReferenceHandler").(*handler.GetIDByReferenceHandler)
 	getProfile := ctn.GetService("GetProfileHandler").(*handler.GetProfileHandler)
 	getInfo := ctn.GetService("GetInfoHandler").(*handler.GetInfoHandler)
+	getFollowers := ctn.GetService("GetFollowersHandler").(*handler.GetFollowersHandler)
+	getFollowing := ctn.GetService("GetFollowingHandler").(*handler.GetFollowingHandler)
 	followUser := ctn.GetService("FollowUserHandler").(*handler.FollowUserHandler)
 	unfollowUser := ctn.GetService("UnfollowUserHandler").(*handler.UnfollowUserHandler)
+	getFollowRequests := ctn.GetService("GetFollowRequestsHandler").(*handler.GetFollowRequestsHandler)
+	approveFollowRequest := ctn.GetService("ApproveFollowRequestHandler").(*handler.ApproveFollowRequestHandler)
+	rejectFollowRequest := ctn.GetService("RejectFollowRequestHandler").(*handler.RejectFollowRequestHandler)
+	blockUser := ctn.GetService("BlockUserHandler").(*handler.BlockUserHandler)
+	unblockUser := ctn.GetService("UnblockUserHandler").(*handler.UnblockUserHandler)
+	getBlockedUsers := ctn.GetService("GetBlockedUsersHandler").(*handler.GetBlockedUsersHandler)
+	muteUser := ctn.GetService("MuteUserHandler").(*handler.MuteUserHandler)
+	unmuteUser := ctn.GetService("UnmuteUserHandler").(*handler.UnmuteUserHandler)
+	unlockUser := ctn.GetService("UnlockUserHandler").(*handler.UnlockUserHandler)
+	updateProfile := ctn.GetService("UpdateProfileHandler").(*handler.UpdateProfileHandler)
+	changePassword := ctn.GetService("ChangePasswordHandler").(*handler.ChangePasswordHandler)
+	requestPasswordReset := ctn.GetService("RequestPasswordResetHandler").(*handler.RequestPasswordResetHandler)
+	resetPassword := ctn.GetService("ResetPasswordHandler").(*handler.ResetPasswordHandler)
+	verifySecondFactor := ctn.GetService("VerifySecondFactorHandler").(*handler.VerifySecondFactorHandler)
+	enrolTwoFactor := ctn.GetService("EnrolTwoFactorHandler").(*handler.EnrolTwoFactorHandler)
+	confirmTwoFactor := ctn.GetService("ConfirmTwoFactorHandler").(*handler.ConfirmTwoFactorHandler)
+	disableTwoFactor := ctn.GetService("DisableTwoFactorHandler").(*handler.DisableTwoFactorHandler)
+	getExport := ctn.GetService("GetExportHandler").(*handler.GetExportHandler)
+	requestDeletion := ctn.GetService("RequestDeletionHandler").(*handler.RequestDeletionHandler)
+	cancelDeletion := ctn.GetService("CancelDeletionHandler").(*handler.CancelDeletionHandler)
+	getDeletion := ctn.GetService("GetDeletionHandler").(*handler.GetDeletionHandler)
 
 	app := core.NewApp()
 	app.AddHealthCheck(database.NewHealthCheck(db))
@@ -42,10 +84,49 @@ func main() {
 	app.Post("/users", createUser)
 	app.Get("/users/id/{referenceId}", getIDByReference)
 	app.Post("/claims", getClaims)
+	app.Post("/claims/2fa", verifySecondFactor)
+	app.Get("/claims/{userReferenceID}", getClaimsByReference)
 	app.Get("/profile/{username}/{userReferenceID}", getProfile)
 	app.Get("/info/{userReferenceID}", getInfo)
+	app.Get("/followers/{username}/{userReferenceID}", getFollowers)
+	app.Get("/following/{username}/{userReferenceID}", getFollowing)
 	app.Post("/follow/{userReferenceId}/{followerReferenceId}", followUser)
 	app.Post("/unfollow/{userReferenceId}/{followerReferenceId}", unfollowUser)
+	app.Get("/follow-requests/{userReferenceID}", getFollowRequests)
+	app.Post("/follow-requests/approve/{userReferenceId}/{followerReferenceId}", approveFollowRequest)
+	app.Post("/follow-requests/reject/{userReferenceId}/{followerReferenceId}", rejectFollowRequest)
+	app.Post("/block/{userReferenceId}/{blockerReferenceId}", blockUser)
+	app.Post("/unblock/{userReferenceId}/{blockerReferenceId}", unblockUser)
+	app.Get("/blocks/{userReferenceID}", getBlockedUsers)
+	app.Post("/mute/{userReferenceId}/{muterReferenceId}", muteUser)
+	app.Post("/unmute/{userReferenceId}/{muterReferenceId}", unmuteUser)
+	app.Post("/unlock/{userReferenceID}", unlockUser)
+	app.Post("/profile/update/{userReferenceID}", updateProfile)
+	app.Post("/password/change/{userReferenceID}", changePassword)
+	app.Post("/password/reset", requestPasswordReset)
+	app.Post("/password/reset/confirm", resetPassword)
+	app.Post("/2fa/enrol/{userReferenceID}", enrolTwoFactor)
+	app.Post("/2fa/confirm/{userReferenceID}", confirmTwoFactor)
+	app.Post("/2fa/disable/{userReferenceID}", disableTwoFactor)
+	app.Get("/export/{userReferenceID}", getExport)
+	app.Post("/deletion/request/{userReferenceID}", requestDeletion)
+	app.Post("/deletion/cancel/{userReferenceID}", cancelDeletion)
+	app.Get("/deletion/{userReferenceID}", getDeletion)
+
+	relay := ctn.GetService("OutboxRelay").(*outbox.Relay)
+	ctx, cancel := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(relayDone)
+	}()
+
+	deletionWorker := ctn.GetService("DeletionWorker").(*deletion.Worker)
+	deletionDone := make(chan struct{})
+	go func() {
+		deletionWorker.Run(ctx)
+		close(deletionDone)
+	}()
 
 	go app.Serve()
 
@@ -53,6 +134,16 @@ func main() {
 	signal.Notify(stop, os.Interrupt, os.Kill)
 	<-stop
 
+	cancel()
+	<-relayDone
+	<-deletionDone
+
+	kafkaPublisher := ctn.GetService("KafkaPublisher").(*kafka.Publisher)
+	err := kafkaPublisher.Close()
+	if err != nil {
+		log.Printf("Error: failed to close event publisher: %v\n", err)
+	}
+
 	log.Println("App stopped.")
 }
 
@@ -60,6 +151,72 @@ func main() {
 type Config struct {
 	PasswordValidatorOptions *password.Options     `json:"passwordValidator"`
 	PasswordHasherOptions    *password.HashOptions `json:"passwordHasher"`
+	Lockout                  *LockoutConfig        `json:"lockout"`
+	PasswordReset            *PasswordResetConfig  `json:"passwordReset"`
+	Notifier                 *NotifierConfig       `json:"notifier"`
+	TwoFactor                *TwoFactorConfig      `json:"twoFactor"`
+	Deletion                 *DeletionConfig       `json:"deletion"`
+}
+
+// DeletionConfig contains config for account deletions, which start once GraceDays
+// have passed since they were requested. Due deletions are polled for every
+// IntervalSeconds, and failed deletions are retried after RetryMinutes, backing off
+// exponentially with each attempt.
+type DeletionConfig struct {
+	GraceDays       int `json:"graceDays"`
+	IntervalSeconds int `json:"intervalSeconds"`
+	RetryMinutes    int `json:"retryMinutes"`
+}
+
+// TwoFactorConfig contains config for two-factor authentication. Issuer is the
+// name authenticator apps show accounts under, and RecoveryCodes is the number
+// of recovery codes generated for each user. Login challenges, issued to users
+// with two-factor authentication, expire after ChallengeExpiryMinutes.
+type TwoFactorConfig struct {
+	Issuer                 string `json:"issuer"`
+	RecoveryCodes          int    `json:"recoveryCodes"`
+	ChallengeExpiryMinutes int    `json:"challengeExpiryMinutes"`
+}
+
+// PasswordResetConfig contains config for password reset tokens, which expire
+// after ExpiryMinutes, and are sent to users as a link to URL.
+type PasswordResetConfig struct {
+	ExpiryMinutes int    `json:"expiryMinutes"`
+	URL           string `json:"url"`
+}
+
+// NotifierConfig contains config for the notifier used to send messages to users.
+// Type is either "log" or "file", where a file notifier writes to File.
+type NotifierConfig struct {
+	Type string `json:"type"`
+	File string `json:"file"`
+}
+
+// LockoutConfig contains the lockout policies for failed login attempts,
+// made against a single user, and from a single IP address.
+type LockoutConfig struct {
+	User *LockoutPolicyConfig `json:"user"`
+	IP   *LockoutPolicyConfig `json:"ip"`
+}
+
+// LockoutPolicyConfig contains config for a lockout policy. Once MaxAttempts
+// failed attempts have been made within WindowMinutes of each other, logins
+// are locked for LockoutSeconds, doubling with each consecutive lockout,
+// up to MaxLockoutMinutes.
+type LockoutPolicyConfig struct {
+	MaxAttempts       int `json:"maxAttempts"`
+	WindowMinutes     int `json:"windowMinutes"`
+	LockoutSeconds    int `json:"lockoutSeconds"`
+	MaxLockoutMinutes int `json:"maxLockoutMinutes"`
+}
+
+func (c *LockoutPolicyConfig) policy() model.LockoutPolicy {
+	return model.LockoutPolicy{
+		MaxAttempts: c.MaxAttempts,
+		Window:      time.Duration(c.WindowMinutes) * time.Minute,
+		Lockout:     time.Duration(c.LockoutSeconds) * time.Second,
+		MaxLockout:  time.Duration(c.MaxLockoutMinutes) * time.Minute,
+	}
 }
 
 func buildConfig() *Config {
@@ -91,19 +248,38 @@ func buildServices(cnf *Config) *core.Container {
 		return db
 	})
 
+	ctn.AddSingleton("KafkaPublisher", func(ctn *core.Container) interface{} {
+		host := os.Getenv(kafkaHostVar)
+		topic := util.ReadEnv(kafkaTopicVar, "users")
+		return kafka.NewPublisher(host, topic)
+	})
+
+	ctn.AddService("EventPublisher", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		topic := util.ReadEnv(kafkaTopicVar, "users")
+		return outbox.NewPublisher(db, topic)
+	})
+
+	ctn.AddSingleton("OutboxRelay", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		pub := ctn.GetService("KafkaPublisher").(*kafka.Publisher)
+		topic := util.ReadEnv(kafkaTopicVar, "users")
+		return outbox.NewRelay(db, topic, pub, outbox.DefaultInterval)
+	})
+
 	ctn.AddService("PasswordValidator", func(ctn *core.Container) interface{} {
 		cnf := ctn.GetService("Config").(*Config)
-		val := password.New(cnf.PasswordValidatorOptions)
+		val, err := password.NewFromOptions(cnf.PasswordValidatorOptions)
+		if err != nil {
+			panic(fmt.Errorf("failed to build PasswordValidator: %v", err))
+		}
+
 		return val
 	})
 
 	ctn.AddService("PasswordHasher", func(ctn *core.Container) interface{} {
 		cnf := ctn.GetService("Config").(*Config)
-		hasher, err := hashpkg.New(
-			cnf.PasswordHasherOptions.IterationCount,
-			cnf.PasswordHasherOptions.SaltSize,
-			cnf.PasswordHasherOptions.KeySize,
-			cnf.PasswordHasherOptions.HashKey)
+		hasher, err := password.NewHasher(cnf.PasswordHasherOptions)
 		if err != nil {
 			panic(fmt.Errorf("failed to build PasswordHasher: %v", err))
 		}
@@ -112,8 +288,8 @@ func buildServices(cnf *Config) *core.Container {
 	})
 
 	ctn.AddService("UserRepository", func(ctn *core.Container) interface{} {
-		url := os.Getenv(connectionStringVar)
-		return repository.NewUserRepository(url)
+		db := ctn.GetService("Database").(database.Database)
+		return repository.NewUserRepository(db)
 	})
 
 	ctn.AddService("FollowerRepository", func(ctn *core.Container) interface{} {
@@ -121,6 +297,87 @@ func buildServices(cnf *Config) *core.Container {
 		return repository.NewFollowerRepository(db)
 	})
 
+	ctn.AddService("FollowRequestRepository", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return repository.NewFollowRequestRepository(db)
+	})
+
+	ctn.AddService("BlockRepository", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return repository.NewBlockRepository(db)
+	})
+
+	ctn.AddService("MuteRepository", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return repository.NewMuteRepository(db)
+	})
+
+	ctn.AddService("LoginThrottleRepository", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return repository.NewLoginThrottleRepository(db)
+	})
+
+	ctn.AddService("PasswordResetTokenRepository", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return repository.NewPasswordResetTokenRepository(db)
+	})
+
+	ctn.AddService("TwoFactorRepository", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return repository.NewTwoFactorRepository(db)
+	})
+
+	ctn.AddService("LoginChallengeRepository", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return repository.NewLoginChallengeRepository(db)
+	})
+
+	ctn.AddService("AccountDeletionRepository", func(ctn *core.Container) interface{} {
+		db := ctn.GetService("Database").(database.Database)
+		return repository.NewAccountDeletionRepository(db)
+	})
+
+	ctn.AddSingleton("AuthClient", func(ctn *core.Container) interface{} {
+		return auth.New(os.Getenv(authAPIVar))
+	})
+
+	ctn.AddSingleton("PostsClient", func(ctn *core.Container) interface{} {
+		return posts.New(os.Getenv(postsAPIVar))
+	})
+
+	ctn.AddSingleton("MediaClient", func(ctn *core.Container) interface{} {
+		return media.New(os.Getenv(mediaAPIVar))
+	})
+
+	ctn.AddSingleton("NotificationsClient", func(ctn *core.Container) interface{} {
+		return notifications.New(os.Getenv(notificationsAPIVar))
+	})
+
+	ctn.AddSingleton("DeletionWorker", func(ctn *core.Container) interface{} {
+		deletions := ctn.GetService("AccountDeletionRepository").(repository.AccountDeletionRepository)
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		db := ctn.GetService("Database").(database.Database)
+		authClient := ctn.GetService("AuthClient").(auth.Client)
+		notificationsClient := ctn.GetService("NotificationsClient").(notifications.Client)
+		postsClient := ctn.GetService("PostsClient").(posts.Client)
+		mediaClient := ctn.GetService("MediaClient").(media.Client)
+		cnf := ctn.GetService("Config").(*Config)
+		interval := time.Duration(cnf.Deletion.IntervalSeconds) * time.Second
+		retry := time.Duration(cnf.Deletion.RetryMinutes) * time.Minute
+
+		return deletion.NewWorker(deletions, repo, db, authClient, notificationsClient, postsClient, mediaClient, interval, retry)
+	})
+
+	ctn.AddSingleton("Notifier", func(ctn *core.Container) interface{} {
+		cnf := ctn.GetService("Config").(*Config)
+		n, err := notifier.New(cnf.Notifier.Type, cnf.Notifier.File)
+		if err != nil {
+			panic(fmt.Errorf("failed to build Notifier: %v", err))
+		}
+
+		return n
+	})
+
 	ctn.AddService("UserProvider", func(ctn *core.Container) interface{} {
 		db := ctn.GetService("Database").(database.Database)
 		return provider.NewUserProvider(db)
@@ -130,15 +387,40 @@ func buildServices(cnf *Config) *core.Container {
 		val := ctn.GetService("PasswordValidator").(password.Validator)
 		hasher := ctn.GetService("PasswordHasher").(hashpkg.Hasher)
 		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		db := ctn.GetService("Database").(database.Database)
+		events := ctn.GetService("EventPublisher").(eventing.Publisher)
 
-		return handler.NewCreateUserHandler(val, hasher, repo)
+		return handler.NewCreateUserHandler(val, hasher, repo, db, events)
 	})
 
 	ctn.AddService("GetClaimsHandler", func(ctn *core.Container) interface{} {
-		hasher := ctn.GetService("PasswordHasher").(hashpkg.Hasher)
+		hasher := ctn.GetService("PasswordHasher").(password.Hasher)
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		throttles := ctn.GetService("LoginThrottleRepository").(repository.LoginThrottleRepository)
+		twoFactors := ctn.GetService("TwoFactorRepository").(repository.TwoFactorRepository)
+		challenges := ctn.GetService("LoginChallengeRepository").(repository.LoginChallengeRepository)
+		db := ctn.GetService("Database").(database.Database)
+		cnf := ctn.GetService("Config").(*Config)
+		lifetime := time.Duration(cnf.TwoFactor.ChallengeExpiryMinutes) * time.Minute
+
+		return handler.NewGetClaimsHandler(hasher, repo, throttles, twoFactors, challenges, db, cnf.Lockout.User.policy(), cnf.Lockout.IP.policy(), lifetime)
+	})
+
+	ctn.AddService("VerifySecondFactorHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		throttles := ctn.GetService("LoginThrottleRepository").(repository.LoginThrottleRepository)
+		twoFactors := ctn.GetService("TwoFactorRepository").(repository.TwoFactorRepository)
+		challenges := ctn.GetService("LoginChallengeRepository").(repository.LoginChallengeRepository)
+		db := ctn.GetService("Database").(database.Database)
+		cnf := ctn.GetService("Config").(*Config)
+
+		return handler.NewVerifySecondFactorHandler(repo, throttles, twoFactors, challenges, db, cnf.Lockout.User.policy(), cnf.Lockout.IP.policy())
+	})
+
+	ctn.AddService("GetClaimsByReferenceHandler", func(ctn *core.Container) interface{} {
 		repo := ctn.GetService("UserRepository").(repository.UserRepository)
 
-		return handler.NewGetClaimsHandler(hasher, repo)
+		return handler.NewGetClaimsByReferenceHandler(repo)
 	})
 
 	ctn.AddService("GetIDByReferenceHandler", func(ctn *core.Container) interface{} {
@@ -159,18 +441,196 @@ func buildServices(cnf *Config) *core.Container {
 		return handler.NewGetInfoHandler(provider)
 	})
 
+	ctn.AddService("GetFollowersHandler", func(ctn *core.Container) interface{} {
+		provider := ctn.GetService("UserProvider").(provider.UserProvider)
+
+		return handler.NewGetFollowersHandler(provider)
+	})
+
+	ctn.AddService("GetFollowingHandler", func(ctn *core.Container) interface{} {
+		provider := ctn.GetService("UserProvider").(provider.UserProvider)
+
+		return handler.NewGetFollowingHandler(provider)
+	})
+
 	ctn.AddService("FollowUserHandler", func(ctn *core.Container) interface{} {
 		repo := ctn.GetService("UserRepository").(repository.UserRepository)
 		followers := ctn.GetService("FollowerRepository").(repository.FollowerRepository)
+		requests := ctn.GetService("FollowRequestRepository").(repository.FollowRequestRepository)
+		db := ctn.GetService("Database").(database.Database)
+		events := ctn.GetService("EventPublisher").(eventing.Publisher)
 
-		return handler.NewFollowUserHandler(repo, followers)
+		return handler.NewFollowUserHandler(repo, followers, requests, db, events)
 	})
 
 	ctn.AddService("UnfollowUserHandler", func(ctn *core.Container) interface{} {
 		repo := ctn.GetService("UserRepository").(repository.UserRepository)
 		followers := ctn.GetService("FollowerRepository").(repository.FollowerRepository)
+		requests := ctn.GetService("FollowRequestRepository").(repository.FollowRequestRepository)
+		db := ctn.GetService("Database").(database.Database)
+		events := ctn.GetService("EventPublisher").(eventing.Publisher)
+
+		return handler.NewUnfollowUserHandler(repo, followers, requests, db, events)
+	})
+
+	ctn.AddService("GetFollowRequestsHandler", func(ctn *core.Container) interface{} {
+		provider := ctn.GetService("UserProvider").(provider.UserProvider)
+
+		return handler.NewGetFollowRequestsHandler(provider)
+	})
+
+	ctn.AddService("ApproveFollowRequestHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		followers := ctn.GetService("FollowerRepository").(repository.FollowerRepository)
+		requests := ctn.GetService("FollowRequestRepository").(repository.FollowRequestRepository)
+		db := ctn.GetService("Database").(database.Database)
+		events := ctn.GetService("EventPublisher").(eventing.Publisher)
+
+		return handler.NewApproveFollowRequestHandler(repo, followers, requests, db, events)
+	})
+
+	ctn.AddService("RejectFollowRequestHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		requests := ctn.GetService("FollowRequestRepository").(repository.FollowRequestRepository)
+
+		return handler.NewRejectFollowRequestHandler(repo, requests)
+	})
+
+	ctn.AddService("BlockUserHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		blocks := ctn.GetService("BlockRepository").(repository.BlockRepository)
+		db := ctn.GetService("Database").(database.Database)
+
+		return handler.NewBlockUserHandler(repo, blocks, db)
+	})
+
+	ctn.AddService("UnblockUserHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		blocks := ctn.GetService("BlockRepository").(repository.BlockRepository)
+
+		return handler.NewUnblockUserHandler(repo, blocks)
+	})
+
+	ctn.AddService("GetBlockedUsersHandler", func(ctn *core.Container) interface{} {
+		provider := ctn.GetService("UserProvider").(provider.UserProvider)
+
+		return handler.NewGetBlockedUsersHandler(provider)
+	})
+
+	ctn.AddService("MuteUserHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		mutes := ctn.GetService("MuteRepository").(repository.MuteRepository)
+
+		return handler.NewMuteUserHandler(repo, mutes)
+	})
+
+	ctn.AddService("UnmuteUserHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		mutes := ctn.GetService("MuteRepository").(repository.MuteRepository)
+
+		return handler.NewUnmuteUserHandler(repo, mutes)
+	})
+
+	ctn.AddService("UnlockUserHandler", func(ctn *core.Container) interface{} {
+		throttles := ctn.GetService("LoginThrottleRepository").(repository.LoginThrottleRepository)
+
+		return handler.NewUnlockUserHandler(throttles)
+	})
+
+	ctn.AddService("UpdateProfileHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+
+		return handler.NewUpdateProfileHandler(repo)
+	})
+
+	ctn.AddService("ChangePasswordHandler", func(ctn *core.Container) interface{} {
+		val := ctn.GetService("PasswordValidator").(password.Validator)
+		hasher := ctn.GetService("PasswordHasher").(hashpkg.Hasher)
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		throttles := ctn.GetService("LoginThrottleRepository").(repository.LoginThrottleRepository)
+		db := ctn.GetService("Database").(database.Database)
+		cnf := ctn.GetService("Config").(*Config)
+
+		return handler.NewChangePasswordHandler(val, hasher, repo, throttles, db, cnf.Lockout.User.policy(), cnf.Lockout.IP.policy())
+	})
+
+	ctn.AddService("RequestPasswordResetHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		tokens := ctn.GetService("PasswordResetTokenRepository").(repository.PasswordResetTokenRepository)
+		n := ctn.GetService("Notifier").(notifier.Notifier)
+		cnf := ctn.GetService("Config").(*Config)
+		lifetime := time.Duration(cnf.PasswordReset.ExpiryMinutes) * time.Minute
+
+		return handler.NewRequestPasswordResetHandler(repo, tokens, n, lifetime, cnf.PasswordReset.URL)
+	})
+
+	ctn.AddService("ResetPasswordHandler", func(ctn *core.Container) interface{} {
+		val := ctn.GetService("PasswordValidator").(password.Validator)
+		hasher := ctn.GetService("PasswordHasher").(hashpkg.Hasher)
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		tokens := ctn.GetService("PasswordResetTokenRepository").(repository.PasswordResetTokenRepository)
+		authClient := ctn.GetService("AuthClient").(auth.Client)
+		db := ctn.GetService("Database").(database.Database)
+
+		return handler.NewResetPasswordHandler(val, hasher, repo, tokens, authClient, db)
+	})
+
+	ctn.AddService("EnrolTwoFactorHandler", func(ctn *core.Container) interface{} {
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		twoFactors := ctn.GetService("TwoFactorRepository").(repository.TwoFactorRepository)
+		cnf := ctn.GetService("Config").(*Config)
+
+		return handler.NewEnrolTwoFactorHandler(repo, twoFactors, cnf.TwoFactor.Issuer)
+	})
+
+	ctn.AddService("ConfirmTwoFactorHandler", func(ctn *core.Container) interface{} {
+		twoFactors := ctn.GetService("TwoFactorRepository").(repository.TwoFactorRepository)
+		db := ctn.GetService("Database").(database.Database)
+		cnf := ctn.GetService("Config").(*Config)
+
+		return handler.NewConfirmTwoFactorHandler(twoFactors, db, cnf.TwoFactor.RecoveryCodes)
+	})
+
+	ctn.AddService("DisableTwoFactorHandler", func(ctn *core.Container) interface{} {
+		hasher := ctn.GetService("PasswordHasher").(hashpkg.Hasher)
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		twoFactors := ctn.GetService("TwoFactorRepository").(repository.TwoFactorRepository)
+		throttles := ctn.GetService("LoginThrottleRepository").(repository.LoginThrottleRepository)
+		db := ctn.GetService("Database").(database.Database)
+		cnf := ctn.GetService("Config").(*Config)
+
+		return handler.NewDisableTwoFactorHandler(hasher, repo, twoFactors, throttles, db, cnf.Lockout.User.policy(), cnf.Lockout.IP.policy())
+	})
+
+	ctn.AddService("GetExportHandler", func(ctn *core.Container) interface{} {
+		provider := ctn.GetService("UserProvider").(provider.UserProvider)
+
+		return handler.NewGetExportHandler(provider)
+	})
+
+	ctn.AddService("RequestDeletionHandler", func(ctn *core.Container) interface{} {
+		hasher := ctn.GetService("PasswordHasher").(hashpkg.Hasher)
+		repo := ctn.GetService("UserRepository").(repository.UserRepository)
+		deletions := ctn.GetService("AccountDeletionRepository").(repository.AccountDeletionRepository)
+		throttles := ctn.GetService("LoginThrottleRepository").(repository.LoginThrottleRepository)
+		db := ctn.GetService("Database").(database.Database)
+		cnf := ctn.GetService("Config").(*Config)
+		grace := time.Duration(cnf.Deletion.GraceDays) * 24 * time.Hour
+
+		return handler.NewRequestDeletionHandler(hasher, repo, deletions, throttles, db, cnf.Lockout.User.policy(), cnf.Lockout.IP.policy(), grace)
+	})
+
+	ctn.AddService("CancelDeletionHandler", func(ctn *core.Container) interface{} {
+		deletions := ctn.GetService("AccountDeletionRepository").(repository.AccountDeletionRepository)
+		db := ctn.GetService("Database").(database.Database)
+
+		return handler.NewCancelDeletionHandler(deletions, db)
+	})
+
+	ctn.AddService("GetDeletionHandler", func(ctn *core.Container) interface{} {
+		deletions := ctn.GetService("AccountDeletionRepository").(repository.AccountDeletionRepository)
 
-		return handler.NewUnfollowUserHandler(repo, followers)
+		return handler.NewGetDeletionHandler(deletions)
 	})
 
 	return ctn
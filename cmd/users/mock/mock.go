@@ -1,6 +1,16 @@
 //go:generate mockgen -package=mock -source=../password/validator.go -destination=validator.go
+//go:generate mockgen -package=mock -source=../password/hasher.go -destination=hasher.go
 //go:generate mockgen -package=repository -source=../repository/user_repository.go -destination=repository/user_repository.go
 //go:generate mockgen -package=repository -source=../repository/follower_repository.go -destination=repository/follower_repository.go
+//go:generate mockgen -package=repository -source=../repository/follow_request_repository.go -destination=repository/follow_request_repository.go
+//go:generate mockgen -package=repository -source=../repository/block_repository.go -destination=repository/block_repository.go
+//go:generate mockgen -package=repository -source=../repository/mute_repository.go -destination=repository/mute_repository.go
+//go:generate mockgen -package=repository -source=../repository/login_throttle_repository.go -destination=repository/login_throttle_repository.go
+//go:generate mockgen -package=repository -source=../repository/password_reset_token_repository.go -destination=repository/password_reset_token_repository.go
+//go:generate mockgen -package=repository -source=../repository/two_factor_repository.go -destination=repository/two_factor_repository.go
+//go:generate mockgen -package=repository -source=../repository/login_challenge_repository.go -destination=repository/login_challenge_repository.go
+//go:generate mockgen -package=repository -source=../repository/account_deletion_repository.go -destination=repository/account_deletion_repository.go
+//go:generate mockgen -package=mock -source=../notifier/notifier.go -destination=notifier/notifier.go
 //go:generate mockgen -package=mock -source=../provider/user_provider.go -destination=provider/user_provider.go
 
 package mock
@@ -8,6 +8,7 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	dto "github.com/reecerussell/open-social/cmd/users/dto"
+	pagination "github.com/reecerussell/open-social/cmd/users/pagination"
 	reflect "reflect"
 )
 
@@ -63,3 +64,78 @@ func (mr *MockUserProviderMockRecorder) GetInfo(ctx, userReferenceID interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInfo", reflect.TypeOf((*MockUserProvider)(nil).GetInfo), ctx, userReferenceID)
 }
+
+// GetFollowers mocks base method.
+func (m *MockUserProvider) GetFollowers(ctx context.Context, username, userReferenceID string, page *pagination.Page) (*dto.FollowList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowers", ctx, username, userReferenceID, page)
+	ret0, _ := ret[0].(*dto.FollowList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowers indicates an expected call of GetFollowers.
+func (mr *MockUserProviderMockRecorder) GetFollowers(ctx, username, userReferenceID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowers", reflect.TypeOf((*MockUserProvider)(nil).GetFollowers), ctx, username, userReferenceID, page)
+}
+
+// GetFollowing mocks base method.
+func (m *MockUserProvider) GetFollowing(ctx context.Context, username, userReferenceID string, page *pagination.Page) (*dto.FollowList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowing", ctx, username, userReferenceID, page)
+	ret0, _ := ret[0].(*dto.FollowList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowing indicates an expected call of GetFollowing.
+func (mr *MockUserProviderMockRecorder) GetFollowing(ctx, username, userReferenceID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowing", reflect.TypeOf((*MockUserProvider)(nil).GetFollowing), ctx, username, userReferenceID, page)
+}
+
+// GetFollowRequests mocks base method.
+func (m *MockUserProvider) GetFollowRequests(ctx context.Context, userReferenceID string, page *pagination.Page) (*dto.FollowList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowRequests", ctx, userReferenceID, page)
+	ret0, _ := ret[0].(*dto.FollowList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowRequests indicates an expected call of GetFollowRequests.
+func (mr *MockUserProviderMockRecorder) GetFollowRequests(ctx, userReferenceID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowRequests", reflect.TypeOf((*MockUserProvider)(nil).GetFollowRequests), ctx, userReferenceID, page)
+}
+
+// GetBlockedUsers mocks base method.
+func (m *MockUserProvider) GetBlockedUsers(ctx context.Context, userReferenceID string, page *pagination.Page) (*dto.FollowList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockedUsers", ctx, userReferenceID, page)
+	ret0, _ := ret[0].(*dto.FollowList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockedUsers indicates an expected call of GetBlockedUsers.
+func (mr *MockUserProviderMockRecorder) GetBlockedUsers(ctx, userReferenceID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockedUsers", reflect.TypeOf((*MockUserProvider)(nil).GetBlockedUsers), ctx, userReferenceID, page)
+}
+
+// GetExport mocks base method.
+func (m *MockUserProvider) GetExport(ctx context.Context, userReferenceID string) (*dto.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, userReferenceID)
+	ret0, _ := ret[0].(*dto.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockUserProviderMockRecorder) GetExport(ctx, userReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockUserProvider)(nil).GetExport), ctx, userReferenceID)
+}
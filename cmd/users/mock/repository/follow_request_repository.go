@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/follow_request_repository.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockFollowRequestRepository is a mock of FollowRequestRepository interface.
+type MockFollowRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFollowRequestRepositoryMockRecorder
+}
+
+// MockFollowRequestRepositoryMockRecorder is the mock recorder for MockFollowRequestRepository.
+type MockFollowRequestRepositoryMockRecorder struct {
+	mock *MockFollowRequestRepository
+}
+
+// NewMockFollowRequestRepository creates a new mock instance.
+func NewMockFollowRequestRepository(ctrl *gomock.Controller) *MockFollowRequestRepository {
+	mock := &MockFollowRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockFollowRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFollowRequestRepository) EXPECT() *MockFollowRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFollowRequestRepository) Create(ctx context.Context, userID int, followerReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, followerReferenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFollowRequestRepositoryMockRecorder) Create(ctx, userID, followerReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFollowRequestRepository)(nil).Create), ctx, userID, followerReferenceID)
+}
+
+// Delete mocks base method.
+func (m *MockFollowRequestRepository) Delete(ctx context.Context, userID int, followerReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, followerReferenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFollowRequestRepositoryMockRecorder) Delete(ctx, userID, followerReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFollowRequestRepository)(nil).Delete), ctx, userID, followerReferenceID)
+}
@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/mute_repository.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockMuteRepository is a mock of MuteRepository interface.
+type MockMuteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMuteRepositoryMockRecorder
+}
+
+// MockMuteRepositoryMockRecorder is the mock recorder for MockMuteRepository.
+type MockMuteRepositoryMockRecorder struct {
+	mock *MockMuteRepository
+}
+
+// NewMockMuteRepository creates a new mock instance.
+func NewMockMuteRepository(ctrl *gomock.Controller) *MockMuteRepository {
+	mock := &MockMuteRepository{ctrl: ctrl}
+	mock.recorder = &MockMuteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMuteRepository) EXPECT() *MockMuteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMuteRepository) Create(ctx context.Context, userID int, muterReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, muterReferenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMuteRepositoryMockRecorder) Create(ctx, userID, muterReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMuteRepository)(nil).Create), ctx, userID, muterReferenceID)
+}
+
+// Delete mocks base method.
+func (m *MockMuteRepository) Delete(ctx context.Context, userID int, muterReferenceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, muterReferenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMuteRepositoryMockRecorder) Delete(ctx, userID, muterReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMuteRepository)(nil).Delete), ctx, userID, muterReferenceID)
+}
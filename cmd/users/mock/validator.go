@@ -33,15 +33,15 @@ func (m *MockValidator) EXPECT() *MockValidatorMockRecorder {
 }
 
 // Validate mocks base method.
-func (m *MockValidator) Validate(password string) error {
+func (m *MockValidator) Validate(password, username string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Validate", password)
+	ret := m.ctrl.Call(m, "Validate", password, username)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Validate indicates an expected call of Validate.
-func (mr *MockValidatorMockRecorder) Validate(password interface{}) *gomock.Call {
+func (mr *MockValidatorMockRecorder) Validate(password, username interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockValidator)(nil).Validate), password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockValidator)(nil).Validate), password, username)
 }
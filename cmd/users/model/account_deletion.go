@@ -0,0 +1,221 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+)
+
+// maxDeletionBackoff is the longest a failed account deletion waits before it is retried.
+const maxDeletionBackoff = 24 * time.Hour
+
+// Account deletion statuses.
+const (
+	DeletionScheduled  = "scheduled"
+	DeletionInProgress = "inProgress"
+	DeletionCancelled  = "cancelled"
+	DeletionCompleted  = "completed"
+)
+
+// Account deletion errors.
+var (
+	ErrDeletionStarted   = errors.New("account deletion has already started")
+	ErrDeletionCancelled = errors.New("account deletion has already been cancelled")
+)
+
+// AccountDeletion is a domain model for a job which deletes a user's account, and all
+// of their data, once the grace period has passed. Until then, the deletion can be
+// cancelled. Each attempt runs every step of the deletion, which must be idempotent,
+// so a partially failed deletion can be retried.
+type AccountDeletion struct {
+	id              int64
+	userID          int
+	userReferenceID string
+	requested       time.Time
+	scheduled       time.Time
+	cancelled       *time.Time
+	completed       *time.Time
+	attempts        int
+	nextAttempt     time.Time
+	lastError       *string
+	mediaIDs        []string
+}
+
+// NewAccountDeletion returns a new AccountDeletion for the user, scheduled after the given grace period.
+func NewAccountDeletion(user *User, grace time.Duration) *AccountDeletion {
+	now := time.Now().UTC()
+	scheduled := now.Add(grace)
+
+	return &AccountDeletion{
+		userID:          user.ID(),
+		userReferenceID: user.ReferenceID(),
+		requested:       now,
+		scheduled:       scheduled,
+		nextAttempt:     scheduled,
+		mediaIDs:        []string{},
+	}
+}
+
+// AccountDeletionFromDao returns a new instance of AccountDeletion, populated with
+// data from the data access object. This should only be used by the repository.
+func AccountDeletionFromDao(d *dao.AccountDeletion) (*AccountDeletion, error) {
+	mediaIDs := []string{}
+	if d.MediaIDs != "" {
+		err := json.Unmarshal([]byte(d.MediaIDs), &mediaIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &AccountDeletion{
+		id:              d.ID,
+		userID:          d.UserID,
+		userReferenceID: d.UserReferenceID,
+		requested:       d.Requested,
+		scheduled:       d.Scheduled,
+		cancelled:       d.Cancelled,
+		completed:       d.Completed,
+		attempts:        d.Attempts,
+		nextAttempt:     d.NextAttempt,
+		lastError:       d.LastError,
+		mediaIDs:        mediaIDs,
+	}, nil
+}
+
+// UserReferenceID returns the reference id of the user being deleted.
+func (d *AccountDeletion) UserReferenceID() string {
+	return d.userReferenceID
+}
+
+// Requested returns the time the deletion was requested.
+func (d *AccountDeletion) Requested() time.Time {
+	return d.requested
+}
+
+// Scheduled returns the time the grace period ends, and the deletion can start.
+func (d *AccountDeletion) Scheduled() time.Time {
+	return d.scheduled
+}
+
+// Attempts returns the number of times the deletion has been attempted.
+func (d *AccountDeletion) Attempts() int {
+	return d.attempts
+}
+
+// LastError returns the error the last attempt failed with, if any.
+func (d *AccountDeletion) LastError() *string {
+	return d.lastError
+}
+
+// MediaIDs returns the reference ids of the user's media, which is
+// collected as their data is deleted, and deleted last.
+func (d *AccountDeletion) MediaIDs() []string {
+	return d.mediaIDs
+}
+
+// Status returns the status of the deletion.
+func (d *AccountDeletion) Status() string {
+	switch {
+	case d.cancelled != nil:
+		return DeletionCancelled
+	case d.completed != nil:
+		return DeletionCompleted
+	case d.attempts > 0:
+		return DeletionInProgress
+	default:
+		return DeletionScheduled
+	}
+}
+
+// IsActive determines if the deletion is yet to be cancelled or completed.
+func (d *AccountDeletion) IsActive() bool {
+	return d.cancelled == nil && d.completed == nil
+}
+
+// Cancel cancels the deletion. An error is returned if the deletion has
+// already been cancelled, or has started, as it can't be undone.
+func (d *AccountDeletion) Cancel() error {
+	if d.cancelled != nil {
+		return ErrDeletionCancelled
+	}
+
+	now := time.Now().UTC()
+	if d.completed != nil || d.attempts > 0 || !now.Before(d.scheduled) {
+		return ErrDeletionStarted
+	}
+
+	d.cancelled = &now
+
+	return nil
+}
+
+// AddMedia records the reference ids of some of the user's media, to be deleted.
+func (d *AccountDeletion) AddMedia(ids ...string) {
+	for _, id := range ids {
+		if !d.hasMedia(id) {
+			d.mediaIDs = append(d.mediaIDs, id)
+		}
+	}
+}
+
+func (d *AccountDeletion) hasMedia(id string) bool {
+	for _, m := range d.mediaIDs {
+		if m == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Fail records an attempt's error, and schedules the next attempt, backing off
+// exponentially from retry with each attempt, up to maxDeletionBackoff.
+func (d *AccountDeletion) Fail(err error, retry time.Duration) {
+	msg := err.Error()
+	d.lastError = &msg
+
+	backoff := retry
+	for i := 1; i < d.attempts && backoff < maxDeletionBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxDeletionBackoff {
+		backoff = maxDeletionBackoff
+	}
+
+	d.nextAttempt = time.Now().UTC().Add(backoff)
+}
+
+// Complete marks the deletion as completed.
+func (d *AccountDeletion) Complete() {
+	now := time.Now().UTC()
+	d.completed = &now
+	d.lastError = nil
+}
+
+// Dao returns a data access object for the deletion.
+func (d *AccountDeletion) Dao() *dao.AccountDeletion {
+	mediaIDs, _ := json.Marshal(d.mediaIDs)
+
+	return &dao.AccountDeletion{
+		ID:              d.id,
+		UserID:          d.userID,
+		UserReferenceID: d.userReferenceID,
+		Requested:       d.requested,
+		Scheduled:       d.scheduled,
+		Cancelled:       d.cancelled,
+		Completed:       d.completed,
+		Attempts:        d.attempts,
+		NextAttempt:     d.nextAttempt,
+		LastError:       d.lastError,
+		MediaIDs:        string(mediaIDs),
+	}
+}
+
+// SetID sets the id of the deletion. This should only
+// be used in the repository when saving a deletion.
+func (d *AccountDeletion) SetID(id int64) {
+	d.id = id
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+)
+
+func TestNewAccountDeletion(t *testing.T) {
+	user := NewUserFromDao(&dao.User{ID: 1, ReferenceID: "123"})
+	d := NewAccountDeletion(user, time.Hour)
+	assert.Equal(t, "123", d.UserReferenceID())
+	assert.Equal(t, time.Hour, d.Scheduled().Sub(d.Requested()))
+	assert.Equal(t, DeletionScheduled, d.Status())
+	assert.True(t, d.IsActive())
+
+	data := d.Dao()
+	assert.Equal(t, 1, data.UserID)
+	assert.Equal(t, d.Scheduled(), data.NextAttempt)
+	assert.Equal(t, "[]", data.MediaIDs)
+}
+
+func TestAccountDeletion_Cancel(t *testing.T) {
+	user := NewUserFromDao(&dao.User{ID: 1, ReferenceID: "123"})
+	d := NewAccountDeletion(user, time.Hour)
+
+	assert.NoError(t, d.Cancel())
+	assert.Equal(t, DeletionCancelled, d.Status())
+	assert.False(t, d.IsActive())
+	assert.Equal(t, ErrDeletionCancelled, d.Cancel())
+}
+
+func TestAccountDeletion_CancelAfterGracePeriod_ReturnsError(t *testing.T) {
+	d, _ := AccountDeletionFromDao(&dao.AccountDeletion{
+		Scheduled: time.Now().UTC().Add(-time.Second),
+	})
+
+	assert.Equal(t, ErrDeletionStarted, d.Cancel())
+	assert.Nil(t, d.Dao().Cancelled)
+}
+
+func TestAccountDeletion_CancelStarted_ReturnsError(t *testing.T) {
+	d, _ := AccountDeletionFromDao(&dao.AccountDeletion{
+		Scheduled: time.Now().UTC().Add(time.Hour),
+		Attempts:  1,
+	})
+
+	assert.Equal(t, DeletionInProgress, d.Status())
+	assert.Equal(t, ErrDeletionStarted, d.Cancel())
+}
+
+func TestAccountDeletion_AddMedia_IgnoresDuplicates(t *testing.T) {
+	d, err := AccountDeletionFromDao(&dao.AccountDeletion{MediaIDs: `["1"]`})
+	assert.NoError(t, err)
+
+	d.AddMedia("1", "2", "2")
+	assert.Equal(t, []string{"1", "2"}, d.MediaIDs())
+	assert.Equal(t, `["1","2"]`, d.Dao().MediaIDs)
+}
+
+func TestAccountDeletionFromDao_WithInvalidMediaIDs_ReturnsError(t *testing.T) {
+	d, err := AccountDeletionFromDao(&dao.AccountDeletion{MediaIDs: "1,2"})
+	assert.Nil(t, d)
+	assert.Error(t, err)
+}
+
+func TestAccountDeletion_Fail_BacksOff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		backoff  time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{4, 8 * time.Minute},
+		{20, maxDeletionBackoff},
+	}
+
+	for _, test := range tests {
+		d, _ := AccountDeletionFromDao(&dao.AccountDeletion{Attempts: test.attempts})
+		d.Fail(errors.New("an error occured"), time.Minute)
+
+		data := d.Dao()
+		assert.Equal(t, "an error occured", *data.LastError)
+		assert.WithinDuration(t, time.Now().UTC().Add(test.backoff), data.NextAttempt, time.Second)
+	}
+}
+
+func TestAccountDeletion_Complete(t *testing.T) {
+	d, _ := AccountDeletionFromDao(&dao.AccountDeletion{Attempts: 2})
+	d.Fail(errors.New("an error occured"), time.Minute)
+	d.Complete()
+
+	assert.Equal(t, DeletionCompleted, d.Status())
+	assert.False(t, d.IsActive())
+	assert.Nil(t, d.LastError())
+}
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+)
+
+const (
+	// loginChallengeTokenSize is the number of random bytes in a login challenge token.
+	loginChallengeTokenSize = 32
+
+	// MaxChallengeAttempts is the number of codes which can be tried against a login challenge.
+	MaxChallengeAttempts = 5
+)
+
+// ErrChallengeExpired is returned when a login challenge can no longer be used.
+var ErrChallengeExpired = errors.New("login challenge has expired, please log in again")
+
+// LoginChallenge is a domain model for the second step of logging in as a user with
+// two-factor authentication. It's issued once their password has been verified, and
+// is exchanged, along with a code, for their claims. Each challenge can only be
+// completed once, with a limited number of attempts, before it expires. Only a
+// hash of the challenge's token is persisted.
+type LoginChallenge struct {
+	id              int64
+	token           string
+	tokenHash       string
+	userID          int
+	userReferenceID string
+	created         time.Time
+	expires         time.Time
+	attempts        int
+	used            *time.Time
+}
+
+// NewLoginChallenge returns a new LoginChallenge for the user, which expires after the given lifetime.
+func NewLoginChallenge(user *User, lifetime time.Duration) (*LoginChallenge, error) {
+	data := make([]byte, loginChallengeTokenSize)
+	_, err := rand.Read(data)
+	if err != nil {
+		return nil, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(data)
+	now := time.Now().UTC()
+
+	return &LoginChallenge{
+		token:           token,
+		tokenHash:       HashLoginChallengeToken(token),
+		userID:          user.ID(),
+		userReferenceID: user.ReferenceID(),
+		created:         now,
+		expires:         now.Add(lifetime),
+	}, nil
+}
+
+// LoginChallengeFromDao returns a new instance of LoginChallenge, populated with data
+// from the data access object. This should only be used by the repository.
+func LoginChallengeFromDao(c *dao.LoginChallenge) *LoginChallenge {
+	return &LoginChallenge{
+		id:              c.ID,
+		tokenHash:       c.TokenHash,
+		userID:          c.UserID,
+		userReferenceID: c.UserReferenceID,
+		created:         c.Created,
+		expires:         c.Expires,
+		attempts:        c.Attempts,
+		used:            c.Used,
+	}
+}
+
+// HashLoginChallengeToken returns the hash of a login challenge token, used to look it up.
+func HashLoginChallengeToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// Token returns the challenge's token. This is only available for newly
+// issued challenges, as the value itself is never persisted.
+func (c *LoginChallenge) Token() string {
+	return c.token
+}
+
+// UserReferenceID returns the reference id of the user the challenge was issued to.
+func (c *LoginChallenge) UserReferenceID() string {
+	return c.userReferenceID
+}
+
+// Expires returns the time the challenge expires.
+func (c *LoginChallenge) Expires() time.Time {
+	return c.expires
+}
+
+// Check returns ErrChallengeExpired if the challenge has expired, has already
+// been completed, or has had too many failed attempts.
+func (c *LoginChallenge) Check(now time.Time) error {
+	if c.used != nil || c.attempts >= MaxChallengeAttempts || !now.Before(c.expires) {
+		return ErrChallengeExpired
+	}
+
+	return nil
+}
+
+// RecordFailure records a failed attempt at the challenge.
+func (c *LoginChallenge) RecordFailure() {
+	c.attempts++
+}
+
+// Complete marks the challenge as used, once a code has been verified.
+func (c *LoginChallenge) Complete(now time.Time) {
+	c.used = &now
+}
+
+// Dao returns a data access object for the challenge.
+func (c *LoginChallenge) Dao() *dao.LoginChallenge {
+	return &dao.LoginChallenge{
+		ID:              c.id,
+		TokenHash:       c.tokenHash,
+		UserID:          c.userID,
+		UserReferenceID: c.userReferenceID,
+		Created:         c.created,
+		Expires:         c.expires,
+		Attempts:        c.attempts,
+		Used:            c.used,
+	}
+}
+
+// SetID sets the id of the challenge. This should only
+// be used in the repository when creating a challenge.
+func (c *LoginChallenge) SetID(id int64) {
+	c.id = id
+}
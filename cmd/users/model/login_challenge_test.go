@@ -0,0 +1,44 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+)
+
+func TestLoginChallenge_Complete(t *testing.T) {
+	user := NewUserFromDao(&dao.User{ID: 1, ReferenceID: "123"})
+	challenge, err := NewLoginChallenge(user, time.Minute)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, challenge.Token())
+	assert.Equal(t, "123", challenge.UserReferenceID())
+	assert.Equal(t, HashLoginChallengeToken(challenge.Token()), challenge.Dao().TokenHash)
+
+	now := time.Now().UTC()
+	assert.NoError(t, challenge.Check(now))
+
+	challenge.Complete(now)
+	assert.Equal(t, ErrChallengeExpired, challenge.Check(now))
+}
+
+func TestLoginChallenge_Check_TooManyAttempts_ReturnsError(t *testing.T) {
+	now := time.Now().UTC()
+	challenge := LoginChallengeFromDao(&dao.LoginChallenge{Expires: now.Add(time.Minute)})
+
+	for i := 0; i < MaxChallengeAttempts; i++ {
+		assert.NoError(t, challenge.Check(now))
+		challenge.RecordFailure()
+	}
+
+	assert.Equal(t, ErrChallengeExpired, challenge.Check(now))
+}
+
+func TestLoginChallenge_Check_Expired_ReturnsError(t *testing.T) {
+	now := time.Now().UTC()
+	challenge := LoginChallengeFromDao(&dao.LoginChallenge{Expires: now})
+
+	assert.Equal(t, ErrChallengeExpired, challenge.Check(now))
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+)
+
+// Lockout errors.
+var (
+	ErrAccountLocked   = errors.New("account is temporarily locked due to too many failed login attempts")
+	ErrTooManyAttempts = errors.New("too many failed login attempts, try again later")
+)
+
+// LockoutPolicy determines when failed login attempts lock out a user or IP address.
+type LockoutPolicy struct {
+	// MaxAttempts is the number of failed attempts allowed within the Window.
+	MaxAttempts int
+
+	// Window is the period failed attempts are counted over, since the last failure.
+	Window time.Duration
+
+	// Lockout is the length of the first lockout, which doubles with each
+	// consecutive lockout, up to MaxLockout.
+	Lockout    time.Duration
+	MaxLockout time.Duration
+}
+
+// duration returns the length of a lockout, given the number of previous lockouts.
+func (p LockoutPolicy) duration(lockouts int) time.Duration {
+	d := p.Lockout
+	for i := 0; i < lockouts; i++ {
+		d *= 2
+		if d >= p.MaxLockout {
+			return p.MaxLockout
+		}
+	}
+
+	return d
+}
+
+// UserThrottleKey returns the key of the login throttle for the user.
+func UserThrottleKey(userReferenceID string) string {
+	return "user:" + userReferenceID
+}
+
+// IPThrottleKey returns the key of the login throttle for the IP address.
+func IPThrottleKey(ipAddress string) string {
+	return "ip:" + ipAddress
+}
+
+// LoginThrottle is a domain model used to track failed login attempts for a
+// user or IP address, identified by its key.
+type LoginThrottle struct {
+	key         string
+	failures    int
+	lockouts    int
+	lastFailure *time.Time
+	lockedUntil *time.Time
+}
+
+// NewLoginThrottle returns a new LoginThrottle for the key, with no failed attempts.
+func NewLoginThrottle(key string) *LoginThrottle {
+	return &LoginThrottle{key: key}
+}
+
+// LoginThrottleFromDao returns a new instance of LoginThrottle, populated with
+// data from the data access object. This should only be used by the repository.
+func LoginThrottleFromDao(t *dao.LoginThrottle) *LoginThrottle {
+	return &LoginThrottle{
+		key:         t.Key,
+		failures:    t.Failures,
+		lockouts:    t.Lockouts,
+		lastFailure: t.LastFailure,
+		lockedUntil: t.LockedUntil,
+	}
+}
+
+// Key returns the throttle's key.
+func (t *LoginThrottle) Key() string {
+	return t.key
+}
+
+// LockedUntil returns the time the lockout ends, if the throttle is locked at the given time.
+func (t *LoginThrottle) LockedUntil(now time.Time) (time.Time, bool) {
+	if t.lockedUntil == nil || !now.Before(*t.lockedUntil) {
+		return time.Time{}, false
+	}
+
+	return *t.lockedUntil, true
+}
+
+// IsClear returns true if the throttle has no failed attempts or lockouts to reset.
+func (t *LoginThrottle) IsClear() bool {
+	return t.failures == 0 && t.lockouts == 0 && t.lockedUntil == nil
+}
+
+// RecordFailure records a failed login attempt at the given time, locking the
+// throttle once the policy's MaxAttempts have been made within its Window.
+// It returns true if the failure caused a lockout.
+func (t *LoginThrottle) RecordFailure(now time.Time, policy LockoutPolicy) bool {
+	if t.lastFailure != nil && now.Sub(*t.lastFailure) > policy.Window {
+		t.failures = 0
+	}
+
+	t.failures++
+	t.lastFailure = &now
+
+	if t.failures < policy.MaxAttempts {
+		return false
+	}
+
+	until := now.Add(policy.duration(t.lockouts))
+	t.lockedUntil = &until
+	t.lockouts++
+	t.failures = 0
+
+	return true
+}
+
+// Dao returns a data access object for the throttle.
+func (t *LoginThrottle) Dao() *dao.LoginThrottle {
+	return &dao.LoginThrottle{
+		Key:         t.key,
+		Failures:    t.failures,
+		Lockouts:    t.lockouts,
+		LastFailure: t.lastFailure,
+		LockedUntil: t.lockedUntil,
+	}
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testLockoutPolicy = LockoutPolicy{
+	MaxAttempts: 3,
+	Window:      15 * time.Minute,
+	Lockout:     time.Minute,
+	MaxLockout:  5 * time.Minute,
+}
+
+func TestLoginThrottle_RecordFailure_LocksAfterMaxAttempts(t *testing.T) {
+	now := time.Now().UTC()
+	throttle := NewLoginThrottle(UserThrottleKey("123"))
+
+	assert.False(t, throttle.RecordFailure(now, testLockoutPolicy))
+	assert.False(t, throttle.RecordFailure(now, testLockoutPolicy))
+
+	_, locked := throttle.LockedUntil(now)
+	assert.False(t, locked)
+
+	assert.True(t, throttle.RecordFailure(now, testLockoutPolicy))
+
+	until, locked := throttle.LockedUntil(now)
+	assert.True(t, locked)
+	assert.Equal(t, now.Add(time.Minute), until)
+
+	_, locked = throttle.LockedUntil(until)
+	assert.False(t, locked)
+}
+
+func TestLoginThrottle_RecordFailure_BacksOffExponentially(t *testing.T) {
+	now := time.Now().UTC()
+	throttle := NewLoginThrottle(UserThrottleKey("123"))
+
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	for _, d := range expected {
+		for i := 0; i < testLockoutPolicy.MaxAttempts; i++ {
+			throttle.RecordFailure(now, testLockoutPolicy)
+		}
+
+		until, locked := throttle.LockedUntil(now)
+		assert.True(t, locked)
+		assert.Equal(t, now.Add(d), until)
+
+		now = until
+	}
+}
+
+func TestLoginThrottle_RecordFailure_ResetsFailuresOutsideWindow(t *testing.T) {
+	now := time.Now().UTC()
+	throttle := NewLoginThrottle(IPThrottleKey("10.0.0.1"))
+
+	throttle.RecordFailure(now, testLockoutPolicy)
+	throttle.RecordFailure(now, testLockoutPolicy)
+
+	now = now.Add(testLockoutPolicy.Window + time.Second)
+	assert.False(t, throttle.RecordFailure(now, testLockoutPolicy))
+	assert.Equal(t, 1, throttle.Dao().Failures)
+}
+
+func TestLoginThrottle_IsClear(t *testing.T) {
+	throttle := NewLoginThrottle(UserThrottleKey("123"))
+	assert.True(t, throttle.IsClear())
+
+	throttle.RecordFailure(time.Now().UTC(), testLockoutPolicy)
+	assert.False(t, throttle.IsClear())
+}
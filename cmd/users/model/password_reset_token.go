@@ -0,0 +1,128 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+)
+
+// passwordResetTokenSize is the number of random bytes in a password reset token.
+const passwordResetTokenSize = 32
+
+// Password reset token errors.
+var (
+	ErrResetTokenExpired = errors.New("password reset token has expired")
+	ErrResetTokenUsed    = errors.New("password reset token has already been used")
+)
+
+// PasswordResetToken is a domain model for a token sent to a user, allowing
+// them to set a new password without their current one. Each token can only
+// be used once, before it expires. Only a hash of the token is persisted.
+type PasswordResetToken struct {
+	id              int64
+	token           string
+	tokenHash       string
+	userID          int
+	userReferenceID string
+	created         time.Time
+	expires         time.Time
+	used            *time.Time
+}
+
+// NewPasswordResetToken returns a new PasswordResetToken for the user, which expires after the given lifetime.
+func NewPasswordResetToken(user *User, lifetime time.Duration) (*PasswordResetToken, error) {
+	data := make([]byte, passwordResetTokenSize)
+	_, err := rand.Read(data)
+	if err != nil {
+		return nil, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(data)
+	now := time.Now().UTC()
+
+	return &PasswordResetToken{
+		token:           token,
+		tokenHash:       HashPasswordResetToken(token),
+		userID:          user.ID(),
+		userReferenceID: user.ReferenceID(),
+		created:         now,
+		expires:         now.Add(lifetime),
+	}, nil
+}
+
+// PasswordResetTokenFromDao returns a new instance of PasswordResetToken, populated with
+// data from the data access object. This should only be used by the repository.
+func PasswordResetTokenFromDao(t *dao.PasswordResetToken) *PasswordResetToken {
+	return &PasswordResetToken{
+		id:              t.ID,
+		tokenHash:       t.TokenHash,
+		userID:          t.UserID,
+		userReferenceID: t.UserReferenceID,
+		created:         t.Created,
+		expires:         t.Expires,
+		used:            t.Used,
+	}
+}
+
+// HashPasswordResetToken returns the hash of a password reset token, used to look it up.
+func HashPasswordResetToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// Token returns the token's value. This is only available for newly
+// issued tokens, as the value itself is never persisted.
+func (t *PasswordResetToken) Token() string {
+	return t.token
+}
+
+// UserReferenceID returns the reference id of the user the token was issued to.
+func (t *PasswordResetToken) UserReferenceID() string {
+	return t.userReferenceID
+}
+
+// Expires returns the time the token expires.
+func (t *PasswordResetToken) Expires() time.Time {
+	return t.expires
+}
+
+// Use marks the token as used. An error is returned if the token has
+// expired or has already been used.
+func (t *PasswordResetToken) Use() error {
+	if t.used != nil {
+		return ErrResetTokenUsed
+	}
+
+	now := time.Now().UTC()
+	if !now.Before(t.expires) {
+		return ErrResetTokenExpired
+	}
+
+	t.used = &now
+
+	return nil
+}
+
+// Dao returns a data access object for the token.
+func (t *PasswordResetToken) Dao() *dao.PasswordResetToken {
+	return &dao.PasswordResetToken{
+		ID:              t.id,
+		TokenHash:       t.tokenHash,
+		UserID:          t.userID,
+		UserReferenceID: t.userReferenceID,
+		Created:         t.created,
+		Expires:         t.expires,
+		Used:            t.used,
+	}
+}
+
+// SetID sets the id of the token. This should only
+// be used in the repository when creating a token.
+func (t *PasswordResetToken) SetID(id int64) {
+	t.id = id
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+)
+
+func TestPasswordResetToken_Use(t *testing.T) {
+	user := NewUserFromDao(&dao.User{ID: 1, ReferenceID: "123"})
+	token, err := NewPasswordResetToken(user, time.Hour)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token.Token())
+	assert.Equal(t, "123", token.UserReferenceID())
+	assert.Equal(t, HashPasswordResetToken(token.Token()), token.Dao().TokenHash)
+
+	assert.NoError(t, token.Use())
+	assert.Equal(t, ErrResetTokenUsed, token.Use())
+}
+
+func TestPasswordResetToken_UseExpired_ReturnsError(t *testing.T) {
+	token := PasswordResetTokenFromDao(&dao.PasswordResetToken{
+		Expires: time.Now().UTC().Add(-time.Second),
+	})
+
+	assert.Equal(t, ErrResetTokenExpired, token.Use())
+	assert.Nil(t, token.Dao().Used)
+}
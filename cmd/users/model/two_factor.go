@@ -0,0 +1,246 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/totp"
+)
+
+// recoveryCodeSize is the number of random bytes in a recovery code.
+const recoveryCodeSize = 10
+
+// Two-factor authentication errors.
+var (
+	ErrTwoFactorEnabled    = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled = errors.New("two-factor authentication is not enabled")
+	ErrInvalidCode         = errors.New("code is invalid")
+	ErrRecoveryCodeUsed    = errors.New("recovery code has already been used")
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactor is a domain model for a user's time-based one-time password (TOTP)
+// authenticator. It's created when the user starts enrolling, but isn't enabled
+// until they've confirmed it with their first code.
+type TwoFactor struct {
+	id              int
+	userID          int
+	userReferenceID string
+	secret          string
+	enabled         bool
+	lastUsedStep    int64
+	created         time.Time
+}
+
+// NewTwoFactor returns a new, unconfirmed TwoFactor for the user, with a new secret.
+func NewTwoFactor(user *User) (*TwoFactor, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactor{
+		userID:          user.ID(),
+		userReferenceID: user.ReferenceID(),
+		secret:          secret,
+		created:         time.Now().UTC(),
+	}, nil
+}
+
+// TwoFactorFromDao returns a new instance of TwoFactor, populated with data
+// from the data access object. This should only be used by the repository.
+func TwoFactorFromDao(t *dao.TwoFactor) *TwoFactor {
+	return &TwoFactor{
+		id:              t.ID,
+		userID:          t.UserID,
+		userReferenceID: t.UserReferenceID,
+		secret:          t.Secret,
+		enabled:         t.Enabled,
+		lastUsedStep:    t.LastUsedStep,
+		created:         t.Created,
+	}
+}
+
+// Secret returns the TOTP secret, encoded as base32.
+func (t *TwoFactor) Secret() string {
+	return t.secret
+}
+
+// URI returns the otpauth:// URI used to add the secret to an authenticator app.
+func (t *TwoFactor) URI(issuer, username string) string {
+	return totp.URI(issuer, username, t.secret)
+}
+
+// Enabled returns true once the authenticator has been confirmed.
+func (t *TwoFactor) Enabled() bool {
+	return t.enabled
+}
+
+// UserReferenceID returns the reference id of the user the authenticator belongs to.
+func (t *TwoFactor) UserReferenceID() string {
+	return t.userReferenceID
+}
+
+// Confirm enables the authenticator, given the first code from it.
+func (t *TwoFactor) Confirm(code string, now time.Time) error {
+	if t.enabled {
+		return ErrTwoFactorEnabled
+	}
+
+	step, ok := totp.Verify(t.secret, code, now, t.lastUsedStep)
+	if !ok {
+		return ErrInvalidCode
+	}
+
+	t.enabled = true
+	t.lastUsedStep = step
+
+	return nil
+}
+
+// VerifyCode verifies a code from the authenticator. Each code can only be used
+// once, so the authenticator must be saved once a code has been verified.
+func (t *TwoFactor) VerifyCode(code string, now time.Time) error {
+	if !t.enabled {
+		return ErrTwoFactorNotEnabled
+	}
+
+	step, ok := totp.Verify(t.secret, code, now, t.lastUsedStep)
+	if !ok {
+		return ErrInvalidCode
+	}
+
+	t.lastUsedStep = step
+
+	return nil
+}
+
+// Dao returns a data access object for the authenticator.
+func (t *TwoFactor) Dao() *dao.TwoFactor {
+	return &dao.TwoFactor{
+		ID:              t.id,
+		UserID:          t.userID,
+		UserReferenceID: t.userReferenceID,
+		Secret:          t.secret,
+		Enabled:         t.enabled,
+		LastUsedStep:    t.lastUsedStep,
+		Created:         t.created,
+	}
+}
+
+// SetID sets the id of the authenticator. This should only
+// be used in the repository when creating an authenticator.
+func (t *TwoFactor) SetID(id int) {
+	t.id = id
+}
+
+// RecoveryCode is a domain model for a single-use code which can be used
+// in place of a TOTP code, should the user lose their authenticator.
+// Only a hash of the code is persisted.
+type RecoveryCode struct {
+	id       int64
+	code     string
+	codeHash string
+	userID   int
+	used     *time.Time
+}
+
+// NewRecoveryCode returns a new RecoveryCode for the authenticator's user.
+func NewRecoveryCode(t *TwoFactor) (*RecoveryCode, error) {
+	data := make([]byte, recoveryCodeSize)
+	_, err := rand.Read(data)
+	if err != nil {
+		return nil, err
+	}
+
+	value := strings.ToLower(recoveryCodeEncoding.EncodeToString(data))
+	code := value[:len(value)/2] + "-" + value[len(value)/2:]
+
+	return &RecoveryCode{
+		code:     code,
+		codeHash: HashRecoveryCode(code),
+		userID:   t.userID,
+	}, nil
+}
+
+// RecoveryCodeFromDao returns a new instance of RecoveryCode, populated with data
+// from the data access object. This should only be used by the repository.
+func RecoveryCodeFromDao(c *dao.RecoveryCode) *RecoveryCode {
+	return &RecoveryCode{
+		id:       c.ID,
+		codeHash: c.CodeHash,
+		userID:   c.UserID,
+		used:     c.Used,
+	}
+}
+
+// HashRecoveryCode returns the hash of a recovery code, used to look it up. Codes
+// are compared regardless of case, hyphens and whitespace, however they're typed.
+func HashRecoveryCode(code string) string {
+	code = strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' || r == '\t' {
+			return -1
+		}
+
+		return r
+	}, strings.ToLower(code))
+
+	hash := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(hash[:])
+}
+
+// IsRecoveryCode returns true if the code isn't a TOTP code, so could be a recovery code.
+func IsRecoveryCode(code string) bool {
+	if len(code) != totp.Digits {
+		return true
+	}
+
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Code returns the code's value. This is only available for newly
+// generated codes, as the value itself is never persisted.
+func (c *RecoveryCode) Code() string {
+	return c.code
+}
+
+// Use marks the code as used. An error is returned if it has already been used.
+func (c *RecoveryCode) Use() error {
+	if c.used != nil {
+		return ErrRecoveryCodeUsed
+	}
+
+	now := time.Now().UTC()
+	c.used = &now
+
+	return nil
+}
+
+// Dao returns a data access object for the code.
+func (c *RecoveryCode) Dao() *dao.RecoveryCode {
+	return &dao.RecoveryCode{
+		ID:       c.id,
+		UserID:   c.userID,
+		CodeHash: c.codeHash,
+		Used:     c.used,
+	}
+}
+
+// SetID sets the id of the code. This should only
+// be used in the repository when creating a code.
+func (c *RecoveryCode) SetID(id int64) {
+	c.id = id
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/totp"
+)
+
+func TestTwoFactor_Confirm(t *testing.T) {
+	user := NewUserFromDao(&dao.User{ID: 1, ReferenceID: "123"})
+	twoFactor, err := NewTwoFactor(user)
+	assert.NoError(t, err)
+	assert.False(t, twoFactor.Enabled())
+
+	now := time.Now()
+	assert.Equal(t, ErrTwoFactorNotEnabled, twoFactor.VerifyCode("123456", now))
+
+	code, _ := totp.Code(twoFactor.Secret(), totp.Step(now))
+	assert.NoError(t, twoFactor.Confirm(code, now))
+	assert.True(t, twoFactor.Enabled())
+	assert.Equal(t, totp.Step(now), twoFactor.Dao().LastUsedStep)
+	assert.Equal(t, ErrTwoFactorEnabled, twoFactor.Confirm(code, now))
+}
+
+func TestTwoFactor_VerifyCode_CannotReuseCode(t *testing.T) {
+	secret, _ := totp.GenerateSecret()
+	twoFactor := TwoFactorFromDao(&dao.TwoFactor{Secret: secret, Enabled: true})
+
+	now := time.Now()
+	code, _ := totp.Code(secret, totp.Step(now))
+	assert.NoError(t, twoFactor.VerifyCode(code, now))
+	assert.Equal(t, ErrInvalidCode, twoFactor.VerifyCode(code, now))
+}
+
+func TestRecoveryCode_Use(t *testing.T) {
+	twoFactor := TwoFactorFromDao(&dao.TwoFactor{UserID: 1})
+	code, err := NewRecoveryCode(twoFactor)
+	assert.NoError(t, err)
+	assert.Len(t, code.Code(), 17)
+	assert.True(t, IsRecoveryCode(code.Code()))
+	assert.Equal(t, HashRecoveryCode(code.Code()), code.Dao().CodeHash)
+
+	assert.NoError(t, code.Use())
+	assert.Equal(t, ErrRecoveryCodeUsed, code.Use())
+}
+
+func TestHashRecoveryCode_IgnoresCaseAndSeparators(t *testing.T) {
+	assert.Equal(t, HashRecoveryCode("abcd2345-efgh6723"), HashRecoveryCode(" ABCD2345 efgh6723"))
+}
+
+func TestIsRecoveryCode(t *testing.T) {
+	assert.False(t, IsRecoveryCode("123456"))
+	assert.True(t, IsRecoveryCode("12345"))
+	assert.True(t, IsRecoveryCode("12345a"))
+}
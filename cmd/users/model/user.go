@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	hashpkg "github.com/reecerussell/adaptive-password-hasher"
 
@@ -17,6 +18,7 @@ const (
 	minUsernameLength = 3
 	maxUsernameLength = 20
 	usernameRegex     = "^[a-z0-9-_.]+$"
+	maxBioLength      = 255
 )
 
 // Common user errors
@@ -30,8 +32,19 @@ type User struct {
 	referenceID  string
 	username     string
 	passwordHash string
-
-	isFollowing bool
+	mediaID      *int
+	bio          *string
+	isPrivate    bool
+
+	// unnormalizedHash is set when the password hash was verified against the
+	// password as given, as it was hashed before passwords were normalized.
+	unnormalizedHash bool
+
+	isFollowing  bool
+	hasRequested bool
+	isBlocking   bool
+	isBlockedBy  bool
+	isMuting     bool
 }
 
 // NewUser constructs a new user domain model.
@@ -61,7 +74,14 @@ func NewUserFromDao(user *dao.User) *User {
 		referenceID:  user.ReferenceID,
 		username:     user.Username,
 		passwordHash: user.PasswordHash,
+		mediaID:      user.MediaID,
+		bio:          user.Bio,
+		isPrivate:    user.IsPrivate,
 		isFollowing:  user.IsFollowing,
+		hasRequested: user.HasRequested,
+		isBlocking:   user.IsBlocking,
+		isBlockedBy:  user.IsBlockedBy,
+		isMuting:     user.IsMuting,
 	}
 }
 
@@ -80,6 +100,48 @@ func (u *User) Username() string {
 	return u.username
 }
 
+// Bio returns the user's bio, or nil if they haven't set one.
+func (u *User) Bio() *string {
+	return u.bio
+}
+
+// MediaID returns the id of the user's avatar, or nil if they haven't set one.
+func (u *User) MediaID() *int {
+	return u.mediaID
+}
+
+// IsPrivate returns true if the user's profile and posts are only visible to
+// their followers, who must have their follow requests approved.
+func (u *User) IsPrivate() bool {
+	return u.isPrivate
+}
+
+// IsFollowing returns true if the requesting user follows this user.
+func (u *User) IsFollowing() bool {
+	return u.isFollowing
+}
+
+// HasRequested returns true if the requesting user has a pending
+// request to follow this user.
+func (u *User) HasRequested() bool {
+	return u.hasRequested
+}
+
+// IsBlocking returns true if the requesting user has blocked this user.
+func (u *User) IsBlocking() bool {
+	return u.isBlocking
+}
+
+// IsBlockedBy returns true if this user has blocked the requesting user.
+func (u *User) IsBlockedBy() bool {
+	return u.isBlockedBy
+}
+
+// IsMuting returns true if the requesting user has muted this user.
+func (u *User) IsMuting() bool {
+	return u.isMuting
+}
+
 // Dao returns a data access object for the user.
 func (u *User) Dao() *dao.User {
 	return &dao.User{
@@ -87,6 +149,9 @@ func (u *User) Dao() *dao.User {
 		ReferenceID:  u.referenceID,
 		Username:     u.username,
 		PasswordHash: u.passwordHash,
+		MediaID:      u.mediaID,
+		Bio:          u.bio,
+		IsPrivate:    u.isPrivate,
 	}
 }
 
@@ -117,29 +182,97 @@ func (u *User) UpdateUsername(username string) error {
 	return nil
 }
 
+// UpdateBio updates the user's bio. Surrounding whitespace is trimmed,
+// and an empty bio clears it.
+func (u *User) UpdateBio(bio string) error {
+	bio = strings.TrimSpace(bio)
+	if bio == "" {
+		u.bio = nil
+		return nil
+	}
+
+	if utf8.RuneCountInString(bio) > maxBioLength {
+		return fmt.Errorf("bio cannot be greater than %d characters long", maxBioLength)
+	}
+
+	u.bio = &bio
+
+	return nil
+}
+
+// SetPrivate sets whether the user's account is private.
+func (u *User) SetPrivate(isPrivate bool) {
+	u.isPrivate = isPrivate
+}
+
+// SetMedia sets the user's avatar to the media with the given id.
+func (u *User) SetMedia(mediaID int) {
+	u.mediaID = &mediaID
+}
+
 // SetPassword validates the user's password, then sets the new password hash.
+// The password is normalized before it's hashed.
 func (u *User) SetPassword(pwd string, val password.Validator, hasher hashpkg.Hasher) error {
-	err := val.Validate(pwd)
+	err := val.Validate(pwd, u.username)
 	if err != nil {
 		return err
 	}
 
-	bytes := hasher.Hash([]byte(pwd))
-	u.passwordHash = base64.StdEncoding.EncodeToString(bytes)
+	u.setPasswordHash(pwd, hasher)
 
 	return nil
 }
 
-// VerifyPassword uses the hasher to verify the given password
-// against the user's password hash.
+// VerifyPassword uses the hasher to verify the given password, once
+// normalized, against the user's password hash.
 func (u *User) VerifyPassword(pwd string, hasher hashpkg.Hasher) error {
 	hashBytes, _ := base64.StdEncoding.DecodeString(u.passwordHash)
-	ok := hasher.Verify([]byte(pwd), hashBytes)
-	if !ok {
-		return ErrInvalidPassword
+	normalized := password.Normalize(pwd)
+	if hasher.Verify([]byte(normalized), hashBytes) {
+		return nil
 	}
 
-	return nil
+	// Hashes made before passwords were normalized can only be verified with
+	// the password as given. These are rehashed once verified.
+	if normalized != pwd && hasher.Verify([]byte(pwd), hashBytes) {
+		u.unnormalizedHash = true
+		return nil
+	}
+
+	return ErrInvalidPassword
+}
+
+// NeedsRehash returns true if the user's password hash was made with
+// different options to the hasher's, or without normalizing the password.
+func (u *User) NeedsRehash(hasher password.Hasher) bool {
+	if u.unnormalizedHash {
+		return true
+	}
+
+	hashBytes, _ := base64.StdEncoding.DecodeString(u.passwordHash)
+	return hasher.NeedsRehash(hashBytes)
+}
+
+// Rehash sets the user's password hash using the hasher's current options.
+// The password isn't validated, so it must have already been verified.
+func (u *User) Rehash(pwd string, hasher hashpkg.Hasher) {
+	u.setPasswordHash(pwd, hasher)
+}
+
+func (u *User) setPasswordHash(pwd string, hasher hashpkg.Hasher) {
+	bytes := hasher.Hash([]byte(password.Normalize(pwd)))
+	u.passwordHash = base64.StdEncoding.EncodeToString(bytes)
+	u.unnormalizedHash = false
+}
+
+// ChangePassword verifies the user's current password, before setting the new one.
+func (u *User) ChangePassword(current, pwd string, val password.Validator, hasher hashpkg.Hasher) error {
+	err := u.VerifyPassword(current, hasher)
+	if err != nil {
+		return err
+	}
+
+	return u.SetPassword(pwd, val, hasher)
 }
 
 // SetID sets the id of the user. This should only
@@ -157,19 +290,53 @@ func (u *User) SetReferenceID(referenceID string) {
 // CanFollow is used to determine wether a user can follow this user or not.
 // An error is returned if the user cannot follow.
 func (u *User) CanFollow() error {
+	if u.isBlocking {
+		return errors.New("user has blocked this user")
+	}
+
+	if u.isBlockedBy {
+		return errors.New("user cannot follow this user")
+	}
+
 	if u.isFollowing {
 		return errors.New("user is already following this user")
 	}
 
+	// Requests left pending when a user made their account public are replaced by the follow.
+	if u.isPrivate && u.hasRequested {
+		return errors.New("user has already requested to follow this user")
+	}
+
 	return nil
 }
 
 // CanUnfollow is used to determine wether a user can unfollow this user or not.
-// An error is returned if the user cannot unfollow.
+// A pending follow request can also be withdrawn by unfollowing. An error is
+// returned if the user cannot unfollow.
 func (u *User) CanUnfollow() error {
-	if !u.isFollowing {
+	if !u.isFollowing && !u.hasRequested {
 		return errors.New("user is not following this user")
 	}
 
 	return nil
 }
+
+// CanBlock is used to determine wether a user can block this user or not.
+// An error is returned if the user cannot block.
+func (u *User) CanBlock() error {
+	if u.isBlocking {
+		return errors.New("user has already blocked this user")
+	}
+
+	return nil
+}
+
+// CanMute is used to determine wether a user can mute this user or not.
+// An error is returned if the user cannot mute.
+func (u *User) CanMute() error {
+	if u.isMuting {
+		return errors.New("user has already muted this user")
+	}
+
+	return nil
+}
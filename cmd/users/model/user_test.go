@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -22,7 +23,7 @@ func TestNewUser(t *testing.T) {
 	const testPassword = "Password123"
 
 	mockValidator := mock.NewMockValidator(ctrl)
-	mockValidator.EXPECT().Validate(testPassword).Return(nil)
+	mockValidator.EXPECT().Validate(testPassword, "john-doe").Return(nil)
 
 	mockHasher := hashermock.NewMockHasher(ctrl)
 	mockHasher.EXPECT().Hash([]byte(testPassword)).Return([]byte(testPassword))
@@ -53,7 +54,7 @@ func TestNewUser_GivenInvalidData_ReturnsError(t *testing.T) {
 		expectedErr := errors.New("an error occured")
 
 		mockValidator := mock.NewMockValidator(ctrl)
-		mockValidator.EXPECT().Validate(testPassword).Return(expectedErr)
+		mockValidator.EXPECT().Validate(testPassword, gomock.Any()).Return(expectedErr)
 
 		user, err := NewUser(testUsername, testPassword, mockValidator, nil)
 		assert.Nil(t, user)
@@ -112,7 +113,7 @@ func TestUser_SetPassword(t *testing.T) {
 	const expectedHash = "UGFzc3dvcmQxMjM="
 
 	mockValidator := mock.NewMockValidator(ctrl)
-	mockValidator.EXPECT().Validate(testPassword).Return(nil)
+	mockValidator.EXPECT().Validate(testPassword, gomock.Any()).Return(nil)
 
 	mockHasher := hashermock.NewMockHasher(ctrl)
 	mockHasher.EXPECT().Hash([]byte(testPassword)).
@@ -135,7 +136,7 @@ func TestUser_SetPassword_ReturnsError(t *testing.T) {
 	expectedErr := errors.New("an error occured")
 
 	mockValidator := mock.NewMockValidator(ctrl)
-	mockValidator.EXPECT().Validate(testPassword).Return(expectedErr)
+	mockValidator.EXPECT().Validate(testPassword, gomock.Any()).Return(expectedErr)
 
 	var user User
 	err := user.SetPassword(testPassword, mockValidator, nil)
@@ -172,6 +173,85 @@ func TestUser_VerifyPassword_ReturnsError(t *testing.T) {
 	assert.Equal(t, ErrInvalidPassword, err)
 }
 
+func TestUser_SetPassword_NormalizesPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockValidator := mock.NewMockValidator(ctrl)
+	mockValidator.EXPECT().Validate("Ｐassword123", gomock.Any()).Return(nil)
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Hash([]byte("Password123")).Return([]byte("Password123"))
+
+	user := &User{}
+	err := user.SetPassword("Ｐassword123", mockValidator, mockHasher)
+	assert.NoError(t, err)
+}
+
+func TestUser_VerifyPassword_NormalizesPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify([]byte("Password123"), gomock.Any()).Return(true)
+	mockPasswordHasher := mock.NewMockHasher(ctrl)
+	mockPasswordHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+
+	user := &User{passwordHash: "UGFzc3dvcmQxMjM="}
+	err := user.VerifyPassword("Ｐassword123", mockHasher)
+	assert.NoError(t, err)
+	assert.False(t, user.NeedsRehash(mockPasswordHasher))
+}
+
+func TestUser_VerifyPassword_GivenUnnormalizedHash_NeedsRehash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testPassword = "Ｐassword123"
+
+	mockHasher := mock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Verify([]byte("Password123"), gomock.Any()).Return(false)
+	mockHasher.EXPECT().Verify([]byte(testPassword), gomock.Any()).Return(true)
+	mockHasher.EXPECT().Hash([]byte("Password123")).Return([]byte("new hash"))
+	mockHasher.EXPECT().NeedsRehash(gomock.Any()).Return(false)
+
+	user := &User{passwordHash: "UGFzc3dvcmQxMjM="}
+	err := user.VerifyPassword(testPassword, mockHasher)
+	assert.NoError(t, err)
+	assert.True(t, user.NeedsRehash(mockHasher))
+
+	user.Rehash(testPassword, mockHasher)
+	assert.Equal(t, "bmV3IGhhc2g=", user.passwordHash)
+	assert.False(t, user.NeedsRehash(mockHasher))
+}
+
+func TestUser_NeedsRehash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testPasswordHash = "UGFzc3dvcmQxMjM="
+
+	mockHasher := mock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().NeedsRehash([]byte("Password123")).Return(true)
+
+	user := &User{passwordHash: testPasswordHash}
+	assert.True(t, user.NeedsRehash(mockHasher))
+}
+
+func TestUser_Rehash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testPassword = "Password123"
+
+	mockHasher := hashermock.NewMockHasher(ctrl)
+	mockHasher.EXPECT().Hash([]byte(testPassword)).Return([]byte("new hash"))
+
+	user := &User{passwordHash: "UGFzc3dvcmQxMjM="}
+	user.Rehash(testPassword, mockHasher)
+	assert.Equal(t, "bmV3IGhhc2g=", user.passwordHash)
+}
+
 func TestNewUserFromDao(t *testing.T) {
 	const (
 		testID           = 23
@@ -186,7 +266,12 @@ func TestNewUserFromDao(t *testing.T) {
 		ReferenceID:  testReferenceID,
 		Username:     testUsername,
 		PasswordHash: testPasswordHash,
+		IsPrivate:    true,
 		IsFollowing:  testIsFollowing,
+		HasRequested: true,
+		IsBlocking:   true,
+		IsBlockedBy:  true,
+		IsMuting:     true,
 	}
 
 	user := NewUserFromDao(d)
@@ -195,6 +280,11 @@ func TestNewUserFromDao(t *testing.T) {
 	assert.Equal(t, testUsername, user.username)
 	assert.Equal(t, testPasswordHash, user.passwordHash)
 	assert.Equal(t, testIsFollowing, user.isFollowing)
+	assert.True(t, user.IsPrivate())
+	assert.True(t, user.HasRequested())
+	assert.True(t, user.IsBlocking())
+	assert.True(t, user.IsBlockedBy())
+	assert.True(t, user.IsMuting())
 }
 
 func TestUser_Dao(t *testing.T) {
@@ -276,6 +366,24 @@ func TestUser_CanFollow_ReturnsError(t *testing.T) {
 		err := user.CanFollow()
 		assert.Equal(t, "user is already following this user", err.Error())
 	})
+
+	t.Run("User Already Requested", func(t *testing.T) {
+		user := User{isPrivate: true, hasRequested: true}
+		err := user.CanFollow()
+		assert.Equal(t, "user has already requested to follow this user", err.Error())
+	})
+
+	t.Run("User Is Blocking", func(t *testing.T) {
+		user := User{isBlocking: true}
+		err := user.CanFollow()
+		assert.Equal(t, "user has blocked this user", err.Error())
+	})
+
+	t.Run("User Is Blocked", func(t *testing.T) {
+		user := User{isBlockedBy: true}
+		err := user.CanFollow()
+		assert.Equal(t, "user cannot follow this user", err.Error())
+	})
 }
 
 func TestUser_CanUnfollow(t *testing.T) {
@@ -284,6 +392,20 @@ func TestUser_CanUnfollow(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestUser_CanUnfollow_PendingRequest(t *testing.T) {
+	user := User{hasRequested: true}
+	err := user.CanUnfollow()
+	assert.NoError(t, err)
+}
+
+func TestUser_SetPrivate(t *testing.T) {
+	var user User
+	user.SetPrivate(true)
+
+	assert.True(t, user.IsPrivate())
+	assert.True(t, user.Dao().IsPrivate)
+}
+
 func TestUser_CanUnfollow_ReturnsError(t *testing.T) {
 	t.Run("User Already Follows", func(t *testing.T) {
 		user := User{isFollowing: false}
@@ -291,3 +413,41 @@ func TestUser_CanUnfollow_ReturnsError(t *testing.T) {
 		assert.Equal(t, "user is not following this user", err.Error())
 	})
 }
+
+func TestUser_CanBlock(t *testing.T) {
+	user := User{isFollowing: true, isBlockedBy: true}
+	err := user.CanBlock()
+	assert.NoError(t, err)
+}
+
+func TestUser_CanBlock_ReturnsError(t *testing.T) {
+	user := User{isBlocking: true}
+	err := user.CanBlock()
+	assert.Equal(t, "user has already blocked this user", err.Error())
+}
+
+func TestUser_CanMute(t *testing.T) {
+	user := User{isFollowing: true}
+	err := user.CanMute()
+	assert.NoError(t, err)
+}
+
+func TestUser_CanMute_ReturnsError(t *testing.T) {
+	user := User{isMuting: true}
+	err := user.CanMute()
+	assert.Equal(t, "user has already muted this user", err.Error())
+}
+
+func TestUser_UpdateBio(t *testing.T) {
+	user := NewUserFromDao(&dao.User{})
+
+	assert.NoError(t, user.UpdateBio("  Hello  "))
+	assert.Equal(t, "Hello", *user.Bio())
+
+	// Length is counted in characters, not bytes.
+	assert.NoError(t, user.UpdateBio(strings.Repeat("é", 255)))
+	assert.Error(t, user.UpdateBio(strings.Repeat("a", 256)))
+
+	assert.NoError(t, user.UpdateBio(" "))
+	assert.Nil(t, user.Bio())
+}
@@ -0,0 +1,82 @@
+// Package notifier provides a pluggable way of sending messages to users, such
+// as password reset links, with implementations which write them to the log
+// or to a file for local runs.
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Message is a message to be delivered to a user.
+type Message struct {
+	// To is the username of the user the message is for.
+	To      string    `json:"to"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+	Sent    time.Time `json:"sent"`
+}
+
+// Notifier is used to deliver messages to users.
+type Notifier interface {
+	Notify(ctx context.Context, msg *Message) error
+}
+
+// New returns the Notifier of the given type, either "log" or "file".
+// A file notifier writes messages to the file at path.
+func New(notifierType, path string) (Notifier, error) {
+	switch notifierType {
+	case "log", "":
+		return NewLogNotifier(), nil
+	case "file":
+		if path == "" {
+			return nil, fmt.Errorf("a file path is required for a file notifier")
+		}
+
+		return NewFileNotifier(path), nil
+	default:
+		return nil, fmt.Errorf("unsupported notifier type: %s", notifierType)
+	}
+}
+
+type logNotifier struct{}
+
+// NewLogNotifier returns a Notifier which writes messages to the log.
+func NewLogNotifier() Notifier {
+	return &logNotifier{}
+}
+
+func (*logNotifier) Notify(ctx context.Context, msg *Message) error {
+	log.Printf("Notification to %s: %s\n%s\n", msg.To, msg.Subject, msg.Body)
+
+	return nil
+}
+
+type fileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileNotifier returns a Notifier which appends messages to the file at
+// path, as JSON, one per line.
+func NewFileNotifier(path string) Notifier {
+	return &fileNotifier{path: path}
+}
+
+func (n *fileNotifier) Notify(ctx context.Context, msg *Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(msg)
+}
@@ -0,0 +1,51 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileNotifier_AppendsMessages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notifier")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "messages.jsonl")
+	n, err := New("file", path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, n.Notify(context.Background(), &Message{To: "john", Subject: "First"}))
+	assert.NoError(t, n.Notify(context.Background(), &Message{To: "jane", Subject: "Second"}))
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+
+	var msg Message
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &msg))
+	assert.Equal(t, "jane", msg.To)
+	assert.Equal(t, "Second", msg.Subject)
+}
+
+func TestNew(t *testing.T) {
+	n, err := New("log", "")
+	assert.NoError(t, err)
+	assert.IsType(t, &logNotifier{}, n)
+
+	_, err = New("file", "")
+	assert.Error(t, err)
+
+	_, err = New("smtp", "")
+	assert.Error(t, err)
+}
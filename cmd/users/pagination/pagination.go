@@ -0,0 +1,116 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/reecerussell/open-social/cmd/users/dto"
+)
+
+const (
+	// DefaultLimit is the number of items in a page, if a limit is not given.
+	DefaultLimit = 20
+
+	// MaxLimit is the maximum number of items that can be requested in a page.
+	MaxLimit = 50
+)
+
+// Common errors
+var (
+	ErrInvalidCursor = errors.New("cursor is invalid")
+	ErrInvalidLimit  = errors.New("limit must be a positive number")
+)
+
+// Cursor is a keyset position within a list of users. Lists are ordered
+// by Username, which is unique, so a cursor points to the last user read.
+type Cursor struct {
+	Username string
+}
+
+// String returns an opaque, url-safe representation of the cursor.
+func (c *Cursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.Username))
+}
+
+// ParseCursor reads a cursor from a value returned by Cursor.String.
+func ParseCursor(value string) (*Cursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(bytes) == 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{
+		Username: string(bytes),
+	}, nil
+}
+
+// Page describes which page of a list to read.
+type Page struct {
+	// Cursor is the position of the last item read. If nil,
+	// the first page is read.
+	Cursor *Cursor
+
+	// Limit is the maximum number of items in the page.
+	Limit int
+}
+
+// CursorUsername returns the cursor's username, or nil if there is no cursor.
+// This is useful as a nullable query parameter.
+func (p *Page) CursorUsername() interface{} {
+	if p.Cursor == nil {
+		return nil
+	}
+
+	return p.Cursor.Username
+}
+
+// PageFromRequest reads a Page from the "cursor" and "limit" query parameters.
+func PageFromRequest(r *http.Request) (*Page, error) {
+	query := r.URL.Query()
+	page := &Page{Limit: DefaultLimit}
+
+	if value := query.Get("cursor"); value != "" {
+		cursor, err := ParseCursor(value)
+		if err != nil {
+			return nil, err
+		}
+
+		page.Cursor = cursor
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return nil, ErrInvalidLimit
+		}
+
+		if limit > MaxLimit {
+			limit = MaxLimit
+		}
+
+		page.Limit = limit
+	}
+
+	return page, nil
+}
+
+// NewFollowList builds a follow list from items read for the given page. The items
+// should contain up to one more item than the page's limit, in which case the extra
+// item is dropped and the list's next cursor is set.
+func NewFollowList(items []*dto.Follow, page *Page) *dto.FollowList {
+	list := &dto.FollowList{
+		Items: items,
+	}
+
+	if len(items) > page.Limit {
+		list.Items = items[:page.Limit]
+
+		last := list.Items[page.Limit-1]
+		cursor := (&Cursor{Username: last.Username}).String()
+		list.NextCursor = &cursor
+	}
+
+	return list
+}
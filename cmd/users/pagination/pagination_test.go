@@ -0,0 +1,89 @@
+package pagination
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dto"
+)
+
+func TestCursor_String_CanBeParsed(t *testing.T) {
+	cursor := &Cursor{Username: "test_user"}
+
+	parsed, err := ParseCursor(cursor.String())
+	assert.NoError(t, err)
+	assert.Equal(t, cursor.Username, parsed.Username)
+}
+
+func TestParseCursor_GivenInvalidValue_ReturnsError(t *testing.T) {
+	t.Run("Invalid Encoding", func(t *testing.T) {
+		cursor, err := ParseCursor("!!!")
+		assert.Nil(t, cursor)
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		cursor, err := ParseCursor("=")
+		assert.Nil(t, cursor)
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+}
+
+func TestPageFromRequest(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodGet, "/", nil)
+		page, err := PageFromRequest(r)
+		assert.NoError(t, err)
+		assert.Nil(t, page.Cursor)
+		assert.Equal(t, DefaultLimit, page.Limit)
+		assert.Nil(t, page.CursorUsername())
+	})
+
+	t.Run("Cursor And Limit", func(t *testing.T) {
+		cursor := &Cursor{Username: "test_user"}
+		r, _ := http.NewRequest(http.MethodGet, "/?limit=5&cursor="+cursor.String(), nil)
+		page, err := PageFromRequest(r)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, page.Limit)
+		assert.Equal(t, cursor.Username, page.CursorUsername())
+	})
+
+	t.Run("Limit Is Capped", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodGet, "/?limit=1000", nil)
+		page, err := PageFromRequest(r)
+		assert.NoError(t, err)
+		assert.Equal(t, MaxLimit, page.Limit)
+	})
+}
+
+func TestPageFromRequest_GivenInvalidLimit_ReturnsError(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodGet, "/?limit=0", nil)
+	page, err := PageFromRequest(r)
+	assert.Nil(t, page)
+	assert.Equal(t, ErrInvalidLimit, err)
+}
+
+func TestNewFollowList(t *testing.T) {
+	items := []*dto.Follow{
+		{Username: "alice"},
+		{Username: "bob"},
+		{Username: "carol"},
+	}
+
+	t.Run("Has Next Page", func(t *testing.T) {
+		list := NewFollowList(items, &Page{Limit: 2})
+		assert.Equal(t, 2, len(list.Items))
+
+		cursor, err := ParseCursor(*list.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, "bob", cursor.Username)
+	})
+
+	t.Run("Last Page", func(t *testing.T) {
+		list := NewFollowList(items, &Page{Limit: 3})
+		assert.Equal(t, 3, len(list.Items))
+		assert.Nil(t, list.NextCursor)
+	})
+}
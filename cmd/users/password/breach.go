@@ -0,0 +1,124 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// ErrBreachedPassword is returned when a password has appeared in a data breach.
+var ErrBreachedPassword = errors.New("password has appeared in a data breach, please choose another")
+
+// breachPrefixLength is the number of characters of a password's hash given to a BreachSource.
+const breachPrefixLength = 5
+
+// BreachSource is used to look up breached passwords using k-anonymity: given the first
+// five characters of a password's SHA-1 hash, in uppercase hex, it returns the remaining
+// characters of each breached hash with that prefix. Neither the password nor its full
+// hash is ever given to the source, so it can safely be backed by a remote service.
+type BreachSource interface {
+	Range(prefix string) ([]string, error)
+}
+
+// NewBreachSource returns the BreachSource configured by opt, or nil if it's disabled.
+func NewBreachSource(opt BreachOptions) (BreachSource, error) {
+	switch opt.Type {
+	case "":
+		return nil, nil
+	case "file":
+		if opt.File == "" {
+			return nil, fmt.Errorf("a file path is required for a file breach source")
+		}
+
+		return LoadFileBreachSource(opt.File)
+	default:
+		return nil, fmt.Errorf("unsupported breach source type: %s", opt.Type)
+	}
+}
+
+type fileBreachSource struct {
+	ranges map[string][]string
+}
+
+// LoadFileBreachSource returns a BreachSource, for offline use, which reads breached
+// password hashes from the file at path. Each line is the SHA-1 hash of a password, in
+// hex, optionally followed by a colon and the number of times it has been seen, which is
+// the format of the downloadable Pwned Passwords list. The whole file is held in memory.
+func LoadFileBreachSource(path string) (BreachSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	src := &fileBreachSource{ranges: make(map[string][]string)}
+
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+
+		if i := strings.IndexByte(line, ':'); i >= 0 {
+			line = line[:i]
+		}
+
+		if len(line) != sha1.Size*2 {
+			return nil, fmt.Errorf("%s:%d: invalid SHA-1 hash", path, n)
+		}
+
+		if _, err := hex.DecodeString(line); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid SHA-1 hash", path, n)
+		}
+
+		hash := strings.ToUpper(line)
+		prefix := hash[:breachPrefixLength]
+		src.ranges[prefix] = append(src.ranges[prefix], hash[breachPrefixLength:])
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return src, nil
+}
+
+func (s *fileBreachSource) Range(prefix string) ([]string, error) {
+	return s.ranges[prefix], nil
+}
+
+type breachCheck struct {
+	src BreachSource
+}
+
+// NewBreachCheck returns a Check which rejects passwords found in the BreachSource.
+// If the source can't be reached the password is allowed, so an outage of the
+// source doesn't stop users from registering or changing their password.
+func NewBreachCheck(src BreachSource) Check {
+	return &breachCheck{src: src}
+}
+
+func (c *breachCheck) Check(password, username string) error {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, err := c.src.Range(hash[:breachPrefixLength])
+	if err != nil {
+		log.Printf("WARN: failed to check password against breach source: %v\n", err)
+		return nil
+	}
+
+	for _, suffix := range suffixes {
+		if strings.EqualFold(suffix, hash[breachPrefixLength:]) {
+			return ErrBreachedPassword
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package password
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+type testBreachSource struct {
+	prefixes []string
+	suffixes []string
+	err      error
+}
+
+func (s *testBreachSource) Range(prefix string) ([]string, error) {
+	s.prefixes = append(s.prefixes, prefix)
+	return s.suffixes, s.err
+}
+
+func TestBreachCheck(t *testing.T) {
+	hash := sha1Hex("Breached_123")
+	src := &testBreachSource{suffixes: []string{"0000000000000000000000000000000000A", strings.ToLower(hash[5:])}}
+	c := NewBreachCheck(src)
+
+	err := c.Check("Breached_123", "")
+	assert.Equal(t, ErrBreachedPassword, err)
+
+	// Only the prefix of the hash is given to the source.
+	assert.Equal(t, []string{hash[:5]}, src.prefixes)
+}
+
+func TestBreachCheck_PasswordNotBreached_ReturnsNil(t *testing.T) {
+	src := &testBreachSource{suffixes: []string{"0000000000000000000000000000000000A"}}
+	c := NewBreachCheck(src)
+
+	assert.NoError(t, c.Check("Password_123", ""))
+}
+
+func TestBreachCheck_SourceFails_ReturnsNil(t *testing.T) {
+	src := &testBreachSource{err: errors.New("an error occured")}
+	c := NewBreachCheck(src)
+
+	assert.NoError(t, c.Check("Password_123", ""))
+}
+
+func TestLoadFileBreachSource(t *testing.T) {
+	hash := sha1Hex("Breached_123")
+	path := filepath.Join(t.TempDir(), "breaches.txt")
+	_ = ioutil.WriteFile(path, []byte(strings.ToLower(hash)+":42\n\n"+sha1Hex("Other_123")+"\n"), 0600)
+
+	src, err := LoadFileBreachSource(path)
+	assert.NoError(t, err)
+
+	suffixes, err := src.Range(hash[:5])
+	assert.NoError(t, err)
+	assert.Contains(t, suffixes, hash[5:])
+
+	suffixes, err = src.Range("FFFFF")
+	assert.NoError(t, err)
+	assert.Empty(t, suffixes)
+}
+
+func TestLoadFileBreachSource_GivenInvalidHash_ReturnsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breaches.txt")
+	_ = ioutil.WriteFile(path, []byte(sha1Hex("Breached_123")+"\nnot-a-hash:1\n"), 0600)
+
+	src, err := LoadFileBreachSource(path)
+	assert.Nil(t, src)
+	assert.Equal(t, path+":2: invalid SHA-1 hash", err.Error())
+}
+
+func TestNewBreachSource(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		src, err := NewBreachSource(BreachOptions{})
+		assert.Nil(t, src)
+		assert.NoError(t, err)
+	})
+
+	t.Run("File Without Path", func(t *testing.T) {
+		src, err := NewBreachSource(BreachOptions{Type: "file"})
+		assert.Nil(t, src)
+		assert.Equal(t, "a file path is required for a file breach source", err.Error())
+	})
+
+	t.Run("Unsupported Type", func(t *testing.T) {
+		src, err := NewBreachSource(BreachOptions{Type: "http"})
+		assert.Nil(t, src)
+		assert.Equal(t, "unsupported breach source type: http", err.Error())
+	})
+}
@@ -0,0 +1,96 @@
+package password
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+)
+
+// Common errors returned by checks.
+var (
+	ErrContainsUsername = errors.New("password cannot contain your username")
+	ErrCommonPassword   = errors.New("password is too common")
+)
+
+// Check is an additional check which a password has to pass to be valid.
+// The username may be empty, if it isn't known yet.
+type Check interface {
+	Check(password, username string) error
+}
+
+// CheckFunc is a function which can be used as a Check.
+type CheckFunc func(password, username string) error
+
+// Check calls f(password, username).
+func (f CheckFunc) Check(password, username string) error {
+	return f(password, username)
+}
+
+// UsernameCheck rejects passwords which contain the username, regardless of case. Both are
+// normalized first, so width and compatibility variants of the username are also rejected.
+var UsernameCheck = CheckFunc(func(password, username string) error {
+	if username == "" {
+		return nil
+	}
+
+	if strings.Contains(strings.ToLower(Normalize(password)), strings.ToLower(Normalize(username))) {
+		return ErrContainsUsername
+	}
+
+	return nil
+})
+
+type blocklistCheck struct {
+	passwords map[string]bool
+}
+
+// NewBlocklistCheck returns a Check which rejects any of the given
+// passwords, regardless of case.
+func NewBlocklistCheck(passwords []string) Check {
+	c := &blocklistCheck{
+		passwords: make(map[string]bool, len(passwords)),
+	}
+
+	for _, p := range passwords {
+		c.passwords[strings.ToLower(p)] = true
+	}
+
+	return c
+}
+
+// LoadBlocklistCheck returns a Check which rejects the passwords in the file at
+// path, one per line. Blank lines, and lines starting with '#', are ignored.
+func LoadBlocklistCheck(path string) (Check, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var passwords []string
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		passwords = append(passwords, line)
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewBlocklistCheck(passwords), nil
+}
+
+func (c *blocklistCheck) Check(password, username string) error {
+	if c.passwords[strings.ToLower(password)] {
+		return ErrCommonPassword
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package password
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsernameCheck(t *testing.T) {
+	assert.NoError(t, UsernameCheck.Check("Password_123", "john"))
+	assert.NoError(t, UsernameCheck.Check("Password_123", ""))
+	assert.Equal(t, ErrContainsUsername, UsernameCheck.Check("my-JOHN-password", "john"))
+}
+
+func TestUsernameCheck_GivenCompatibilityVariants_ReturnsError(t *testing.T) {
+	// Fullwidth letters normalize to their ASCII equivalents.
+	assert.Equal(t, ErrContainsUsername, UsernameCheck.Check("my-ＪＯＨＮ-password", "john"))
+	assert.Equal(t, ErrContainsUsername, UsernameCheck.Check("my-john-password", "ｊｏｈｎ"))
+}
+
+func TestBlocklistCheck(t *testing.T) {
+	c := NewBlocklistCheck([]string{"Password1", "qwerty"})
+
+	assert.Equal(t, ErrCommonPassword, c.Check("password1", ""))
+	assert.Equal(t, ErrCommonPassword, c.Check("QWERTY", ""))
+	assert.NoError(t, c.Check("Password_123", ""))
+}
+
+func TestLoadBlocklistCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+	_ = ioutil.WriteFile(path, []byte("# common passwords\n\npassword1\n  qwerty  \n"), 0600)
+
+	c, err := LoadBlocklistCheck(path)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrCommonPassword, c.Check("password1", ""))
+	assert.Equal(t, ErrCommonPassword, c.Check("qwerty", ""))
+	assert.NoError(t, c.Check("# common passwords", ""))
+}
+
+func TestLoadBlocklistCheck_FileDoesNotExist_ReturnsError(t *testing.T) {
+	c, err := LoadBlocklistCheck(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Nil(t, c)
+	assert.True(t, os.IsNotExist(err))
+}
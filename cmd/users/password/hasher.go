@@ -0,0 +1,99 @@
+package password
+
+import (
+	"encoding/binary"
+
+	hashpkg "github.com/reecerussell/adaptive-password-hasher"
+)
+
+// headerSize is the length of a hash's header: a format marker, followed by the
+// hash key, iteration count and salt size, each as a big-endian uint32.
+const headerSize = 13
+
+// Hasher is used to hash and verify passwords. As each hash records the options
+// it was made with, hashes made before the options were changed can still be
+// verified, and can be detected so they're rehashed with the current options.
+type Hasher interface {
+	hashpkg.Hasher
+
+	// NeedsRehash returns true if the hash wasn't made with the current options.
+	NeedsRehash(hash []byte) bool
+}
+
+type hasher struct {
+	opt     *HashOptions
+	current hashpkg.Hasher
+}
+
+// NewHasher returns a new instance of Hasher, which hashes passwords with the given options.
+func NewHasher(opt *HashOptions) (Hasher, error) {
+	current, err := hashpkg.New(opt.IterationCount, opt.SaltSize, opt.KeySize, opt.HashKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hasher{
+		opt:     opt,
+		current: current,
+	}, nil
+}
+
+func (h *hasher) Hash(pwd []byte) []byte {
+	return h.current.Hash(pwd)
+}
+
+// Verify verifies the password using the options the hash was made with, otherwise
+// hashes with a smaller salt or key than the current options would never match.
+func (h *hasher) Verify(pwd, hash []byte) bool {
+	opt, ok := readHashOptions(hash)
+	if !ok {
+		return false
+	}
+
+	if *opt == *h.opt {
+		return h.current.Verify(pwd, hash)
+	}
+
+	if opt.HashKey != hashpkg.HashSHA256 && opt.HashKey != hashpkg.HashSHA512 {
+		return false
+	}
+
+	verifier, err := hashpkg.New(opt.IterationCount, opt.SaltSize, opt.KeySize, opt.HashKey)
+	if err != nil {
+		return false
+	}
+
+	return verifier.Verify(pwd, hash)
+}
+
+func (h *hasher) NeedsRehash(hash []byte) bool {
+	opt, ok := readHashOptions(hash)
+	if !ok {
+		return true
+	}
+
+	return *opt != *h.opt
+}
+
+// readHashOptions reads the options a hash was made with from its header. The key
+// size isn't recorded, so is taken from the length of the hash after the salt.
+func readHashOptions(hash []byte) (*HashOptions, bool) {
+	if len(hash) < headerSize || hash[0] != 0x01 {
+		return nil, false
+	}
+
+	saltSize := int(binary.BigEndian.Uint32(hash[9:13]))
+	keySize := len(hash) - headerSize - saltSize
+	if saltSize < 1 || keySize < 1 {
+		return nil, false
+	}
+
+	opt := &HashOptions{
+		HashKey:        int(binary.BigEndian.Uint32(hash[1:5])),
+		IterationCount: int(binary.BigEndian.Uint32(hash[5:9])),
+		SaltSize:       saltSize * 8,
+		KeySize:        keySize * 8,
+	}
+
+	return opt, true
+}
@@ -0,0 +1,79 @@
+package password
+
+import (
+	"testing"
+
+	hashpkg "github.com/reecerussell/adaptive-password-hasher"
+	"github.com/stretchr/testify/assert"
+)
+
+var testHashOptions = HashOptions{
+	IterationCount: 1000,
+	SaltSize:       128,
+	KeySize:        256,
+	HashKey:        hashpkg.HashSHA256,
+}
+
+func TestHasher(t *testing.T) {
+	opt := testHashOptions
+	hasher, err := NewHasher(&opt)
+	assert.NoError(t, err)
+
+	hash := hasher.Hash([]byte("Password_123"))
+	assert.True(t, hasher.Verify([]byte("Password_123"), hash))
+	assert.False(t, hasher.Verify([]byte("Password_124"), hash))
+	assert.False(t, hasher.NeedsRehash(hash))
+}
+
+func TestHasher_GivenInvalidOptions_ReturnsError(t *testing.T) {
+	hasher, err := NewHasher(&HashOptions{IterationCount: 0, SaltSize: 128, KeySize: 256, HashKey: 1})
+	assert.Nil(t, hasher)
+	assert.Equal(t, hashpkg.ErrInvalidIterationCount, err)
+}
+
+func TestHasher_OptionsChanged_VerifiesAndNeedsRehash(t *testing.T) {
+	old := testHashOptions
+	oldHasher, _ := NewHasher(&old)
+	hash := oldHasher.Hash([]byte("Password_123"))
+
+	tests := map[string]HashOptions{
+		"IterationCount": {IterationCount: 2000, SaltSize: 128, KeySize: 256, HashKey: hashpkg.HashSHA256},
+		"SaltSize":       {IterationCount: 1000, SaltSize: 256, KeySize: 256, HashKey: hashpkg.HashSHA256},
+		"KeySize":        {IterationCount: 1000, SaltSize: 128, KeySize: 512, HashKey: hashpkg.HashSHA256},
+		"HashKey":        {IterationCount: 1000, SaltSize: 128, KeySize: 256, HashKey: hashpkg.HashSHA512},
+	}
+
+	for name, opt := range tests {
+		opt := opt
+		t.Run(name, func(t *testing.T) {
+			hasher, _ := NewHasher(&opt)
+			assert.True(t, hasher.Verify([]byte("Password_123"), hash))
+			assert.False(t, hasher.Verify([]byte("Password_124"), hash))
+			assert.True(t, hasher.NeedsRehash(hash))
+
+			rehashed := hasher.Hash([]byte("Password_123"))
+			assert.False(t, hasher.NeedsRehash(rehashed))
+		})
+	}
+}
+
+func TestHasher_GivenInvalidHash_ReturnsFalse(t *testing.T) {
+	opt := testHashOptions
+	hasher, _ := NewHasher(&opt)
+
+	tests := map[string][]byte{
+		"Empty":          {},
+		"Short":          {0x01, 0x00},
+		"Invalid Marker": append([]byte{0x02}, make([]byte, 60)...),
+		"Invalid Salt":   append([]byte{0x01, 0, 0, 0, 1, 0, 0, 0x03, 0xe8, 0xff, 0xff, 0xff, 0xff}, make([]byte, 48)...),
+		"Invalid Alg":    append([]byte{0x01, 0, 0, 0, 9, 0, 0, 0x03, 0xe8, 0, 0, 0, 16}, make([]byte, 48)...),
+	}
+
+	for name, hash := range tests {
+		hash := hash
+		t.Run(name, func(t *testing.T) {
+			assert.False(t, hasher.Verify([]byte("Password_123"), hash))
+			assert.True(t, hasher.NeedsRehash(hash))
+		})
+	}
+}
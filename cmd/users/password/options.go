@@ -4,8 +4,13 @@ package password
 // password has to hit, to be considered valid.
 type Options struct {
 	// RequiredLength is the minimum length a password has to be.
+	// Lengths are measured in characters, not bytes.
 	RequiredLength int `json:"requiredLength"`
 
+	// MaxLength is the maximum length a password can be, which limits
+	// the cost of hashing it. Ignored if less than 1.
+	MaxLength int `json:"maxLength"`
+
 	// RequireUppercase is a flag which demands at least one
 	// character to be uppercase.
 	RequireUppercase bool `json:"requireUppercase"`
@@ -25,6 +30,26 @@ type Options struct {
 	// RequiredUniqueChars is an integer value, that determines
 	// how many unique characters are required in a password.
 	RequiredUniqueChars int `json:"requiredUniqueChars"`
+
+	// DisallowUsername is a flag which rejects passwords
+	// containing the user's username.
+	DisallowUsername bool `json:"disallowUsername"`
+
+	// BlocklistFile is the path to a file of common passwords, one per
+	// line, which are rejected regardless of case. Optional.
+	BlocklistFile string `json:"blocklistFile"`
+
+	// Breach configures the source of breached passwords, which are rejected.
+	Breach BreachOptions `json:"breach"`
+}
+
+// BreachOptions configures the BreachSource used to reject breached passwords.
+type BreachOptions struct {
+	// Type is the type of breach source, either "file" or empty, to disable it.
+	Type string `json:"type"`
+
+	// File is the path to the file used by the "file" breach source.
+	File string `json:"file"`
 }
 
 // HashOptions contains the configuration used to hash and
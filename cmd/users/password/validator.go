@@ -3,24 +3,68 @@ package password
 import (
 	"errors"
 	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
 )
 
-// Validator is an interface used to validate passwords.
+// Validator is an interface used to validate passwords. The username of
+// the password's user is used by checks, and may be empty if it isn't known.
 type Validator interface {
-	Validate(password string) error
+	Validate(password, username string) error
 }
 
 type validator struct {
-	opt *Options
+	opt    *Options
+	checks []Check
+}
+
+// New returns a new instance of Validator, with the given options. The given
+// checks are run once the password has met the options' requirements.
+func New(opt *Options, checks ...Check) Validator {
+	if opt.DisallowUsername {
+		checks = append([]Check{UsernameCheck}, checks...)
+	}
+
+	return &validator{
+		opt:    opt,
+		checks: checks,
+	}
 }
 
-// New returns a new instance of Validator, with the given options.
-func New(opt *Options) Validator {
-	return &validator{opt: opt}
+// NewFromOptions returns a new instance of Validator, with the given options,
+// loading the blocklist and breach source they configure.
+func NewFromOptions(opt *Options) (Validator, error) {
+	var checks []Check
+
+	if opt.BlocklistFile != "" {
+		c, err := LoadBlocklistCheck(opt.BlocklistFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load blocklist: %v", err)
+		}
+
+		checks = append(checks, c)
+	}
+
+	src, err := NewBreachSource(opt.Breach)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load breach source: %v", err)
+	}
+
+	if src != nil {
+		checks = append(checks, NewBreachCheck(src))
+	}
+
+	return New(opt, checks...), nil
 }
 
-func (v *validator) Validate(password string) error {
-	l := len(password)
+// Validate validates the password once it has been normalized, measuring
+// and classifying it by character (rune), rather than by byte.
+func (v *validator) Validate(password, username string) error {
+	password = Normalize(password)
+
+	l := utf8.RuneCountInString(password)
 	if l < 1 {
 		return errors.New("password is required")
 	}
@@ -29,44 +73,36 @@ func (v *validator) Validate(password string) error {
 		return fmt.Errorf("password must be at least %d characters long", v.opt.RequiredLength)
 	}
 
+	if v.opt.MaxLength >= 1 && l > v.opt.MaxLength {
+		return fmt.Errorf("password cannot be longer than %d characters", v.opt.MaxLength)
+	}
+
 	var (
 		hasNonAlphanumeric bool
 		hasDigit           bool
 		hasLower           bool
 		hasUpper           bool
-		uniqueChars        []byte
+		uniqueChars        = make(map[rune]bool)
 	)
 
-	for i := 0; i < l; i++ {
-		c := byte(password[i])
-
+	for _, c := range password {
 		if !hasNonAlphanumeric && !isLetterOrDigit(c) {
 			hasNonAlphanumeric = true
 		}
 
-		if !hasDigit && isDigit(c) {
+		if !hasDigit && unicode.IsDigit(c) {
 			hasDigit = true
 		}
 
-		if !hasLower && isLower(c) {
+		if !hasLower && unicode.IsLower(c) {
 			hasLower = true
 		}
 
-		if !hasUpper && isUpper(c) {
+		if !hasUpper && unicode.IsUpper(c) {
 			hasUpper = true
 		}
 
-		d := true
-
-		for _, dc := range uniqueChars {
-			if dc == c {
-				d = false
-			}
-		}
-
-		if d {
-			uniqueChars = append(uniqueChars, c)
-		}
+		uniqueChars[c] = true
 	}
 
 	if v.opt.RequireNonAlphanumeric && !hasNonAlphanumeric {
@@ -89,32 +125,26 @@ func (v *validator) Validate(password string) error {
 		return fmt.Errorf("password requires at least %d unique characters", v.opt.RequiredUniqueChars)
 	}
 
-	return nil
-}
-
-// isDigit returns a flag indicating whether the supplied character
-// is a digit - true if the character is a digit, otherwise false.
-func isDigit(c byte) bool {
-	return c >= '0' && c <= '9'
-}
+	for _, c := range v.checks {
+		err := c.Check(password, username)
+		if err != nil {
+			return err
+		}
+	}
 
-// isLower returns a flag indicating whether the supplied character is
-// a lower case ASCII letter - true if the character is a lower case
-// ASCII letter, otherwise false.
-func isLower(c byte) bool {
-	return c >= 'a' && c <= 'z'
+	return nil
 }
 
-// isUpper returns a flag indicating whether the supplied character is
-// an upper case ASCII letter - true if the character is an upper case ASCII
-// letter, otherwise false.
-func isUpper(c byte) bool {
-	return c >= 'A' && c <= 'Z'
+// Normalize returns the NFKC normalized form of the password, so that the
+// same password is always hashed the same way, however it was typed.
+// Passwords must be normalized before being hashed or verified.
+func Normalize(password string) string {
+	return norm.NFKC.String(password)
 }
 
-// isLetterOrDigit returns a flag indicating whether the supplied character is
-// an ASCII letter or digit - true if the character is an ASCII letter or digit,
-// otherwise false.
-func isLetterOrDigit(c byte) bool {
-	return isUpper(c) || isLower(c) || isDigit(c)
+// isLetterOrDigit returns a flag indicating whether the supplied character
+// is a Unicode letter or digit, or a mark combined with one - true if it
+// is, otherwise false.
+func isLetterOrDigit(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || unicode.IsMark(c)
 }
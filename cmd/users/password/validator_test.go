@@ -2,6 +2,8 @@ package password
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,21 +19,21 @@ func TestValidate(t *testing.T) {
 		RequiredUniqueChars:    5,
 	})
 
-	err := val.Validate("Password_123")
+	err := val.Validate("Password_123", "")
 	assert.NoError(t, err)
 }
 
 func TestValidate_GivenInvalidPassword_ReturnsError(t *testing.T) {
 	t.Run("Requires Non-Empty Password", func(t *testing.T) {
 		val := New(&Options{})
-		err := val.Validate("")
+		err := val.Validate("", "")
 		assert.Equal(t, "password is required", err.Error())
 	})
 
 	t.Run("Requires 8 Characters", func(t *testing.T) {
 		opt := &Options{RequiredLength: 8}
 		val := New(opt)
-		err := val.Validate("pass123")
+		err := val.Validate("pass123", "")
 		exp := fmt.Sprintf("password must be at least %d characters long", opt.RequiredLength)
 		assert.Equal(t, exp, err.Error())
 	})
@@ -39,36 +41,196 @@ func TestValidate_GivenInvalidPassword_ReturnsError(t *testing.T) {
 	t.Run("Requires Non Alphanumeric", func(t *testing.T) {
 		opt := &Options{RequireNonAlphanumeric: true}
 		val := New(opt)
-		err := val.Validate("password123")
+		err := val.Validate("password123", "")
 		assert.Equal(t, "password requires an non-alphanumeric character", err.Error())
 	})
 
 	t.Run("Requires Digit", func(t *testing.T) {
 		opt := &Options{RequireDigit: true}
 		val := New(opt)
-		err := val.Validate("password")
+		err := val.Validate("password", "")
 		assert.Equal(t, "password requires a digit", err.Error())
 	})
 
 	t.Run("Requires Lowercase", func(t *testing.T) {
 		opt := &Options{RequireLowercase: true}
 		val := New(opt)
-		err := val.Validate("PASSWORD")
+		err := val.Validate("PASSWORD", "")
 		assert.Equal(t, "password requires a lowercase letter", err.Error())
 	})
 
 	t.Run("Requires Lowercase", func(t *testing.T) {
 		opt := &Options{RequireUppercase: true}
 		val := New(opt)
-		err := val.Validate("password")
+		err := val.Validate("password", "")
 		assert.Equal(t, "password requires an uppercase letter", err.Error())
 	})
 
 	t.Run("Requires 4 Unique Characters", func(t *testing.T) {
 		opt := &Options{RequiredUniqueChars: 4}
 		val := New(opt)
-		err := val.Validate("aaaa")
+		err := val.Validate("aaaa", "")
 		exp := fmt.Sprintf("password requires at least %d unique characters", opt.RequiredUniqueChars)
 		assert.Equal(t, exp, err.Error())
 	})
 }
+
+func TestValidate_RunsChecks(t *testing.T) {
+	var called []string
+	check := func(name string, err error) Check {
+		return CheckFunc(func(password, username string) error {
+			called = append(called, name)
+			assert.Equal(t, "Password_123", password)
+			assert.Equal(t, "john", username)
+			return err
+		})
+	}
+
+	t.Run("All Pass", func(t *testing.T) {
+		called = nil
+		val := New(&Options{}, check("a", nil), check("b", nil))
+		err := val.Validate("Password_123", "john")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, called)
+	})
+
+	t.Run("Stops At First Failure", func(t *testing.T) {
+		called = nil
+		testErr := fmt.Errorf("check failed")
+		val := New(&Options{}, check("a", testErr), check("b", nil))
+		err := val.Validate("Password_123", "john")
+		assert.Equal(t, testErr, err)
+		assert.Equal(t, []string{"a"}, called)
+	})
+
+	t.Run("Not Run If Options Fail", func(t *testing.T) {
+		called = nil
+		val := New(&Options{RequiredLength: 20}, check("a", nil))
+		err := val.Validate("Password_123", "john")
+		assert.Error(t, err)
+		assert.Empty(t, called)
+	})
+}
+
+func TestValidate_DisallowUsername(t *testing.T) {
+	val := New(&Options{DisallowUsername: true})
+
+	err := val.Validate("JohnDoe_123", "johndoe")
+	assert.Equal(t, ErrContainsUsername, err)
+
+	err = val.Validate("JohnDoe_123", "")
+	assert.NoError(t, err)
+}
+
+func TestNewFromOptions(t *testing.T) {
+	dir := t.TempDir()
+	blocklist := filepath.Join(dir, "blocklist.txt")
+	_ = ioutil.WriteFile(blocklist, []byte("password1\n"), 0600)
+	breaches := filepath.Join(dir, "breaches.txt")
+	_ = ioutil.WriteFile(breaches, []byte(sha1Hex("Breached_123")+":10\n"), 0600)
+
+	val, err := NewFromOptions(&Options{
+		DisallowUsername: true,
+		BlocklistFile:    blocklist,
+		Breach:           BreachOptions{Type: "file", File: breaches},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, ErrContainsUsername, val.Validate("john_123", "john"))
+	assert.Equal(t, ErrCommonPassword, val.Validate("PASSWORD1", "john"))
+	assert.Equal(t, ErrBreachedPassword, val.Validate("Breached_123", "john"))
+	assert.NoError(t, val.Validate("Password_123", "john"))
+}
+
+func TestNewFromOptions_GivenInvalidOptions_ReturnsError(t *testing.T) {
+	t.Run("Missing Blocklist", func(t *testing.T) {
+		val, err := NewFromOptions(&Options{BlocklistFile: filepath.Join(t.TempDir(), "missing.txt")})
+		assert.Nil(t, val)
+		assert.Contains(t, err.Error(), "failed to load blocklist")
+	})
+
+	t.Run("Invalid Breach Source", func(t *testing.T) {
+		val, err := NewFromOptions(&Options{Breach: BreachOptions{Type: "http"}})
+		assert.Nil(t, val)
+		assert.Equal(t, "failed to load breach source: unsupported breach source type: http", err.Error())
+	})
+}
+
+func TestValidate_GivenUnicodePassword_MeasuresCharacters(t *testing.T) {
+	t.Run("Length", func(t *testing.T) {
+		val := New(&Options{RequiredLength: 8})
+
+		// 4 characters, but 12 bytes.
+		err := val.Validate("пароль"[:8], "")
+		assert.Equal(t, "password must be at least 8 characters long", err.Error())
+
+		err = val.Validate("пароль12", "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Max Length", func(t *testing.T) {
+		val := New(&Options{MaxLength: 6})
+
+		err := val.Validate("пароль", "")
+		assert.NoError(t, err)
+
+		err = val.Validate("пароль1", "")
+		assert.Equal(t, "password cannot be longer than 6 characters", err.Error())
+	})
+
+	t.Run("Unique Characters", func(t *testing.T) {
+		val := New(&Options{RequiredUniqueChars: 3})
+
+		// Each character shares its leading byte.
+		err := val.Validate("абаб", "")
+		assert.Equal(t, "password requires at least 3 unique characters", err.Error())
+
+		err = val.Validate("абв", "")
+		assert.NoError(t, err)
+	})
+}
+
+func TestValidate_GivenUnicodePassword_ClassifiesCharacters(t *testing.T) {
+	val := New(&Options{
+		RequireUppercase:       true,
+		RequireLowercase:       true,
+		RequireDigit:           true,
+		RequireNonAlphanumeric: true,
+	})
+
+	err := val.Validate("Пароль١!", "")
+	assert.NoError(t, err)
+
+	err = val.Validate("Пароль١é", "")
+	assert.Equal(t, "password requires an non-alphanumeric character", err.Error())
+
+	err = val.Validate("пароль١!", "")
+	assert.Equal(t, "password requires an uppercase letter", err.Error())
+
+	err = val.Validate("ПАРОЛЬ١!", "")
+	assert.Equal(t, "password requires a lowercase letter", err.Error())
+}
+
+func TestValidate_NormalizesPassword(t *testing.T) {
+	check := CheckFunc(func(password, username string) error {
+		assert.Equal(t, "Password12", password)
+		return nil
+	})
+
+	// Fullwidth characters are normalized to their ASCII equivalents,
+	// so "Ｐ" and "P" are counted as the same character.
+	val := New(&Options{RequiredUniqueChars: 10, RequireDigit: true})
+	err := val.Validate("PＰassword１2", "")
+	assert.Equal(t, "password requires at least 10 unique characters", err.Error())
+
+	val = New(&Options{RequireDigit: true}, check)
+	err = val.Validate("Ｐａｓｓｗｏｒｄ１２", "")
+	assert.NoError(t, err)
+}
+
+func TestNormalize(t *testing.T) {
+	// "é" as a single code point, and as "e" followed by a combining accent.
+	assert.Equal(t, Normalize("café"), Normalize("café"))
+	assert.Equal(t, "Password", Normalize("Ｐａｓｓｗｏｒｄ"))
+	assert.Equal(t, "Password_123", Normalize("Password_123"))
+}
@@ -4,20 +4,50 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 
 	"github.com/reecerussell/open-social/cmd/users/dto"
+	"github.com/reecerussell/open-social/cmd/users/pagination"
 	"github.com/reecerussell/open-social/database"
 )
 
 // Common errors.
 var (
 	ErrProfileNotFound = errors.New("profile not found")
+	ErrProfilePrivate  = errors.New("profile is private")
 )
 
 // UserProvider is used to query user data, for read-only operations.
 type UserProvider interface {
+	// GetProfile gets the profile of the user with the given username. If either the user or the
+	// user with userReferenceID has blocked the other, ErrProfileNotFound is returned.
 	GetProfile(ctx context.Context, username, userReferenceID string) (*dto.Profile, error)
 	GetInfo(ctx context.Context, userReferenceID string) (*dto.Info, error)
+
+	// GetFollowers gets a page of the followers of the user with the given username, ordered by
+	// username. Each follower is marked with whether the user with userReferenceID follows them.
+	// If the user is private, and isn't followed by the user with userReferenceID,
+	// ErrProfilePrivate is returned. Blocked users are treated as if they don't exist.
+	GetFollowers(ctx context.Context, username, userReferenceID string, page *pagination.Page) (*dto.FollowList, error)
+
+	// GetFollowing gets a page of the users followed by the user with the given username, ordered by
+	// username. Each user is marked with whether the user with userReferenceID follows them.
+	// If the user is private, and isn't followed by the user with userReferenceID,
+	// ErrProfilePrivate is returned. Blocked users are treated as if they don't exist.
+	GetFollowing(ctx context.Context, username, userReferenceID string, page *pagination.Page) (*dto.FollowList, error)
+
+	// GetFollowRequests gets a page of the users waiting for the user with userReferenceID
+	// to approve their follow requests, ordered by username. Each user is marked with
+	// whether the user follows them.
+	GetFollowRequests(ctx context.Context, userReferenceID string, page *pagination.Page) (*dto.FollowList, error)
+
+	// GetBlockedUsers gets a page of the users blocked by the user
+	// with userReferenceID, ordered by username.
+	GetBlockedUsers(ctx context.Context, userReferenceID string, page *pagination.Page) (*dto.FollowList, error)
+
+	// GetExport gets the user's profile data, including the usernames of
+	// their followers and the users they follow, for their data export.
+	GetExport(ctx context.Context, userReferenceID string) (*dto.Export, error)
 }
 
 type userProvider struct {
@@ -48,11 +78,24 @@ func (p *userProvider) GetProfile(ctx context.Context, username, userReferenceID
 			ELSE CAST(0 AS BIT)
 		END AS [IsFollowing],
 		CASE [U].[Id] WHEN [CU].[Id] THEN CAST(1 AS BIT) ELSE CAST(0 AS BIT) END AS [IsOwner],
-		(SELECT COUNT([Id]) FROM [Posts] WHERE [UserId] = [U].[Id]) AS [PostCount]
+		(SELECT COUNT([Id]) FROM [Posts] WHERE [UserId] = [U].[Id]) AS [PostCount],
+		(SELECT COUNT([UserId]) FROM [UserFollowers] WHERE [FollowerId] = [U].[Id]) AS [FollowingCount],
+		[U].[IsPrivate],
+		CASE WHEN EXISTS (SELECT 1 FROM [FollowRequests]
+				WHERE [UserId] = [U].[Id] AND [FollowerId] = [CU].[Id])
+			THEN CAST(1 AS BIT)
+			ELSE CAST(0 AS BIT)
+		END AS [IsRequested],
+		CASE WHEN EXISTS (SELECT 1 FROM [UserMutes]
+				WHERE [UserId] = [CU].[Id] AND [MutedUserId] = [U].[Id])
+			THEN CAST(1 AS BIT)
+			ELSE CAST(0 AS BIT)
+		END AS [IsMuted]
 	FROM [Users] AS [U]
 	LEFT JOIN [Media] AS [M] ON [M].[Id] = [U].[MediaId]
 	INNER JOIN [Users] [CU] ON [CU].[ReferenceId] = @userReferenceId
-	WHERE [U].[Username] = @username;`
+	WHERE [U].[Username] = @username
+		AND [dbo].IsBlocked([U].[Id], [CU].[Id]) = 0;`
 
 	row, err := p.db.Single(ctx, query, sql.Named("username", username), sql.Named("userReferenceId", userReferenceID))
 	if err != nil {
@@ -69,6 +112,10 @@ func (p *userProvider) GetProfile(ctx context.Context, username, userReferenceID
 		&profile.IsFollowing,
 		&profile.IsOwner,
 		&profile.PostCount,
+		&profile.FollowingCount,
+		&profile.IsPrivate,
+		&profile.IsRequested,
+		&profile.IsMuted,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -86,7 +133,9 @@ func (p *userProvider) GetInfo(ctx context.Context, userReferenceID string) (*dt
 		CAST([U].[ReferenceId] AS CHAR(36)) AS [Id],
 		[U].[Username] AS [Username],
 		CAST([M].[ReferenceId] AS CHAR(36)) AS [MediaId],
-		(SELECT COUNT([UserId]) FROM [UserFollowers] WHERE [UserId] = [U].[Id]) AS [FollowerCount]
+		(SELECT COUNT([UserId]) FROM [UserFollowers] WHERE [UserId] = [U].[Id]) AS [FollowerCount],
+		(SELECT COUNT([UserId]) FROM [UserFollowers] WHERE [FollowerId] = [U].[Id]) AS [FollowingCount],
+		[U].[IsPrivate]
 	FROM [Users] AS [U]
 	LEFT JOIN [Media] AS [M] ON [M].[Id] = [U].[MediaId]
 	WHERE [U].[ReferenceId] = @userReferenceId;`
@@ -102,6 +151,8 @@ func (p *userProvider) GetInfo(ctx context.Context, userReferenceID string) (*dt
 		&info.Username,
 		&info.MediaID,
 		&info.FollowerCount,
+		&info.FollowingCount,
+		&info.IsPrivate,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -113,3 +164,220 @@ func (p *userProvider) GetInfo(ctx context.Context, userReferenceID string) (*dt
 
 	return &info, nil
 }
+
+// followListQuery is used to read a page of a user's follows. It's formatted with the column
+// of UserFollowers holding the user, and the column holding the users to list. Users who
+// have blocked, or been blocked by, the requesting user are left out.
+const followListQuery = `SELECT TOP (@limit)
+		CAST([F].[ReferenceId] AS CHAR(36)) AS [Id],
+		[F].[Username],
+		CAST([M].[ReferenceId] AS CHAR(36)) AS [MediaId],
+		CASE WHEN EXISTS (SELECT 1 FROM [UserFollowers]
+				WHERE [UserId] = [F].[Id] AND [FollowerId] = [CU].[Id])
+			THEN CAST(1 AS BIT)
+			ELSE CAST(0 AS BIT)
+		END AS [IsFollowing]
+	FROM [UserFollowers] AS [UF]
+	INNER JOIN [Users] AS [U] ON [U].[Id] = [UF].[%s]
+	INNER JOIN [Users] AS [F] ON [F].[Id] = [UF].[%s]
+	LEFT JOIN [Media] AS [M] ON [M].[Id] = [F].[MediaId]
+	LEFT JOIN [Users] AS [CU] ON [CU].[ReferenceId] = @userReferenceId
+	WHERE [U].[Username] = @username
+		AND [dbo].IsBlocked([F].[Id], [CU].[Id]) = 0
+		AND (@cursorUsername IS NULL OR [F].[Username] > @cursorUsername)
+	ORDER BY [F].[Username];`
+
+func (p *userProvider) GetFollowers(ctx context.Context, username, userReferenceID string, page *pagination.Page) (*dto.FollowList, error) {
+	return p.getFollowList(ctx, fmt.Sprintf(followListQuery, "UserId", "FollowerId"), username, userReferenceID, page)
+}
+
+func (p *userProvider) GetFollowing(ctx context.Context, username, userReferenceID string, page *pagination.Page) (*dto.FollowList, error) {
+	return p.getFollowList(ctx, fmt.Sprintf(followListQuery, "FollowerId", "UserId"), username, userReferenceID, page)
+}
+
+func (p *userProvider) getFollowList(ctx context.Context, query, username, userReferenceID string, page *pagination.Page) (*dto.FollowList, error) {
+	// An empty list is ambiguous, so check the user exists, and can be viewed, first.
+	// Users who have blocked, or been blocked by, the requesting user aren't found.
+	const canViewQuery = `SELECT [dbo].CanViewProfile([U].[Id], @userReferenceId) AS [CanView]
+		FROM [Users] AS [U]
+		LEFT JOIN [Users] AS [CU] ON [CU].[ReferenceId] = @userReferenceId
+		WHERE [U].[Username] = @username
+			AND [dbo].IsBlocked([U].[Id], [CU].[Id]) = 0;`
+
+	row, err := p.db.Single(ctx, canViewQuery, sql.Named("username", username), sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return nil, err
+	}
+
+	var canView bool
+	err = row.Scan(&canView)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = ErrProfileNotFound
+		}
+
+		return nil, err
+	}
+
+	if !canView {
+		return nil, ErrProfilePrivate
+	}
+
+	// Read an extra item to determine if there is a next page.
+	return p.readFollowList(ctx, query, page,
+		sql.Named("limit", page.Limit+1),
+		sql.Named("username", username),
+		sql.Named("userReferenceId", userReferenceID),
+		sql.Named("cursorUsername", page.CursorUsername()))
+}
+
+func (p *userProvider) GetFollowRequests(ctx context.Context, userReferenceID string, page *pagination.Page) (*dto.FollowList, error) {
+	const query = `SELECT TOP (@limit)
+		CAST([F].[ReferenceId] AS CHAR(36)) AS [Id],
+		[F].[Username],
+		CAST([M].[ReferenceId] AS CHAR(36)) AS [MediaId],
+		CASE WHEN EXISTS (SELECT 1 FROM [UserFollowers]
+				WHERE [UserId] = [F].[Id] AND [FollowerId] = [U].[Id])
+			THEN CAST(1 AS BIT)
+			ELSE CAST(0 AS BIT)
+		END AS [IsFollowing]
+	FROM [FollowRequests] AS [FR]
+	INNER JOIN [Users] AS [U] ON [U].[Id] = [FR].[UserId]
+	INNER JOIN [Users] AS [F] ON [F].[Id] = [FR].[FollowerId]
+	LEFT JOIN [Media] AS [M] ON [M].[Id] = [F].[MediaId]
+	WHERE [U].[ReferenceId] = @userReferenceId
+		AND (@cursorUsername IS NULL OR [F].[Username] > @cursorUsername)
+	ORDER BY [F].[Username];`
+
+	// Read an extra item to determine if there is a next page.
+	return p.readFollowList(ctx, query, page,
+		sql.Named("limit", page.Limit+1),
+		sql.Named("userReferenceId", userReferenceID),
+		sql.Named("cursorUsername", page.CursorUsername()))
+}
+
+func (p *userProvider) GetBlockedUsers(ctx context.Context, userReferenceID string, page *pagination.Page) (*dto.FollowList, error) {
+	// Blocks remove any follows between the users, so IsFollowing is always false.
+	const query = `SELECT TOP (@limit)
+		CAST([B].[ReferenceId] AS CHAR(36)) AS [Id],
+		[B].[Username],
+		CAST([M].[ReferenceId] AS CHAR(36)) AS [MediaId],
+		CAST(0 AS BIT) AS [IsFollowing]
+	FROM [UserBlocks] AS [UB]
+	INNER JOIN [Users] AS [U] ON [U].[Id] = [UB].[UserId]
+	INNER JOIN [Users] AS [B] ON [B].[Id] = [UB].[BlockedUserId]
+	LEFT JOIN [Media] AS [M] ON [M].[Id] = [B].[MediaId]
+	WHERE [U].[ReferenceId] = @userReferenceId
+		AND (@cursorUsername IS NULL OR [B].[Username] > @cursorUsername)
+	ORDER BY [B].[Username];`
+
+	// Read an extra item to determine if there is a next page.
+	return p.readFollowList(ctx, query, page,
+		sql.Named("limit", page.Limit+1),
+		sql.Named("userReferenceId", userReferenceID),
+		sql.Named("cursorUsername", page.CursorUsername()))
+}
+
+// readFollowList reads a page of follows, using the given query.
+func (p *userProvider) readFollowList(ctx context.Context, query string, page *pagination.Page, args ...interface{}) (*dto.FollowList, error) {
+	rows, err := p.db.Multiple(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []*dto.Follow{}
+
+	for rows.Next() {
+		var item dto.Follow
+		err := rows.Scan(
+			&item.ID,
+			&item.Username,
+			&item.MediaID,
+			&item.IsFollowing,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pagination.NewFollowList(items, page), nil
+}
+
+func (p *userProvider) GetExport(ctx context.Context, userReferenceID string) (*dto.Export, error) {
+	const query = `SELECT
+		CAST([U].[ReferenceId] AS CHAR(36)) AS [Id],
+		[U].[Username],
+		[U].[Bio],
+		CAST([M].[ReferenceId] AS CHAR(36)) AS [MediaId]
+	FROM [Users] AS [U]
+	LEFT JOIN [Media] AS [M] ON [M].[Id] = [U].[MediaId]
+	WHERE [U].[ReferenceId] = @userReferenceId;`
+
+	row, err := p.db.Single(ctx, query, sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return nil, err
+	}
+
+	export := dto.Export{
+		Followers: []string{},
+		Following: []string{},
+	}
+	err = row.Scan(
+		&export.ID,
+		&export.Username,
+		&export.Bio,
+		&export.MediaID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = ErrProfileNotFound
+		}
+
+		return nil, err
+	}
+
+	const followsQuery = `SELECT CAST(1 AS BIT) AS [IsFollower], [F].[Username]
+		FROM [UserFollowers] AS [UF]
+		INNER JOIN [Users] AS [U] ON [U].[Id] = [UF].[UserId]
+		INNER JOIN [Users] AS [F] ON [F].[Id] = [UF].[FollowerId]
+		WHERE [U].[ReferenceId] = @userReferenceId
+	UNION ALL
+	SELECT CAST(0 AS BIT) AS [IsFollower], [F].[Username]
+		FROM [UserFollowers] AS [UF]
+		INNER JOIN [Users] AS [U] ON [U].[Id] = [UF].[FollowerId]
+		INNER JOIN [Users] AS [F] ON [F].[Id] = [UF].[UserId]
+		WHERE [U].[ReferenceId] = @userReferenceId;`
+
+	rows, err := p.db.Multiple(ctx, followsQuery, sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var isFollower bool
+		var username string
+		err := rows.Scan(&isFollower, &username)
+		if err != nil {
+			return nil, err
+		}
+
+		if isFollower {
+			export.Followers = append(export.Followers, username)
+		} else {
+			export.Following = append(export.Following, username)
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
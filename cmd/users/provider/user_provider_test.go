@@ -9,6 +9,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/reecerussell/open-social/cmd/users/pagination"
 	mock "github.com/reecerussell/open-social/mock/database"
 )
 
@@ -31,6 +32,10 @@ func TestUserProvider_GetProfile_ReturnsProfileSuccessfully(t *testing.T) {
 			*(dest[5].(*bool)) = true
 			*(dest[6].(*bool)) = false
 			*(dest[7].(*int)) = 5
+			*(dest[8].(*int)) = 3
+			*(dest[9].(*bool)) = true
+			*(dest[10].(*bool)) = true
+			*(dest[11].(*bool)) = true
 
 			return nil
 		})
@@ -50,6 +55,10 @@ func TestUserProvider_GetProfile_ReturnsProfileSuccessfully(t *testing.T) {
 	assert.True(t, profile.IsFollowing)
 	assert.False(t, profile.IsOwner)
 	assert.Equal(t, 5, profile.PostCount)
+	assert.Equal(t, 3, profile.FollowingCount)
+	assert.True(t, profile.IsPrivate)
+	assert.True(t, profile.IsRequested)
+	assert.True(t, profile.IsMuted)
 }
 
 func TestUserProvider_GetProfileQueryFails_ReturnsError(t *testing.T) {
@@ -104,6 +113,7 @@ func TestUserProvider_GetInfo_ReturnsInfoSuccessfully(t *testing.T) {
 			*(dest[1].(*string)) = "test"
 			*(dest[2].(**string)) = nil
 			*(dest[3].(*int)) = 10
+			*(dest[4].(*int)) = 4
 
 			return nil
 		})
@@ -119,6 +129,7 @@ func TestUserProvider_GetInfo_ReturnsInfoSuccessfully(t *testing.T) {
 	assert.Equal(t, "test", info.Username)
 	assert.Nil(t, info.MediaID)
 	assert.Equal(t, 10, info.FollowerCount)
+	assert.Equal(t, 4, info.FollowingCount)
 }
 
 func TestUserProvider_GetInfoQueryFails_ReturnsError(t *testing.T) {
@@ -156,3 +167,268 @@ func TestUserProvider_GetInfoDoesNotExist_ReturnsError(t *testing.T) {
 	assert.Nil(t, info)
 	assert.Equal(t, ErrProfileNotFound, err)
 }
+
+func TestUserProvider_GetExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "320434"
+	testCtx := context.Background()
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*string)) = testUserReferenceID
+		*(dest[1].(*string)) = "test"
+		return nil
+	})
+
+	follows := []struct {
+		isFollower bool
+		username   string
+	}{
+		{true, "alice"},
+		{false, "bob"},
+		{true, "carol"},
+	}
+	i := 0
+	mockRows := mock.NewMockRows(ctrl)
+	mockRows.EXPECT().Next().DoAndReturn(func() bool {
+		return i < len(follows)
+	}).Times(len(follows) + 1)
+	mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*bool)) = follows[i].isFollower
+		*(dest[1].(*string)) = follows[i].username
+		i++
+		return nil
+	}).Times(len(follows))
+	mockRows.EXPECT().Err().Return(nil)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
+
+	provider := NewUserProvider(mockDatabase)
+	export, err := provider.GetExport(testCtx, testUserReferenceID)
+	assert.NoError(t, err)
+	assert.Equal(t, testUserReferenceID, export.ID)
+	assert.Equal(t, "test", export.Username)
+	assert.Equal(t, []string{"alice", "carol"}, export.Followers)
+	assert.Equal(t, []string{"bob"}, export.Following)
+}
+
+func TestUserProvider_GetExportDoesNotExist_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUserReferenceID := "320434"
+	testCtx := context.Background()
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).Return(sql.ErrNoRows)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	provider := NewUserProvider(mockDatabase)
+	export, err := provider.GetExport(testCtx, testUserReferenceID)
+	assert.Nil(t, export)
+	assert.Equal(t, ErrProfileNotFound, err)
+}
+
+func TestUserProvider_GetFollowers_ReturnsPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUsername := "test"
+	testUserReferenceID := "320434"
+	testCtx := context.Background()
+	testPage := &pagination.Page{Limit: 2}
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*bool)) = true
+		return nil
+	})
+
+	follows := []struct {
+		username    string
+		isFollowing bool
+	}{
+		{"alice", true},
+		{"bob", false},
+		{"carol", false},
+	}
+	i := 0
+	mockRows := mock.NewMockRows(ctrl)
+	mockRows.EXPECT().Next().DoAndReturn(func() bool {
+		return i < len(follows)
+	}).Times(len(follows) + 1)
+	mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*string)) = "id-" + follows[i].username
+		*(dest[1].(*string)) = follows[i].username
+		*(dest[3].(*bool)) = follows[i].isFollowing
+		i++
+		return nil
+	}).Times(len(follows))
+	mockRows.EXPECT().Err().Return(nil)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
+
+	provider := NewUserProvider(mockDatabase)
+	list, err := provider.GetFollowers(testCtx, testUsername, testUserReferenceID, testPage)
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 2)
+	assert.Equal(t, "id-alice", list.Items[0].ID)
+	assert.True(t, list.Items[0].IsFollowing)
+	assert.Equal(t, "bob", list.Items[1].Username)
+	assert.False(t, list.Items[1].IsFollowing)
+	assert.Equal(t, (&pagination.Cursor{Username: "bob"}).String(), *list.NextCursor)
+}
+
+func TestUserProvider_GetFollowingLastPage_ReturnsNoCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testUsername := "test"
+	testUserReferenceID := "320434"
+	testCtx := context.Background()
+	testPage := &pagination.Page{
+		Cursor: &pagination.Cursor{Username: "alice"},
+		Limit:  2,
+	}
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*bool)) = true
+		return nil
+	})
+
+	mockRows := mock.NewMockRows(ctrl)
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(false),
+	)
+	mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*string)) = "id-bob"
+		*(dest[1].(*string)) = "bob"
+		return nil
+	})
+	mockRows.EXPECT().Err().Return(nil)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
+
+	provider := NewUserProvider(mockDatabase)
+	list, err := provider.GetFollowing(testCtx, testUsername, testUserReferenceID, testPage)
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 1)
+	assert.Nil(t, list.NextCursor)
+}
+
+func TestUserProvider_GetFollowersDoesNotExist_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).Return(sql.ErrNoRows)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	provider := NewUserProvider(mockDatabase)
+	list, err := provider.GetFollowers(testCtx, "test", "320434", &pagination.Page{Limit: 2})
+	assert.Nil(t, list)
+	assert.Equal(t, ErrProfileNotFound, err)
+}
+
+func TestUserProvider_GetFollowersProfileIsPrivate_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*bool)) = false
+		return nil
+	})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	provider := NewUserProvider(mockDatabase)
+	list, err := provider.GetFollowers(testCtx, "test", "320434", &pagination.Page{Limit: 2})
+	assert.Nil(t, list)
+	assert.Equal(t, ErrProfilePrivate, err)
+}
+
+func TestUserProvider_GetFollowRequests_ReturnsPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRows := mock.NewMockRows(ctrl)
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(false),
+	)
+	mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*string)) = "id-alice"
+		*(dest[1].(*string)) = "alice"
+		return nil
+	})
+	mockRows.EXPECT().Err().Return(nil)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
+
+	provider := NewUserProvider(mockDatabase)
+	list, err := provider.GetFollowRequests(testCtx, "320434", &pagination.Page{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 1)
+	assert.Equal(t, "alice", list.Items[0].Username)
+	assert.Nil(t, list.NextCursor)
+}
+
+func TestUserProvider_GetBlockedUsers_ReturnsPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRows := mock.NewMockRows(ctrl)
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Next().Return(false),
+	)
+	gomock.InOrder(
+		mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*string)) = "id-alice"
+			*(dest[1].(*string)) = "alice"
+			return nil
+		}),
+		mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*string)) = "id-bob"
+			*(dest[1].(*string)) = "bob"
+			return nil
+		}),
+	)
+	mockRows.EXPECT().Err().Return(nil)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
+
+	provider := NewUserProvider(mockDatabase)
+	list, err := provider.GetBlockedUsers(testCtx, "320434", &pagination.Page{Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 1)
+	assert.Equal(t, "alice", list.Items[0].Username)
+	assert.Equal(t, (&pagination.Cursor{Username: "alice"}).String(), *list.NextCursor)
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/database"
+)
+
+// Account deletion data errors
+var (
+	ErrDeletionNotFound = errors.New("account deletion not found")
+)
+
+// AccountDeletionRepository is used to manipulate persisted account deletions.
+type AccountDeletionRepository interface {
+	// Save saves a new deletion, replacing any previous deletion for the user.
+	Save(ctx context.Context, d *model.AccountDeletion) error
+
+	// Get gets the user's deletion. Within a transaction, the deletion is
+	// locked until the transaction ends, so it can't be claimed concurrently.
+	Get(ctx context.Context, userReferenceID string) (*model.AccountDeletion, error)
+	Update(ctx context.Context, d *model.AccountDeletion) error
+
+	// Claim claims up to limit deletions which are due to be attempted, counting an attempt
+	// against each. Claimed deletions aren't attempted again until lease, unless they're updated.
+	Claim(ctx context.Context, now, lease time.Time, limit int) ([]*model.AccountDeletion, error)
+}
+
+type accountDeletionRepository struct {
+	db database.Database
+}
+
+// NewAccountDeletionRepository returns a new instance of AccountDeletionRepository.
+func NewAccountDeletionRepository(db database.Database) AccountDeletionRepository {
+	return &accountDeletionRepository{db: db}
+}
+
+func (r *accountDeletionRepository) Save(ctx context.Context, d *model.AccountDeletion) error {
+	const query = `MERGE [AccountDeletions] WITH (HOLDLOCK) AS [T]
+				USING (SELECT @userId AS [UserId]) AS [S] ON [T].[UserId] = [S].[UserId]
+				WHEN MATCHED THEN
+					UPDATE SET [UserReferenceId] = @userReferenceId, [Requested] = @requested,
+						[Scheduled] = @scheduled, [Cancelled] = NULL, [Completed] = NULL, [Attempts] = 0,
+						[NextAttempt] = @nextAttempt, [LastError] = NULL, [MediaIds] = @mediaIds
+				WHEN NOT MATCHED THEN
+					INSERT ([UserId],[UserReferenceId],[Requested],[Scheduled],[Attempts],[NextAttempt],[MediaIds])
+					VALUES (@userId, @userReferenceId, @requested, @scheduled, 0, @nextAttempt, @mediaIds)
+				OUTPUT [inserted].[Id];`
+
+	deletion := d.Dao()
+	row, err := r.db.Single(ctx, query,
+		sql.Named("userId", deletion.UserID),
+		sql.Named("userReferenceId", deletion.UserReferenceID),
+		sql.Named("requested", deletion.Requested),
+		sql.Named("scheduled", deletion.Scheduled),
+		sql.Named("nextAttempt", deletion.NextAttempt),
+		sql.Named("mediaIds", deletion.MediaIDs))
+	if err != nil {
+		return err
+	}
+
+	var id int64
+	err = row.Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	d.SetID(id)
+
+	return nil
+}
+
+func (r *accountDeletionRepository) Get(ctx context.Context, userReferenceID string) (*model.AccountDeletion, error) {
+	const query = `SELECT
+			[Id],
+			[UserId],
+			CAST([UserReferenceId] AS CHAR(36)),
+			[Requested],
+			[Scheduled],
+			[Cancelled],
+			[Completed],
+			[Attempts],
+			[NextAttempt],
+			[LastError],
+			[MediaIds]
+		FROM [AccountDeletions] WITH (UPDLOCK, ROWLOCK)
+		WHERE [UserReferenceId] = @userReferenceId;`
+
+	row, err := r.db.Single(ctx, query, sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return nil, err
+	}
+
+	deletion, err := scanAccountDeletion(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrDeletionNotFound
+		}
+
+		return nil, err
+	}
+
+	return deletion, nil
+}
+
+func (r *accountDeletionRepository) Update(ctx context.Context, d *model.AccountDeletion) error {
+	const query = `UPDATE [AccountDeletions] SET [Cancelled] = @cancelled, [Completed] = @completed,
+						[NextAttempt] = @nextAttempt, [LastError] = @lastError, [MediaIds] = @mediaIds
+					WHERE [Id] = @id;`
+
+	deletion := d.Dao()
+	_, err := r.db.Execute(ctx, query,
+		sql.Named("cancelled", deletion.Cancelled),
+		sql.Named("completed", deletion.Completed),
+		sql.Named("nextAttempt", deletion.NextAttempt),
+		sql.Named("lastError", deletion.LastError),
+		sql.Named("mediaIds", deletion.MediaIDs),
+		sql.Named("id", deletion.ID))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *accountDeletionRepository) Claim(ctx context.Context, now, lease time.Time, limit int) ([]*model.AccountDeletion, error) {
+	const query = `UPDATE TOP (@limit) [AccountDeletions] WITH (UPDLOCK, READPAST, ROWLOCK)
+				SET [Attempts] = [Attempts] + 1, [NextAttempt] = @lease
+				OUTPUT
+					[inserted].[Id],
+					[inserted].[UserId],
+					CAST([inserted].[UserReferenceId] AS CHAR(36)),
+					[inserted].[Requested],
+					[inserted].[Scheduled],
+					[inserted].[Cancelled],
+					[inserted].[Completed],
+					[inserted].[Attempts],
+					[inserted].[NextAttempt],
+					[inserted].[LastError],
+					[inserted].[MediaIds]
+				WHERE [Cancelled] IS NULL AND [Completed] IS NULL
+					AND [Scheduled] <= @now AND [NextAttempt] <= @now;`
+
+	rows, err := r.db.Multiple(ctx, query,
+		sql.Named("limit", limit),
+		sql.Named("lease", lease),
+		sql.Named("now", now))
+	if err != nil {
+		return nil, err
+	}
+
+	deletions := []*model.AccountDeletion{}
+
+	for rows.Next() {
+		deletion, err := scanAccountDeletion(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deletions = append(deletions, deletion)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return deletions, nil
+}
+
+// scanAccountDeletion reads an account deletion from either a database.Row, or database.Rows.
+func scanAccountDeletion(row database.Row) (*model.AccountDeletion, error) {
+	var deletion dao.AccountDeletion
+	err := row.Scan(
+		&deletion.ID,
+		&deletion.UserID,
+		&deletion.UserReferenceID,
+		&deletion.Requested,
+		&deletion.Scheduled,
+		&deletion.Cancelled,
+		&deletion.Completed,
+		&deletion.Attempts,
+		&deletion.NextAttempt,
+		&deletion.LastError,
+		&deletion.MediaIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.AccountDeletionFromDao(&deletion)
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestAccountDeletionRepository_Save_SetsID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	user := model.NewUserFromDao(&dao.User{ID: 1, ReferenceID: "2389"})
+	testDeletion := model.NewAccountDeletion(user, time.Hour)
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*int64)) = 12
+		return nil
+	})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	repo := NewAccountDeletionRepository(mockDatabase)
+	err := repo.Save(testCtx, testDeletion)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), testDeletion.Dao().ID)
+}
+
+func TestAccountDeletionRepository_GetNonExistantDeletion_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).Return(sql.ErrNoRows)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), sql.Named("userReferenceId", "2389")).Return(mockRow, nil)
+
+	repo := NewAccountDeletionRepository(mockDatabase)
+	deletion, err := repo.Get(testCtx, "2389")
+	assert.Nil(t, deletion)
+	assert.Equal(t, ErrDeletionNotFound, err)
+}
+
+func TestAccountDeletionRepository_Claim_ReturnsDeletions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testNow := time.Now().UTC()
+	testLease := testNow.Add(time.Minute)
+
+	mockRows := mock.NewMockRows(ctrl)
+	mockRows.EXPECT().Next().Return(true)
+	mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[2].(*string)) = "2389"
+		*(dest[7].(*int)) = 1
+		*(dest[10].(*string)) = `["9823"]`
+		return nil
+	})
+	mockRows.EXPECT().Next().Return(false)
+	mockRows.EXPECT().Err().Return(nil)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(),
+		sql.Named("limit", 10),
+		sql.Named("lease", testLease),
+		sql.Named("now", testNow)).Return(mockRows, nil)
+
+	repo := NewAccountDeletionRepository(mockDatabase)
+	deletions, err := repo.Claim(testCtx, testNow, testLease, 10)
+	assert.NoError(t, err)
+	assert.Len(t, deletions, 1)
+	assert.Equal(t, "2389", deletions[0].UserReferenceID())
+	assert.Equal(t, 1, deletions[0].Attempts())
+	assert.Equal(t, []string{"9823"}, deletions[0].MediaIDs())
+}
+
+func TestAccountDeletionRepository_ClaimQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(nil, testError)
+
+	repo := NewAccountDeletionRepository(mockDatabase)
+	deletions, err := repo.Claim(testCtx, time.Now(), time.Now(), 10)
+	assert.Nil(t, deletions)
+	assert.Equal(t, testError, err)
+}
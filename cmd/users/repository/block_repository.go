@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/reecerussell/open-social/database"
+)
+
+// Common errors
+var (
+	ErrBlockNotFound = errors.New("block not found")
+)
+
+// BlockRepository is used to manipulate the blocks between users.
+type BlockRepository interface {
+	// Create blocks the user for the blocker, removing any follows and follow
+	// requests between them. If the blocker doesn't exist, ErrUserNotFound is returned.
+	Create(ctx context.Context, userID int, blockerReferenceID string) error
+
+	// Delete removes the blocker's block of the user. If they haven't
+	// blocked the user, ErrBlockNotFound is returned.
+	Delete(ctx context.Context, userID int, blockerReferenceID string) error
+}
+
+type blockRepository struct {
+	db database.Database
+}
+
+// NewBlockRepository returns a new instance of BlockRepository.
+func NewBlockRepository(db database.Database) BlockRepository {
+	return &blockRepository{db: db}
+}
+
+func (r *blockRepository) Create(ctx context.Context, userID int, blockerReferenceID string) error {
+	const query = `INSERT INTO [UserBlocks] ([UserId], [BlockedUserId], [Blocked])
+		SELECT [Id], @userId, @blocked FROM [Users] WHERE [ReferenceId] = @blockerReferenceId;`
+
+	rowsAffected, err := r.db.Execute(ctx, query,
+		sql.Named("userId", userID),
+		sql.Named("blocked", time.Now().UTC()),
+		sql.Named("blockerReferenceId", blockerReferenceID))
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrUserNotFound
+	}
+
+	const unfollowQuery = `DELETE [UF] FROM [UserFollowers] AS [UF]
+			INNER JOIN [Users] AS [B] ON [B].[ReferenceId] = @blockerReferenceId
+			WHERE ([UF].[UserId] = @userId AND [UF].[FollowerId] = [B].[Id])
+				OR ([UF].[UserId] = [B].[Id] AND [UF].[FollowerId] = @userId);
+
+		DELETE [FR] FROM [FollowRequests] AS [FR]
+			INNER JOIN [Users] AS [B] ON [B].[ReferenceId] = @blockerReferenceId
+			WHERE ([FR].[UserId] = @userId AND [FR].[FollowerId] = [B].[Id])
+				OR ([FR].[UserId] = [B].[Id] AND [FR].[FollowerId] = @userId);`
+
+	_, err = r.db.Execute(ctx, unfollowQuery,
+		sql.Named("userId", userID),
+		sql.Named("blockerReferenceId", blockerReferenceID))
+
+	return err
+}
+
+func (r *blockRepository) Delete(ctx context.Context, userID int, blockerReferenceID string) error {
+	const query = `DELETE [UB] FROM [UserBlocks] AS [UB]
+		INNER JOIN [Users] AS [B] ON [B].[Id] = [UB].[UserId]
+		WHERE [UB].[BlockedUserId] = @userId AND [B].[ReferenceId] = @blockerReferenceId;`
+
+	rowsAffected, err := r.db.Execute(ctx, query, sql.Named("userId", userID), sql.Named("blockerReferenceId", blockerReferenceID))
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrBlockNotFound
+	}
+
+	return nil
+}
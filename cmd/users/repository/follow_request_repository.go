@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/reecerussell/open-social/database"
+)
+
+// Common errors
+var (
+	ErrFollowRequestNotFound = errors.New("follow request not found")
+)
+
+// FollowRequestRepository is used to manipulate the pending requests
+// to follow private users.
+type FollowRequestRepository interface {
+	Create(ctx context.Context, userID int, followerReferenceID string) error
+
+	// Delete deletes the follower's pending request to follow the user. If
+	// they don't have one, ErrFollowRequestNotFound is returned.
+	Delete(ctx context.Context, userID int, followerReferenceID string) error
+}
+
+type followRequestRepository struct {
+	db database.Database
+}
+
+// NewFollowRequestRepository returns a new instance of FollowRequestRepository.
+func NewFollowRequestRepository(db database.Database) FollowRequestRepository {
+	return &followRequestRepository{db: db}
+}
+
+func (r *followRequestRepository) Create(ctx context.Context, userID int, followerReferenceID string) error {
+	const query = `INSERT INTO [FollowRequests] ([UserId], [FollowerId], [Requested])
+		SELECT @userId, [Id], @requested FROM [Users] WHERE [ReferenceId] = @followerReferenceId;`
+
+	rowsAffected, err := r.db.Execute(ctx, query,
+		sql.Named("userId", userID),
+		sql.Named("requested", time.Now().UTC()),
+		sql.Named("followerReferenceId", followerReferenceID))
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrFollowerNotFound
+	}
+
+	return nil
+}
+
+func (r *followRequestRepository) Delete(ctx context.Context, userID int, followerReferenceID string) error {
+	const query = `DELETE [FR] FROM [FollowRequests] AS [FR]
+		INNER JOIN [Users] AS [F] ON [F].[Id] = [FR].[FollowerId]
+		WHERE [FR].[UserId] = @userId AND [F].[ReferenceId] = @followerReferenceId;`
+
+	rowsAffected, err := r.db.Execute(ctx, query, sql.Named("userId", userID), sql.Named("followerReferenceId", followerReferenceID))
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrFollowRequestNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestFollowRequestRepository_Create_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+	repo := NewFollowRequestRepository(mockDatabase)
+
+	err := repo.Create(testCtx, 10, "390274jlw")
+	assert.NoError(t, err)
+}
+
+func TestFollowRequestRepository_CreateFollowerDoesNotExist_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	repo := NewFollowRequestRepository(mockDatabase)
+
+	err := repo.Create(testCtx, 10, "390274jlw")
+	assert.Equal(t, ErrFollowerNotFound, err)
+}
+
+func TestFollowRequestRepository_Delete_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+	repo := NewFollowRequestRepository(mockDatabase)
+
+	err := repo.Delete(testCtx, 10, "390274jlw")
+	assert.NoError(t, err)
+}
+
+func TestFollowRequestRepository_DeleteNoRequest_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	repo := NewFollowRequestRepository(mockDatabase)
+
+	err := repo.Delete(testCtx, 10, "390274jlw")
+	assert.Equal(t, ErrFollowRequestNotFound, err)
+}
+
+func TestFollowRequestRepository_DeleteExecutionFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(-1), testError)
+
+	repo := NewFollowRequestRepository(mockDatabase)
+
+	err := repo.Delete(testCtx, 10, "390274jlw")
+	assert.Equal(t, testError, err)
+}
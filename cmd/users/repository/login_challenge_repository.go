@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/database"
+)
+
+// Login challenge data errors
+var (
+	ErrChallengeNotFound = errors.New("login challenge not found")
+)
+
+// LoginChallengeRepository is used to manipulate persisted login challenges.
+type LoginChallengeRepository interface {
+	Create(ctx context.Context, c *model.LoginChallenge) error
+
+	// Get gets a login challenge by its token. Within a transaction, the challenge
+	// is locked until the transaction ends, so it can't be attempted concurrently.
+	Get(ctx context.Context, token string) (*model.LoginChallenge, error)
+	Update(ctx context.Context, c *model.LoginChallenge) error
+}
+
+type loginChallengeRepository struct {
+	db database.Database
+}
+
+// NewLoginChallengeRepository returns a new instance of LoginChallengeRepository.
+func NewLoginChallengeRepository(db database.Database) LoginChallengeRepository {
+	return &loginChallengeRepository{db: db}
+}
+
+func (r *loginChallengeRepository) Create(ctx context.Context, c *model.LoginChallenge) error {
+	const query = `INSERT INTO [LoginChallenges] ([TokenHash],[UserId],[Created],[Expires],[Attempts])
+					VALUES (@tokenHash, @userId, @created, @expires, @attempts)
+				SELECT CAST(SCOPE_IDENTITY() AS BIGINT)`
+
+	challenge := c.Dao()
+	row, err := r.db.Single(ctx, query,
+		sql.Named("tokenHash", challenge.TokenHash),
+		sql.Named("userId", challenge.UserID),
+		sql.Named("created", challenge.Created),
+		sql.Named("expires", challenge.Expires),
+		sql.Named("attempts", challenge.Attempts))
+	if err != nil {
+		return err
+	}
+
+	var id int64
+	err = row.Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	c.SetID(id)
+
+	return nil
+}
+
+func (r *loginChallengeRepository) Get(ctx context.Context, token string) (*model.LoginChallenge, error) {
+	const query = `SELECT
+			[C].[Id],
+			[C].[TokenHash],
+			[C].[UserId],
+			CAST([U].[ReferenceId] AS CHAR(36)),
+			[C].[Created],
+			[C].[Expires],
+			[C].[Attempts],
+			[C].[Used]
+		FROM [LoginChallenges] AS [C] WITH (UPDLOCK, ROWLOCK)
+		INNER JOIN [Users] AS [U] ON [U].[Id] = [C].[UserId]
+		WHERE [C].[TokenHash] = @tokenHash;`
+
+	row, err := r.db.Single(ctx, query, sql.Named("tokenHash", model.HashLoginChallengeToken(token)))
+	if err != nil {
+		return nil, err
+	}
+
+	var challenge dao.LoginChallenge
+	err = row.Scan(
+		&challenge.ID,
+		&challenge.TokenHash,
+		&challenge.UserID,
+		&challenge.UserReferenceID,
+		&challenge.Created,
+		&challenge.Expires,
+		&challenge.Attempts,
+		&challenge.Used,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChallengeNotFound
+		}
+
+		return nil, err
+	}
+
+	return model.LoginChallengeFromDao(&challenge), nil
+}
+
+func (r *loginChallengeRepository) Update(ctx context.Context, c *model.LoginChallenge) error {
+	const query = `UPDATE [LoginChallenges] SET [Attempts] = @attempts, [Used] = @used WHERE [Id] = @id;`
+
+	challenge := c.Dao()
+	_, err := r.db.Execute(ctx, query,
+		sql.Named("attempts", challenge.Attempts),
+		sql.Named("used", challenge.Used),
+		sql.Named("id", challenge.ID))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
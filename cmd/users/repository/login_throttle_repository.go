@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/database"
+)
+
+// LoginThrottleRepository is used to persist the failed login attempts of users and IP addresses.
+type LoginThrottleRepository interface {
+	// Get gets the throttle with the key, or a new throttle if there have been
+	// no failed attempts. Within a transaction, the throttle is locked until
+	// the transaction ends, so failures can't be recorded concurrently.
+	Get(ctx context.Context, key string) (*model.LoginThrottle, error)
+	Save(ctx context.Context, t *model.LoginThrottle) error
+
+	// Delete clears the throttle with the key, unlocking it and resetting its backoff.
+	Delete(ctx context.Context, key string) error
+}
+
+type loginThrottleRepository struct {
+	db database.Database
+}
+
+// NewLoginThrottleRepository returns a new instance of LoginThrottleRepository.
+func NewLoginThrottleRepository(db database.Database) LoginThrottleRepository {
+	return &loginThrottleRepository{db: db}
+}
+
+func (r *loginThrottleRepository) Get(ctx context.Context, key string) (*model.LoginThrottle, error) {
+	const query = `SELECT [Key], [Failures], [Lockouts], [LastFailure], [LockedUntil]
+		FROM [LoginThrottles] WITH (UPDLOCK, HOLDLOCK)
+		WHERE [Key] = @key;`
+
+	row, err := r.db.Single(ctx, query, sql.Named("key", key))
+	if err != nil {
+		return nil, err
+	}
+
+	var throttle dao.LoginThrottle
+	err = row.Scan(
+		&throttle.Key,
+		&throttle.Failures,
+		&throttle.Lockouts,
+		&throttle.LastFailure,
+		&throttle.LockedUntil,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewLoginThrottle(key), nil
+		}
+
+		return nil, err
+	}
+
+	return model.LoginThrottleFromDao(&throttle), nil
+}
+
+func (r *loginThrottleRepository) Save(ctx context.Context, t *model.LoginThrottle) error {
+	const query = `MERGE [LoginThrottles] WITH (HOLDLOCK) AS [T]
+		USING (SELECT @key AS [Key]) AS [S] ON [T].[Key] = [S].[Key]
+		WHEN MATCHED THEN
+			UPDATE SET [Failures] = @failures, [Lockouts] = @lockouts,
+				[LastFailure] = @lastFailure, [LockedUntil] = @lockedUntil
+		WHEN NOT MATCHED THEN
+			INSERT ([Key], [Failures], [Lockouts], [LastFailure], [LockedUntil])
+			VALUES (@key, @failures, @lockouts, @lastFailure, @lockedUntil);`
+
+	throttle := t.Dao()
+	_, err := r.db.Execute(ctx, query,
+		sql.Named("key", throttle.Key),
+		sql.Named("failures", throttle.Failures),
+		sql.Named("lockouts", throttle.Lockouts),
+		sql.Named("lastFailure", throttle.LastFailure),
+		sql.Named("lockedUntil", throttle.LockedUntil))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *loginThrottleRepository) Delete(ctx context.Context, key string) error {
+	const query = `DELETE FROM [LoginThrottles] WHERE [Key] = @key;`
+
+	_, err := r.db.Execute(ctx, query, sql.Named("key", key))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/model"
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestLoginThrottleRepository_Get_ReturnsThrottle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*string)) = "user:2389"
+		*(dest[1].(*int)) = 2
+
+		return nil
+	})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	repo := NewLoginThrottleRepository(mockDatabase)
+	throttle, err := repo.Get(testCtx, "user:2389")
+	assert.NoError(t, err)
+	assert.Equal(t, "user:2389", throttle.Key())
+	assert.Equal(t, 2, throttle.Dao().Failures)
+}
+
+func TestLoginThrottleRepository_GetNonExistant_ReturnsNewThrottle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).Return(sql.ErrNoRows)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	repo := NewLoginThrottleRepository(mockDatabase)
+	throttle, err := repo.Get(testCtx, "ip:10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ip:10.0.0.1", throttle.Key())
+	assert.True(t, throttle.IsClear())
+}
+
+func TestLoginThrottleRepository_Save_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+	repo := NewLoginThrottleRepository(mockDatabase)
+	err := repo.Save(testCtx, model.NewLoginThrottle("user:2389"))
+	assert.NoError(t, err)
+}
+
+func TestLoginThrottleRepository_DeleteExecutionFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(-1), testError)
+
+	repo := NewLoginThrottleRepository(mockDatabase)
+	err := repo.Delete(testCtx, "user:2389")
+	assert.Equal(t, testError, err)
+}
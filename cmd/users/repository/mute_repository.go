@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/reecerussell/open-social/database"
+)
+
+// Common errors
+var (
+	ErrMuteNotFound = errors.New("mute not found")
+)
+
+// MuteRepository is used to manipulate the users muted by other users.
+// Muting a user hides their posts from the muter's feed, without unfollowing them.
+type MuteRepository interface {
+	// Create mutes the user for the muter. If the muter
+	// doesn't exist, ErrUserNotFound is returned.
+	Create(ctx context.Context, userID int, muterReferenceID string) error
+
+	// Delete unmutes the user for the muter. If they haven't
+	// muted the user, ErrMuteNotFound is returned.
+	Delete(ctx context.Context, userID int, muterReferenceID string) error
+}
+
+type muteRepository struct {
+	db database.Database
+}
+
+// NewMuteRepository returns a new instance of MuteRepository.
+func NewMuteRepository(db database.Database) MuteRepository {
+	return &muteRepository{db: db}
+}
+
+func (r *muteRepository) Create(ctx context.Context, userID int, muterReferenceID string) error {
+	const query = `INSERT INTO [UserMutes] ([UserId], [MutedUserId], [Muted])
+		SELECT [Id], @userId, @muted FROM [Users] WHERE [ReferenceId] = @muterReferenceId;`
+
+	rowsAffected, err := r.db.Execute(ctx, query,
+		sql.Named("userId", userID),
+		sql.Named("muted", time.Now().UTC()),
+		sql.Named("muterReferenceId", muterReferenceID))
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *muteRepository) Delete(ctx context.Context, userID int, muterReferenceID string) error {
+	const query = `DELETE [UM] FROM [UserMutes] AS [UM]
+		INNER JOIN [Users] AS [M] ON [M].[Id] = [UM].[UserId]
+		WHERE [UM].[MutedUserId] = @userId AND [M].[ReferenceId] = @muterReferenceId;`
+
+	rowsAffected, err := r.db.Execute(ctx, query, sql.Named("userId", userID), sql.Named("muterReferenceId", muterReferenceID))
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrMuteNotFound
+	}
+
+	return nil
+}
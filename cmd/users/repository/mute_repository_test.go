@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestMuteRepository_Create_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+	repo := NewMuteRepository(mockDatabase)
+
+	err := repo.Create(testCtx, 10, "390274jlw")
+	assert.NoError(t, err)
+}
+
+func TestMuteRepository_CreateUserDoesNotExist_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	repo := NewMuteRepository(mockDatabase)
+
+	err := repo.Create(testCtx, 10, "390274jlw")
+	assert.Equal(t, ErrUserNotFound, err)
+}
+
+func TestMuteRepository_CreateExecutionFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(-1), testError)
+
+	repo := NewMuteRepository(mockDatabase)
+
+	err := repo.Create(testCtx, 10, "390274jlw")
+	assert.Equal(t, testError, err)
+}
+
+func TestMuteRepository_Delete_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+	repo := NewMuteRepository(mockDatabase)
+
+	err := repo.Delete(testCtx, 10, "390274jlw")
+	assert.NoError(t, err)
+}
+
+func TestMuteRepository_DeleteNoMute_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	repo := NewMuteRepository(mockDatabase)
+
+	err := repo.Delete(testCtx, 10, "390274jlw")
+	assert.Equal(t, ErrMuteNotFound, err)
+}
+
+func TestMuteRepository_DeleteExecutionFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testError := errors.New("an error occured")
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(-1), testError)
+
+	repo := NewMuteRepository(mockDatabase)
+
+	err := repo.Delete(testCtx, 10, "390274jlw")
+	assert.Equal(t, testError, err)
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/database"
+)
+
+// Password reset token data errors
+var (
+	ErrResetTokenNotFound = errors.New("password reset token not found")
+)
+
+// PasswordResetTokenRepository is used to manipulate persisted password reset tokens.
+type PasswordResetTokenRepository interface {
+	Create(ctx context.Context, t *model.PasswordResetToken) error
+
+	// Get gets a password reset token by its value. Within a transaction, the token
+	// is locked until the transaction ends, so it can't be used concurrently.
+	Get(ctx context.Context, token string) (*model.PasswordResetToken, error)
+	Update(ctx context.Context, t *model.PasswordResetToken) error
+
+	// RevokeUser marks all of the user's unused tokens as used.
+	RevokeUser(ctx context.Context, userReferenceID string) error
+}
+
+type passwordResetTokenRepository struct {
+	db database.Database
+}
+
+// NewPasswordResetTokenRepository returns a new instance of PasswordResetTokenRepository.
+func NewPasswordResetTokenRepository(db database.Database) PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{db: db}
+}
+
+func (r *passwordResetTokenRepository) Create(ctx context.Context, t *model.PasswordResetToken) error {
+	const query = `INSERT INTO [PasswordResetTokens] ([TokenHash],[UserId],[Created],[Expires])
+					VALUES (@tokenHash, @userId, @created, @expires)
+				SELECT CAST(SCOPE_IDENTITY() AS BIGINT)`
+
+	token := t.Dao()
+	row, err := r.db.Single(ctx, query,
+		sql.Named("tokenHash", token.TokenHash),
+		sql.Named("userId", token.UserID),
+		sql.Named("created", token.Created),
+		sql.Named("expires", token.Expires))
+	if err != nil {
+		return err
+	}
+
+	var id int64
+	err = row.Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	t.SetID(id)
+
+	return nil
+}
+
+func (r *passwordResetTokenRepository) Get(ctx context.Context, token string) (*model.PasswordResetToken, error) {
+	const query = `SELECT
+			[T].[Id],
+			[T].[TokenHash],
+			[T].[UserId],
+			CAST([U].[ReferenceId] AS CHAR(36)),
+			[T].[Created],
+			[T].[Expires],
+			[T].[Used]
+		FROM [PasswordResetTokens] AS [T] WITH (UPDLOCK, ROWLOCK)
+		INNER JOIN [Users] AS [U] ON [U].[Id] = [T].[UserId]
+		WHERE [T].[TokenHash] = @tokenHash;`
+
+	row, err := r.db.Single(ctx, query, sql.Named("tokenHash", model.HashPasswordResetToken(token)))
+	if err != nil {
+		return nil, err
+	}
+
+	var resetToken dao.PasswordResetToken
+	err = row.Scan(
+		&resetToken.ID,
+		&resetToken.TokenHash,
+		&resetToken.UserID,
+		&resetToken.UserReferenceID,
+		&resetToken.Created,
+		&resetToken.Expires,
+		&resetToken.Used,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrResetTokenNotFound
+		}
+
+		return nil, err
+	}
+
+	return model.PasswordResetTokenFromDao(&resetToken), nil
+}
+
+func (r *passwordResetTokenRepository) Update(ctx context.Context, t *model.PasswordResetToken) error {
+	const query = `UPDATE [PasswordResetTokens] SET [Used] = @used WHERE [Id] = @id;`
+
+	token := t.Dao()
+	_, err := r.db.Execute(ctx, query,
+		sql.Named("used", token.Used),
+		sql.Named("id", token.ID))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *passwordResetTokenRepository) RevokeUser(ctx context.Context, userReferenceID string) error {
+	const query = `UPDATE [T] SET [T].[Used] = @used
+					FROM [PasswordResetTokens] AS [T]
+					INNER JOIN [Users] AS [U] ON [U].[Id] = [T].[UserId]
+					WHERE [U].[ReferenceId] = @userReferenceId AND [T].[Used] IS NULL;`
+
+	_, err := r.db.Execute(ctx, query,
+		sql.Named("used", time.Now().UTC()),
+		sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
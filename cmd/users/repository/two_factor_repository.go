@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	"github.com/reecerussell/open-social/database"
+)
+
+// Two-factor authentication data errors
+var (
+	ErrTwoFactorNotFound    = errors.New("two-factor authentication is not set up")
+	ErrRecoveryCodeNotFound = errors.New("recovery code not found")
+)
+
+// TwoFactorRepository is used to manipulate persisted two-factor authenticators,
+// and their recovery codes. Each user has at most one authenticator.
+type TwoFactorRepository interface {
+	// Get gets the user's authenticator. Within a transaction, it's locked until
+	// the transaction ends, so a code can't be used concurrently.
+	Get(ctx context.Context, userReferenceID string) (*model.TwoFactor, error)
+
+	// Save creates or replaces the authenticator of its user.
+	Save(ctx context.Context, t *model.TwoFactor) error
+
+	// Delete deletes the user's authenticator, and their recovery codes.
+	Delete(ctx context.Context, userReferenceID string) error
+
+	// SetRecoveryCodes replaces the recovery codes of the authenticator's user.
+	SetRecoveryCodes(ctx context.Context, t *model.TwoFactor, codes []*model.RecoveryCode) error
+
+	// GetRecoveryCode gets one of the user's recovery codes, by its value. Within a
+	// transaction, it's locked until the transaction ends, so it can't be used concurrently.
+	GetRecoveryCode(ctx context.Context, userReferenceID, code string) (*model.RecoveryCode, error)
+	UpdateRecoveryCode(ctx context.Context, c *model.RecoveryCode) error
+}
+
+type twoFactorRepository struct {
+	db database.Database
+}
+
+// NewTwoFactorRepository returns a new instance of TwoFactorRepository.
+func NewTwoFactorRepository(db database.Database) TwoFactorRepository {
+	return &twoFactorRepository{db: db}
+}
+
+func (r *twoFactorRepository) Get(ctx context.Context, userReferenceID string) (*model.TwoFactor, error) {
+	const query = `SELECT
+			[T].[Id],
+			[T].[UserId],
+			CAST([U].[ReferenceId] AS CHAR(36)),
+			[T].[Secret],
+			[T].[Enabled],
+			[T].[LastUsedStep],
+			[T].[Created]
+		FROM [TwoFactors] AS [T] WITH (UPDLOCK, ROWLOCK)
+		INNER JOIN [Users] AS [U] ON [U].[Id] = [T].[UserId]
+		WHERE [U].[ReferenceId] = @userReferenceId;`
+
+	row, err := r.db.Single(ctx, query, sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return nil, err
+	}
+
+	var twoFactor dao.TwoFactor
+	err = row.Scan(
+		&twoFactor.ID,
+		&twoFactor.UserID,
+		&twoFactor.UserReferenceID,
+		&twoFactor.Secret,
+		&twoFactor.Enabled,
+		&twoFactor.LastUsedStep,
+		&twoFactor.Created,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTwoFactorNotFound
+		}
+
+		return nil, err
+	}
+
+	return model.TwoFactorFromDao(&twoFactor), nil
+}
+
+func (r *twoFactorRepository) Save(ctx context.Context, t *model.TwoFactor) error {
+	const query = `MERGE [TwoFactors] WITH (HOLDLOCK) AS [T]
+		USING (SELECT @userId AS [UserId]) AS [S] ON [T].[UserId] = [S].[UserId]
+		WHEN MATCHED THEN
+			UPDATE SET [Secret] = @secret, [Enabled] = @enabled,
+				[LastUsedStep] = @lastUsedStep, [Created] = @created
+		WHEN NOT MATCHED THEN
+			INSERT ([UserId], [Secret], [Enabled], [LastUsedStep], [Created])
+			VALUES (@userId, @secret, @enabled, @lastUsedStep, @created)
+		OUTPUT [inserted].[Id];`
+
+	twoFactor := t.Dao()
+	row, err := r.db.Single(ctx, query,
+		sql.Named("userId", twoFactor.UserID),
+		sql.Named("secret", twoFactor.Secret),
+		sql.Named("enabled", twoFactor.Enabled),
+		sql.Named("lastUsedStep", twoFactor.LastUsedStep),
+		sql.Named("created", twoFactor.Created))
+	if err != nil {
+		return err
+	}
+
+	var id int
+	err = row.Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	t.SetID(id)
+
+	return nil
+}
+
+func (r *twoFactorRepository) Delete(ctx context.Context, userReferenceID string) error {
+	const query = `DECLARE @userId INT = (SELECT [Id] FROM [Users] WHERE [ReferenceId] = @userReferenceId);
+		DELETE FROM [RecoveryCodes] WHERE [UserId] = @userId;
+		DELETE FROM [TwoFactors] WHERE [UserId] = @userId;`
+
+	_, err := r.db.Execute(ctx, query, sql.Named("userReferenceId", userReferenceID))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *twoFactorRepository) SetRecoveryCodes(ctx context.Context, t *model.TwoFactor, codes []*model.RecoveryCode) error {
+	const deleteQuery = `DELETE FROM [RecoveryCodes] WHERE [UserId] = @userId;`
+	const insertQuery = `INSERT INTO [RecoveryCodes] ([UserId],[CodeHash],[Used])
+					VALUES (@userId, @codeHash, @used)
+				SELECT CAST(SCOPE_IDENTITY() AS BIGINT)`
+
+	userID := t.Dao().UserID
+	_, err := r.db.Execute(ctx, deleteQuery, sql.Named("userId", userID))
+	if err != nil {
+		return err
+	}
+
+	for _, c := range codes {
+		code := c.Dao()
+		row, err := r.db.Single(ctx, insertQuery,
+			sql.Named("userId", userID),
+			sql.Named("codeHash", code.CodeHash),
+			sql.Named("used", code.Used))
+		if err != nil {
+			return err
+		}
+
+		var id int64
+		err = row.Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		c.SetID(id)
+	}
+
+	return nil
+}
+
+func (r *twoFactorRepository) GetRecoveryCode(ctx context.Context, userReferenceID, code string) (*model.RecoveryCode, error) {
+	const query = `SELECT [C].[Id], [C].[UserId], [C].[CodeHash], [C].[Used]
+		FROM [RecoveryCodes] AS [C] WITH (UPDLOCK, ROWLOCK)
+		INNER JOIN [Users] AS [U] ON [U].[Id] = [C].[UserId]
+		WHERE [U].[ReferenceId] = @userReferenceId AND [C].[CodeHash] = @codeHash;`
+
+	row, err := r.db.Single(ctx, query,
+		sql.Named("userReferenceId", userReferenceID),
+		sql.Named("codeHash", model.HashRecoveryCode(code)))
+	if err != nil {
+		return nil, err
+	}
+
+	var recoveryCode dao.RecoveryCode
+	err = row.Scan(
+		&recoveryCode.ID,
+		&recoveryCode.UserID,
+		&recoveryCode.CodeHash,
+		&recoveryCode.Used,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrRecoveryCodeNotFound
+		}
+
+		return nil, err
+	}
+
+	return model.RecoveryCodeFromDao(&recoveryCode), nil
+}
+
+func (r *twoFactorRepository) UpdateRecoveryCode(ctx context.Context, c *model.RecoveryCode) error {
+	const query = `UPDATE [RecoveryCodes] SET [Used] = @used WHERE [Id] = @id;`
+
+	code := c.Dao()
+	_, err := r.db.Execute(ctx, query,
+		sql.Named("used", code.Used),
+		sql.Named("id", code.ID))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
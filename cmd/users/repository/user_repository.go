@@ -7,9 +7,7 @@ import (
 
 	"github.com/reecerussell/open-social/cmd/users/dao"
 	"github.com/reecerussell/open-social/cmd/users/model"
-
-	// MSSQL driver
-	_ "github.com/denisenkom/go-mssqldb"
+	"github.com/reecerussell/open-social/database"
 )
 
 var (
@@ -25,36 +23,34 @@ type UserRepository interface {
 	// for the user with the reference userReferenceID.
 	GetUserByReference(ctx context.Context, referenceID, userReferenceID string) (*model.User, error)
 	GetIDByReference(ctx context.Context, referenceID string) (*int, error)
+	Update(ctx context.Context, u *model.User) error
+
+	// Delete deletes the user, along with their follows, blocks, mutes and login data,
+	// returning the reference id of their avatar, if they have one, which is left to be
+	// deleted by the media service. Deleting a user who doesn't exist isn't an error.
+	Delete(ctx context.Context, referenceID string) ([]string, error)
 }
 
 type userRepository struct {
-	url string
+	db database.Database
 }
 
-func NewUserRepository(url string) UserRepository {
-	return &userRepository{url: url}
+func NewUserRepository(db database.Database) UserRepository {
+	return &userRepository{db: db}
 }
 
 func (r *userRepository) Create(ctx context.Context, u *model.User) error {
-	db, err := sql.Open("sqlserver", r.url)
-	if err != nil {
-		return err
-	}
-
 	const query = `INSERT INTO [Users] ([ReferenceId],[Username],[PasswordHash])
 					VALUES (NEWID(), @username, @passwordHash)
 				SELECT [Id], CAST([ReferenceId] AS CHAR(36)) FROM [Users] WHERE [Id] = SCOPE_IDENTITY()`
 
-	stmt, err := db.PrepareContext(ctx, query)
-	if err != nil {
-		return err
-	}
-	defer stmt.Close()
-
 	user := u.Dao()
-	row := stmt.QueryRowContext(ctx,
+	row, err := r.db.Single(ctx, query,
 		sql.Named("username", user.Username),
 		sql.Named("passwordHash", user.PasswordHash))
+	if err != nil {
+		return err
+	}
 
 	// Read the user's ids
 	err = row.Scan(&user.ID, &user.ReferenceID)
@@ -70,27 +66,21 @@ func (r *userRepository) Create(ctx context.Context, u *model.User) error {
 }
 
 func (r *userRepository) DoesUsernameExist(ctx context.Context, username string, excludeRefID *string) (bool, error) {
-	db, err := sql.Open("sqlserver", r.url)
-	if err != nil {
-		return false, err
-	}
-
 	query := "SELECT COUNT(*) FROM [Users] WHERE [Username] = @username"
 	args := []interface{}{sql.Named("username", username)}
 
 	if excludeRefID != nil {
-		query += " [ReferenceId] != @referenceId"
+		query += " AND [ReferenceId] != @referenceId"
 		args = append(args, sql.Named("referenceId", *excludeRefID))
 	}
 
-	stmt, err := db.PrepareContext(ctx, query)
+	row, err := r.db.Single(ctx, query, args...)
 	if err != nil {
 		return false, err
 	}
-	defer stmt.Close()
 
 	var count int64
-	err = stmt.QueryRowContext(ctx, args...).Scan(&count)
+	err = row.Scan(&count)
 	if err != nil {
 		return false, err
 	}
@@ -99,26 +89,23 @@ func (r *userRepository) DoesUsernameExist(ctx context.Context, username string,
 }
 
 func (r *userRepository) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
-	db, err := sql.Open("sqlserver", r.url)
-	if err != nil {
-		return nil, err
-	}
-
-	const query = `SELECT [Id], CAST([ReferenceId] AS CHAR(36)), [Username], [PasswordHash]
+	const query = `SELECT [Id], CAST([ReferenceId] AS CHAR(36)), [Username], [PasswordHash], [MediaId], [Bio], [IsPrivate]
 					FROM [Users] WHERE [Username] = @username`
 
-	stmt, err := db.PrepareContext(ctx, query)
+	row, err := r.db.Single(ctx, query, sql.Named("username", username))
 	if err != nil {
 		return nil, err
 	}
-	defer stmt.Close()
 
 	var user dao.User
-	err = stmt.QueryRowContext(ctx, sql.Named("username", username)).Scan(
+	err = row.Scan(
 		&user.ID,
 		&user.ReferenceID,
 		&user.Username,
 		&user.PasswordHash,
+		&user.MediaID,
+		&user.Bio,
+		&user.IsPrivate,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -132,23 +119,15 @@ func (r *userRepository) GetUserByUsername(ctx context.Context, username string)
 }
 
 func (r *userRepository) GetIDByReference(ctx context.Context, referenceID string) (*int, error) {
-	db, err := sql.Open("sqlserver", r.url)
-	if err != nil {
-		return nil, err
-	}
-
 	const query = `SELECT [Id] FROM [Users] WHERE [ReferenceId] = @referenceId;`
 
-	stmt, err := db.PrepareContext(ctx, query)
+	row, err := r.db.Single(ctx, query, sql.Named("referenceID", referenceID))
 	if err != nil {
 		return nil, err
 	}
-	defer stmt.Close()
 
 	var id int
-	err = stmt.QueryRowContext(ctx, sql.Named("referenceID", referenceID)).Scan(
-		&id,
-	)
+	err = row.Scan(&id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrUserNotFound
@@ -161,40 +140,68 @@ func (r *userRepository) GetIDByReference(ctx context.Context, referenceID strin
 }
 
 func (r *userRepository) GetUserByReference(ctx context.Context, referenceID, userReferenceID string) (*model.User, error) {
-	db, err := sql.Open("sqlserver", r.url)
-	if err != nil {
-		return nil, err
-	}
-
 	const query = `SELECT
 		[U].[Id],
 		CAST([U].[ReferenceId] AS CHAR(36)),
 		[U].[Username],
 		[U].[PasswordHash],
+		[U].[MediaId],
+		[U].[Bio],
+		[U].[IsPrivate],
 		CASE (SELECT COUNT([UserId]) FROM [UserFollowers] AS [UF]
 				INNER JOIN [Users] AS [F] ON [F].[Id] = [UF].[FollowerId]
 				WHERE [UF].[UserId] = [U].[Id] AND [F].[ReferenceId] = @userReferenceId)
 			WHEN 1 THEN CAST(1 AS BIT)
 			ELSE CAST(0 AS BIT)
-		END AS [IsFollowing]
+		END AS [IsFollowing],
+		CASE (SELECT COUNT([UserId]) FROM [FollowRequests] AS [FR]
+				INNER JOIN [Users] AS [F] ON [F].[Id] = [FR].[FollowerId]
+				WHERE [FR].[UserId] = [U].[Id] AND [F].[ReferenceId] = @userReferenceId)
+			WHEN 1 THEN CAST(1 AS BIT)
+			ELSE CAST(0 AS BIT)
+		END AS [HasRequested],
+		CASE WHEN EXISTS (SELECT 1 FROM [UserBlocks] AS [UB]
+				INNER JOIN [Users] AS [B] ON [B].[Id] = [UB].[UserId]
+				WHERE [UB].[BlockedUserId] = [U].[Id] AND [B].[ReferenceId] = @userReferenceId)
+			THEN CAST(1 AS BIT)
+			ELSE CAST(0 AS BIT)
+		END AS [IsBlocking],
+		CASE WHEN EXISTS (SELECT 1 FROM [UserBlocks] AS [UB]
+				INNER JOIN [Users] AS [B] ON [B].[Id] = [UB].[BlockedUserId]
+				WHERE [UB].[UserId] = [U].[Id] AND [B].[ReferenceId] = @userReferenceId)
+			THEN CAST(1 AS BIT)
+			ELSE CAST(0 AS BIT)
+		END AS [IsBlockedBy],
+		CASE WHEN EXISTS (SELECT 1 FROM [UserMutes] AS [UM]
+				INNER JOIN [Users] AS [M] ON [M].[Id] = [UM].[UserId]
+				WHERE [UM].[MutedUserId] = [U].[Id] AND [M].[ReferenceId] = @userReferenceId)
+			THEN CAST(1 AS BIT)
+			ELSE CAST(0 AS BIT)
+		END AS [IsMuting]
 		FROM [Users] AS [U]
 		WHERE [U].[ReferenceId] = @referenceId;`
 
-	stmt, err := db.PrepareContext(ctx, query)
+	row, err := r.db.Single(ctx, query,
+		sql.Named("referenceId", referenceID),
+		sql.Named("userReferenceId", userReferenceID))
 	if err != nil {
 		return nil, err
 	}
-	defer stmt.Close()
 
 	var user dao.User
-	err = stmt.QueryRowContext(ctx,
-		sql.Named("referenceId", referenceID),
-		sql.Named("userReferenceId", userReferenceID)).Scan(
+	err = row.Scan(
 		&user.ID,
 		&user.ReferenceID,
 		&user.Username,
 		&user.PasswordHash,
+		&user.MediaID,
+		&user.Bio,
+		&user.IsPrivate,
 		&user.IsFollowing,
+		&user.HasRequested,
+		&user.IsBlocking,
+		&user.IsBlockedBy,
+		&user.IsMuting,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -206,3 +213,78 @@ func (r *userRepository) GetUserByReference(ctx context.Context, referenceID, us
 
 	return model.NewUserFromDao(&user), nil
 }
+
+func (r *userRepository) Update(ctx context.Context, u *model.User) error {
+	const query = `UPDATE [Users] SET [Username] = @username, [PasswordHash] = @passwordHash,
+						[MediaId] = @mediaId, [Bio] = @bio, [IsPrivate] = @isPrivate
+					WHERE [Id] = @id;`
+
+	user := u.Dao()
+	rowsAffected, err := r.db.Execute(ctx, query,
+		sql.Named("username", user.Username),
+		sql.Named("passwordHash", user.PasswordHash),
+		sql.Named("mediaId", user.MediaID),
+		sql.Named("bio", user.Bio),
+		sql.Named("isPrivate", user.IsPrivate),
+		sql.Named("id", user.ID))
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, referenceID string) ([]string, error) {
+	const query = `SET NOCOUNT ON;
+
+		DECLARE @userId INT = (SELECT [Id] FROM [Users] WHERE [ReferenceId] = @referenceId);
+		DECLARE @media TABLE ([ReferenceId] CHAR(36));
+
+		INSERT INTO @media SELECT CAST([M].[ReferenceId] AS CHAR(36))
+			FROM [Users] AS [U]
+			INNER JOIN [Media] AS [M] ON [M].[Id] = [U].[MediaId]
+			WHERE [U].[Id] = @userId;
+
+		DELETE FROM [UserFollowers] WHERE [UserId] = @userId OR [FollowerId] = @userId;
+		DELETE FROM [FollowRequests] WHERE [UserId] = @userId OR [FollowerId] = @userId;
+		DELETE FROM [UserBlocks] WHERE [UserId] = @userId OR [BlockedUserId] = @userId;
+		DELETE FROM [UserMutes] WHERE [UserId] = @userId OR [MutedUserId] = @userId;
+		DELETE FROM [RecoveryCodes] WHERE [UserId] = @userId;
+		DELETE FROM [TwoFactors] WHERE [UserId] = @userId;
+		DELETE FROM [LoginChallenges] WHERE [UserId] = @userId;
+		DELETE FROM [PasswordResetTokens] WHERE [UserId] = @userId;
+		DELETE FROM [LoginThrottles] WHERE [Key] = @throttleKey;
+		DELETE FROM [Users] WHERE [Id] = @userId;
+
+		SELECT [ReferenceId] FROM @media;`
+
+	rows, err := r.db.Multiple(ctx, query,
+		sql.Named("referenceId", referenceID),
+		sql.Named("throttleKey", model.UserThrottleKey(referenceID)))
+	if err != nil {
+		return nil, err
+	}
+
+	mediaIDs := []string{}
+
+	for rows.Next() {
+		var id string
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		mediaIDs = append(mediaIDs, id)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return mediaIDs, nil
+}
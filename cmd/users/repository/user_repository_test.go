@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/cmd/users/dao"
+	"github.com/reecerussell/open-social/cmd/users/model"
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestUserRepository_Create_SetsIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testUser := model.NewUserFromDao(&dao.User{Username: "test"})
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*int)) = 12
+		*(dest[1].(*string)) = "2389"
+
+		return nil
+	})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	repo := NewUserRepository(mockDatabase)
+	err := repo.Create(testCtx, testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, testUser.ID())
+	assert.Equal(t, "2389", testUser.ReferenceID())
+}
+
+func TestUserRepository_CreateQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(nil, testError)
+
+	repo := NewUserRepository(mockDatabase)
+	err := repo.Create(testCtx, model.NewUserFromDao(&dao.User{}))
+	assert.Equal(t, testError, err)
+}
+
+func TestUserRepository_GetIDByReferenceNonExistantUser_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).Return(sql.ErrNoRows)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any()).Return(mockRow, nil)
+
+	repo := NewUserRepository(mockDatabase)
+	id, err := repo.GetIDByReference(testCtx, "2389")
+	assert.Nil(t, id)
+	assert.Equal(t, ErrUserNotFound, err)
+}
+
+func TestUserRepository_Update_ReturnsNoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+	repo := NewUserRepository(mockDatabase)
+	err := repo.Update(testCtx, model.NewUserFromDao(&dao.User{ID: 12}))
+	assert.NoError(t, err)
+}
+
+func TestUserRepository_UpdateNonExistantUser_ReturnsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	repo := NewUserRepository(mockDatabase)
+	err := repo.Update(testCtx, model.NewUserFromDao(&dao.User{ID: 12}))
+	assert.Equal(t, ErrUserNotFound, err)
+}
+
+func TestUserRepository_DoesUsernameExist_ExcludesUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testReferenceID := "2389"
+
+	mockRow := mock.NewMockRow(ctrl)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*dest[0].(*int64) = 0
+		return nil
+	})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Single(testCtx, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, query string, args ...interface{}) (*mock.MockRow, error) {
+			assert.Contains(t, query, "WHERE [Username] = @username AND [ReferenceId] != @referenceId")
+			assert.Len(t, args, 2)
+			return mockRow, nil
+		})
+
+	repo := NewUserRepository(mockDatabase)
+	exists, err := repo.DoesUsernameExist(testCtx, "john", &testReferenceID)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestUserRepository_Delete_ReturnsMediaIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockRows := mock.NewMockRows(ctrl)
+	mockRows.EXPECT().Next().Return(true)
+	mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*string)) = "9823"
+		return nil
+	})
+	mockRows.EXPECT().Next().Return(false)
+	mockRows.EXPECT().Err().Return(nil)
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(),
+		sql.Named("referenceId", "2389"),
+		sql.Named("throttleKey", "user:2389")).Return(mockRows, nil)
+
+	repo := NewUserRepository(mockDatabase)
+	mediaIDs, err := repo.Delete(testCtx, "2389")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"9823"}, mediaIDs)
+}
+
+func TestUserRepository_DeleteQueryFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(nil, testError)
+
+	repo := NewUserRepository(mockDatabase)
+	mediaIDs, err := repo.Delete(testCtx, "2389")
+	assert.Nil(t, mediaIDs)
+	assert.Equal(t, testError, err)
+}
@@ -0,0 +1,89 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is the SHA1 secret used by the test vectors in RFC 6238, appendix B.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// The RFC's vectors are eight digits long, so only the last six are compared.
+	tests := map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	}
+
+	for unix, exp := range tests {
+		code, err := Code(rfcSecret, Step(time.Unix(unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, exp[2:], code)
+	}
+}
+
+func TestCode_GivenInvalidSecret_ReturnsError(t *testing.T) {
+	code, err := Code("not base32!", 1)
+	assert.Equal(t, "", code)
+	assert.Error(t, err)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	other, _ := GenerateSecret()
+	assert.NotEqual(t, secret, other)
+
+	_, err = Code(secret, 1)
+	assert.NoError(t, err)
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+
+	t.Run("Current Step", func(t *testing.T) {
+		code, _ := Code(rfcSecret, step)
+		matched, ok := Verify(rfcSecret, code, now, 0)
+		assert.True(t, ok)
+		assert.Equal(t, step, matched)
+	})
+
+	t.Run("Within Skew", func(t *testing.T) {
+		code, _ := Code(rfcSecret, step-1)
+		matched, ok := Verify(rfcSecret, code, now, 0)
+		assert.True(t, ok)
+		assert.Equal(t, step-1, matched)
+	})
+
+	t.Run("Outside Skew", func(t *testing.T) {
+		code, _ := Code(rfcSecret, step-2)
+		_, ok := Verify(rfcSecret, code, now, 0)
+		assert.False(t, ok)
+	})
+
+	t.Run("Already Used", func(t *testing.T) {
+		code, _ := Code(rfcSecret, step)
+		_, ok := Verify(rfcSecret, code, now, step)
+		assert.False(t, ok)
+	})
+
+	t.Run("Wrong Length", func(t *testing.T) {
+		_, ok := Verify(rfcSecret, "12345", now, 0)
+		assert.False(t, ok)
+	})
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Open Social", "john", "JBSWY3DPEHPK3PXP")
+	assert.Equal(t, "otpauth://totp/Open%20Social:john?algorithm=SHA1&digits=6&issuer=Open+Social&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+}
@@ -3,6 +3,9 @@ package database
 import (
 	"context"
 	"database/sql"
+
+	// MSSQL driver
+	_ "github.com/denisenkom/go-mssqldb"
 )
 
 // SaveFunc is used to commit or rollback changes on execute.
@@ -15,6 +18,15 @@ type Database interface {
 	Execute(ctx context.Context, query string, args ...interface{}) (int64, error)
 	ExecuteTx(ctx context.Context, query string, args ...interface{}) (int64, SaveFunc, error)
 	Ping(ctx context.Context) error
+	UnitOfWork
+}
+
+// UnitOfWork is used to make multiple changes to the database atomically.
+type UnitOfWork interface {
+	// Transaction runs fn as a unit of work. Any queries made with the context given
+	// to fn are part of the same transaction, which is committed if fn returns nil,
+	// or rolled back otherwise. Calling Transaction within fn joins the outer transaction.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 // Rows is an interface which is implemented by sql.Rows. This makes testing
@@ -48,7 +60,7 @@ func New(connectionString string) (Database, error) {
 }
 
 func (db *database) Multiple(ctx context.Context, query string, args ...interface{}) (Rows, error) {
-	stmt, err := db.sql.PrepareContext(ctx, query)
+	stmt, err := db.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +70,7 @@ func (db *database) Multiple(ctx context.Context, query string, args ...interfac
 }
 
 func (db *database) Single(ctx context.Context, query string, args ...interface{}) (Row, error) {
-	stmt, err := db.sql.PrepareContext(ctx, query)
+	stmt, err := db.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +80,7 @@ func (db *database) Single(ctx context.Context, query string, args ...interface{
 }
 
 func (db *database) Execute(ctx context.Context, query string, args ...interface{}) (int64, error) {
-	stmt, err := db.sql.PrepareContext(ctx, query)
+	stmt, err := db.conn(ctx).PrepareContext(ctx, query)
 	if err != nil {
 		return -1, err
 	}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+// txContextKey is the context key used to store the transaction of a unit of work.
+type txContextKey struct{}
+
+// preparer is implemented by both sql.DB and sql.Tx.
+type preparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+func (db *database) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = fn(context.WithValue(ctx, txContextKey{}, tx))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// conn returns the transaction of the unit of work in ctx, if there is one,
+// otherwise the database connection pool.
+func (db *database) conn(ctx context.Context) preparer {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return db.sql
+}
@@ -0,0 +1,212 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrConsumerNotRunning is returned from a Consumer's health check when it is not consuming messages.
+var ErrConsumerNotRunning = errors.New("consumer is not running")
+
+// HandlerFunc handles a consumed event. The event's payload is a pointer
+// to the payload type of the event, such as *PostLikedPayload.
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+// RetryPolicy determines how a message is retried when its handler fails.
+// The delay between each attempt doubles, starting at InitialBackoff, up to MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy is a RetryPolicy suitable for most consumers.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+// backoff returns the delay to wait before retrying, after the given attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+
+	return d
+}
+
+// DeadLetterTopic returns the name of the dead-letter topic for the given topic.
+func DeadLetterTopic(topic string) string {
+	return topic + ".dead-letter"
+}
+
+// DeadLetter is published to the dead-letter topic for a message which could not be handled.
+type DeadLetter struct {
+	Topic    string    `json:"topic"`
+	Key      string    `json:"key"`
+	Value    []byte    `json:"value"`
+	Error    string    `json:"error"`
+	Attempts int       `json:"attempts"`
+	FailedAt time.Time `json:"failedAt"`
+}
+
+// Consumer consumes events from a Subscriber, dispatching them to the handler
+// registered for their type. Failed messages are retried according to the RetryPolicy,
+// before being published to the dead-letter publisher. Messages are only committed
+// once handled, or dead-lettered, giving at-least-once delivery.
+type Consumer struct {
+	sub        Subscriber
+	deadLetter Publisher
+	retry      RetryPolicy
+	handlers   map[string]HandlerFunc
+
+	mu      sync.Mutex
+	running bool
+	err     error
+}
+
+// NewConsumer returns a new instance of Consumer.
+func NewConsumer(sub Subscriber, deadLetter Publisher, retry RetryPolicy) *Consumer {
+	if retry.MaxAttempts < 1 {
+		retry.MaxAttempts = 1
+	}
+
+	return &Consumer{
+		sub:        sub,
+		deadLetter: deadLetter,
+		retry:      retry,
+		handlers:   make(map[string]HandlerFunc),
+	}
+}
+
+// Handle registers h as the handler for events of the given type. Events
+// without a registered handler are committed without being handled.
+func (c *Consumer) Handle(eventType string, h HandlerFunc) {
+	c.handlers[eventType] = h
+}
+
+// Run consumes messages until ctx is cancelled, at which point it returns nil
+// once the message in-flight has been handled. A message which is interrupted
+// by ctx being cancelled is not committed, so will be redelivered. An error is
+// returned if messages can no longer be consumed.
+func (c *Consumer) Run(ctx context.Context) error {
+	c.setRunning(true, nil)
+
+	for {
+		msg, err := c.sub.Fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				c.setRunning(false, nil)
+				return nil
+			}
+
+			return c.fail(fmt.Errorf("failed to fetch message: %v", err))
+		}
+
+		err = c.process(ctx, msg)
+		if ctx.Err() != nil {
+			c.setRunning(false, nil)
+			return nil
+		}
+
+		if err != nil {
+			return c.fail(err)
+		}
+
+		err = c.sub.Commit(ctx, msg)
+		if err != nil {
+			return c.fail(fmt.Errorf("failed to commit message: %v", err))
+		}
+	}
+}
+
+// process handles a single message, retrying it as necessary. An error is only
+// returned if the message could neither be handled nor dead-lettered.
+func (c *Consumer) process(ctx context.Context, msg *Message) error {
+	event, err := DecodeEvent(msg.Value)
+	if err != nil {
+		return c.publishDeadLetter(ctx, msg, err, 0)
+	}
+
+	h, ok := c.handlers[event.Type]
+	if !ok {
+		return nil
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = h(ctx, event)
+		if err == nil || ctx.Err() != nil {
+			return nil
+		}
+
+		log.Printf("Error: failed to handle %s event (attempt %d/%d): %v\n", event.Type, attempt, c.retry.MaxAttempts, err)
+
+		if attempt >= c.retry.MaxAttempts {
+			return c.publishDeadLetter(ctx, msg, err, attempt)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(c.retry.backoff(attempt)):
+		}
+	}
+}
+
+func (c *Consumer) publishDeadLetter(ctx context.Context, msg *Message, cause error, attempts int) error {
+	dl := &DeadLetter{
+		Topic:    msg.Topic,
+		Key:      msg.Key,
+		Value:    msg.Value,
+		Error:    cause.Error(),
+		Attempts: attempts,
+		FailedAt: time.Now().UTC(),
+	}
+
+	err := c.deadLetter.Publish(ctx, msg.Key, dl)
+	if err != nil {
+		return fmt.Errorf("failed to dead-letter message: %v", err)
+	}
+
+	return nil
+}
+
+func (c *Consumer) setRunning(running bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.running = running
+	c.err = err
+}
+
+func (c *Consumer) fail(err error) error {
+	log.Printf("Error: consumer stopped: %v\n", err)
+	c.setRunning(false, err)
+
+	return err
+}
+
+// Check is used as a health check, ensuring the consumer is running.
+func (c *Consumer) Check(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+
+	if !c.running {
+		return ErrConsumerNotRunning
+	}
+
+	return nil
+}
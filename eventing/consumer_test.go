@@ -0,0 +1,234 @@
+package eventing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/eventing"
+	"github.com/reecerussell/open-social/eventing/memory"
+)
+
+const (
+	testTopic   = "posts"
+	testGroupID = "test"
+)
+
+var testDeadLetterTopic = eventing.DeadLetterTopic(testTopic)
+
+var testRetryPolicy = eventing.RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     time.Millisecond,
+}
+
+// waitFor polls cond until it returns true, failing the test if it takes too long.
+func waitFor(t *testing.T, cond func() bool) {
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func publishLike(t *testing.T, broker *memory.Broker) {
+	event := eventing.NewEvent(eventing.PostLiked, &eventing.PostLikedPayload{
+		PostReferenceID: "123",
+		UserReferenceID: "456",
+	})
+
+	err := broker.Publisher(testTopic).Publish(context.Background(), "123", event)
+	assert.NoError(t, err)
+}
+
+func newConsumer(broker *memory.Broker) *eventing.Consumer {
+	sub := broker.Subscriber(testTopic, testGroupID)
+	return eventing.NewConsumer(sub, broker.Publisher(testDeadLetterTopic), testRetryPolicy)
+}
+
+func TestConsumer_GivenEvent_HandlesAndCommits(t *testing.T) {
+	broker := memory.NewBroker()
+	publishLike(t, broker)
+
+	handled := make(chan *eventing.PostLikedPayload, 1)
+	c := newConsumer(broker)
+	c.Handle(eventing.PostLiked, func(ctx context.Context, event *eventing.Event) error {
+		handled <- event.Payload.(*eventing.PostLikedPayload)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+
+	payload := <-handled
+	assert.Equal(t, "123", payload.PostReferenceID)
+	assert.Equal(t, "456", payload.UserReferenceID)
+
+	waitFor(t, func() bool { return broker.Committed(testTopic, testGroupID) == 1 })
+	assert.NoError(t, c.Check(ctx))
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Equal(t, eventing.ErrConsumerNotRunning, c.Check(context.Background()))
+}
+
+func TestConsumer_HandlerFailsThenSucceeds_Retries(t *testing.T) {
+	broker := memory.NewBroker()
+	publishLike(t, broker)
+
+	attempts := 0
+	c := newConsumer(broker)
+	c.Handle(eventing.PostLiked, func(ctx context.Context, event *eventing.Event) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("an error occured")
+		}
+
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+
+	waitFor(t, func() bool { return broker.Committed(testTopic, testGroupID) == 1 })
+	cancel()
+	assert.NoError(t, <-done)
+
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 0, len(broker.Messages(testDeadLetterTopic)))
+}
+
+func TestConsumer_HandlerKeepsFailing_PublishesDeadLetter(t *testing.T) {
+	broker := memory.NewBroker()
+	publishLike(t, broker)
+
+	attempts := 0
+	c := newConsumer(broker)
+	c.Handle(eventing.PostLiked, func(ctx context.Context, event *eventing.Event) error {
+		attempts++
+		return errors.New("an error occured")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+
+	waitFor(t, func() bool { return broker.Committed(testTopic, testGroupID) == 1 })
+	cancel()
+	assert.NoError(t, <-done)
+
+	assert.Equal(t, testRetryPolicy.MaxAttempts, attempts)
+
+	messages := broker.Messages(testDeadLetterTopic)
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, "123", messages[0].Key)
+
+	var dl eventing.DeadLetter
+	_ = json.Unmarshal(messages[0].Value, &dl)
+	assert.Equal(t, testTopic, dl.Topic)
+	assert.Equal(t, "an error occured", dl.Error)
+	assert.Equal(t, testRetryPolicy.MaxAttempts, dl.Attempts)
+	assert.Equal(t, broker.Messages(testTopic)[0].Value, dl.Value)
+}
+
+func TestConsumer_GivenInvalidMessage_PublishesDeadLetter(t *testing.T) {
+	broker := memory.NewBroker()
+	err := broker.Publisher(testTopic).Publish(context.Background(), "123", "not an event")
+	assert.NoError(t, err)
+
+	c := newConsumer(broker)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+
+	waitFor(t, func() bool { return broker.Committed(testTopic, testGroupID) == 1 })
+	cancel()
+	assert.NoError(t, <-done)
+
+	messages := broker.Messages(testDeadLetterTopic)
+	assert.Equal(t, 1, len(messages))
+
+	var dl eventing.DeadLetter
+	_ = json.Unmarshal(messages[0].Value, &dl)
+	assert.Equal(t, eventing.ErrInvalidEvent.Error(), dl.Error)
+	assert.Equal(t, 0, dl.Attempts)
+}
+
+func TestConsumer_GivenUnhandledEventType_Commits(t *testing.T) {
+	broker := memory.NewBroker()
+	publishLike(t, broker)
+
+	c := newConsumer(broker)
+	c.Handle(eventing.PostCreated, func(ctx context.Context, event *eventing.Event) error {
+		t.Fatal("handler should not be called")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+
+	waitFor(t, func() bool { return broker.Committed(testTopic, testGroupID) == 1 })
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Equal(t, 0, len(broker.Messages(testDeadLetterTopic)))
+}
+
+func TestConsumer_StoppedWhileRetrying_RedeliversMessage(t *testing.T) {
+	broker := memory.NewBroker()
+	publishLike(t, broker)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := eventing.NewConsumer(broker.Subscriber(testTopic, testGroupID), broker.Publisher(testDeadLetterTopic), eventing.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Hour,
+		MaxBackoff:     time.Hour,
+	})
+	c.Handle(eventing.PostLiked, func(ctx context.Context, event *eventing.Event) error {
+		cancel()
+		return errors.New("an error occured")
+	})
+
+	err := c.Run(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), broker.Committed(testTopic, testGroupID))
+
+	handled := make(chan struct{}, 1)
+	c = newConsumer(broker)
+	c.Handle(eventing.PostLiked, func(ctx context.Context, event *eventing.Event) error {
+		handled <- struct{}{}
+		return nil
+	})
+
+	ctx, cancel = context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+
+	<-handled
+	waitFor(t, func() bool { return broker.Committed(testTopic, testGroupID) == 1 })
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestConsumer_FetchFails_ReturnsError(t *testing.T) {
+	broker := memory.NewBroker()
+	sub := broker.Subscriber(testTopic, testGroupID)
+	_ = sub.Close()
+
+	c := eventing.NewConsumer(sub, broker.Publisher(testDeadLetterTopic), testRetryPolicy)
+	assert.Equal(t, eventing.ErrConsumerNotRunning, c.Check(context.Background()))
+
+	err := c.Run(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, err, c.Check(context.Background()))
+}
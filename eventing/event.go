@@ -0,0 +1,186 @@
+package eventing
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types published by the services.
+const (
+	PostCreated    = "PostCreated"
+	PostLiked      = "PostLiked"
+	PostUnliked    = "PostUnliked"
+	CommentCreated = "CommentCreated"
+	UserCreated    = "UserCreated"
+	UserFollowed   = "UserFollowed"
+	UserUnfollowed = "UserUnfollowed"
+
+	FollowRequested       = "FollowRequested"
+	FollowRequestApproved = "FollowRequestApproved"
+)
+
+// EventVersion is the current version of the event payloads. It should be
+// incremented when a breaking change is made to a payload, so consumers
+// are able to tell the shapes apart.
+const EventVersion = 1
+
+// Event is the envelope every domain event is published in. It is serialized
+// as JSON, with the following shape:
+//
+//	{
+//	    "id": "5c6fa1a2-6a39-4d4c-8e56-0c1d0b0c7c1e",
+//	    "type": "PostCreated",
+//	    "version": 1,
+//	    "occurredAt": "2021-03-06T12:00:00Z",
+//	    "payload": { ... }
+//	}
+//
+// The id is unique to each event, and is kept when an event is redelivered, so
+// it can be used by consumers as an idempotency key. The payload is one of the
+// payload types declared in this package, matching the event's type.
+type Event struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	Version    int         `json:"version"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Payload    interface{} `json:"payload"`
+}
+
+// NewEvent returns a new Event of the given type, wrapping payload.
+func NewEvent(eventType string, payload interface{}) *Event {
+	return &Event{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		Version:    EventVersion,
+		OccurredAt: time.Now().UTC(),
+		Payload:    payload,
+	}
+}
+
+// ErrInvalidEvent is returned when a message cannot be decoded as an Event.
+var ErrInvalidEvent = errors.New("message is not a valid event")
+
+// DecodeEvent decodes an Event from its JSON envelope. The payload is decoded
+// into a pointer to the payload type of the event, such as *PostCreatedPayload.
+// Payloads of unknown event types are left as a json.RawMessage.
+func DecodeEvent(data []byte) (*Event, error) {
+	var envelope struct {
+		ID         string          `json:"id"`
+		Type       string          `json:"type"`
+		Version    int             `json:"version"`
+		OccurredAt time.Time       `json:"occurredAt"`
+		Payload    json.RawMessage `json:"payload"`
+	}
+	err := json.Unmarshal(data, &envelope)
+	if err != nil || envelope.Type == "" {
+		return nil, ErrInvalidEvent
+	}
+
+	event := &Event{
+		ID:         envelope.ID,
+		Type:       envelope.Type,
+		Version:    envelope.Version,
+		OccurredAt: envelope.OccurredAt,
+		Payload:    envelope.Payload,
+	}
+
+	payload := newPayload(envelope.Type)
+	if payload != nil {
+		err = json.Unmarshal(envelope.Payload, payload)
+		if err != nil {
+			return nil, ErrInvalidEvent
+		}
+
+		event.Payload = payload
+	}
+
+	return event, nil
+}
+
+// newPayload returns a pointer to a new payload for the given event type,
+// or nil if the event type is not known.
+func newPayload(eventType string) interface{} {
+	switch eventType {
+	case PostCreated:
+		return &PostCreatedPayload{}
+	case PostLiked:
+		return &PostLikedPayload{}
+	case PostUnliked:
+		return &PostUnlikedPayload{}
+	case CommentCreated:
+		return &CommentCreatedPayload{}
+	case UserCreated:
+		return &UserCreatedPayload{}
+	case UserFollowed:
+		return &UserFollowedPayload{}
+	case UserUnfollowed:
+		return &UserUnfollowedPayload{}
+	case FollowRequested:
+		return &FollowRequestedPayload{}
+	case FollowRequestApproved:
+		return &FollowRequestApprovedPayload{}
+	default:
+		return nil
+	}
+}
+
+// PostCreatedPayload is the payload of a PostCreated event.
+type PostCreatedPayload struct {
+	PostReferenceID string `json:"postReferenceId"`
+	UserReferenceID string `json:"userReferenceId"`
+	Caption         string `json:"caption"`
+	HasMedia        bool   `json:"hasMedia"`
+}
+
+// PostLikedPayload is the payload of a PostLiked event.
+type PostLikedPayload struct {
+	PostReferenceID string `json:"postReferenceId"`
+	UserReferenceID string `json:"userReferenceId"`
+}
+
+// PostUnlikedPayload is the payload of a PostUnliked event.
+type PostUnlikedPayload struct {
+	PostReferenceID string `json:"postReferenceId"`
+	UserReferenceID string `json:"userReferenceId"`
+}
+
+// CommentCreatedPayload is the payload of a CommentCreated event.
+type CommentCreatedPayload struct {
+	CommentReferenceID string `json:"commentReferenceId"`
+	PostReferenceID    string `json:"postReferenceId"`
+	UserReferenceID    string `json:"userReferenceId"`
+	Text               string `json:"text"`
+}
+
+// UserCreatedPayload is the payload of a UserCreated event.
+type UserCreatedPayload struct {
+	UserReferenceID string `json:"userReferenceId"`
+	Username        string `json:"username"`
+}
+
+// UserFollowedPayload is the payload of a UserFollowed event.
+type UserFollowedPayload struct {
+	UserReferenceID     string `json:"userReferenceId"`
+	FollowerReferenceID string `json:"followerReferenceId"`
+}
+
+// UserUnfollowedPayload is the payload of a UserUnfollowed event.
+type UserUnfollowedPayload struct {
+	UserReferenceID     string `json:"userReferenceId"`
+	FollowerReferenceID string `json:"followerReferenceId"`
+}
+
+// FollowRequestedPayload is the payload of a FollowRequested event.
+type FollowRequestedPayload struct {
+	UserReferenceID     string `json:"userReferenceId"`
+	FollowerReferenceID string `json:"followerReferenceId"`
+}
+
+// FollowRequestApprovedPayload is the payload of a FollowRequestApproved event.
+type FollowRequestApprovedPayload struct {
+	UserReferenceID     string `json:"userReferenceId"`
+	FollowerReferenceID string `json:"followerReferenceId"`
+}
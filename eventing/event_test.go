@@ -0,0 +1,63 @@
+package eventing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeEvent_GivenKnownType_DecodesPayload(t *testing.T) {
+	original := NewEvent(UserFollowed, &UserFollowedPayload{
+		UserReferenceID:     "123",
+		FollowerReferenceID: "456",
+	})
+	data, _ := json.Marshal(original)
+
+	event, err := DecodeEvent(data)
+	assert.NoError(t, err)
+	assert.Equal(t, original.ID, event.ID)
+	assert.Equal(t, UserFollowed, event.Type)
+	assert.Equal(t, EventVersion, event.Version)
+	assert.False(t, event.OccurredAt.IsZero())
+
+	payload := event.Payload.(*UserFollowedPayload)
+	assert.Equal(t, "123", payload.UserReferenceID)
+	assert.Equal(t, "456", payload.FollowerReferenceID)
+}
+
+func TestDecodeEvent_GivenUnknownType_KeepsRawPayload(t *testing.T) {
+	event, err := DecodeEvent([]byte(`{"type":"Unknown","version":1,"payload":{"foo":"bar"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, json.RawMessage(`{"foo":"bar"}`), event.Payload)
+}
+
+func TestDecodeEvent_GivenInvalidData_ReturnsError(t *testing.T) {
+	tests := map[string]string{
+		"Invalid JSON":    `{`,
+		"Missing Type":    `{"version":1}`,
+		"Invalid Payload": `{"type":"PostLiked","payload":"abc"}`,
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			event, err := DecodeEvent([]byte(data))
+			assert.Nil(t, event)
+			assert.Equal(t, ErrInvalidEvent, err)
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, p.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, p.backoff(2))
+	assert.Equal(t, 400*time.Millisecond, p.backoff(3))
+	assert.Equal(t, 800*time.Millisecond, p.backoff(4))
+	assert.Equal(t, time.Second, p.backoff(5))
+}
@@ -0,0 +1,28 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// HealthCheck is an implementation of the HealthCheck interface,
+// used to check the status of an app by ensuring it can connect to Kafka.
+type HealthCheck struct {
+	addr string
+}
+
+// NewHealthCheck returns a new instance of HealthCheck for the given broker address.
+func NewHealthCheck(addr string) *HealthCheck {
+	return &HealthCheck{addr: addr}
+}
+
+// Check ensures a connection can be made to the Kafka broker.
+func (hc *HealthCheck) Check(ctx context.Context) error {
+	conn, err := kafka.DialContext(ctx, "tcp", hc.addr)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 )
 
+// batchTimeout is kept low as messages are published synchronously
+// as part of a request, so shouldn't be held waiting for a full batch.
+const batchTimeout = 10 * time.Millisecond
+
 // Publisher is an implementation of the Publisher interface for Kafka.
 type Publisher struct {
 	w *kafka.Writer
@@ -17,9 +22,10 @@ type Publisher struct {
 func NewPublisher(addr, topic string) *Publisher {
 	return &Publisher{
 		w: &kafka.Writer{
-			Addr:     kafka.TCP(addr),
-			Topic:    topic,
-			Balancer: &kafka.LeastBytes{},
+			Addr:         kafka.TCP(addr),
+			Topic:        topic,
+			Balancer:     &kafka.LeastBytes{},
+			BatchTimeout: batchTimeout,
 		},
 	}
 }
@@ -27,16 +33,25 @@ func NewPublisher(addr, topic string) *Publisher {
 // Publish pushes a new message to Kafka, with the given key and
 // a value as message as a JSON string.
 func (p *Publisher) Publish(ctx context.Context, key string, message interface{}) error {
-	bytes, _ := json.Marshal(message)
+	bytes, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to serialize message: %v", err)
+	}
+
 	m := kafka.Message{
 		Key:   []byte(key),
 		Value: bytes,
 	}
 
-	err := p.w.WriteMessages(ctx, m)
+	err = p.w.WriteMessages(ctx, m)
 	if err != nil {
 		return fmt.Errorf("failed to publish message: %v", err)
 	}
 
 	return nil
 }
+
+// Close flushes any pending messages and closes the underlying writer.
+func (p *Publisher) Close() error {
+	return p.w.Close()
+}
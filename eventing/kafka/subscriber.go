@@ -0,0 +1,57 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/reecerussell/open-social/eventing"
+)
+
+// Subscriber is an implementation of the Subscriber interface for Kafka,
+// consuming a topic as part of a consumer group.
+type Subscriber struct {
+	r *kafka.Reader
+}
+
+// NewSubscriber returns a new instance of Subscriber, consuming the given topic
+// as a member of the consumer group.
+func NewSubscriber(addr, topic, groupID string) *Subscriber {
+	return &Subscriber{
+		r: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: []string{addr},
+			Topic:   topic,
+			GroupID: groupID,
+		}),
+	}
+}
+
+// Fetch blocks until the next message is available for the consumer group.
+func (s *Subscriber) Fetch(ctx context.Context) (*eventing.Message, error) {
+	m, err := s.r.FetchMessage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventing.Message{
+		Topic:     m.Topic,
+		Partition: m.Partition,
+		Offset:    m.Offset,
+		Key:       string(m.Key),
+		Value:     m.Value,
+	}, nil
+}
+
+// Commit commits the offset of msg for the consumer group.
+func (s *Subscriber) Commit(ctx context.Context, msg *eventing.Message) error {
+	return s.r.CommitMessages(ctx, kafka.Message{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+	})
+}
+
+// Close leaves the consumer group and closes the underlying reader.
+func (s *Subscriber) Close() error {
+	return s.r.Close()
+}
@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/reecerussell/open-social/eventing"
+)
+
+// ErrSubscriberClosed is returned when fetching from a closed subscriber.
+var ErrSubscriberClosed = errors.New("subscriber is closed")
+
+// Broker is an in-process message broker, used to test consumers without Kafka.
+// Each topic has a single partition, and each consumer group tracks its own
+// committed offset per topic, so a new subscriber resumes from the last commit.
+type Broker struct {
+	mu      sync.Mutex
+	topics  map[string][]*eventing.Message
+	offsets map[string]int64
+	notify  chan struct{}
+}
+
+// NewBroker returns a new, empty instance of Broker.
+func NewBroker() *Broker {
+	return &Broker{
+		topics:  make(map[string][]*eventing.Message),
+		offsets: make(map[string]int64),
+		notify:  make(chan struct{}),
+	}
+}
+
+// Publisher returns an eventing.Publisher which publishes messages to the given topic.
+func (b *Broker) Publisher(topic string) eventing.Publisher {
+	return &brokerPublisher{
+		broker: b,
+		topic:  topic,
+	}
+}
+
+// Subscriber returns an eventing.Subscriber which consumes messages from the
+// given topic, as part of the consumer group.
+func (b *Broker) Subscriber(topic, groupID string) eventing.Subscriber {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return &brokerSubscriber{
+		broker:   b,
+		topic:    topic,
+		groupID:  groupID,
+		position: b.offsets[offsetKey(topic, groupID)],
+		done:     make(chan struct{}),
+	}
+}
+
+// Messages returns the messages which have been published to the topic.
+func (b *Broker) Messages(topic string) []*eventing.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	messages := make([]*eventing.Message, len(b.topics[topic]))
+	copy(messages, b.topics[topic])
+
+	return messages
+}
+
+// Committed returns the next offset to be consumed from the topic by the consumer group.
+func (b *Broker) Committed(topic, groupID string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.offsets[offsetKey(topic, groupID)]
+}
+
+func (b *Broker) publish(topic, key string, value []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.topics[topic] = append(b.topics[topic], &eventing.Message{
+		Topic:  topic,
+		Offset: int64(len(b.topics[topic])),
+		Key:    key,
+		Value:  value,
+	})
+
+	// Wake up any subscribers waiting for a message.
+	close(b.notify)
+	b.notify = make(chan struct{})
+}
+
+func offsetKey(topic, groupID string) string {
+	return groupID + "/" + topic
+}
+
+type brokerPublisher struct {
+	broker *Broker
+	topic  string
+}
+
+func (p *brokerPublisher) Publish(ctx context.Context, key string, message interface{}) error {
+	bytes, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	p.broker.publish(p.topic, key, bytes)
+
+	return nil
+}
+
+type brokerSubscriber struct {
+	broker   *Broker
+	topic    string
+	groupID  string
+	position int64
+	closed   bool
+	done     chan struct{}
+}
+
+func (s *brokerSubscriber) Fetch(ctx context.Context) (*eventing.Message, error) {
+	b := s.broker
+
+	for {
+		b.mu.Lock()
+		if s.closed {
+			b.mu.Unlock()
+			return nil, ErrSubscriberClosed
+		}
+
+		messages := b.topics[s.topic]
+		if s.position < int64(len(messages)) {
+			msg := messages[s.position]
+			s.position++
+			b.mu.Unlock()
+
+			return msg, nil
+		}
+
+		notify := b.notify
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-s.done:
+			return nil, ErrSubscriberClosed
+		case <-notify:
+		}
+	}
+}
+
+func (s *brokerSubscriber) Commit(ctx context.Context, msg *eventing.Message) error {
+	b := s.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := offsetKey(s.topic, s.groupID)
+	if msg.Offset+1 > b.offsets[key] {
+		b.offsets[key] = msg.Offset + 1
+	}
+
+	return nil
+}
+
+func (s *brokerSubscriber) Close() error {
+	b := s.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+	}
+
+	return nil
+}
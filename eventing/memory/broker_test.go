@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker_SubscriberInGroup_ResumesFromCommittedOffset(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker()
+	pub := b.Publisher("posts")
+	_ = pub.Publish(ctx, "1", "first")
+	_ = pub.Publish(ctx, "2", "second")
+
+	sub := b.Subscriber("posts", "group")
+	msg, err := sub.Fetch(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", msg.Key)
+	assert.NoError(t, sub.Commit(ctx, msg))
+
+	msg, err = sub.Fetch(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", msg.Key)
+	assert.NoError(t, sub.Close())
+
+	// The second message was not committed, so is redelivered.
+	sub = b.Subscriber("posts", "group")
+	msg, err = sub.Fetch(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", msg.Key)
+
+	// Other groups consume the topic independently.
+	other := b.Subscriber("posts", "other")
+	msg, err = other.Fetch(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", msg.Key)
+}
+
+func TestBroker_Fetch_WaitsForMessage(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker()
+	sub := b.Subscriber("posts", "group")
+
+	fetched := make(chan string)
+	go func() {
+		msg, _ := sub.Fetch(ctx)
+		fetched <- msg.Key
+	}()
+
+	_ = b.Publisher("posts").Publish(ctx, "1", "first")
+	assert.Equal(t, "1", <-fetched)
+}
+
+func TestBroker_Fetch_ContextCancelled_ReturnsError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	msg, err := NewBroker().Subscriber("posts", "group").Fetch(ctx)
+	assert.Nil(t, msg)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestBroker_Fetch_SubscriberClosed_ReturnsError(t *testing.T) {
+	sub := NewBroker().Subscriber("posts", "group")
+	_ = sub.Close()
+
+	msg, err := sub.Fetch(context.Background())
+	assert.Nil(t, msg)
+	assert.Equal(t, ErrSubscriberClosed, err)
+}
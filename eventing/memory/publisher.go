@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// Message is a message which has been published to a Publisher.
+type Message struct {
+	Key   string
+	Value []byte
+}
+
+// Publisher is an in-memory implementation of the eventing.Publisher interface,
+// which records each published message. It is intended to be used in tests.
+type Publisher struct {
+	mu       sync.Mutex
+	messages []*Message
+}
+
+// NewPublisher returns a new, empty instance of Publisher.
+func NewPublisher() *Publisher {
+	return &Publisher{}
+}
+
+// Publish records the message, serialized as JSON, against the given key.
+func (p *Publisher) Publish(ctx context.Context, key string, message interface{}) error {
+	bytes, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, &Message{
+		Key:   key,
+		Value: bytes,
+	})
+
+	return nil
+}
+
+// Messages returns a copy of the messages published, in the order they were published.
+func (p *Publisher) Messages() []*Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	messages := make([]*Message, len(p.messages))
+	copy(messages, p.messages)
+
+	return messages
+}
@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/eventing"
+)
+
+func TestPublisher_Publish_RecordsMessage(t *testing.T) {
+	p := NewPublisher()
+
+	err := p.Publish(context.Background(), "123", map[string]string{"foo": "bar"})
+	assert.NoError(t, err)
+
+	messages := p.Messages()
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, "123", messages[0].Key)
+	assert.Equal(t, `{"foo":"bar"}`, string(messages[0].Value))
+}
+
+func TestPublisher_Publish_GivenInvalidMessage_ReturnsError(t *testing.T) {
+	p := NewPublisher()
+
+	err := p.Publish(context.Background(), "123", make(chan int))
+	assert.Error(t, err)
+	assert.Equal(t, 0, len(p.Messages()))
+}
+
+func TestPublisher_ImplementsPublisher(t *testing.T) {
+	var _ eventing.Publisher = NewPublisher()
+}
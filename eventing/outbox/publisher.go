@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/eventing"
+)
+
+// Publisher is an implementation of the Publisher interface which writes messages
+// to the Outbox table, to later be published by a Relay. When used within a unit
+// of work, the message is only stored if the transaction is committed.
+type Publisher struct {
+	db    database.Database
+	topic string
+}
+
+// NewPublisher returns a new instance of Publisher, for messages to the given topic.
+func NewPublisher(db database.Database, topic string) *Publisher {
+	return &Publisher{
+		db:    db,
+		topic: topic,
+	}
+}
+
+// Publish writes the message, as JSON, to the outbox. The idempotency key of the
+// message is the id of the event, if the message is an *eventing.Event.
+func (p *Publisher) Publish(ctx context.Context, key string, message interface{}) error {
+	bytes, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to serialize message: %v", err)
+	}
+
+	idempotencyKey := uuid.New().String()
+	if event, ok := message.(*eventing.Event); ok && event.ID != "" {
+		idempotencyKey = event.ID
+	}
+
+	const query = `INSERT INTO [Outbox] ([IdempotencyKey],[Topic],[Key],[Message],[Created])
+					VALUES (@idempotencyKey, @topic, @key, @message, GETUTCDATE());`
+
+	_, err = p.db.Execute(ctx, query,
+		sql.Named("idempotencyKey", idempotencyKey),
+		sql.Named("topic", p.topic),
+		sql.Named("key", key),
+		sql.Named("message", string(bytes)))
+	if err != nil {
+		return fmt.Errorf("failed to write message to outbox: %v", err)
+	}
+
+	return nil
+}
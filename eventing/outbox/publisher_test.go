@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/eventing"
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+func TestPublisher_GivenEvent_WritesEventToOutbox(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testEvent := eventing.NewEvent(eventing.PostLiked, &eventing.PostLikedPayload{})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, query string, args ...interface{}) (int64, error) {
+			assert.Equal(t, sql.Named("idempotencyKey", testEvent.ID), args[0])
+			assert.Equal(t, sql.Named("topic", "posts"), args[1])
+			assert.Equal(t, sql.Named("key", "123"), args[2])
+
+			return 1, nil
+		})
+
+	p := NewPublisher(mockDatabase, "posts")
+	err := p.Publish(testCtx, "123", testEvent)
+	assert.NoError(t, err)
+}
+
+func TestPublisher_ExecuteFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(-1), testError)
+
+	p := NewPublisher(mockDatabase, "posts")
+	err := p.Publish(testCtx, "123", "message")
+	assert.Equal(t, "failed to write message to outbox: an error occured", err.Error())
+}
@@ -0,0 +1,155 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/reecerussell/open-social/database"
+	"github.com/reecerussell/open-social/eventing"
+)
+
+// Relay defaults.
+const (
+	DefaultInterval  = time.Second
+	DefaultBatchSize = 100
+)
+
+// Relay drains messages from the Outbox table into a Publisher. Each message is
+// marked as published once it has been published, so delivery is at-least-once;
+// consumers should use the event's id as an idempotency key.
+type Relay struct {
+	db        database.Database
+	topic     string
+	pub       eventing.Publisher
+	interval  time.Duration
+	batchSize int
+}
+
+// NewRelay returns a new instance of Relay, which publishes messages for the
+// given topic to pub, polling the outbox every interval.
+func NewRelay(db database.Database, topic string, pub eventing.Publisher, interval time.Duration) *Relay {
+	return &Relay{
+		db:        db,
+		topic:     topic,
+		pub:       pub,
+		interval:  interval,
+		batchSize: DefaultBatchSize,
+	}
+}
+
+// Run drains the outbox every interval, until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := r.Drain(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Error: failed to relay outbox messages: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain publishes all of the unpublished messages in the outbox, returning
+// the number of messages published.
+func (r *Relay) Drain(ctx context.Context) (int, error) {
+	total := 0
+
+	for {
+		n, err := r.relayBatch(ctx)
+		total += n
+		if err != nil {
+			return total, err
+		}
+
+		if n < r.batchSize {
+			return total, nil
+		}
+	}
+}
+
+type message struct {
+	id    int64
+	key   string
+	value string
+}
+
+// relayBatch publishes a batch of messages. The messages are locked for the duration of
+// the transaction, so other instances of the relay skip them rather than publishing them twice.
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	published := 0
+	var pubErr error
+
+	err := r.db.Transaction(ctx, func(ctx context.Context) error {
+		messages, err := r.readBatch(ctx)
+		if err != nil {
+			return err
+		}
+
+		const query = `UPDATE [Outbox] SET [Published] = GETUTCDATE() WHERE [Id] = @id;`
+
+		for _, m := range messages {
+			pubErr = r.pub.Publish(ctx, m.key, json.RawMessage(m.value))
+			if pubErr != nil {
+				// Commit the messages published so far.
+				return nil
+			}
+
+			_, err = r.db.Execute(ctx, query, sql.Named("id", m.id))
+			if err != nil {
+				return err
+			}
+
+			published++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return published, pubErr
+}
+
+func (r *Relay) readBatch(ctx context.Context) ([]*message, error) {
+	const query = `SELECT TOP (@batchSize) [Id], [Key], [Message]
+					FROM [Outbox] WITH (UPDLOCK, READPAST, ROWLOCK)
+					WHERE [Topic] = @topic AND [Published] IS NULL
+					ORDER BY [Id];`
+
+	rows, err := r.db.Multiple(ctx, query,
+		sql.Named("batchSize", r.batchSize),
+		sql.Named("topic", r.topic))
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*message
+
+	for rows.Next() {
+		var m message
+		err := rows.Scan(&m.id, &m.key, &m.value)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, &m)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
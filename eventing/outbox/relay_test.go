@@ -0,0 +1,144 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/eventing/memory"
+	mock "github.com/reecerussell/open-social/mock/database"
+)
+
+type testMessage struct {
+	id    int64
+	key   string
+	value string
+}
+
+func expectTransaction(db *mock.MockDatabase) {
+	db.EXPECT().Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func newMockRows(ctrl *gomock.Controller, messages ...testMessage) *mock.MockRows {
+	i := 0
+	rows := mock.NewMockRows(ctrl)
+	rows.EXPECT().Next().DoAndReturn(func() bool {
+		return i < len(messages)
+	}).Times(len(messages) + 1)
+	rows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+		*(dest[0].(*int64)) = messages[i].id
+		*(dest[1].(*string)) = messages[i].key
+		*(dest[2].(*string)) = messages[i].value
+		i++
+
+		return nil
+	}).Times(len(messages))
+	rows.EXPECT().Err().Return(nil)
+
+	return rows
+}
+
+func TestRelay_Drain_PublishesAndMarksMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	mockRows := newMockRows(ctrl,
+		testMessage{id: 1, key: "123", value: `{"type":"PostLiked"}`},
+		testMessage{id: 2, key: "456", value: `{"type":"PostUnliked"}`})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	expectTransaction(mockDatabase)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), sql.Named("id", int64(1))).Return(int64(1), nil)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), sql.Named("id", int64(2))).Return(int64(1), nil)
+
+	pub := memory.NewPublisher()
+	r := NewRelay(mockDatabase, "posts", pub, DefaultInterval)
+	n, err := r.Drain(testCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	messages := pub.Messages()
+	assert.Equal(t, 2, len(messages))
+	assert.Equal(t, "123", messages[0].Key)
+	assert.Equal(t, `{"type":"PostLiked"}`, string(messages[0].Value))
+	assert.Equal(t, "456", messages[1].Key)
+	assert.Equal(t, `{"type":"PostUnliked"}`, string(messages[1].Value))
+}
+
+func TestRelay_Drain_GivenFullBatch_ReadsNextBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	expectTransaction(mockDatabase)
+	expectTransaction(mockDatabase)
+	gomock.InOrder(
+		mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).
+			Return(newMockRows(ctrl, testMessage{id: 1, key: "1", value: `{}`}), nil),
+		mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).
+			Return(newMockRows(ctrl), nil),
+	)
+	mockDatabase.EXPECT().Execute(testCtx, gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+	pub := memory.NewPublisher()
+	r := NewRelay(mockDatabase, "posts", pub, DefaultInterval)
+	r.batchSize = 1
+
+	n, err := r.Drain(testCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestRelay_Drain_ReadFails_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	expectTransaction(mockDatabase)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(nil, testError)
+
+	r := NewRelay(mockDatabase, "posts", memory.NewPublisher(), DefaultInterval)
+	n, err := r.Drain(testCtx)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, testError, err)
+}
+
+type failingPublisher struct {
+	err error
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, key string, message interface{}) error {
+	return p.err
+}
+
+func TestRelay_Drain_PublishFails_LeavesMessageUnpublished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCtx := context.Background()
+	testError := errors.New("an error occured")
+	mockRows := newMockRows(ctrl, testMessage{id: 1, key: "123", value: `{}`})
+
+	mockDatabase := mock.NewMockDatabase(ctrl)
+	expectTransaction(mockDatabase)
+	mockDatabase.EXPECT().Multiple(testCtx, gomock.Any(), gomock.Any()).Return(mockRows, nil)
+
+	r := NewRelay(mockDatabase, "posts", &failingPublisher{err: testError}, DefaultInterval)
+	n, err := r.Drain(testCtx)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, testError, err)
+}
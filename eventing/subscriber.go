@@ -0,0 +1,27 @@
+package eventing
+
+import "context"
+
+// Message is a message consumed from a sub/pub.
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       string
+	Value     []byte
+}
+
+// Subscriber is a high-level interface used to consume messages from a sub/pub,
+// as part of a consumer group.
+type Subscriber interface {
+	// Fetch blocks until the next message is available, or ctx is cancelled.
+	Fetch(ctx context.Context) (*Message, error)
+
+	// Commit marks the message as consumed by the group, so it will
+	// not be delivered to the group again.
+	Commit(ctx context.Context, msg *Message) error
+
+	// Close stops the subscriber, leaving any uncommitted messages
+	// to be redelivered to the group.
+	Close() error
+}
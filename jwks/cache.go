@@ -0,0 +1,129 @@
+package jwks
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/reecerussell/gojwt"
+)
+
+// Default cache settings.
+const (
+	DefaultTTL             = 15 * time.Minute
+	DefaultRefreshInterval = 30 * time.Second
+)
+
+// Cache fetches a key set from a URL, caching the keys for verifying tokens.
+// The key set is fetched again once the TTL has passed, or when a token is
+// signed with a key that isn't in the cache, so new keys are picked up as
+// soon as they're used. Fetches for unknown keys are rate limited by the
+// refresh interval, to stop a flood of bad tokens from overloading the issuer.
+//
+// Only one fetch is made at a time, without holding the lock, so cached keys
+// are still served while it runs. Callers after a key which isn't cached wait
+// for it to finish.
+type Cache struct {
+	url             string
+	ttl             time.Duration
+	refreshInterval time.Duration
+	client          *http.Client
+
+	mu         sync.Mutex
+	keys       map[string]gojwt.Algorithm
+	fetched    time.Time
+	attempted  time.Time
+	refreshing chan struct{}
+}
+
+// NewCache returns a new instance of Cache for the key set at the given URL.
+func NewCache(url string, ttl, refreshInterval time.Duration) *Cache {
+	return &Cache{
+		url:             url,
+		ttl:             ttl,
+		refreshInterval: refreshInterval,
+		client:          &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Verifier returns the algorithm used to verify tokens signed with the key with the given id.
+// ErrKeyNotFound is returned if the key set doesn't contain the key.
+func (c *Cache) Verifier(kid string) (gojwt.Algorithm, error) {
+	c.mu.Lock()
+
+	now := time.Now()
+	alg, ok := c.keys[kid]
+	stale := now.Sub(c.fetched) >= c.ttl
+
+	switch {
+	case c.refreshing != nil && !ok:
+		done := c.refreshing
+		c.mu.Unlock()
+		<-done
+
+		c.mu.Lock()
+		alg, ok = c.keys[kid]
+	case c.refreshing == nil && (!ok || stale) && now.Sub(c.attempted) >= c.refreshInterval:
+		c.attempted = now
+		done := make(chan struct{})
+		c.refreshing = done
+		c.mu.Unlock()
+
+		keys, err := c.fetch()
+
+		c.mu.Lock()
+		if err != nil {
+			// Keep using the keys we have, until the issuer is available again.
+			log.Printf("Error: failed to fetch key set: %v\n", err)
+		} else {
+			c.keys = keys
+			c.fetched = now
+		}
+
+		c.refreshing = nil
+		close(done)
+		alg, ok = c.keys[kid]
+	}
+
+	c.mu.Unlock()
+
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return alg, nil
+}
+
+func (c *Cache) fetch() (map[string]gojwt.Algorithm, error) {
+	resp, err := c.client.Get(c.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var set Set
+	err = json.NewDecoder(resp.Body).Decode(&set)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]gojwt.Algorithm, len(set.Keys))
+	for _, key := range set.Keys {
+		alg, err := key.Verifier()
+		if err != nil {
+			log.Printf("WARN: skipping key %s: %v\n", key.ID, err)
+			continue
+		}
+
+		keys[key.ID] = alg
+	}
+
+	return keys, nil
+}
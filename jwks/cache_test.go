@@ -0,0 +1,184 @@
+package jwks
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testIssuer struct {
+	mu       sync.Mutex
+	set      *Set
+	requests int
+	status   int
+	release  chan struct{}
+}
+
+func (i *testIssuer) addKey(t *testing.T, id string) {
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.set.Keys = append(i.set.Keys, NewKey(id, "RS256", &pk.PublicKey))
+}
+
+func (i *testIssuer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	i.mu.Lock()
+	i.requests++
+	release := i.release
+	i.mu.Unlock()
+
+	// Requests are held until released, if set.
+	if release != nil {
+		<-release
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.status != 0 {
+		w.WriteHeader(i.status)
+		return
+	}
+
+	json.NewEncoder(w).Encode(i.set)
+}
+
+func (i *testIssuer) requestCount() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.requests
+}
+
+func newTestIssuer(t *testing.T) (*testIssuer, *httptest.Server) {
+	issuer := &testIssuer{set: &Set{}}
+	issuer.addKey(t, "first")
+
+	return issuer, httptest.NewServer(issuer)
+}
+
+func TestCache_Verifier_CachesKeys(t *testing.T) {
+	issuer, srv := newTestIssuer(t)
+	defer srv.Close()
+
+	cache := NewCache(srv.URL, time.Hour, 0)
+
+	for i := 0; i < 3; i++ {
+		alg, err := cache.Verifier("first")
+		assert.NoError(t, err)
+		assert.Equal(t, "RS256", alg.Name())
+	}
+
+	assert.Equal(t, 1, issuer.requests)
+}
+
+func TestCache_Verifier_FetchesUnknownKey(t *testing.T) {
+	issuer, srv := newTestIssuer(t)
+	defer srv.Close()
+
+	cache := NewCache(srv.URL, time.Hour, 0)
+
+	_, err := cache.Verifier("first")
+	assert.NoError(t, err)
+
+	issuer.addKey(t, "second")
+
+	alg, err := cache.Verifier("second")
+	assert.NoError(t, err)
+	assert.NotNil(t, alg)
+	assert.Equal(t, 2, issuer.requests)
+}
+
+func TestCache_Verifier_RateLimitsUnknownKeys(t *testing.T) {
+	issuer, srv := newTestIssuer(t)
+	defer srv.Close()
+
+	cache := NewCache(srv.URL, time.Hour, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		alg, err := cache.Verifier("unknown")
+		assert.Nil(t, alg)
+		assert.Equal(t, ErrKeyNotFound, err)
+	}
+
+	assert.Equal(t, 1, issuer.requests)
+}
+
+func TestCache_Verifier_KeepsKeysWhenFetchFails(t *testing.T) {
+	issuer, srv := newTestIssuer(t)
+	defer srv.Close()
+
+	cache := NewCache(srv.URL, 0, 0)
+
+	_, err := cache.Verifier("first")
+	assert.NoError(t, err)
+
+	issuer.mu.Lock()
+	issuer.status = http.StatusInternalServerError
+	issuer.mu.Unlock()
+
+	alg, err := cache.Verifier("first")
+	assert.NoError(t, err)
+	assert.NotNil(t, alg)
+	assert.Equal(t, 2, issuer.requests)
+}
+
+func TestCache_Verifier_ServesCachedKeysWhileFetching(t *testing.T) {
+	issuer, srv := newTestIssuer(t)
+	defer srv.Close()
+
+	cache := NewCache(srv.URL, time.Hour, 0)
+
+	_, err := cache.Verifier("first")
+	assert.NoError(t, err)
+
+	issuer.addKey(t, "second")
+	release := make(chan struct{})
+	issuer.mu.Lock()
+	issuer.release = release
+	issuer.mu.Unlock()
+
+	errs := make(chan error, 2)
+	verify := func() {
+		_, err := cache.Verifier("second")
+		errs <- err
+	}
+
+	go verify()
+	for issuer.requestCount() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Waits for the fetch in progress, rather than making another.
+	go verify()
+
+	cached := make(chan error, 1)
+	go func() {
+		_, err := cache.Verifier("first")
+		cached <- err
+	}()
+
+	select {
+	case err := <-cached:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("cached key wasn't served while fetching the key set")
+	}
+
+	close(release)
+	assert.NoError(t, <-errs)
+	assert.NoError(t, <-errs)
+	assert.Equal(t, 2, issuer.requestCount())
+}
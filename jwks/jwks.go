@@ -0,0 +1,166 @@
+// Package jwks provides types used to publish and consume a JSON Web Key Set,
+// used to verify access tokens signed with one of several RSA keys.
+package jwks
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/reecerussell/gojwt"
+	gojwtrsa "github.com/reecerussell/gojwt/rsa"
+)
+
+// Path is the path the key set is published at.
+const Path = "/.well-known/jwks.json"
+
+// Key errors.
+var (
+	ErrKeyNotFound    = errors.New("signing key not found")
+	ErrUnsupportedKey = errors.New("unsupported key type")
+	ErrMissingKeyID   = errors.New("token has no key id")
+	ErrInvalidHeader  = errors.New("token header is invalid")
+
+	ErrAlgorithmMismatch = errors.New("token algorithm does not match the key")
+	ErrInvalidSignature  = errors.New("token signature is invalid")
+)
+
+// Key is a public RSA JSON Web Key, as defined by RFC 7517.
+type Key struct {
+	Type      string `json:"kty"`
+	Use       string `json:"use"`
+	ID        string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// Set is a JSON Web Key Set.
+type Set struct {
+	Keys []*Key `json:"keys"`
+}
+
+// NewKey returns a new signing Key for the public key, with the given id and
+// algorithm name, such as "RS256".
+func NewKey(id, alg string, pub *rsa.PublicKey) *Key {
+	return &Key{
+		Type:      "RSA",
+		Use:       "sig",
+		ID:        id,
+		Algorithm: alg,
+		Modulus:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}
+}
+
+// PublicKey returns the RSA public key the Key represents.
+func (k *Key) PublicKey() (*rsa.PublicKey, error) {
+	if k.Type != "RSA" {
+		return nil, ErrUnsupportedKey
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(k.Modulus)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.Exponent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// Verifier returns a gojwt.Algorithm which can be used to verify tokens signed with the key.
+func (k *Key) Verifier() (gojwt.Algorithm, error) {
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: der,
+	})
+
+	return gojwtrsa.New(data, crypto.SHA256)
+}
+
+// Header is the header of a JSON Web Token, including the id of the key used to sign it.
+type Header struct {
+	Type      string `json:"typ"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid,omitempty"`
+}
+
+// ParseHeader decodes the header of the token.
+func ParseHeader(token string) (*Header, error) {
+	i := strings.IndexByte(token, '.')
+	if i < 0 {
+		return nil, ErrInvalidHeader
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token[:i])
+	if err != nil {
+		return nil, ErrInvalidHeader
+	}
+
+	var h Header
+	err = json.Unmarshal(data, &h)
+	if err != nil {
+		return nil, ErrInvalidHeader
+	}
+
+	return &h, nil
+}
+
+// KeyID reads the id of the key the token was signed with, from its header.
+func KeyID(token string) (string, error) {
+	h, err := ParseHeader(token)
+	if err != nil {
+		return "", err
+	}
+
+	if h.KeyID == "" {
+		return "", ErrMissingKeyID
+	}
+
+	return h.KeyID, nil
+}
+
+// VerifySignature verifies the token was signed by alg, without validating
+// any of its claims. Unlike gojwt's Verify, this leaves the time based claims
+// to be validated by the caller, allowing for clock skew.
+func VerifySignature(token string, alg gojwt.Algorithm) error {
+	h, err := ParseHeader(token)
+	if err != nil {
+		return err
+	}
+
+	if h.Algorithm != alg.Name() {
+		return ErrAlgorithmMismatch
+	}
+
+	i := strings.LastIndexByte(token, '.')
+	signature, err := base64.RawURLEncoding.DecodeString(token[i+1:])
+	if err != nil || !alg.Verify([]byte(token[:i]), signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package jwks
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey_PublicKey_RoundTrips(t *testing.T) {
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	key := NewKey("key", "RS256", &pk.PublicKey)
+	assert.Equal(t, "RSA", key.Type)
+	assert.Equal(t, "sig", key.Use)
+	assert.Equal(t, "AQAB", key.Exponent)
+
+	pub, err := key.PublicKey()
+	assert.NoError(t, err)
+	assert.True(t, pk.PublicKey.Equal(pub))
+}
+
+func TestKey_UnsupportedType_ReturnsError(t *testing.T) {
+	key := &Key{Type: "EC"}
+
+	alg, err := key.Verifier()
+	assert.Nil(t, alg)
+	assert.Equal(t, ErrUnsupportedKey, err)
+}
+
+func TestKeyID(t *testing.T) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"RS256","kid":"key"}`))
+
+	kid, err := KeyID(header + ".e30.c2lnbmF0dXJl")
+	assert.NoError(t, err)
+	assert.Equal(t, "key", kid)
+}
+
+func TestKeyID_NoKeyID_ReturnsError(t *testing.T) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"RS256"}`))
+
+	kid, err := KeyID(header + ".e30.c2lnbmF0dXJl")
+	assert.Equal(t, "", kid)
+	assert.Equal(t, ErrMissingKeyID, err)
+}
+
+func TestKeyID_InvalidHeader_ReturnsError(t *testing.T) {
+	tokens := []string{"", "abc", "!!!.e30.c2lnbmF0dXJl", "bm90IGpzb24.e30.c2lnbmF0dXJl"}
+
+	for _, token := range tokens {
+		kid, err := KeyID(token)
+		assert.Equal(t, "", kid)
+		assert.Equal(t, ErrInvalidHeader, err, token)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	alg, err := NewKey("key", "RS256", &pk.PublicKey).Verifier()
+	assert.NoError(t, err)
+
+	data := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"RS256","kid":"key"}`)) + ".e30"
+	hash := sha256.Sum256([]byte(data))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, pk, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	token := data + "." + base64.RawURLEncoding.EncodeToString(signature)
+	assert.NoError(t, VerifySignature(token, alg))
+
+	tampered := data[:len(data)-3] + "eyJ1aWQiOiIxIn0." + base64.RawURLEncoding.EncodeToString(signature)
+	assert.Equal(t, ErrInvalidSignature, VerifySignature(tampered, alg))
+
+	hs256 := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"HS256","kid":"key"}`)) + ".e30.c2lnbmF0dXJl"
+	assert.Equal(t, ErrAlgorithmMismatch, VerifySignature(hs256, alg))
+}
@@ -0,0 +1,119 @@
+// Package filesystem provides an implementation of media.Service which stores
+// content in a directory on the local filesystem, for local development and
+// deployments without a storage bucket.
+package filesystem
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/reecerussell/open-social/media"
+)
+
+// directory is an implementation of media.Service for a directory on the local filesystem.
+type directory struct {
+	root string
+}
+
+// New returns a new instance of media.Service, which stores content under
+// the root directory. The directory is created if it doesn't exist.
+func New(root string) (media.Service, error) {
+	if root == "" {
+		return nil, fmt.Errorf("a root directory is required")
+	}
+
+	err := os.MkdirAll(root, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create root: %v", err)
+	}
+
+	return &directory{root: root}, nil
+}
+
+// path returns the path content with the given key is stored at. Files are named
+// by a hash of their key, so keys can't refer to paths outside of the root, and are
+// sharded into two levels of directories, so no one directory grows too large.
+func (d *directory) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+
+	return filepath.Join(d.root, name[:2], name[2:4], name)
+}
+
+// Upload writes the content to a temporary file, before renaming it into place,
+// so content is never partially written, even if the upload fails.
+func (d *directory) Upload(ctx context.Context, key string, r io.Reader) error {
+	path := d.path(key)
+	dir := filepath.Dir(path)
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory: %v", err)
+	}
+
+	f, err := ioutil.TempFile(dir, ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = io.Copy(f, r)
+	if err == nil {
+		err = f.Sync()
+	}
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write: %v", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close file: %v", err)
+	}
+
+	err = os.Rename(f.Name(), path)
+	if err != nil {
+		return fmt.Errorf("failed to rename file: %v", err)
+	}
+
+	return nil
+}
+
+func (d *directory) Download(ctx context.Context, key string, offset int64, w io.Writer) error {
+	f, err := os.Open(d.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return media.ErrNotFound
+		}
+
+		return fmt.Errorf("open: %v", err)
+	}
+	defer f.Close()
+
+	_, err = f.Seek(offset, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("seek: %v", err)
+	}
+
+	_, err = io.Copy(w, f)
+	if err != nil {
+		return fmt.Errorf("read: %v", err)
+	}
+
+	return nil
+}
+
+func (d *directory) Delete(ctx context.Context, key string) error {
+	err := os.Remove(d.path(key))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("delete: %v", err)
+	}
+
+	return nil
+}
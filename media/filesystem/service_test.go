@@ -0,0 +1,143 @@
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/media"
+)
+
+func newTestDirectory(t *testing.T) (*directory, func()) {
+	root, err := ioutil.TempDir("", "media")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service, err := New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return service.(*directory), func() { os.RemoveAll(root) }
+}
+
+func TestNew_GivenNoRoot_ReturnsError(t *testing.T) {
+	service, err := New("")
+	assert.Nil(t, service)
+	assert.Error(t, err)
+}
+
+func TestDirectory_Upload_CanBeDownloaded(t *testing.T) {
+	d, cleanup := newTestDirectory(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	err := d.Upload(ctx, "23947", strings.NewReader("hello world"))
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = d.Download(ctx, "23947", 0, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", buf.String())
+}
+
+func TestDirectory_Download_GivenOffset_WritesRemainingContent(t *testing.T) {
+	d, cleanup := newTestDirectory(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_ = d.Upload(ctx, "23947", strings.NewReader("hello world"))
+
+	var buf bytes.Buffer
+	err := d.Download(ctx, "23947", 6, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "world", buf.String())
+}
+
+func TestDirectory_Upload_OverwritesContent(t *testing.T) {
+	d, cleanup := newTestDirectory(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_ = d.Upload(ctx, "23947", strings.NewReader("hello world"))
+	err := d.Upload(ctx, "23947", strings.NewReader("goodbye"))
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	_ = d.Download(ctx, "23947", 0, &buf)
+	assert.Equal(t, "goodbye", buf.String())
+}
+
+func TestDirectory_Upload_ShardsContentAndLeavesNoTemporaryFiles(t *testing.T) {
+	d, cleanup := newTestDirectory(t)
+	defer cleanup()
+
+	err := d.Upload(context.Background(), "23947", strings.NewReader("hello world"))
+	assert.NoError(t, err)
+
+	var files []string
+	_ = filepath.Walk(d.root, func(path string, info os.FileInfo, err error) error {
+		if !info.IsDir() {
+			rel, _ := filepath.Rel(d.root, path)
+			files = append(files, rel)
+		}
+
+		return nil
+	})
+
+	assert.Len(t, files, 1)
+	parts := strings.Split(files[0], string(filepath.Separator))
+	assert.Len(t, parts, 3)
+	assert.True(t, strings.HasPrefix(parts[2], parts[0]+parts[1]))
+}
+
+func TestDirectory_Path_StaysWithinRoot(t *testing.T) {
+	d, cleanup := newTestDirectory(t)
+	defer cleanup()
+
+	for _, key := range []string{"../../etc/passwd", "/abs", ".", ".."} {
+		path := d.path(key)
+		rel, err := filepath.Rel(d.root, path)
+		assert.NoError(t, err)
+		assert.False(t, strings.HasPrefix(rel, ".."), key)
+	}
+}
+
+func TestDirectory_DownloadNonExistant_ReturnsNotFound(t *testing.T) {
+	d, cleanup := newTestDirectory(t)
+	defer cleanup()
+
+	var buf bytes.Buffer
+	err := d.Download(context.Background(), "23947", 0, &buf)
+	assert.Equal(t, 0, buf.Len())
+	assert.Equal(t, media.ErrNotFound, err)
+}
+
+func TestDirectory_Delete_RemovesContent(t *testing.T) {
+	d, cleanup := newTestDirectory(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_ = d.Upload(ctx, "23947", strings.NewReader("hello world"))
+
+	err := d.Delete(ctx, "23947")
+	assert.NoError(t, err)
+
+	err = d.Download(ctx, "23947", 0, ioutil.Discard)
+	assert.Equal(t, media.ErrNotFound, err)
+}
+
+func TestDirectory_DeleteNonExistant_ReturnsNoError(t *testing.T) {
+	d, cleanup := newTestDirectory(t)
+	defer cleanup()
+
+	err := d.Delete(context.Background(), "23947")
+	assert.NoError(t, err)
+}
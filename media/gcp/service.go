@@ -3,7 +3,7 @@ package gcp
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"os"
 	"time"
 
@@ -46,13 +46,14 @@ func New(ctx context.Context, bucketName string) (media.Service, error) {
 	}, nil
 }
 
-func (b *bucket) Upload(ctx context.Context, key string, data []byte) error {
+func (b *bucket) Upload(ctx context.Context, key string, r io.Reader) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*time.Duration(b.timeoutSeconds))
 	defer cancel()
 
 	w := b.client.Bucket(b.bucketName).Object(key).NewWriter(ctx)
-	_, err := w.Write(data)
+	_, err := io.Copy(w, r)
 	if err != nil {
+		w.Close()
 		return fmt.Errorf("failed to write: %v", err)
 	}
 
@@ -64,20 +65,36 @@ func (b *bucket) Upload(ctx context.Context, key string, data []byte) error {
 	return nil
 }
 
-func (b *bucket) Download(ctx context.Context, key string) ([]byte, error) {
+func (b *bucket) Download(ctx context.Context, key string, offset int64, w io.Writer) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*time.Duration(b.timeoutSeconds))
 	defer cancel()
 
-	r, err := b.client.Bucket(b.bucketName).Object(key).NewReader(ctx)
+	r, err := b.client.Bucket(b.bucketName).Object(key).NewRangeReader(ctx, offset, -1)
 	if err != nil {
-		return nil, fmt.Errorf("reader: %v", err)
+		if err == storage.ErrObjectNotExist {
+			return media.ErrNotFound
+		}
+
+		return fmt.Errorf("reader: %v", err)
 	}
 	defer r.Close()
 
-	data, err := ioutil.ReadAll(r)
+	_, err = io.Copy(w, r)
 	if err != nil {
-		return nil, fmt.Errorf("read: %v", err)
+		return fmt.Errorf("read: %v", err)
+	}
+
+	return nil
+}
+
+func (b *bucket) Delete(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*time.Duration(b.timeoutSeconds))
+	defer cancel()
+
+	err := b.client.Bucket(b.bucketName).Object(key).Delete(ctx)
+	if err != nil && err != storage.ErrObjectNotExist {
+		return fmt.Errorf("delete: %v", err)
 	}
 
-	return data, nil
+	return nil
 }
@@ -0,0 +1,226 @@
+// Package s3 provides an implementation of media.Service for Amazon S3, and
+// S3-compatible stores such as MinIO. Requests are signed with AWS Signature
+// Version 4, and buckets are addressed by path, which all S3-compatible
+// stores support.
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/reecerussell/open-social/media"
+)
+
+// Config contains the config for an S3-compatible bucket. Endpoint is the base
+// URL of the store, such as https://s3.eu-west-2.amazonaws.com, or
+// http://localhost:9000 for a local MinIO server.
+type Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// bucket is an implementation of media.Service for S3-compatible buckets.
+type bucket struct {
+	endpoint       string
+	bucketName     string
+	timeoutSeconds int
+	signer         *signer
+	client         *http.Client
+}
+
+// New returns a new instance of media.Service for S3-compatible buckets.
+func New(cnf *Config) (media.Service, error) {
+	endpoint, err := url.Parse(cnf.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint: %s", cnf.Endpoint)
+	}
+
+	if cnf.Bucket == "" {
+		return nil, fmt.Errorf("a bucket is required")
+	}
+
+	region := cnf.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	return &bucket{
+		endpoint:       strings.TrimSuffix(cnf.Endpoint, "/"),
+		bucketName:     cnf.Bucket,
+		timeoutSeconds: 30,
+		signer: &signer{
+			region:          region,
+			accessKeyID:     cnf.AccessKeyID,
+			secretAccessKey: cnf.SecretAccessKey,
+		},
+		client: http.DefaultClient,
+	}, nil
+}
+
+// Upload spools the content to a temporary file, hashing it on the way, as S3 needs
+// to know its length and hash before it's sent, without holding it in memory.
+func (b *bucket) Upload(ctx context.Context, key string, r io.Reader) error {
+	f, err := ioutil.TempFile("", "s3-upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, h), r)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to spool: %v", err)
+	}
+
+	resp, err := b.do(ctx, http.MethodPut, key, nil, ioutil.NopCloser(f), size, hex.EncodeToString(h.Sum(nil)))
+	if err != nil {
+		return fmt.Errorf("failed to write: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to write: %v", readError(resp))
+	}
+
+	return nil
+}
+
+func (b *bucket) Download(ctx context.Context, key string, offset int64, w io.Writer) error {
+	var header http.Header
+	if offset > 0 {
+		header = http.Header{"Range": {fmt.Sprintf("bytes=%d-", offset)}}
+	}
+
+	resp, err := b.do(ctx, http.MethodGet, key, header, nil, 0, hashHex(nil))
+	if err != nil {
+		return fmt.Errorf("reader: %v", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// A store which ignores the range sends the whole object, which would
+		// be written from the wrong position.
+		if offset > 0 {
+			return fmt.Errorf("reader: range was not satisfied: %s", resp.Status)
+		}
+	case http.StatusPartialContent:
+	case http.StatusNotFound:
+		return media.ErrNotFound
+	default:
+		return fmt.Errorf("reader: %v", readError(resp))
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	if err != nil {
+		return fmt.Errorf("read: %v", err)
+	}
+
+	return nil
+}
+
+func (b *bucket) Delete(ctx context.Context, key string) error {
+	resp, err := b.do(ctx, http.MethodDelete, key, nil, nil, 0, hashHex(nil))
+	if err != nil {
+		return fmt.Errorf("delete: %v", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("delete: %v", readError(resp))
+	}
+}
+
+// do sends a signed request, with the given headers, for the object with the
+// given key, with a body of the given size, whose SHA-256 hash is payloadHash.
+//
+// The request times out if the response headers haven't arrived in time. The
+// response body isn't covered by the timeout, as large objects may take longer
+// to read; it's only bound by ctx.
+func (b *bucket) do(ctx context.Context, method, key string, header http.Header, body io.Reader, size int64, payloadHash string) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	timer := time.AfterFunc(time.Second*time.Duration(b.timeoutSeconds), cancel)
+
+	url := b.endpoint + "/" + escape(b.bucketName, true) + "/" + escape(key, false)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		timer.Stop()
+		cancel()
+		return nil, err
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	req.ContentLength = size
+	b.signer.sign(req, payloadHash, time.Now())
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		timer.Stop()
+		cancel()
+		return nil, err
+	}
+
+	// The timer may have fired after the headers arrived, but before it was
+	// stopped, in which case the body can't be read.
+	if !timer.Stop() {
+		resp.Body.Close()
+		cancel()
+		return nil, context.DeadlineExceeded
+	}
+
+	// The context must outlive the call, as the body is read after it returns.
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelBody cancels a request's context once its response body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// errorResponse is the body of an error response from S3.
+type errorResponse struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// readError reads the error from an unsuccessful response.
+func readError(resp *http.Response) error {
+	var e errorResponse
+	err := xml.NewDecoder(resp.Body).Decode(&e)
+	if err != nil || e.Code == "" {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return fmt.Errorf("%s: %s", e.Code, e.Message)
+}
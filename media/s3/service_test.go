@@ -0,0 +1,230 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reecerussell/open-social/media"
+)
+
+// fakeStore is a minimal S3-compatible store, which verifies the
+// signature of each request, before serving it from memory.
+type fakeStore struct {
+	mu      sync.Mutex
+	signer  *signer
+	objects map[string][]byte
+
+	// ignoreRange sends whole objects, as some stores do, if set.
+	ignoreRange bool
+}
+
+func (s *fakeStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	if !s.verify(r, body) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`<Error><Code>SignatureDoesNotMatch</Code><Message>The signature does not match.</Message></Error>`))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		s.objects[r.URL.Path] = body
+	case http.MethodGet:
+		data, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			return
+		}
+
+		if s.ignoreRange {
+			w.Write(data)
+			return
+		}
+
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// verify signs a copy of the request, with the signed headers, and compares the signatures.
+func (s *fakeStore) verify(r *http.Request, body []byte) bool {
+	if r.Header.Get("X-Amz-Content-Sha256") != hashHex(body) {
+		return false
+	}
+
+	t, err := time.Parse(amzDateFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+
+	req, _ := http.NewRequest(r.Method, "http://"+r.Host+r.URL.EscapedPath(), nil)
+	if v := r.Header.Get("Range"); v != "" {
+		req.Header.Set("Range", v)
+	}
+	s.signer.sign(req, hashHex(body), t)
+
+	return req.Header.Get("Authorization") == r.Header.Get("Authorization")
+}
+
+func newTestBucket(t *testing.T, secret string) (media.Service, *fakeStore, func()) {
+	store := &fakeStore{
+		signer: &signer{
+			region:          "eu-west-2",
+			accessKeyID:     "test-key",
+			secretAccessKey: "test-secret",
+		},
+		objects: map[string][]byte{},
+	}
+	server := httptest.NewServer(store)
+
+	service, err := New(&Config{
+		Endpoint:        server.URL,
+		Region:          "eu-west-2",
+		Bucket:          "media",
+		AccessKeyID:     "test-key",
+		SecretAccessKey: secret,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return service, store, server.Close
+}
+
+func TestNew_GivenInvalidConfig_ReturnsError(t *testing.T) {
+	_, err := New(&Config{Endpoint: "localhost", Bucket: "media"})
+	assert.Error(t, err)
+
+	_, err = New(&Config{Endpoint: "http://localhost:9000"})
+	assert.Error(t, err)
+}
+
+func TestBucket_Upload_CanBeDownloaded(t *testing.T) {
+	service, store, cleanup := newTestBucket(t, "test-secret")
+	defer cleanup()
+
+	ctx := context.Background()
+	err := service.Upload(ctx, "23947 a+b", strings.NewReader("hello world"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello world"), store.objects["/media/23947 a+b"])
+
+	var buf bytes.Buffer
+	err = service.Download(ctx, "23947 a+b", 0, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", buf.String())
+}
+
+func TestBucket_Download_GivenOffset_WritesRemainingContent(t *testing.T) {
+	service, _, cleanup := newTestBucket(t, "test-secret")
+	defer cleanup()
+
+	ctx := context.Background()
+	_ = service.Upload(ctx, "23947", strings.NewReader("hello world"))
+
+	var buf bytes.Buffer
+	err := service.Download(ctx, "23947", 6, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "world", buf.String())
+}
+
+func TestBucket_Download_GivenOffsetAndRangeIgnored_ReturnsError(t *testing.T) {
+	service, store, cleanup := newTestBucket(t, "test-secret")
+	defer cleanup()
+
+	ctx := context.Background()
+	_ = service.Upload(ctx, "23947", strings.NewReader("hello world"))
+	store.ignoreRange = true
+
+	var buf bytes.Buffer
+	err := service.Download(ctx, "23947", 6, &buf)
+	assert.Error(t, err)
+	assert.Equal(t, 0, buf.Len())
+}
+
+func TestBucket_Download_SlowBody_DoesNotTimeOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("hello "))
+		w.(http.Flusher).Flush()
+
+		time.Sleep(1500 * time.Millisecond)
+		w.Write([]byte("world"))
+	}))
+	defer server.Close()
+
+	service, _ := New(&Config{Endpoint: server.URL, Bucket: "media"})
+	service.(*bucket).timeoutSeconds = 1
+
+	var buf bytes.Buffer
+	err := service.Download(context.Background(), "23947", 0, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", buf.String())
+}
+
+func TestBucket_Download_SlowHeaders_TimesOut(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	service, _ := New(&Config{Endpoint: server.URL, Bucket: "media"})
+	service.(*bucket).timeoutSeconds = 1
+
+	var buf bytes.Buffer
+	err := service.Download(context.Background(), "23947", 0, &buf)
+	assert.Error(t, err)
+}
+
+func TestBucket_DownloadNonExistant_ReturnsNotFound(t *testing.T) {
+	service, _, cleanup := newTestBucket(t, "test-secret")
+	defer cleanup()
+
+	var buf bytes.Buffer
+	err := service.Download(context.Background(), "23947", 0, &buf)
+	assert.Equal(t, 0, buf.Len())
+	assert.Equal(t, media.ErrNotFound, err)
+}
+
+func TestBucket_Delete_RemovesContent(t *testing.T) {
+	service, store, cleanup := newTestBucket(t, "test-secret")
+	defer cleanup()
+
+	ctx := context.Background()
+	_ = service.Upload(ctx, "23947", strings.NewReader("hello world"))
+
+	err := service.Delete(ctx, "23947")
+	assert.NoError(t, err)
+	assert.Empty(t, store.objects)
+
+	err = service.Delete(ctx, "23947")
+	assert.NoError(t, err)
+}
+
+func TestBucket_GivenWrongCredentials_ReturnsError(t *testing.T) {
+	service, _, cleanup := newTestBucket(t, "wrong-secret")
+	defer cleanup()
+
+	err := service.Upload(context.Background(), "23947", strings.NewReader("hello world"))
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "SignatureDoesNotMatch"), err.Error())
+}
@@ -1,9 +1,24 @@
 package media
 
-import "context"
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned by Download if there is no content stored at a key.
+var ErrNotFound = errors.New("media content not found")
 
 // Service is a high level interface used to manage media content.
 type Service interface {
-	Upload(ctx context.Context, key string, data []byte) error
-	Download(ctx context.Context, key string) ([]byte, error)
+	// Upload stores the content read from r at key, replacing any existing content.
+	Upload(ctx context.Context, key string, r io.Reader) error
+
+	// Download writes the content stored at key to w, starting from offset. If
+	// there is no content, ErrNotFound is returned before anything is written.
+	Download(ctx context.Context, key string, offset int64, w io.Writer) error
+
+	// Delete removes the content stored at key. Deleting content which
+	// doesn't exist isn't an error.
+	Delete(ctx context.Context, key string) error
 }
@@ -0,0 +1,217 @@
+// Package mediaurl signs and verifies the URLs media is downloaded from, so
+// media can only be downloaded by those it was handed to, until the URL expires.
+//
+// URLs are signed with HMAC-SHA256, using one of several keys, so keys can be
+// rotated: a new key is added to every service, then used to sign URLs, and
+// the old key is removed once the URLs signed with it have expired.
+package mediaurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The query parameters of a signed URL.
+const (
+	ExpiresParam   = "exp"
+	KeyIDParam     = "kid"
+	UserIDParam    = "uid"
+	SignatureParam = "sig"
+)
+
+// UserCookie is the name of the cookie identifying the user requesting media,
+// set by the backend with a token from SignUser, used to verify URLs which
+// are bound to a user.
+const UserCookie = "media_user"
+
+// Key errors.
+var (
+	ErrNoKeys      = errors.New("no media url keys are configured")
+	ErrInvalidKey  = errors.New("media url keys must be an id and a base64 secret, separated by a colon")
+	ErrDuplicateID = errors.New("media url key ids must be unique")
+)
+
+// Verification errors.
+var (
+	ErrMissingSignature = errors.New("url is not signed")
+	ErrUnknownKey       = errors.New("url is signed with an unknown key")
+	ErrInvalidSignature = errors.New("url signature is invalid")
+	ErrExpired          = errors.New("url has expired")
+	ErrUserMismatch     = errors.New("url was issued to another user")
+)
+
+// Key is a secret used to sign URLs, identified by ID.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// ParseKeys parses a comma separated list of keys, each an id and a base64
+// encoded secret, separated by a colon, such as "2:c2VjcmV0,1:b2xk".
+func ParseKeys(s string) ([]*Key, error) {
+	var keys []*Key
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		parts := strings.SplitN(v, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, ErrInvalidKey
+		}
+
+		secret, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil || len(secret) == 0 {
+			return nil, ErrInvalidKey
+		}
+
+		keys = append(keys, &Key{ID: parts[0], Secret: secret})
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	return keys, nil
+}
+
+// Signer signs URLs with the first of its keys, and verifies URLs signed
+// with any of them.
+type Signer struct {
+	keys []*Key
+
+	now func() time.Time
+}
+
+// NewSigner returns a new instance of Signer for the keys.
+func NewSigner(keys []*Key) (*Signer, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	ids := make(map[string]bool)
+	for _, k := range keys {
+		if ids[k.ID] {
+			return nil, ErrDuplicateID
+		}
+
+		ids[k.ID] = true
+	}
+
+	return &Signer{keys: keys}, nil
+}
+
+// Sign returns the query of a URL for the media with the given reference id,
+// valid until expires. If userID isn't empty, the URL is bound to the user,
+// and only valid when requested by them.
+func (s *Signer) Sign(referenceID, userID string, expires time.Time) url.Values {
+	return s.sign("media", referenceID, userID, expires)
+}
+
+// Verify verifies the query of a URL for the media with the given reference
+// id, requested by the user with the given id, which is empty if unknown.
+func (s *Signer) Verify(referenceID string, query url.Values, userID string) error {
+	uid, err := s.verify("media", referenceID, query)
+	if err != nil {
+		return err
+	}
+
+	if uid != "" && uid != userID {
+		return ErrUserMismatch
+	}
+
+	return nil
+}
+
+// SignUser returns a token identifying the user, valid until expires,
+// used to tell who is requesting URLs which are bound to a user.
+func (s *Signer) SignUser(userID string, expires time.Time) string {
+	return s.sign("user", "", userID, expires).Encode()
+}
+
+// VerifyUser verifies the token, returning the id of the user it identifies.
+func (s *Signer) VerifyUser(token string) (string, error) {
+	query, err := url.ParseQuery(token)
+	if err != nil {
+		return "", ErrMissingSignature
+	}
+
+	return s.verify("user", "", query)
+}
+
+// sign signs the resource, of the given kind, so the kinds can't be mistaken for one another.
+func (s *Signer) sign(kind, resource, userID string, expires time.Time) url.Values {
+	key := s.keys[0]
+	exp := strconv.FormatInt(expires.Unix(), 10)
+
+	query := url.Values{}
+	query.Set(ExpiresParam, exp)
+	query.Set(KeyIDParam, key.ID)
+	if userID != "" {
+		query.Set(UserIDParam, userID)
+	}
+	query.Set(SignatureParam, signature(key, kind, resource, userID, exp))
+
+	return query
+}
+
+// verify verifies the signature of the resource, of the given kind,
+// returning the id of the user it's bound to, if any.
+func (s *Signer) verify(kind, resource string, query url.Values) (string, error) {
+	sig := query.Get(SignatureParam)
+	exp := query.Get(ExpiresParam)
+	if sig == "" || exp == "" {
+		return "", ErrMissingSignature
+	}
+
+	key := s.key(query.Get(KeyIDParam))
+	if key == nil {
+		return "", ErrUnknownKey
+	}
+
+	userID := query.Get(UserIDParam)
+	expected := signature(key, kind, resource, userID, exp)
+	if !hmac.Equal([]byte(sig), []byte(expected)) {
+		return "", ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+
+	if !now().Before(time.Unix(unix, 0)) {
+		return "", ErrExpired
+	}
+
+	return userID, nil
+}
+
+func (s *Signer) key(id string) *Key {
+	for _, k := range s.keys {
+		if k.ID == id {
+			return k
+		}
+	}
+
+	return nil
+}
+
+func signature(key *Key, kind, resource, userID, exp string) string {
+	mac := hmac.New(sha256.New, key.Secret)
+	mac.Write([]byte(kind + "\n" + resource + "\n" + userID + "\n" + exp))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,174 @@
+package mediaurl
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2020, 11, 2, 15, 4, 5, 0, time.UTC)
+
+func newTestSigner(t *testing.T, keys string) *Signer {
+	k, err := ParseKeys(keys)
+	if err != nil {
+		t.Fatalf("failed to parse keys: %v", err)
+	}
+
+	s, err := NewSigner(k)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	s.now = func() time.Time { return testNow }
+
+	return s
+}
+
+func TestParseKeys(t *testing.T) {
+	keys, err := ParseKeys("2:c2VjcmV0, 1:b2xk")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+	assert.Equal(t, "2", keys[0].ID)
+	assert.Equal(t, []byte("secret"), keys[0].Secret)
+	assert.Equal(t, "1", keys[1].ID)
+	assert.Equal(t, []byte("old"), keys[1].Secret)
+}
+
+func TestParseKeys_GivenInvalidKeys_ReturnsError(t *testing.T) {
+	tests := []struct {
+		keys string
+		err  error
+	}{
+		{"", ErrNoKeys},
+		{" , ", ErrNoKeys},
+		{"c2VjcmV0", ErrInvalidKey},
+		{":c2VjcmV0", ErrInvalidKey},
+		{"1:", ErrInvalidKey},
+		{"1:!!!", ErrInvalidKey},
+	}
+
+	for _, test := range tests {
+		keys, err := ParseKeys(test.keys)
+		assert.Nil(t, keys, test.keys)
+		assert.Equal(t, test.err, err, test.keys)
+	}
+}
+
+func TestNewSigner_GivenDuplicateKeyIDs_ReturnsError(t *testing.T) {
+	s, err := NewSigner([]*Key{{ID: "1", Secret: []byte("a")}, {ID: "1", Secret: []byte("b")}})
+	assert.Nil(t, s)
+	assert.Equal(t, ErrDuplicateID, err)
+
+	s, err = NewSigner(nil)
+	assert.Nil(t, s)
+	assert.Equal(t, ErrNoKeys, err)
+}
+
+func TestSigner_Sign_Verifies(t *testing.T) {
+	s := newTestSigner(t, "1:c2VjcmV0")
+
+	query := s.Sign("2389", "", testNow.Add(time.Hour))
+	assert.Equal(t, "1", query.Get(KeyIDParam))
+	assert.Equal(t, "1604333045", query.Get(ExpiresParam))
+	assert.Empty(t, query.Get(UserIDParam))
+
+	assert.NoError(t, s.Verify("2389", query, ""))
+	assert.NoError(t, s.Verify("2389", query, "someone"))
+}
+
+func TestSigner_Sign_BindsUser(t *testing.T) {
+	s := newTestSigner(t, "1:c2VjcmV0")
+
+	query := s.Sign("2389", "user", testNow.Add(time.Hour))
+	assert.Equal(t, "user", query.Get(UserIDParam))
+
+	assert.NoError(t, s.Verify("2389", query, "user"))
+	assert.Equal(t, ErrUserMismatch, s.Verify("2389", query, "other"))
+	assert.Equal(t, ErrUserMismatch, s.Verify("2389", query, ""))
+}
+
+func TestSigner_Verify_GivenInvalidQuery_ReturnsError(t *testing.T) {
+	s := newTestSigner(t, "1:c2VjcmV0")
+	valid := s.Sign("2389", "user", testNow.Add(time.Hour))
+
+	with := func(name, value string) url.Values {
+		query := url.Values{}
+		for k, v := range valid {
+			query[k] = v
+		}
+
+		if value == "" {
+			query.Del(name)
+		} else {
+			query.Set(name, value)
+		}
+
+		return query
+	}
+
+	tests := []struct {
+		name  string
+		query url.Values
+		err   error
+	}{
+		{"NoSignature", with(SignatureParam, ""), ErrMissingSignature},
+		{"NoExpiry", with(ExpiresParam, ""), ErrMissingSignature},
+		{"UnknownKey", with(KeyIDParam, "2"), ErrUnknownKey},
+		{"ChangedExpiry", with(ExpiresParam, "1704332945"), ErrInvalidSignature},
+		{"ChangedUser", with(UserIDParam, "other"), ErrInvalidSignature},
+		{"UnboundUser", with(UserIDParam, ""), ErrInvalidSignature},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.err, s.Verify("2389", test.query, "other"))
+		})
+	}
+
+	assert.Equal(t, ErrInvalidSignature, s.Verify("9823", valid, "user"))
+}
+
+func TestSigner_Verify_WhereExpired_ReturnsError(t *testing.T) {
+	s := newTestSigner(t, "1:c2VjcmV0")
+
+	query := s.Sign("2389", "", testNow)
+	assert.Equal(t, ErrExpired, s.Verify("2389", query, ""))
+}
+
+func TestSigner_Verify_GivenRotatedKeys_VerifiesOldKey(t *testing.T) {
+	old := newTestSigner(t, "1:b2xk")
+	query := old.Sign("2389", "", testNow.Add(time.Hour))
+
+	s := newTestSigner(t, "2:c2VjcmV0,1:b2xk")
+	assert.NoError(t, s.Verify("2389", query, ""))
+	assert.Equal(t, "2", s.Sign("2389", "", testNow.Add(time.Hour)).Get(KeyIDParam))
+
+	retired := newTestSigner(t, "2:c2VjcmV0")
+	assert.Equal(t, ErrUnknownKey, retired.Verify("2389", query, ""))
+}
+
+func TestSigner_SignUser_Verifies(t *testing.T) {
+	s := newTestSigner(t, "1:c2VjcmV0")
+
+	token := s.SignUser("user", testNow.Add(time.Hour))
+	userID, err := s.VerifyUser(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "user", userID)
+
+	userID, err = s.VerifyUser(s.SignUser("user", testNow))
+	assert.Empty(t, userID)
+	assert.Equal(t, ErrExpired, err)
+
+	userID, err = s.VerifyUser("%")
+	assert.Empty(t, userID)
+	assert.Equal(t, ErrMissingSignature, err)
+}
+
+func TestSigner_SignUser_CannotBeUsedAsURL(t *testing.T) {
+	s := newTestSigner(t, "1:c2VjcmV0")
+
+	query, _ := url.ParseQuery(s.SignUser("user", testNow.Add(time.Hour)))
+	assert.Equal(t, ErrInvalidSignature, s.Verify("", query, "user"))
+}
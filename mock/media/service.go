@@ -7,6 +7,7 @@ package media
 import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
+	io "io"
 	reflect "reflect"
 )
 
@@ -34,30 +35,43 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // Upload mocks base method.
-func (m *MockService) Upload(ctx context.Context, key string, data []byte) error {
+func (m *MockService) Upload(ctx context.Context, key string, r io.Reader) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Upload", ctx, key, data)
+	ret := m.ctrl.Call(m, "Upload", ctx, key, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Upload indicates an expected call of Upload.
-func (mr *MockServiceMockRecorder) Upload(ctx, key, data interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) Upload(ctx, key, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockService)(nil).Upload), ctx, key, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockService)(nil).Upload), ctx, key, r)
 }
 
 // Download mocks base method.
-func (m *MockService) Download(ctx context.Context, key string) ([]byte, error) {
+func (m *MockService) Download(ctx context.Context, key string, offset int64, w io.Writer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Download", ctx, key)
-	ret0, _ := ret[0].([]byte)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Download", ctx, key, offset, w)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Download indicates an expected call of Download.
-func (mr *MockServiceMockRecorder) Download(ctx, key interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) Download(ctx, key, offset, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockService)(nil).Download), ctx, key, offset, w)
+}
+
+// Delete mocks base method.
+func (m *MockService) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockService)(nil).Download), ctx, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), ctx, key)
 }
@@ -0,0 +1 @@
+DROP TABLE [dbo].[AccountDeletions];
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE [dbo].[AccountDeletions] (
+	[Id] BIGINT NOT NULL PRIMARY KEY IDENTITY(1,1),
+	[UserId] INT NOT NULL UNIQUE,
+	[UserReferenceId] UNIQUEIDENTIFIER NOT NULL UNIQUE,
+	[Requested] DATETIME NOT NULL,
+	[Scheduled] DATETIME NOT NULL,
+	[Cancelled] DATETIME NULL,
+	[Completed] DATETIME NULL,
+	[Attempts] INT NOT NULL,
+	[NextAttempt] DATETIME NOT NULL,
+	[LastError] NVARCHAR(MAX) NULL,
+	[MediaIds] NVARCHAR(MAX) NOT NULL
+);
+
+CREATE INDEX IX_AccountDeletions_NextAttempt ON [dbo].[AccountDeletions] ([NextAttempt]) WHERE [Cancelled] IS NULL AND [Completed] IS NULL;
\ No newline at end of file
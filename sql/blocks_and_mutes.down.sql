@@ -0,0 +1,2 @@
+DROP TABLE [dbo].[UserMutes];
+DROP TABLE [dbo].[UserBlocks];
\ No newline at end of file
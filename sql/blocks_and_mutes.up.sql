@@ -0,0 +1,17 @@
+CREATE TABLE [dbo].[UserBlocks] (
+	[UserId] INT NOT NULL,
+	[BlockedUserId] INT NOT NULL,
+	[Blocked] DATETIME NOT NULL,
+	CONSTRAINT PK_UserBlocks PRIMARY KEY ([UserId], [BlockedUserId]),
+	CONSTRAINT FK_UserBlocks_UserId FOREIGN KEY ([UserId]) REFERENCES [Users] ([Id]),
+	CONSTRAINT FK_UserBlocks_BlockedUserId FOREIGN KEY ([BlockedUserId]) REFERENCES [Users] ([Id])
+);
+
+CREATE TABLE [dbo].[UserMutes] (
+	[UserId] INT NOT NULL,
+	[MutedUserId] INT NOT NULL,
+	[Muted] DATETIME NOT NULL,
+	CONSTRAINT PK_UserMutes PRIMARY KEY ([UserId], [MutedUserId]),
+	CONSTRAINT FK_UserMutes_UserId FOREIGN KEY ([UserId]) REFERENCES [Users] ([Id]),
+	CONSTRAINT FK_UserMutes_MutedUserId FOREIGN KEY ([MutedUserId]) REFERENCES [Users] ([Id])
+);
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE OR ALTER FUNCTION [dbo].[CanViewProfile] (@ProfileUserId INT, @UserReferenceId UNIQUEIDENTIFIER)
+RETURNS BIT
+BEGIN
+	IF EXISTS(SELECT [Id] FROM [Users] WHERE [Id] = @ProfileUserId AND ([IsPrivate] = 0 OR [ReferenceId] = @UserReferenceId))
+	BEGIN
+		RETURN CAST(1 AS BIT)
+	END
+
+	IF EXISTS(SELECT [UF].[UserId] FROM [UserFollowers] AS [UF]
+		INNER JOIN [Users] AS [U] ON [U].[Id] = [UF].[FollowerId]
+		WHERE [UF].[UserId] = @ProfileUserId AND [U].[ReferenceId] = @UserReferenceId)
+	BEGIN
+		RETURN CAST(1 AS BIT)
+	END
+
+	RETURN CAST(0 AS BIT)
+END
\ No newline at end of file
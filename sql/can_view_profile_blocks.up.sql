@@ -0,0 +1,22 @@
+CREATE OR ALTER FUNCTION [dbo].[CanViewProfile] (@ProfileUserId INT, @UserReferenceId UNIQUEIDENTIFIER)
+RETURNS BIT
+BEGIN
+	DECLARE @UserId INT = (SELECT [Id] FROM [Users] WHERE [ReferenceId] = @UserReferenceId)
+
+	IF @UserId <> @ProfileUserId AND [dbo].IsBlocked(@ProfileUserId, @UserId) = 1
+	BEGIN
+		RETURN CAST(0 AS BIT)
+	END
+
+	IF EXISTS(SELECT [Id] FROM [Users] WHERE [Id] = @ProfileUserId AND ([IsPrivate] = 0 OR [Id] = @UserId))
+	BEGIN
+		RETURN CAST(1 AS BIT)
+	END
+
+	IF EXISTS(SELECT [UserId] FROM [UserFollowers] WHERE [UserId] = @ProfileUserId AND [FollowerId] = @UserId)
+	BEGIN
+		RETURN CAST(1 AS BIT)
+	END
+
+	RETURN CAST(0 AS BIT)
+END
\ No newline at end of file
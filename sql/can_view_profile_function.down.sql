@@ -0,0 +1 @@
+DROP FUNCTION [dbo].[CanViewProfile];
\ No newline at end of file
@@ -0,0 +1 @@
+DROP FUNCTION [dbo].[IsBlocked];
\ No newline at end of file
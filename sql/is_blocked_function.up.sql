@@ -0,0 +1,12 @@
+CREATE OR ALTER FUNCTION [dbo].[IsBlocked] (@UserId INT, @OtherUserId INT)
+RETURNS BIT
+BEGIN
+	IF EXISTS(SELECT [UserId] FROM [UserBlocks]
+		WHERE ([UserId] = @UserId AND [BlockedUserId] = @OtherUserId)
+			OR ([UserId] = @OtherUserId AND [BlockedUserId] = @UserId))
+	BEGIN
+		RETURN CAST(1 AS BIT)
+	END
+
+	RETURN CAST(0 AS BIT)
+END
\ No newline at end of file
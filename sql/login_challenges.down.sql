@@ -0,0 +1 @@
+DROP TABLE [dbo].[LoginChallenges];
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE [dbo].[LoginChallenges] (
+	[Id] BIGINT NOT NULL PRIMARY KEY IDENTITY(1,1),
+	[TokenHash] CHAR(64) NOT NULL UNIQUE,
+	[UserId] INT NOT NULL,
+	[Created] DATETIME NOT NULL,
+	[Expires] DATETIME NOT NULL,
+	[Attempts] INT NOT NULL,
+	[Used] DATETIME NULL,
+	CONSTRAINT FK_LoginChallenges_UserId FOREIGN KEY ([UserId]) REFERENCES [Users] ([Id])
+);
+
+CREATE INDEX IX_LoginChallenges_UserId ON [dbo].[LoginChallenges] ([UserId]);
\ No newline at end of file
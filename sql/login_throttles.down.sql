@@ -0,0 +1 @@
+DROP TABLE [dbo].[LoginThrottles];
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE [dbo].[LoginThrottles] (
+	[Key] NVARCHAR(100) NOT NULL PRIMARY KEY,
+	[Failures] INT NOT NULL,
+	[Lockouts] INT NOT NULL,
+	[LastFailure] DATETIME NULL,
+	[LockedUntil] DATETIME NULL
+);
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE [dbo].[MediaRenditions] DROP COLUMN [Size], [ETag];
+
+ALTER TABLE [dbo].[Media] DROP CONSTRAINT DF_Media_Uploaded;
+ALTER TABLE [dbo].[Media] DROP COLUMN [Uploaded];
\ No newline at end of file
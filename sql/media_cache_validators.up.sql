@@ -0,0 +1,3 @@
+ALTER TABLE [dbo].[Media] ADD [Uploaded] DATETIME NOT NULL CONSTRAINT DF_Media_Uploaded DEFAULT GETUTCDATE();
+
+ALTER TABLE [dbo].[MediaRenditions] ADD [Size] BIGINT NULL, [ETag] CHAR(64) NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE [dbo].[MediaRenditions];
\ No newline at end of file
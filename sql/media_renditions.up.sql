@@ -0,0 +1,8 @@
+CREATE TABLE [dbo].[MediaRenditions] (
+	[MediaId] INT NOT NULL,
+	[Name] VARCHAR(20) NOT NULL,
+	[Width] INT NOT NULL,
+	[Height] INT NOT NULL,
+	CONSTRAINT PK_MediaRenditions PRIMARY KEY ([MediaId], [Name]),
+	CONSTRAINT FK_MediaRenditions_MediaId FOREIGN KEY ([MediaId]) REFERENCES [Media] ([Id])
+);
\ No newline at end of file
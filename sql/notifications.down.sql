@@ -0,0 +1 @@
+DROP TABLE [dbo].[Notifications];
\ No newline at end of file
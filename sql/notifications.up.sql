@@ -0,0 +1,16 @@
+CREATE TABLE [dbo].[Notifications] (
+	[Id] INT NOT NULL PRIMARY KEY IDENTITY(1,1),
+	[ReferenceId] UNIQUEIDENTIFIER NOT NULL UNIQUE,
+	[IdempotencyKey] UNIQUEIDENTIFIER NOT NULL UNIQUE,
+	[UserId] INT NOT NULL,
+	[ActorId] INT NOT NULL,
+	[Type] VARCHAR(20) NOT NULL,
+	[PostId] INT NULL,
+	[Created] DATETIME NOT NULL,
+	[IsRead] BIT NOT NULL DEFAULT 0,
+	CONSTRAINT FK_Notifications_UserId FOREIGN KEY ([UserId]) REFERENCES [Users] ([Id]),
+	CONSTRAINT FK_Notifications_ActorId FOREIGN KEY ([ActorId]) REFERENCES [Users] ([Id]),
+	CONSTRAINT FK_Notifications_PostId FOREIGN KEY ([PostId]) REFERENCES [Posts] ([Id])
+);
+
+CREATE INDEX IX_Notifications_UserId ON [dbo].[Notifications] ([UserId], [IsRead], [Created]);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE [dbo].[Outbox];
\ No newline at end of file
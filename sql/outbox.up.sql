@@ -0,0 +1,11 @@
+CREATE TABLE [dbo].[Outbox] (
+	[Id] BIGINT NOT NULL PRIMARY KEY IDENTITY(1,1),
+	[IdempotencyKey] UNIQUEIDENTIFIER NOT NULL UNIQUE,
+	[Topic] VARCHAR(255) NOT NULL,
+	[Key] VARCHAR(255) NOT NULL,
+	[Message] NVARCHAR(MAX) NOT NULL,
+	[Created] DATETIME NOT NULL,
+	[Published] DATETIME NULL
+);
+
+CREATE INDEX IX_Outbox_Topic_Published ON [dbo].[Outbox] ([Topic], [Published], [Id]);
\ No newline at end of file
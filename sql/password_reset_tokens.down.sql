@@ -0,0 +1 @@
+DROP TABLE [dbo].[PasswordResetTokens];
\ No newline at end of file
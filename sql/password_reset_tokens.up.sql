@@ -0,0 +1,11 @@
+CREATE TABLE [dbo].[PasswordResetTokens] (
+	[Id] BIGINT NOT NULL PRIMARY KEY IDENTITY(1,1),
+	[TokenHash] CHAR(64) NOT NULL UNIQUE,
+	[UserId] INT NOT NULL,
+	[Created] DATETIME NOT NULL,
+	[Expires] DATETIME NOT NULL,
+	[Used] DATETIME NULL,
+	CONSTRAINT FK_PasswordResetTokens_UserId FOREIGN KEY ([UserId]) REFERENCES [Users] ([Id])
+);
+
+CREATE INDEX IX_PasswordResetTokens_UserId ON [dbo].[PasswordResetTokens] ([UserId]);
\ No newline at end of file
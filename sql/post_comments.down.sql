@@ -0,0 +1 @@
+DROP TABLE [dbo].[PostComments];
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE [dbo].[PostComments] (
+	[Id] INT NOT NULL PRIMARY KEY IDENTITY(1,1),
+	[ReferenceId] UNIQUEIDENTIFIER NOT NULL UNIQUE,
+	[PostId] INT NOT NULL,
+	[UserId] INT NOT NULL,
+	[Posted] DATETIME NOT NULL,
+	[Text] NVARCHAR(255) NOT NULL,
+	CONSTRAINT FK_PostComments_PostId FOREIGN KEY ([PostId]) REFERENCES [Posts] ([Id]),
+	CONSTRAINT FK_PostComments_UserId FOREIGN KEY ([UserId]) REFERENCES [Users] ([Id])
+);
+
+CREATE INDEX IX_PostComments_PostId ON [dbo].[PostComments] ([PostId], [Posted]);
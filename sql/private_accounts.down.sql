@@ -0,0 +1,4 @@
+DROP TABLE [dbo].[FollowRequests];
+
+ALTER TABLE [dbo].[Users] DROP CONSTRAINT DF_Users_IsPrivate;
+ALTER TABLE [dbo].[Users] DROP COLUMN [IsPrivate];
\ No newline at end of file
@@ -0,0 +1,10 @@
+ALTER TABLE [dbo].[Users] ADD [IsPrivate] BIT NOT NULL CONSTRAINT DF_Users_IsPrivate DEFAULT 0;
+
+CREATE TABLE [dbo].[FollowRequests] (
+	[UserId] INT NOT NULL,
+	[FollowerId] INT NOT NULL,
+	[Requested] DATETIME NOT NULL,
+	CONSTRAINT PK_FollowRequests PRIMARY KEY ([UserId], [FollowerId]),
+	CONSTRAINT FK_FollowRequests_UserId FOREIGN KEY ([UserId]) REFERENCES [Users] ([Id]),
+	CONSTRAINT FK_FollowRequests_FollowerId FOREIGN KEY ([FollowerId]) REFERENCES [Users] ([Id])
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE [dbo].[RefreshTokens];
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE [dbo].[RefreshTokens] (
+	[Id] BIGINT NOT NULL PRIMARY KEY IDENTITY(1,1),
+	[TokenHash] CHAR(64) NOT NULL UNIQUE,
+	[FamilyId] UNIQUEIDENTIFIER NOT NULL,
+	[UserReferenceId] UNIQUEIDENTIFIER NOT NULL,
+	[Created] DATETIME NOT NULL,
+	[Expires] DATETIME NOT NULL,
+	[Used] DATETIME NULL,
+	[Revoked] DATETIME NULL
+);
+
+CREATE INDEX IX_RefreshTokens_FamilyId ON [dbo].[RefreshTokens] ([FamilyId]);
+CREATE INDEX IX_RefreshTokens_UserReferenceId ON [dbo].[RefreshTokens] ([UserReferenceId]);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE [dbo].[RecoveryCodes];
+DROP TABLE [dbo].[TwoFactors];
\ No newline at end of file
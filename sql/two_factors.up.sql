@@ -0,0 +1,19 @@
+CREATE TABLE [dbo].[TwoFactors] (
+	[Id] INT NOT NULL PRIMARY KEY IDENTITY(1,1),
+	[UserId] INT NOT NULL UNIQUE,
+	[Secret] NVARCHAR(64) NOT NULL,
+	[Enabled] BIT NOT NULL,
+	[LastUsedStep] BIGINT NOT NULL,
+	[Created] DATETIME NOT NULL,
+	CONSTRAINT FK_TwoFactors_UserId FOREIGN KEY ([UserId]) REFERENCES [Users] ([Id])
+);
+
+CREATE TABLE [dbo].[RecoveryCodes] (
+	[Id] BIGINT NOT NULL PRIMARY KEY IDENTITY(1,1),
+	[UserId] INT NOT NULL,
+	[CodeHash] CHAR(64) NOT NULL,
+	[Used] DATETIME NULL,
+	CONSTRAINT FK_RecoveryCodes_UserId FOREIGN KEY ([UserId]) REFERENCES [Users] ([Id])
+);
+
+CREATE INDEX IX_RecoveryCodes_UserId ON [dbo].[RecoveryCodes] ([UserId]);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE [dbo].[Users] ALTER COLUMN [Bio] VARCHAR(255) NULL;
+
+ALTER TABLE [dbo].[Users] DROP CONSTRAINT FK_Users_MediaId;
+
+ALTER TABLE [dbo].[Users] ADD CONSTRAINT FK_Users_MediaId FOREIGN KEY ([MediaId]) REFERENCES [Users] ([Id]);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE [dbo].[Users] DROP CONSTRAINT FK_Users_MediaId;
+
+ALTER TABLE [dbo].[Users] ADD CONSTRAINT FK_Users_MediaId FOREIGN KEY ([MediaId]) REFERENCES [Media] ([Id]);
+
+ALTER TABLE [dbo].[Users] ALTER COLUMN [Bio] NVARCHAR(255) NULL;
\ No newline at end of file
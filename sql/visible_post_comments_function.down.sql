@@ -0,0 +1 @@
+DROP FUNCTION [dbo].[GetVisiblePostComments];
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE OR ALTER FUNCTION [dbo].[GetVisiblePostComments] (@PostId INT, @UserId INT)
+RETURNS INT
+BEGIN
+	DECLARE @count INT
+
+	SELECT @count=COUNT([C].[Id])
+	FROM [PostComments] AS [C]
+	WHERE [C].[PostId] = @PostId
+		AND NOT EXISTS (SELECT [UserId] FROM [UserBlocks]
+			WHERE ([UserId] = [C].[UserId] AND [BlockedUserId] = @UserId)
+				OR ([UserId] = @UserId AND [BlockedUserId] = [C].[UserId]))
+
+	RETURN @count
+END;
\ No newline at end of file
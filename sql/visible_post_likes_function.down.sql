@@ -0,0 +1 @@
+DROP FUNCTION [dbo].[GetVisiblePostLikes];
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE OR ALTER FUNCTION [dbo].[GetVisiblePostLikes] (@PostId INT, @UserId INT)
+RETURNS INT
+BEGIN
+	DECLARE @count INT
+
+	SELECT @count=COUNT([L].[PostId])
+	FROM [PostLikes] AS [L]
+	WHERE [L].[PostId] = @PostId
+		AND NOT EXISTS (SELECT [UserId] FROM [UserBlocks]
+			WHERE ([UserId] = [L].[UserId] AND [BlockedUserId] = @UserId)
+				OR ([UserId] = @UserId AND [BlockedUserId] = [L].[UserId]))
+
+	RETURN @count
+END;
\ No newline at end of file
@@ -10,8 +10,13 @@ export const submitRegistration = data => dispatch => {
         throw new Error(res.error);
       }
 
-      const { token, expires } = res.data.accessToken;
-      Auth.setAccessToken(token, expires);
+      const {
+        token,
+        expires,
+        refreshToken,
+        refreshExpires,
+      } = res.data.accessToken;
+      Auth.setAccessToken(token, expires, refreshToken, refreshExpires);
 
       dispatch(authActions.registerSuccess());
     })
@@ -27,10 +32,17 @@ export const submitLogin = data => dispatch => {
         throw new Error(res.error);
       }
 
-      const { token, expires } = res.data;
-      Auth.setAccessToken(token, expires);
+      const { token, expires, refreshToken, refreshExpires } = res.data;
+      Auth.setAccessToken(token, expires, refreshToken, refreshExpires);
 
       dispatch(authActions.loginSuccess());
     })
     .catch(err => dispatch(authActions.loginError(err.toString())));
 };
+
+export const logout = () => {
+  const refreshToken = Auth.getRefreshToken();
+  Auth.clearTokens();
+
+  return Api.post("auth/logout", { refreshToken });
+};
@@ -9,6 +9,7 @@ import { postApi } from "../../api";
 const defaultState = {
   id: "",
   mediaId: null,
+  mediaUrl: null,
   posted: new Date().toISOString(),
   username: "",
   caption: "",
@@ -45,9 +46,9 @@ const Post = ({ posts, loading, error, loadPost, likePost, unlikePost }) => {
 
   return (
     <div className="section" id="post">
-      {post.mediaId && (
+      {post.mediaUrl && (
         <Image
-          id={post.mediaId}
+          src={post.mediaUrl}
           alt={post.caption}
           className="img-fluid"
           onDoubleClick={handleLikeClick}
@@ -92,6 +93,7 @@ Post.propTypes = {
     PropTypes.shape({
       id: PropTypes.string.isRequired,
       mediaId: PropTypes.string,
+      mediaUrl: PropTypes.string,
       posted: PropTypes.string.isRequired,
       username: PropTypes.string.isRequired,
       caption: PropTypes.string.isRequired,
@@ -4,11 +4,9 @@ import { connect } from "react-redux";
 import { bindActionCreators } from "redux";
 import { useParams } from "react-router-dom";
 import { userApi } from "../../api";
-import environment from "../../environment";
 import { Feed } from "../shared";
 
-const ProfileImage = ({ id }) => {
-  const url = environment.mediaUrl + id;
+const ProfileImage = ({ url }) => {
   const style = {
     backgroundImage: `url(${url})`,
   };
@@ -52,7 +50,7 @@ const Profile = ({
     <>
       <div className="section p-4 mb-4" id="profile">
         <div className="row">
-          {profile.mediaId && <ProfileImage id={profile.mediaId} />}
+          {profile.mediaUrl && <ProfileImage url={profile.mediaUrl} />}
 
           <div className="col-sm-9">
             <h1 className="header-1 text-left">{profile.username}</h1>
@@ -100,6 +98,7 @@ Profile.propTypes = {
     userId: PropTypes.string.isRequired,
     username: PropTypes.string.isRequired,
     mediaId: PropTypes.string,
+    mediaUrl: PropTypes.string,
     bio: PropTypes.string,
     followerCount: PropTypes.number.isRequired,
     isFollowing: PropTypes.bool.isRequired,
@@ -128,6 +127,7 @@ PropTypes.defaultProps = {
     userId: "",
     username: "",
     mediaId: null,
+    mediaUrl: null,
     bio: null,
     followerCount: 0,
     isFollowing: false,
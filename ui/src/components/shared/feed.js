@@ -19,9 +19,9 @@ const Feed = ({ items, likePost, unlikePost }) => {
 
   return items.map((item, key) => (
     <div className="section mb-4" key={key}>
-      {item.mediaId && (
+      {item.mediaUrl && (
         <Image
-          id={item.mediaId}
+          src={item.mediaUrl}
           className="img-fluid"
           alt={item.caption}
           onDoubleClick={handleLikePost(item)}
@@ -1,12 +1,11 @@
 import React from "react";
 import LazyLoad from "react-lazyload";
 import PropTypes from "prop-types";
-import environment from "../../environment";
 
-const Image = ({ id, alt, className, onDoubleClick }) => (
+const Image = ({ src, alt, className, onDoubleClick }) => (
   <LazyLoad once>
     <img
-      src={environment.mediaUrl + id}
+      src={src}
       alt={alt}
       className={className}
       onDoubleClick={onDoubleClick}
@@ -15,7 +14,7 @@ const Image = ({ id, alt, className, onDoubleClick }) => (
 );
 
 Image.propTypes = {
-  id: PropTypes.string.isRequired,
+  src: PropTypes.string.isRequired,
   alt: PropTypes.string.isRequired,
   className: PropTypes.string,
   onDoubleClick: PropTypes.func,
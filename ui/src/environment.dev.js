@@ -1,5 +1,4 @@
 const env = {
-  mediaUrl: "http://localhost/media/",
   apiUrl: "http://localhost/api/",
 };
 
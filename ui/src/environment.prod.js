@@ -1,5 +1,4 @@
 const env = {
-  mediaUrl: "/media/",
   apiUrl: "/api/",
 };
 
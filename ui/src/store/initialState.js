@@ -14,6 +14,7 @@ const initialState = {
       userId: "",
       username: "",
       mediaId: null,
+      mediaUrl: null,
       bio: null,
       followerCount: 0,
       isFollowing: false,
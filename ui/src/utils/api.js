@@ -1,5 +1,10 @@
 import env from "../environment";
-import { getAccessToken } from "../utils/auth";
+import {
+  getAccessToken,
+  getRefreshToken,
+  setAccessToken,
+  clearTokens,
+} from "../utils/auth";
 
 const get = async (url, options) =>
   await send(url, { method: "GET", ...options });
@@ -21,14 +26,66 @@ const postForm = async (url, formData, options) =>
     ...options,
   });
 
-const send = async (url, options) => {
+// refreshing is the in-flight refresh request, shared by concurrent requests,
+// as a refresh token can only be used once.
+let refreshing = null;
+
+// refreshAccessToken exchanges the stored refresh token for a new access
+// token, returning it, or null if the session could not be refreshed.
+const refreshAccessToken = () => {
+  if (!refreshing) {
+    refreshing = requestRefresh().finally(() => (refreshing = null));
+  }
+
+  return refreshing;
+};
+
+const requestRefresh = async () => {
+  const refreshToken = getRefreshToken();
+  if (!refreshToken) {
+    return null;
+  }
+
+  try {
+    const res = await fetch(env.apiUrl + "auth/refresh", {
+      method: "POST",
+      body: JSON.stringify({ refreshToken }),
+      headers: {
+        "Content-Type": "application/json",
+      },
+    });
+    if (res.status !== 200) {
+      clearTokens();
+      return null;
+    }
+
+    const data = await res.json();
+    setAccessToken(
+      data.token,
+      data.expires,
+      data.refreshToken,
+      data.refreshExpires
+    );
+
+    return data.token;
+  } catch (e) {
+    console.error("Failed to refresh access token", e);
+    return null;
+  }
+};
+
+const send = async (url, options, retried) => {
   const dest = env.apiUrl + url;
 
   options.headers = {
     ...options.headers,
   };
 
-  const accessToken = getAccessToken();
+  let accessToken = getAccessToken();
+  if (!accessToken && !retried) {
+    accessToken = await refreshAccessToken();
+  }
+
   if (accessToken) {
     options.headers.Authorization = "Bearer " + accessToken;
   }
@@ -36,6 +93,10 @@ const send = async (url, options) => {
   try {
     const res = await fetch(dest, options);
 
+    if (res.status === 401 && !retried && (await refreshAccessToken())) {
+      return await send(url, options, true);
+    }
+
     switch (res.status) {
       case 200:
         let data = null;
@@ -1,37 +1,71 @@
 const AUTH_STORAGE_KEY = "oa_auth";
 
-const getAccessToken = () => {
+const readAuthData = () => {
   const authJson = localStorage.getItem(AUTH_STORAGE_KEY);
   if (!authJson) {
     return null;
   }
 
   try {
-    const authData = JSON.parse(authJson);
-    if (authData.expiryDate < new Date().getTime()) {
-      localStorage.removeItem(AUTH_STORAGE_KEY);
-      return null;
-    }
-
-    return authData.accessToken;
+    return JSON.parse(authJson);
   } catch {
     localStorage.removeItem(AUTH_STORAGE_KEY);
     return null;
   }
 };
 
-const setAccessToken = (accessToken, expiryTimestamp) => {
+const toTime = expiryTimestamp => {
   const expiryDateUtc = new Date(expiryTimestamp * 1000).toUTCString();
-  const expiryDate = new Date(expiryDateUtc).getTime();
+  return new Date(expiryDateUtc).getTime();
+};
+
+const getAccessToken = () => {
+  const authData = readAuthData();
+  if (!authData || authData.expiryDate < new Date().getTime()) {
+    return null;
+  }
+
+  return authData.accessToken;
+};
+
+const getRefreshToken = () => {
+  const authData = readAuthData();
+  if (
+    !authData ||
+    !authData.refreshToken ||
+    authData.refreshExpiryDate < new Date().getTime()
+  ) {
+    return null;
+  }
 
+  return authData.refreshToken;
+};
+
+const setAccessToken = (
+  accessToken,
+  expiryTimestamp,
+  refreshToken,
+  refreshExpiryTimestamp
+) => {
   const data = {
     accessToken,
-    expiryDate,
+    expiryDate: toTime(expiryTimestamp),
+    refreshToken,
+    refreshExpiryDate: toTime(refreshExpiryTimestamp),
   };
 
   localStorage.setItem(AUTH_STORAGE_KEY, JSON.stringify(data));
 };
 
-const isAuthenticated = () => getAccessToken() !== null;
+const clearTokens = () => localStorage.removeItem(AUTH_STORAGE_KEY);
 
-export { getAccessToken, setAccessToken, isAuthenticated };
+const isAuthenticated = () =>
+  getAccessToken() !== null || getRefreshToken() !== null;
+
+export {
+  getAccessToken,
+  getRefreshToken,
+  setAccessToken,
+  clearTokens,
+  isAuthenticated,
+};